@@ -17,8 +17,8 @@ import (
 // GetTableName returns the table name from the SimpleTableExpr
 // only if it's a simple expression. Otherwise, it returns "".
 func GetTableName(node SimpleTableExpr) string {
-	if n, ok := node.(*TableName); ok && n.Qualifier == nil {
-		return string(n.Name)
+	if n, ok := node.(*TableName); ok && n.Qualifier.IsEmpty() {
+		return n.Name.String()
 	}
 	// sub-select or '.' expression
 	return ""
@@ -58,7 +58,7 @@ func GetColumnByName(sqlNode SQLNode, name string) (*ColumnDefinition, error) {
 // it's a simple expression. Otherwise, it returns "".
 func GetColName(node Expr) string {
 	if n, ok := node.(*ColName); ok {
-		return string(n.Name)
+		return n.Name.String()
 	}
 	return ""
 }
@@ -128,7 +128,7 @@ func AsInterface(node ValExpr) (interface{}, error) {
 	case ListArg:
 		return string(node), nil
 	case StrVal:
-		return sqltypes.MakeString(node), nil
+		return sqltypes.MakeString([]byte(node.Val)), nil
 	case NumVal:
 		n, err := sqltypes.BuildNumeric(string(node))
 		if err != nil {
@@ -88,7 +88,7 @@ func TestModifyColumns(t *testing.T) {
 	table, ok := tree.(*CreateTable)
 	assert.True(t, ok)
 
-	col := &ColumnDefinition{ColName: "ID", ColType: "int", ColumnAtts: ColumnAtts{AST_AUTO_INCREMENT, AST_PRIMARY_KEY}}
+	col := &ColumnDefinition{ColName: "ID", ColType: ColumnType{Type: AST_INT}, ColumnAtts: ColumnAtts{AST_AUTO_INCREMENT, AST_PRIMARY_KEY}}
 	table.ColumnDefinitions = append(table.ColumnDefinitions, col)
 
 	sql_expected := `create table t1 (
@@ -100,3 +100,12 @@ func TestModifyColumns(t *testing.T) {
 
 	assert.Equal(t, sql_expected, sql_actual)
 }
+
+func TestGetColName(t *testing.T) {
+	tree, err := Parse("select FirstName, `Last Name`, 1 from t1")
+	assert.Nil(t, err)
+	exprs := tree.(*Select).SelectExprs
+	assert.Equal(t, "FirstName", GetColName(exprs[0].(*NonStarExpr).Expr))
+	assert.Equal(t, "Last Name", GetColName(exprs[1].(*NonStarExpr).Expr))
+	assert.Equal(t, "", GetColName(exprs[2].(*NonStarExpr).Expr))
+}
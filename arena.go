@@ -0,0 +1,191 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// arenaChunk is the number of nodes of each type allocated at once.
+const arenaChunk = 128
+
+// Arena allocates the most frequent AST nodes from contiguous chunks
+// instead of one at a time. It is meant for services that parse a
+// large number of statements: reusing one Arena per goroutine with
+// ParseWithArena and Reset cuts the allocation count substantially.
+//
+// Nodes allocated from an Arena must not be used after Reset is
+// called. An Arena is not safe for concurrent use.
+type Arena struct {
+	colNames     []ColName
+	colNamesN    int
+	tableNames   []TableName
+	tableNamesN  int
+	nonStars     []NonStarExpr
+	nonStarsN    int
+	aliased      []AliasedTableExpr
+	aliasedN     int
+	comparisons  []ComparisonExpr
+	comparisonsN int
+	binaries     []BinaryExpr
+	binariesN    int
+	ands         []AndExpr
+	andsN        int
+	ors          []OrExpr
+	orsN         int
+}
+
+// NewArena creates an empty Arena.
+func NewArena() *Arena {
+	return &Arena{}
+}
+
+// ParseWithArena parses sql like Parse, allocating nodes from a.
+// The returned Statement is valid until a.Reset is called.
+func ParseWithArena(sql string, a *Arena) (Statement, error) {
+	tokenizer := NewStringTokenizer(sql)
+	tokenizer.arena = a
+	if yyParse(tokenizer) != 0 {
+		return nil, tokenizer.parseError()
+	}
+	return tokenizer.ParseTree, nil
+}
+
+// Reset releases every node allocated from a. The most recent
+// chunk of each type is kept and reused by subsequent parses.
+func (a *Arena) Reset() {
+	clear(a.colNames[:a.colNamesN])
+	a.colNamesN = 0
+	clear(a.tableNames[:a.tableNamesN])
+	a.tableNamesN = 0
+	clear(a.nonStars[:a.nonStarsN])
+	a.nonStarsN = 0
+	clear(a.aliased[:a.aliasedN])
+	a.aliasedN = 0
+	clear(a.comparisons[:a.comparisonsN])
+	a.comparisonsN = 0
+	clear(a.binaries[:a.binariesN])
+	a.binariesN = 0
+	clear(a.ands[:a.andsN])
+	a.andsN = 0
+	clear(a.ors[:a.orsN])
+	a.orsN = 0
+}
+
+func (a *Arena) colName(v ColName) *ColName {
+	var n *ColName
+	if a == nil {
+		n = new(ColName)
+	} else {
+		if a.colNamesN == len(a.colNames) {
+			a.colNames, a.colNamesN = make([]ColName, arenaChunk), 0
+		}
+		n = &a.colNames[a.colNamesN]
+		a.colNamesN++
+	}
+	*n = v
+	return n
+}
+
+func (a *Arena) tableName(v TableName) *TableName {
+	var n *TableName
+	if a == nil {
+		n = new(TableName)
+	} else {
+		if a.tableNamesN == len(a.tableNames) {
+			a.tableNames, a.tableNamesN = make([]TableName, arenaChunk), 0
+		}
+		n = &a.tableNames[a.tableNamesN]
+		a.tableNamesN++
+	}
+	*n = v
+	return n
+}
+
+func (a *Arena) nonStarExpr(v NonStarExpr) *NonStarExpr {
+	var n *NonStarExpr
+	if a == nil {
+		n = new(NonStarExpr)
+	} else {
+		if a.nonStarsN == len(a.nonStars) {
+			a.nonStars, a.nonStarsN = make([]NonStarExpr, arenaChunk), 0
+		}
+		n = &a.nonStars[a.nonStarsN]
+		a.nonStarsN++
+	}
+	*n = v
+	return n
+}
+
+func (a *Arena) aliasedTableExpr(v AliasedTableExpr) *AliasedTableExpr {
+	var n *AliasedTableExpr
+	if a == nil {
+		n = new(AliasedTableExpr)
+	} else {
+		if a.aliasedN == len(a.aliased) {
+			a.aliased, a.aliasedN = make([]AliasedTableExpr, arenaChunk), 0
+		}
+		n = &a.aliased[a.aliasedN]
+		a.aliasedN++
+	}
+	*n = v
+	return n
+}
+
+func (a *Arena) comparisonExpr(v ComparisonExpr) *ComparisonExpr {
+	var n *ComparisonExpr
+	if a == nil {
+		n = new(ComparisonExpr)
+	} else {
+		if a.comparisonsN == len(a.comparisons) {
+			a.comparisons, a.comparisonsN = make([]ComparisonExpr, arenaChunk), 0
+		}
+		n = &a.comparisons[a.comparisonsN]
+		a.comparisonsN++
+	}
+	*n = v
+	return n
+}
+
+func (a *Arena) binaryExpr(v BinaryExpr) *BinaryExpr {
+	var n *BinaryExpr
+	if a == nil {
+		n = new(BinaryExpr)
+	} else {
+		if a.binariesN == len(a.binaries) {
+			a.binaries, a.binariesN = make([]BinaryExpr, arenaChunk), 0
+		}
+		n = &a.binaries[a.binariesN]
+		a.binariesN++
+	}
+	*n = v
+	return n
+}
+
+func (a *Arena) andExpr(v AndExpr) *AndExpr {
+	var n *AndExpr
+	if a == nil {
+		n = new(AndExpr)
+	} else {
+		if a.andsN == len(a.ands) {
+			a.ands, a.andsN = make([]AndExpr, arenaChunk), 0
+		}
+		n = &a.ands[a.andsN]
+		a.andsN++
+	}
+	*n = v
+	return n
+}
+
+func (a *Arena) orExpr(v OrExpr) *OrExpr {
+	var n *OrExpr
+	if a == nil {
+		n = new(OrExpr)
+	} else {
+		if a.orsN == len(a.ors) {
+			a.ors, a.orsN = make([]OrExpr, arenaChunk), 0
+		}
+		n = &a.ors[a.orsN]
+		a.orsN++
+	}
+	*n = v
+	return n
+}
@@ -7,7 +7,9 @@ package sqlparser
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 
 	"github.com/getlantern/sqlparser/dependency/sqltypes"
 )
@@ -25,264 +27,2791 @@ import (
 // This will help avoid name collisions.
 
 // Parse parses the sql and returns a Statement, which
-// is the AST representation of the query.
+// is the AST representation of the query. Leading and
+// trailing semicolons are ignored. If the sql contains no
+// statement at all, Parse returns a nil Statement. Scripts of
+// several statements are parsed by ParseScript, and split into
+// their statements by SplitScript. Parse is safe for concurrent
+// use, see Parser. Malformed sql is reported as an error: should
+// the parser panic on it all the same, the panic is returned as an
+// error too.
 func Parse(sql string) (Statement, error) {
-	tokenizer := NewStringTokenizer(sql)
-	if yyParse(tokenizer) != 0 {
-		return nil, errors.New(tokenizer.LastError)
+	return parsePooled(sql, Options{}, nil)
+}
+
+// Dialect selects the SQL dialect accepted by ParseWithOptions.
+type Dialect int
+
+const (
+	// MySQL is the default dialect.
+	MySQL Dialect = iota
+	// MariaDB additionally accepts the NEXTVAL(seq) and
+	// seq.nextval sequence accessors, and RETURNING clauses.
+	MariaDB
+	// Postgres additionally accepts ARRAY[...] constructors
+	// and subscripts, $1 positional parameters, ::type casts,
+	// ILIKE, ~* and !~* for REGEXP, RETURNING clauses, E'...'
+	// escape strings and identifiers quoted with double quotes,
+	// which do not enclose strings. Backslashes are literal in
+	// other strings.
+	Postgres
+	// BigQuery additionally accepts ARRAY[...] and STRUCT(...)
+	// constructors and subscripts.
+	BigQuery
+)
+
+// String returns the name of the dialect.
+func (d Dialect) String() string {
+	switch d {
+	case MySQL:
+		return "mysql"
+	case MariaDB:
+		return "mariadb"
+	case Postgres:
+		return "postgres"
+	case BigQuery:
+		return "bigquery"
+	}
+	return fmt.Sprintf("dialect(%d)", int(d))
+}
+
+func (d Dialect) hasArrays() bool {
+	return d == Postgres || d == BigQuery
+}
+
+// Options controls how ParseWithOptions parses a statement.
+//
+// The limits, if set, bound the resources used to parse sql from
+// untrusted sources. Sql exceeding one is rejected with a
+// LimitError as soon as it is found to. MaxLength limits the
+// length of the sql, in bytes. MaxDepth limits the nesting of
+// parentheses, which enclose subqueries as well as expressions
+// and lists. MaxQueries limits the number of queries combined by
+// UNION, INTERSECT and EXCEPT.
+//
+// Clauses adds custom clauses to SELECT statements, as described
+// by ClauseDef. NoTimeRange disables the TIMERANGE clause, ASOF
+// ... UNTIL, so that asof and until are plain identifiers.
+type Options struct {
+	Dialect Dialect
+
+	MaxLength  int
+	MaxDepth   int
+	MaxQueries int
+
+	Clauses     []ClauseDef
+	NoTimeRange bool
+}
+
+// ParseWithOptions parses sql like Parse, using the
+// dialect and settings in opts.
+func ParseWithOptions(sql string, opts Options) (Statement, error) {
+	return parsePooled(sql, opts, nil)
+}
+
+// ParseWithRowHandler parses sql like Parse, except that the rows
+// of an INSERT ... VALUES or VALUES statement are passed to onRow
+// one at a time as soon as they are parsed, and are not kept in the
+// returned statement. This bounds memory use for very large multi-row inserts.
+// The rows of VALUES in subqueries are kept in the statement. If onRow
+// returns an error, parsing stops and the error is returned.
+func ParseWithRowHandler(sql string, onRow func(RowTuple) error) (Statement, error) {
+	return parsePooled(sql, Options{}, onRow)
+}
+
+// streamedRowsError returns an error if the rows passed to the
+// RowHandler of tkn are not simply the rows of the statement
+// parsed, as for a UNION of VALUES statements, whose rows cannot
+// be combined once passed.
+func (tkn *Tokenizer) streamedRowsError() error {
+	if !tkn.streamedRows {
+		return nil
+	}
+	switch stmt := tkn.ParseTree.(type) {
+	case *Insert:
+		if _, ok := stmt.Rows.(Values); ok {
+			return nil
+		}
+	case *ValuesStatement:
+		if stmt.OrderBy == nil && stmt.Limit == nil {
+			return nil
+		}
+	}
+	return errors.New("rows passed to a RowHandler must be those of an INSERT ... VALUES or VALUES statement")
+}
+
+// ParseNext parses the next of the statements read by tokenizer,
+// which is usually created by NewTokenizer, so that a large script,
+// such as a mysqldump file, can be parsed one statement at a time
+// without being read into memory first. Empty statements are
+// skipped, and io.EOF is returned at the end of the input. After
+// an error, the next call resumes with the statement following the
+// one that failed.
+//
+// Statements end with a semicolon, or with the delimiter set by a
+// DELIMITER directive, as for SplitScript, so that compound
+// statements such as BEGIN ... END blocks, and CREATE PROCEDURE
+// and CREATE FUNCTION statements with such bodies, can be parsed
+// once the delimiter is changed.
+func ParseNext(tokenizer *Tokenizer) (Statement, error) {
+	tokenizer.multi = true
+	for {
+		if tokenizer.ForceEOF {
+			tokenizer.skipStatement()
+		}
+		if tokenizer.lastChar == 0 {
+			tokenizer.next()
+		}
+		for tokenizer.atDelimiter() {
+			tokenizer.skipDelimiter()
+			tokenizer.skipBlank()
+		}
+		if tokenizer.readErr != nil {
+			return nil, tokenizer.readErr
+		}
+		if tokenizer.lastChar == EOFCHAR {
+			return nil, io.EOF
+		}
+		tokenizer.ParseTree = nil
+		tokenizer.errorToken = ""
+		tokenizer.posVarIndex = 0
+		tokenizer.tokens = 0
+		tokenizer.rowErr = nil
+		tokenizer.firstToken, tokenizer.streamedRows = 0, false
+		tokenizer.expected = nil
+		failed := yyParse(tokenizer) != 0
+		if tokenizer.readErr != nil {
+			// The statement may have been cut short.
+			return nil, tokenizer.readErr
+		}
+		if failed {
+			// Skip the rest of the statement on the next call.
+			tokenizer.ForceEOF = true
+			if tokenizer.rowErr != nil {
+				return nil, tokenizer.rowErr
+			}
+			return nil, tokenizer.parseError()
+		}
+		if err := tokenizer.streamedRowsError(); err != nil {
+			return nil, err
+		}
+		if tokenizer.ParseTree != nil {
+			return tokenizer.ParseTree, nil
+		}
+	}
+}
+
+// SQLNode defines the interface for all nodes
+// generated by the parser.
+type SQLNode interface {
+	Format(buf *TrackedBuffer)
+}
+
+// String returns a string representation of an SQLNode.
+func String(node SQLNode) string {
+	buf := NewTrackedBuffer(nil)
+	buf.Myprintf("%v", node)
+	return buf.String()
+}
+
+// FormatDialect returns a string representation of an SQLNode
+// for dialect, whichever dialect it was parsed from: identifiers
+// are quoted, and strings, LIMIT clauses, share locks and REGEXP
+// written as dialect expects. For dialects other than Postgres,
+// ILIKE is written as LIKE comparing lower(...) values, and $1
+// parameters as ?, and for MySQL and MariaDB, ::type casts as
+// CAST. It returns an error if node holds a construct dialect
+// cannot express, such as ON DUPLICATE KEY UPDATE in Postgres,
+// ARRAY[...] in MySQL or RETURNING in MySQL.
+func FormatDialect(node SQLNode, dialect Dialect) (string, error) {
+	buf := NewTrackedBuffer(nil)
+	buf.SetDialect(dialect)
+	buf.Myprintf("%v", node)
+	if err := buf.DialectError(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Statement represents a statement.
+type Statement interface {
+	IStatement()
+	SQLNode
+}
+
+func (*Union) IStatement()            {}
+func (*ParenSelect) IStatement()      {}
+func (*ValuesStatement) IStatement()  {}
+func (*Select) IStatement()           {}
+func (*Insert) IStatement()           {}
+func (*Update) IStatement()           {}
+func (*Delete) IStatement()           {}
+func (*LoadData) IStatement()         {}
+func (*Set) IStatement()              {}
+func (*DDL) IStatement()              {}
+func (*AlterTable) IStatement()       {}
+func (*Other) IStatement()            {}
+func (*Sequence) IStatement()         {}
+func (*DeclareCursor) IStatement()    {}
+func (*OpenCursor) IStatement()       {}
+func (*FetchCursor) IStatement()      {}
+func (*CloseCursor) IStatement()      {}
+func (*Prepare) IStatement()          {}
+func (*Execute) IStatement()          {}
+func (*Deallocate) IStatement()       {}
+func (*Call) IStatement()             {}
+func (*CreateRoutine) IStatement()    {}
+func (*Block) IStatement()            {}
+func (*IfStatement) IStatement()      {}
+func (*While) IStatement()            {}
+func (*Loop) IStatement()             {}
+func (*Repeat) IStatement()           {}
+func (*Leave) IStatement()            {}
+func (*Iterate) IStatement()          {}
+func (*DeclareVars) IStatement()      {}
+func (*DeclareHandler) IStatement()   {}
+func (*Signal) IStatement()           {}
+func (*Begin) IStatement()            {}
+func (*Commit) IStatement()           {}
+func (*Rollback) IStatement()         {}
+func (*Savepoint) IStatement()        {}
+func (*ReleaseSavepoint) IStatement() {}
+func (*SetTransaction) IStatement()   {}
+func (*Grant) IStatement()            {}
+func (*Revoke) IStatement()           {}
+func (*CreateUser) IStatement()       {}
+func (*AlterUser) IStatement()        {}
+func (*SetPassword) IStatement()      {}
+func (*Show) IStatement()             {}
+func (*Describe) IStatement()         {}
+func (*Explain) IStatement()          {}
+
+// SelectStatement any SELECT statement.
+type SelectStatement interface {
+	ISelectStatement()
+	IStatement()
+	IInsertRows()
+	SQLNode
+}
+
+func (*Select) ISelectStatement()          {}
+func (*Union) ISelectStatement()           {}
+func (*ParenSelect) ISelectStatement()     {}
+func (*ValuesStatement) ISelectStatement() {}
+
+// Select represents a SELECT statement.
+type Select struct {
+	With             *With
+	Comments         Comments
+	Hints            Hints
+	Distinct         string
+	HighPriority     bool
+	StraightJoin     bool
+	ResultSize       string
+	BufferResult     bool
+	MaxStatementTime NumVal
+	Cache            string
+	CalcFoundRows    bool
+	SelectExprs      SelectExprs
+	From             TableExprs
+	Where            *Where
+	TimeRange        *TimeRange
+	Clauses          Clauses
+	GroupBy          SelectExprs
+	WithRollup       bool
+	Having           *Where
+	Qualify          *Where
+	Windows          []*NamedWindow
+	OrderBy          OrderBy
+	Limit            *Limit
+	Lock             string
+}
+
+// Select.Distinct
+const (
+	AST_DISTINCT    = "distinct "
+	AST_DISTINCTROW = "distinctrow "
+)
+
+// Select.ResultSize
+const (
+	AST_SQL_SMALL_RESULT = "sql_small_result "
+	AST_SQL_BIG_RESULT   = "sql_big_result "
+)
+
+// Select.Cache
+const (
+	AST_SQL_CACHE    = "sql_cache "
+	AST_SQL_NO_CACHE = "sql_no_cache "
+)
+
+// Select.Lock is AST_FOR_UPDATE or AST_SHARE_MODE, which FOR SHARE
+// is parsed as, or AST_FOR_UPDATE or AST_FOR_SHARE followed by
+// AST_NOWAIT or AST_SKIP_LOCKED.
+const (
+	AST_FOR_UPDATE = " for update"
+	AST_FOR_SHARE  = " for share"
+	AST_SHARE_MODE = " lock in share mode"
+
+	AST_NOWAIT      = " nowait"
+	AST_SKIP_LOCKED = " skip locked"
+)
+
+func (node *Select) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%vselect %v%v", node.With, node.Comments, node.Hints)
+	node.formatOptions(buf)
+	buf.Myprintf("%v", node.SelectExprs)
+	if len(node.From) > 0 {
+		buf.Myprintf(" from %v", node.From)
+	}
+	buf.Myprintf("%v%v%v", node.TimeRange, node.Clauses, node.Where)
+	if len(node.GroupBy) > 0 {
+		buf.Myprintf(" group by %v", node.GroupBy)
+	}
+	if node.WithRollup {
+		buf.WriteString(" with rollup")
+	}
+	buf.Myprintf("%v%v", node.Having, node.Qualify)
+	prefix := " window "
+	for _, window := range node.Windows {
+		buf.Myprintf("%s%v", prefix, window)
+		prefix = ", "
+	}
+	buf.Myprintf("%v%v", node.OrderBy, node.Limit)
+	formatLock(buf, node.Lock)
+}
+
+// formatLock formats lock, a Select.Lock, for the dialect of buf.
+func formatLock(buf *TrackedBuffer, lock string) {
+	if lock == AST_SHARE_MODE && buf.dialect == Postgres {
+		lock = AST_FOR_SHARE
+	}
+	buf.WriteString(lock)
+}
+
+// formatOptions formats the options following SELECT, each
+// followed by a space.
+func (node *Select) formatOptions(buf *TrackedBuffer) {
+	buf.WriteString(node.Distinct)
+	if node.HighPriority {
+		buf.WriteString("high_priority ")
+	}
+	if node.StraightJoin {
+		buf.WriteString("straight_join ")
+	}
+	buf.WriteString(node.ResultSize)
+	if node.BufferResult {
+		buf.WriteString("sql_buffer_result ")
+	}
+	if node.MaxStatementTime != "" {
+		buf.Myprintf("max_statement_time = %v ", node.MaxStatementTime)
+	}
+	buf.WriteString(node.Cache)
+	if node.CalcFoundRows {
+		buf.WriteString("sql_calc_found_rows ")
+	}
+}
+
+// Union represents a UNION, EXCEPT or INTERSECT statement.
+// INTERSECT binds tighter than UNION and EXCEPT, so the
+// parser nests it below them. OrderBy, Limit and Lock apply
+// to the combined rows. The parser sets them from the clauses
+// following the last operand, unless it is parenthesized.
+type Union struct {
+	With        *With
+	Type        string
+	Left, Right SelectStatement
+	OrderBy     OrderBy
+	Limit       *Limit
+	Lock        string
+}
+
+// Union.Type
+const (
+	AST_UNION              = "union"
+	AST_UNION_ALL          = "union all"
+	AST_UNION_DISTINCT     = "union distinct"
+	AST_SET_MINUS          = "minus"
+	AST_EXCEPT             = "except"
+	AST_EXCEPT_ALL         = "except all"
+	AST_EXCEPT_DISTINCT    = "except distinct"
+	AST_INTERSECT          = "intersect"
+	AST_INTERSECT_ALL      = "intersect all"
+	AST_INTERSECT_DISTINCT = "intersect distinct"
+)
+
+func (node *Union) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v%v %s %v%v%v", node.With, node.Left, node.Type, node.Right,
+		node.OrderBy, node.Limit)
+	formatLock(buf, node.Lock)
+}
+
+// newUnion builds a Union, moving the ORDER BY, LIMIT and lock
+// clauses that the grammar attaches to an unparenthesized right
+// operand to the Union, which they apply to.
+func newUnion(typ string, left, right SelectStatement) *Union {
+	node := &Union{Type: typ, Left: left, Right: right}
+	switch right := right.(type) {
+	case *Select:
+		node.OrderBy, node.Limit, node.Lock = right.OrderBy, right.Limit, right.Lock
+		right.OrderBy, right.Limit, right.Lock = nil, nil, ""
+	case *Union:
+		node.OrderBy, node.Limit, node.Lock = right.OrderBy, right.Limit, right.Lock
+		right.OrderBy, right.Limit, right.Lock = nil, nil, ""
+	case *ValuesStatement:
+		node.OrderBy, node.Limit = right.OrderBy, right.Limit
+		right.OrderBy, right.Limit = nil, nil
+	}
+	return node
+}
+
+// ValuesStatement represents a VALUES statement, which returns
+// its rows as a table, as in VALUES ROW(1, 2), ROW(3, 4). Rows
+// are formatted with ROW in the MySQL dialect, which requires
+// it, and without it in the others.
+type ValuesStatement struct {
+	Rows    Values
+	OrderBy OrderBy
+	Limit   *Limit
+}
+
+func (node *ValuesStatement) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	prefix := "values "
+	for _, row := range node.Rows {
+		buf.WriteString(prefix)
+		if _, ok := row.(ValTuple); ok && buf.dialect == MySQL {
+			buf.WriteString("row")
+		}
+		buf.Myprintf("%v", row)
+		prefix = ", "
+	}
+	buf.Myprintf("%v%v", node.OrderBy, node.Limit)
+}
+
+// insertRows returns the rows of an INSERT statement given
+// as rows: the VALUES of an INSERT ... VALUES statement are
+// parsed as a ValuesStatement.
+func insertRows(rows SelectStatement) InsertRows {
+	if values, ok := rows.(*ValuesStatement); ok && values.OrderBy == nil && values.Limit == nil {
+		return values.Rows
+	}
+	return rows
+}
+
+// ParenSelect represents a parenthesized operand of a Union.
+type ParenSelect struct {
+	Select SelectStatement
+}
+
+func (node *ParenSelect) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("(%v)", node.Select)
+}
+
+// With represents a WITH clause, which names subqueries for
+// use by the statement it precedes. The parser attaches it to
+// the Select or Union it applies to.
+type With struct {
+	Recursive bool
+	CTEs      []*CommonTableExpr
+}
+
+func (node *With) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("with ")
+	if node.Recursive {
+		buf.Myprintf("recursive ")
+	}
+	prefix := ""
+	for _, cte := range node.CTEs {
+		buf.Myprintf("%s%v", prefix, cte)
+		prefix = ", "
+	}
+	buf.Myprintf(" ")
+}
+
+// CommonTableExpr represents a named subquery of a WITH clause,
+// name [(columns)] AS (select).
+type CommonTableExpr struct {
+	Name     TableIdent
+	Columns  []ColIdent
+	Subquery *Subquery
+}
+
+func (node *CommonTableExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v", node.Name)
+	formatColumnAliases(buf, node.Columns)
+	buf.Myprintf(" as %v", node.Subquery)
+}
+
+// formatColumnAliases writes the column names given to a
+// CTE, a table alias or a view in parentheses, if there are any.
+func formatColumnAliases(buf *TrackedBuffer, cols []ColIdent) {
+	if len(cols) == 0 {
+		return
+	}
+	prefix := "("
+	for _, col := range cols {
+		buf.Myprintf("%s%v", prefix, col)
+		prefix = ", "
+	}
+	buf.Myprintf(")")
+}
+
+// Insert represents an INSERT statement.
+type Insert struct {
+	Comments   Comments
+	Hints      Hints
+	Priority   string
+	Ignore     bool
+	Table      *TableName
+	Partitions Partitions
+	Columns    Columns
+	Rows       InsertRows
+	RowAlias   *RowAlias
+	OnDup      OnDup
+	// Returning is set by the RETURNING clause
+	// of the Postgres dialect.
+	Returning SelectExprs
+}
+
+func (node *Insert) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("insert %v%v", node.Comments, node.Hints)
+	formatModifiers(buf, node.Priority, node.Ignore)
+	buf.Myprintf("into %v%v%v %v%v%v",
+		node.Table, node.Partitions, node.Columns, node.Rows, node.RowAlias, node.OnDup)
+	formatReturning(buf, node.Returning)
+}
+
+// RowAlias represents the alias of the rows of a MySQL INSERT,
+// as in AS new(a, b), by which its ON DUPLICATE KEY UPDATE clause
+// refers to the values they would have inserted. Columns, if set,
+// name the values, in the order of the columns of the INSERT.
+type RowAlias struct {
+	Name    TableIdent
+	Columns Columns
+}
+
+func (node *RowAlias) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf(" as %v%v", node.Name, node.Columns)
+}
+
+// Insert.Priority, and AST_LOW_PRIORITY, which Update.Priority
+// and Delete.Priority may be as well
+const (
+	AST_DELAYED       = "delayed"
+	AST_HIGH_PRIORITY = "high_priority"
+)
+
+// formatModifiers formats the priority and IGNORE modifiers of an
+// INSERT, UPDATE or DELETE, each followed by a space.
+func formatModifiers(buf *TrackedBuffer, priority string, ignore bool) {
+	if priority != "" {
+		buf.Myprintf("%s ", priority)
+	}
+	if ignore {
+		buf.WriteString("ignore ")
+	}
+}
+
+// InsertRows represents the rows for an INSERT statement.
+type InsertRows interface {
+	IInsertRows()
+	SQLNode
+}
+
+func (*Select) IInsertRows()          {}
+func (*Union) IInsertRows()           {}
+func (*ParenSelect) IInsertRows()     {}
+func (*ValuesStatement) IInsertRows() {}
+func (Values) IInsertRows()           {}
+
+// Update represents an UPDATE statement. Table holds the table
+// updated, or the tables and joins of a multiple-table UPDATE.
+type Update struct {
+	Comments  Comments
+	Hints     Hints
+	Priority  string
+	Ignore    bool
+	Table     TableExprs
+	Exprs     UpdateExprs
+	Where     *Where
+	OrderBy   OrderBy
+	Limit     *Limit
+	Returning SelectExprs
+}
+
+func (node *Update) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("update %v%v", node.Comments, node.Hints)
+	formatModifiers(buf, node.Priority, node.Ignore)
+	buf.Myprintf("%v set %v%v%v%v",
+		node.Table, node.Exprs, node.Where, node.OrderBy, node.Limit)
+	formatReturning(buf, node.Returning)
+}
+
+// Delete represents a DELETE statement. Table is set for a
+// single-table DELETE, and Targets and From for one that deletes
+// from the Targets among the tables of From. Using is set if
+// these were given as DELETE FROM ... USING.
+type Delete struct {
+	Comments  Comments
+	Hints     Hints
+	Priority  string
+	Quick     bool
+	Ignore    bool
+	Table     *TableName
+	Targets   []*TableName
+	From      TableExprs
+	Using     bool
+	Where     *Where
+	OrderBy   OrderBy
+	Limit     *Limit
+	Returning SelectExprs
+}
+
+func (node *Delete) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("delete %v%v", node.Comments, node.Hints)
+	formatModifiers(buf, node.Priority, false)
+	if node.Quick {
+		buf.WriteString("quick ")
+	}
+	if node.Ignore {
+		buf.WriteString("ignore ")
+	}
+	if len(node.Targets) == 0 {
+		buf.Myprintf("from %v%v%v%v",
+			node.Table, node.Where, node.OrderBy, node.Limit)
+		formatReturning(buf, node.Returning)
+		return
+	}
+	if node.Using {
+		buf.Myprintf("from ")
+	}
+	prefix := ""
+	for _, target := range node.Targets {
+		buf.Myprintf("%s%v", prefix, target)
+		prefix = ", "
+	}
+	if node.Using {
+		buf.Myprintf(" using %v%v", node.From, node.Where)
+		return
+	}
+	buf.Myprintf(" from %v%v", node.From, node.Where)
+}
+
+func formatReturning(buf *TrackedBuffer, exprs SelectExprs) {
+	if len(exprs) != 0 {
+		if buf.formatsFor(MySQL, BigQuery) {
+			buf.unsupported("returning")
+		}
+		buf.Myprintf(" returning %v", exprs)
+	}
+}
+
+// LoadData represents a LOAD DATA INFILE statement. Priority
+// is set by LOW_PRIORITY and CONCURRENT, and Duplicate by
+// REPLACE and IGNORE. IgnoreLines is the number of lines
+// skipped by IGNORE ... LINES, and is empty if none are.
+type LoadData struct {
+	Priority    string
+	Local       bool
+	File        StrVal
+	Duplicate   string
+	Table       *TableName
+	Partitions  Partitions
+	Charset     string
+	Fields      *LoadFields
+	Lines       *LoadLines
+	IgnoreLines NumVal
+	Columns     Columns
+	Set         UpdateExprs
+}
+
+// LoadData.Priority and Duplicate
+const (
+	AST_LOW_PRIORITY = "low_priority"
+	AST_CONCURRENT   = "concurrent"
+	AST_REPLACE      = "replace"
+)
+
+func (node *LoadData) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString("load data ")
+	if node.Priority != "" {
+		buf.Myprintf("%s ", node.Priority)
+	}
+	if node.Local {
+		buf.WriteString("local ")
+	}
+	buf.Myprintf("infile %v ", node.File)
+	if node.Duplicate != "" {
+		buf.Myprintf("%s ", node.Duplicate)
+	}
+	buf.Myprintf("into table %v%v", node.Table, node.Partitions)
+	if node.Charset != "" {
+		buf.Myprintf(" character set %s", node.Charset)
+	}
+	buf.Myprintf("%v%v", node.Fields, node.Lines)
+	if node.IgnoreLines != "" {
+		buf.Myprintf(" ignore %v lines", node.IgnoreLines)
+	}
+	if len(node.Columns) != 0 {
+		buf.Myprintf(" %v", node.Columns)
+	}
+	if len(node.Set) != 0 {
+		buf.Myprintf(" set %v", node.Set)
+	}
+}
+
+// LoadFields represents the FIELDS clause of LOAD DATA,
+// which also goes by COLUMNS. The options not given are nil.
+type LoadFields struct {
+	TerminatedBy       *StrVal
+	EnclosedBy         *StrVal
+	OptionallyEnclosed bool
+	EscapedBy          *StrVal
+}
+
+func (node *LoadFields) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString(" fields")
+	if node.TerminatedBy != nil {
+		buf.Myprintf(" terminated by %v", *node.TerminatedBy)
+	}
+	if node.EnclosedBy != nil {
+		if node.OptionallyEnclosed {
+			buf.WriteString(" optionally")
+		}
+		buf.Myprintf(" enclosed by %v", *node.EnclosedBy)
+	}
+	if node.EscapedBy != nil {
+		buf.Myprintf(" escaped by %v", *node.EscapedBy)
+	}
+}
+
+// LoadLines represents the LINES clause of LOAD DATA.
+// The options not given are nil.
+type LoadLines struct {
+	StartingBy   *StrVal
+	TerminatedBy *StrVal
+}
+
+func (node *LoadLines) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString(" lines")
+	if node.StartingBy != nil {
+		buf.Myprintf(" starting by %v", *node.StartingBy)
+	}
+	if node.TerminatedBy != nil {
+		buf.Myprintf(" terminated by %v", *node.TerminatedBy)
+	}
+}
+
+// Set represents a SET statement.
+type Set struct {
+	Comments Comments
+	Exprs    SetExprs
+}
+
+func (node *Set) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("set %v%v", node.Comments, node.Exprs)
+}
+
+// Begin represents a BEGIN or START TRANSACTION statement.
+// AccessMode is set by START TRANSACTION READ ONLY and
+// READ WRITE.
+type Begin struct {
+	AccessMode string
+}
+
+// Transaction access modes
+const (
+	AST_READ_ONLY  = "read only"
+	AST_READ_WRITE = "read write"
+)
+
+func (node *Begin) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.AccessMode != "" {
+		buf.Myprintf("start transaction %s", node.AccessMode)
+		return
+	}
+	buf.Myprintf("begin")
+}
+
+// Commit represents a COMMIT statement.
+type Commit struct{}
+
+func (node *Commit) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("commit")
+}
+
+// Rollback represents a ROLLBACK statement. Savepoint is set
+// by ROLLBACK TO SAVEPOINT.
+type Rollback struct {
+	Savepoint ColIdent
+}
+
+func (node *Rollback) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("rollback")
+	if !node.Savepoint.IsEmpty() {
+		buf.Myprintf(" to %v", node.Savepoint)
+	}
+}
+
+// Savepoint represents a SAVEPOINT statement.
+type Savepoint struct {
+	Name ColIdent
+}
+
+func (node *Savepoint) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("savepoint %v", node.Name)
+}
+
+// ReleaseSavepoint represents a RELEASE SAVEPOINT statement.
+type ReleaseSavepoint struct {
+	Name ColIdent
+}
+
+func (node *ReleaseSavepoint) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("release savepoint %v", node.Name)
+}
+
+// SetTransaction represents a SET TRANSACTION statement.
+// Scope is empty or one of the SetExpr.Scope values, and
+// AccessMode one of the Begin.AccessMode values.
+type SetTransaction struct {
+	Comments       Comments
+	Scope          string
+	IsolationLevel string
+	AccessMode     string
+}
+
+// SetTransaction.IsolationLevel
+const (
+	AST_READ_UNCOMMITTED = "read uncommitted"
+	AST_READ_COMMITTED   = "read committed"
+	AST_REPEATABLE_READ  = "repeatable read"
+	AST_SERIALIZABLE     = "serializable"
+)
+
+func (node *SetTransaction) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("set %v", node.Comments)
+	if node.Scope != "" {
+		buf.Myprintf("%s ", node.Scope)
+	}
+	buf.Myprintf("transaction")
+	prefix := " "
+	if node.IsolationLevel != "" {
+		buf.Myprintf("%sisolation level %s", prefix, node.IsolationLevel)
+		prefix = ", "
+	}
+	if node.AccessMode != "" {
+		buf.Myprintf("%s%s", prefix, node.AccessMode)
+	}
+}
+
+// Grant represents a GRANT statement.
+type Grant struct {
+	Privileges      []*Privilege
+	On              *GrantObject
+	To              []*Account
+	WithGrantOption bool
+}
+
+func (node *Grant) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("grant ")
+	formatPrivileges(buf, node.Privileges)
+	buf.Myprintf(" on %v to ", node.On)
+	formatAccounts(buf, node.To)
+	if node.WithGrantOption {
+		buf.Myprintf(" with grant option")
+	}
+}
+
+// Revoke represents a REVOKE statement.
+type Revoke struct {
+	Privileges []*Privilege
+	On         *GrantObject
+	From       []*Account
+}
+
+func (node *Revoke) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("revoke ")
+	formatPrivileges(buf, node.Privileges)
+	buf.Myprintf(" on %v from ", node.On)
+	formatAccounts(buf, node.From)
+}
+
+// Privilege represents a privilege granted or revoked. Type holds
+// its words in lower case, such as "select" or "grant option", and
+// Columns the columns it is restricted to, if any.
+type Privilege struct {
+	Type    string
+	Columns []ColIdent
+}
+
+func (node *Privilege) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%s", node.Type)
+	formatColumnAliases(buf, node.Columns)
+}
+
+func formatPrivileges(buf *TrackedBuffer, privileges []*Privilege) {
+	prefix := ""
+	for _, privilege := range privileges {
+		buf.Myprintf("%s%v", prefix, privilege)
+		prefix = ", "
+	}
+}
+
+// GrantObject represents the object of a GRANT or REVOKE
+// statement. Type is empty, AST_TABLE, AST_FUNCTION or
+// AST_PROCEDURE. Database and Name are "*" for all databases
+// or objects, and Database is empty if Name is unqualified.
+type GrantObject struct {
+	Type     string
+	Database TableIdent
+	Name     TableIdent
+}
+
+func (node *GrantObject) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Type != "" {
+		buf.Myprintf("%s ", node.Type)
+	}
+	if !node.Database.IsEmpty() {
+		formatGrantName(buf, node.Database)
+		buf.Myprintf(".")
+	}
+	formatGrantName(buf, node.Name)
+}
+
+func formatGrantName(buf *TrackedBuffer, name TableIdent) {
+	if name.val == "*" && !name.quoted {
+		buf.Myprintf("*")
+		return
+	}
+	buf.Myprintf("%v", name)
+}
+
+// Account represents a MySQL account, as in 'user'@'host'.
+// Host is empty if it was not given.
+type Account struct {
+	User, Host string
+}
+
+func (node *Account) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v", StrVal{Val: node.User})
+	if node.Host != "" {
+		buf.Myprintf("@%v", StrVal{Val: node.Host})
+	}
+}
+
+// newAccount returns the account written as the identifier id,
+// as in user@host.
+func newAccount(id string) *Account {
+	if i := strings.IndexByte(id, '@'); i >= 0 {
+		return &Account{User: id[:i], Host: id[i+1:]}
+	}
+	return &Account{User: id}
+}
+
+func formatAccounts(buf *TrackedBuffer, accounts []*Account) {
+	prefix := ""
+	for _, account := range accounts {
+		buf.Myprintf("%s%v", prefix, account)
+		prefix = ", "
+	}
+}
+
+// CreateUser represents a CREATE USER statement.
+type CreateUser struct {
+	IfNotExists bool
+	Users       []*UserSpec
+}
+
+func (node *CreateUser) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("create user ")
+	if node.IfNotExists {
+		buf.Myprintf("if not exists ")
+	}
+	formatUserSpecs(buf, node.Users)
+}
+
+// AlterUser represents an ALTER USER statement.
+type AlterUser struct {
+	IfExists bool
+	Users    []*UserSpec
+}
+
+func (node *AlterUser) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("alter user ")
+	if node.IfExists {
+		buf.Myprintf("if exists ")
+	}
+	formatUserSpecs(buf, node.Users)
+}
+
+// UserSpec represents an account of a CREATE USER or ALTER USER
+// statement along with its authentication. Password is set by
+// IDENTIFIED BY, Plugin by IDENTIFIED WITH, and AuthString by
+// IDENTIFIED WITH ... AS.
+type UserSpec struct {
+	Account    *Account
+	Plugin     string
+	Password   *StrVal
+	AuthString *StrVal
+}
+
+func (node *UserSpec) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v", node.Account)
+	if node.Plugin != "" {
+		buf.Myprintf(" identified with %s", node.Plugin)
+		if node.Password != nil {
+			buf.Myprintf(" by %v", *node.Password)
+		}
+		if node.AuthString != nil {
+			buf.Myprintf(" as %v", *node.AuthString)
+		}
+		return
+	}
+	if node.Password != nil {
+		buf.Myprintf(" identified by %v", *node.Password)
+	}
+}
+
+func formatUserSpecs(buf *TrackedBuffer, users []*UserSpec) {
+	prefix := ""
+	for _, user := range users {
+		buf.Myprintf("%s%v", prefix, user)
+		prefix = ", "
+	}
+}
+
+// SetPassword represents a SET PASSWORD statement. For is nil
+// if it sets the password of the current user.
+type SetPassword struct {
+	For      *Account
+	Password StrVal
+}
+
+func (node *SetPassword) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("set password")
+	if node.For != nil {
+		buf.Myprintf(" for %v", node.For)
+	}
+	buf.Myprintf(" = %v", node.Password)
+}
+
+// SetExprs represents a list of SET assignments.
+type SetExprs []*SetExpr
+
+func (node SetExprs) Format(buf *TrackedBuffer) {
+	var prefix string
+	for _, n := range node {
+		buf.Myprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// SetExpr represents a single SET assignment. Name is the
+// variable name without any sigil or scope prefix. SET NAMES
+// and SET CHARACTER SET are represented by the kinds AST_NAMES
+// and AST_CHARACTER_SET, which set Charset, which may be
+// AST_DEFAULT, instead of Name and Expr. Collation is set by
+// the COLLATE clause of SET NAMES.
+type SetExpr struct {
+	Scope     string
+	Kind      string
+	Name      ColIdent
+	Operator  string
+	Expr      ValExpr
+	Charset   string
+	Collation string
+}
+
+// SetExpr.Scope
+const (
+	AST_GLOBAL  = "global"
+	AST_SESSION = "session"
+	AST_LOCAL   = "local"
+)
+
+// SetExpr.Kind, besides AST_CHARACTER_SET
+const (
+	AST_SYSTEM_VAR = "@@"
+	AST_USER_VAR   = "@"
+	AST_NAMES      = "names"
+)
+
+// SetExpr.Operator
+const (
+	AST_ASSIGN = ":="
+)
+
+func (node *SetExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case AST_SYSTEM_VAR:
+		buf.Myprintf("@@")
+		if node.Scope != "" {
+			buf.Myprintf("%s.", node.Scope)
+		}
+		buf.Myprintf("%s", node.Name.String())
+	case AST_USER_VAR:
+		buf.Myprintf("@%v", node.Name)
+	case AST_NAMES, AST_CHARACTER_SET:
+		buf.Myprintf("%s ", node.Kind)
+		formatCharsetName(buf, node.Charset)
+		if node.Collation != "" {
+			buf.Myprintf(" collate ")
+			formatCharsetName(buf, node.Collation)
+		}
+		return
+	default:
+		if node.Scope != "" {
+			buf.Myprintf("%s ", node.Scope)
+		}
+		buf.Myprintf("%v", node.Name)
+	}
+	operator := node.Operator
+	if operator == "" {
+		operator = AST_EQ
+	}
+	buf.Myprintf(" %s %v", operator, node.Expr)
+}
+
+// newSetExpr builds a SetExpr out of an optional scope keyword
+// and a variable reference that may carry the @@ sigil, as in
+// "@@global.max_connections". User variables are scanned as
+// USER_VAR tokens instead.
+func newSetExpr(scope string, col *ColName, operator string, expr ValExpr) (*SetExpr, error) {
+	node := &SetExpr{Scope: scope, Operator: operator, Expr: expr}
+	name := col.Name.String()
+	if !col.Qualifier.IsEmpty() {
+		qualifier := col.Qualifier.Lowered()
+		switch qualifier {
+		case "@@" + AST_GLOBAL, "@@" + AST_SESSION, "@@" + AST_LOCAL:
+		default:
+			return nil, fmt.Errorf("unexpected variable qualifier %s", col.Qualifier.String())
+		}
+		if scope != "" {
+			return nil, fmt.Errorf("scope specified twice for %s", name)
+		}
+		node.Scope = qualifier[2:]
+		node.Kind = AST_SYSTEM_VAR
+	} else if strings.HasPrefix(name, AST_SYSTEM_VAR) {
+		node.Kind = AST_SYSTEM_VAR
+		name = name[2:]
+	}
+	if node.Kind != "" && scope != "" {
+		return nil, fmt.Errorf("scope cannot be applied to %s", col.Name.String())
+	}
+	if name == "" || strings.HasPrefix(name, AST_USER_VAR) {
+		return nil, fmt.Errorf("invalid variable name %s", col.Name.String())
+	}
+	node.Name = NewColIdent(name)
+	return node, nil
+}
+
+// DDL represents a CREATE, ALTER, DROP, RENAME or TRUNCATE
+// statement other than CREATE TABLE. Kind is the type of object the statement acts
+// on: AST_TABLE, AST_VIEW, AST_INDEX or AST_DATABASE. Table names
+// that object, or for AST_INDEX the table the index belongs to.
+// NewName is only set for AST_RENAME. IndexName is set for
+// AST_INDEX, and Index holds the definition given to CREATE INDEX.
+// OrReplace, Columns, Select and CheckOption describe the view
+// created by CREATE VIEW. Tables lists the tables dropped by DROP
+// TABLE, and Renames the tables renamed by RENAME TABLE, of which
+// Table and NewName are the first. Temporary is set by DROP
+// TEMPORARY TABLE.
+type DDL struct {
+	Action      string
+	Kind        string
+	IfExists    bool
+	IfNotExists bool
+	Table       TableIdent
+	NewName     TableIdent
+	IndexName   ColIdent
+	Index       *IndexDefinition
+	OrReplace   bool
+	Columns     []ColIdent
+	Select      SelectStatement
+	CheckOption string
+	Tables      []TableIdent
+	Renames     []*TableRename
+	Temporary   bool
+}
+
+// TableRename represents one of the renames of a RENAME TABLE
+// statement.
+type TableRename struct {
+	From, To TableIdent
+}
+
+func (node *TableRename) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v to %v", node.From, node.To)
+}
+
+// DDL.CheckOption
+const (
+	AST_CHECK_OPTION          = "with check option"
+	AST_CASCADED_CHECK_OPTION = "with cascaded check option"
+	AST_LOCAL_CHECK_OPTION    = "with local check option"
+)
+
+type ColumnAtts []string
+
+func (node ColumnAtts) Format(buf *TrackedBuffer) {
+	prefix := " "
+	for _, v := range node {
+		if v != "" {
+			buf.Myprintf("%s%s", prefix, v)
+		}
+	}
+}
+
+// Nullability records whether a column definition
+// explicitly allows or disallows NULL values.
+type Nullability int
+
+const (
+	NullUnspecified Nullability = iota
+	NullAllowed
+	NullNotAllowed
+)
+
+// ColumnType represents the data type of a column. Length holds the
+// display width of integer types, the length of character types and
+// the precision of decimal types; Scale holds the number of digits
+// after the decimal point. EnumValues holds the members of an ENUM
+// or SET type. Charset and Collate are only set for character
+// types.
+type ColumnType struct {
+	Type       string
+	Length     NumVal
+	Scale      NumVal
+	Unsigned   bool
+	Zerofill   bool
+	Charset    string
+	Collate    string
+	EnumValues []string
+}
+
+func (node ColumnType) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%s", node.Type)
+	switch {
+	case node.EnumValues != nil:
+		buf.Myprintf("(")
+		for i, v := range node.EnumValues {
+			if i > 0 {
+				buf.Myprintf(", ")
+			}
+			StrVal{Val: v}.Format(buf)
+		}
+		buf.Myprintf(")")
+	case node.Scale != "":
+		buf.Myprintf("(%s, %s)", string(node.Length), string(node.Scale))
+	case node.Length != "":
+		buf.Myprintf("(%s)", string(node.Length))
+	}
+	if node.Unsigned {
+		buf.Myprintf(" %s", AST_UNSIGNED)
+	}
+	if node.Zerofill {
+		buf.Myprintf(" %s", AST_ZEROFILL)
+	}
+	if node.Charset != "" {
+		buf.Myprintf(" character set %s", node.Charset)
+	}
+	if node.Collate != "" {
+		buf.Myprintf(" collate ")
+		formatCharsetName(buf, node.Collate)
+	}
+}
+
+// IsNumeric reports whether node is an integer, decimal,
+// floating point or BIT type.
+func (node ColumnType) IsNumeric() bool {
+	switch node.Type {
+	case AST_BIT, AST_TINYINT, AST_SMALLINT, AST_MEDIUMINT, AST_INT, AST_INTEGER, AST_BIGINT,
+		AST_REAL, AST_DOUBLE, AST_FLOAT, AST_DECIMAL, AST_NUMERIC:
+		return true
+	}
+	return false
+}
+
+// IsTextual reports whether node is a character type, including
+// ENUM and SET.
+func (node ColumnType) IsTextual() bool {
+	switch node.Type {
+	case AST_CHAR, AST_VARCHAR, AST_TEXT, AST_ENUM, AST_SET:
+		return true
+	}
+	return false
+}
+
+// IsTemporal reports whether node is a date or time type.
+func (node ColumnType) IsTemporal() bool {
+	switch node.Type {
+	case AST_DATE, AST_TIME, AST_TIMESTAMP, AST_DATETIME, AST_YEAR:
+		return true
+	}
+	return false
+}
+
+// Size returns Length and Scale as numbers, or 0 for either if
+// it is not given.
+func (node ColumnType) Size() (length, scale int) {
+	length, _ = strconv.Atoi(string(node.Length))
+	scale, _ = strconv.Atoi(string(node.Scale))
+	return length, scale
+}
+
+// ColumnDefinition represents a column in a CREATE TABLE statement.
+// HasDefault distinguishes DEFAULT NULL, where DefaultValue is a
+// *NullVal, from a column without a default. DefaultValue can be
+// any expression, such as current_timestamp() or -1. OnUpdate is
+// the value of an ON UPDATE clause, such as current_timestamp(), or
+// nil. In both, CURRENT_TIMESTAMP, LOCALTIME and LOCALTIMESTAMP are
+// function calls, written with parentheses, whether or not they
+// were parsed with them. Comment is the string of a COMMENT clause.
+type ColumnDefinition struct {
+	ColName      string
+	ColType      ColumnType
+	Nullable     Nullability
+	HasDefault   bool
+	DefaultValue ValExpr
+	OnUpdate     ValExpr
+	ColumnAtts   ColumnAtts
+	Comment      string
+}
+
+func (node *ColumnDefinition) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	formatID(buf, node.ColName, false)
+	buf.Myprintf(" %v", node.ColType)
+	switch node.Nullable {
+	case NullAllowed:
+		buf.Myprintf(" %s", AST_NULL)
+	case NullNotAllowed:
+		buf.Myprintf(" %s", AST_NOT_NULL)
+	}
+	if node.HasDefault {
+		buf.Myprintf(" %s %v", AST_DEFAULT, node.DefaultValue)
+	}
+	if node.OnUpdate != nil {
+		buf.Myprintf(" %s %v", AST_ON_UPDATE, node.OnUpdate)
+	}
+	buf.Myprintf("%v", node.ColumnAtts)
+	if node.Comment != "" {
+		buf.Myprintf(" %s %v", AST_COMMENT, StrVal{Val: node.Comment})
+	}
+}
+
+// timestampFuncs are the functions that give the current time,
+// and may be called without parentheses in DEFAULT and ON UPDATE.
+var timestampFuncs = map[string]bool{
+	"current_timestamp": true,
+	"localtime":         true,
+	"localtimestamp":    true,
+}
+
+// timestampFunc returns expr, the value of a DEFAULT or ON UPDATE
+// clause, as a call of the function it names if it is one of the
+// timestampFuncs written without parentheses.
+func timestampFunc(expr ValExpr) ValExpr {
+	col, ok := expr.(*ColName)
+	if !ok || !col.Qualifier.IsEmpty() || col.Name.quoted || !timestampFuncs[col.Name.Lowered()] {
+		return expr
+	}
+	return &FuncExpr{Name: col.Name}
+}
+
+type ColumnDefinitions []*ColumnDefinition
+
+func (node ColumnDefinitions) Format(buf *TrackedBuffer) {
+	prefix := ""
+	buf.Myprintf("(\n")
+	for i := 0; i < len(node); i++ {
+		buf.Myprintf("%s\t%v", prefix, node[i])
+		prefix = ",\n"
+	}
+	buf.Myprintf("\n)")
+}
+
+// IndexDefinition represents a PRIMARY KEY, UNIQUE KEY, FOREIGN KEY,
+// FULLTEXT KEY, SPATIAL KEY, plain index or CHECK constraint in a
+// CREATE TABLE statement. Type is AST_PRIMARY_KEY, AST_UNIQUE_KEY,
+// AST_FOREIGN_KEY, AST_FULLTEXT_KEY, AST_SPATIAL_KEY, AST_INDEX or
+// AST_CHECK. Constraint holds the name given in a CONSTRAINT
+// clause, and Using the index type, such as btree. References is
+// only set for AST_FOREIGN_KEY, and Check, the checked condition,
+// for AST_CHECK, which has no columns. NotEnforced is set by NOT
+// ENFORCED.
+type IndexDefinition struct {
+	Constraint  ColIdent
+	Type        string
+	Name        ColIdent
+	Columns     IndexColumns
+	Using       string
+	Options     IndexOptions
+	References  *References
+	Check       BoolExpr
+	NotEnforced bool
+}
+
+const (
+	AST_INDEX        = "index"
+	AST_FOREIGN_KEY  = "foreign key"
+	AST_FULLTEXT_KEY = "fulltext key"
+	AST_SPATIAL_KEY  = "spatial key"
+	AST_CHECK        = "check"
+)
+
+func (node *IndexDefinition) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if !node.Constraint.IsEmpty() {
+		buf.Myprintf("constraint %v ", node.Constraint)
+	}
+	if node.Type == AST_CHECK {
+		buf.Myprintf("check (%v)", node.Check)
+		if node.NotEnforced {
+			buf.Myprintf(" not enforced")
+		}
+		return
+	}
+	buf.Myprintf("%s", node.Type)
+	if !node.Name.IsEmpty() {
+		buf.Myprintf(" %v", node.Name)
+	}
+	buf.Myprintf(" %v", node.Columns)
+	if node.Using != "" {
+		buf.Myprintf(" using %s", node.Using)
+	}
+	buf.Myprintf("%v%v", node.Options, node.References)
+}
+
+type IndexOptions []*IndexOption
+
+func (node IndexOptions) Format(buf *TrackedBuffer) {
+	for _, opt := range node {
+		buf.Myprintf(" %v", opt)
+	}
+}
+
+// IndexOption represents an option of CREATE INDEX following its
+// columns, such as COMMENT 'x', KEY_BLOCK_SIZE=8, INVISIBLE or
+// ALGORITHM=INPLACE. Name is lower case. Value holds identifiers
+// and numbers as written, and strings without their quotes, which
+// may leave it empty. It is always empty for VISIBLE and INVISIBLE.
+type IndexOption struct {
+	Name  string
+	Value string
+}
+
+// IndexOption.Name
+const (
+	AST_VISIBLE     = "visible"
+	AST_INVISIBLE   = "invisible"
+	AST_LOCK        = "lock"
+	AST_WITH_PARSER = "with parser"
+)
+
+func (node *IndexOption) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	switch {
+	case node.Name == AST_VISIBLE || node.Name == AST_INVISIBLE:
+		buf.Myprintf("%s", node.Name)
+	case node.Name == AST_COMMENT:
+		buf.Myprintf("%s %v", node.Name, StrVal{Val: node.Value})
+	case node.Name == AST_WITH_PARSER:
+		buf.Myprintf("%s %v", node.Name, NewColIdent(node.Value))
+	case isBareWord(node.Value):
+		buf.Myprintf("%s=%s", node.Name, node.Value)
+	default:
+		buf.Myprintf("%s=%v", node.Name, StrVal{Val: node.Value})
+	}
+}
+
+// References represents the REFERENCES clause of a foreign key.
+// OnDelete and OnUpdate are empty unless the clause gives a
+// referential action, such as AST_CASCADE.
+type References struct {
+	Table    TableIdent
+	Columns  IndexColumns
+	OnDelete string
+	OnUpdate string
+}
+
+// References.OnDelete, References.OnUpdate
+const (
+	AST_CASCADE     = "cascade"
+	AST_RESTRICT    = "restrict"
+	AST_SET_NULL    = "set null"
+	AST_SET_DEFAULT = "set default"
+	AST_NO_ACTION   = "no action"
+)
+
+func (node *References) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf(" references %v %v", node.Table, node.Columns)
+	if node.OnDelete != "" {
+		buf.Myprintf(" on delete %s", node.OnDelete)
+	}
+	if node.OnUpdate != "" {
+		buf.Myprintf(" on update %s", node.OnUpdate)
+	}
+}
+
+// IndexColumns represents the column list of an index definition.
+type IndexColumns []*IndexColumn
+
+func (node IndexColumns) Format(buf *TrackedBuffer) {
+	prefix := "("
+	for _, col := range node {
+		buf.Myprintf("%s%v", prefix, col)
+		prefix = ", "
+	}
+	buf.Myprintf(")")
+}
+
+// IndexColumn represents a column in an index definition. Length
+// is set for prefix indexes, and Direction is empty unless ASC or
+// DESC was given.
+type IndexColumn struct {
+	Column    ColIdent
+	Length    NumVal
+	Direction string
+}
+
+func (node *IndexColumn) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v", node.Column)
+	if node.Length != "" {
+		buf.Myprintf("(%s)", string(node.Length))
+	}
+	if node.Direction != "" {
+		buf.Myprintf(" %s", node.Direction)
+	}
+}
+
+// CreateTable represents a CREATE TABLE statement. Indexes holds
+// the index and key constraint definitions, which are formatted
+// after the columns, and Options the table options that follow
+// the closing parenthesis. Temporary is set by CREATE TEMPORARY
+// TABLE. LikeTable is set by CREATE TABLE ... LIKE, which has no
+// definitions, and Select by CREATE TABLE ... AS SELECT, whose
+// definitions are optional. Partitions holds the partitioning
+// after PARTITION BY as written, which is not parsed.
+type CreateTable struct {
+	Temporary         bool
+	IfNotExists       bool
+	Name              TableIdent
+	ColumnDefinitions ColumnDefinitions
+	Indexes           []*IndexDefinition
+	Options           TableOptions
+	Partitions        string
+	LikeTable         *TableName
+	Select            SelectStatement
+}
+
+func (node *CreateTable) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("create ")
+	if node.Temporary {
+		buf.Myprintf("temporary ")
+	}
+	buf.Myprintf("table ")
+	if node.IfNotExists {
+		buf.Myprintf("if not exists ")
+	}
+	buf.Myprintf("%v", node.Name)
+	if node.LikeTable != nil {
+		buf.Myprintf(" like %v", node.LikeTable)
+		return
+	}
+	switch {
+	case len(node.Indexes) != 0:
+		buf.Myprintf(" (\n")
+		prefix := ""
+		for _, col := range node.ColumnDefinitions {
+			buf.Myprintf("%s\t%v", prefix, col)
+			prefix = ",\n"
+		}
+		for _, index := range node.Indexes {
+			buf.Myprintf("%s\t%v", prefix, index)
+			prefix = ",\n"
+		}
+		buf.Myprintf("\n)")
+	case len(node.ColumnDefinitions) != 0:
+		buf.Myprintf(" %v", node.ColumnDefinitions)
+	}
+	buf.Myprintf("%v", node.Options)
+	if node.Partitions != "" {
+		buf.Myprintf(" partition by %s", node.Partitions)
+	}
+	if node.Select != nil {
+		buf.Myprintf(" as %v", node.Select)
+	}
+}
+func (node *CreateTable) IStatement() {}
+
+// TableOptions represents the table options of a CREATE TABLE
+// statement.
+type TableOptions []*TableOption
+
+func (node TableOptions) Format(buf *TrackedBuffer) {
+	for _, opt := range node {
+		buf.Myprintf(" %v", opt)
+	}
+}
+
+// TableOption represents a table option, such as ENGINE=InnoDB.
+// Name is lower case, and is AST_CHARACTER_SET for both CHARSET and
+// CHARACTER SET. Value holds identifiers and numbers as written,
+// and strings without their quotes.
+type TableOption struct {
+	Name  string
+	Value string
+}
+
+// TableOption.Name
+const (
+	AST_ENGINE        = "engine"
+	AST_CHARACTER_SET = "character set"
+	AST_COLLATE       = "collate"
+)
+
+// tableOptionNames holds the names of the table options that are
+// not keywords, which are the only ones given by an identifier.
+var tableOptionNames = map[string]bool{
+	"autoextend_size":            true,
+	"avg_row_length":             true,
+	"checksum":                   true,
+	"comment":                    true,
+	"compression":                true,
+	"connection":                 true,
+	"delay_key_write":            true,
+	"encryption":                 true,
+	"engine":                     true,
+	"engine_attribute":           true,
+	"insert_method":              true,
+	"key_block_size":             true,
+	"max_rows":                   true,
+	"min_rows":                   true,
+	"pack_keys":                  true,
+	"password":                   true,
+	"row_format":                 true,
+	"secondary_engine_attribute": true,
+	"stats_auto_recalc":          true,
+	"stats_persistent":           true,
+	"stats_sample_pages":         true,
+	"tablespace":                 true,
+}
+
+func (node *TableOption) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%s=", node.Name)
+	if node.Name == AST_COMMENT || !isBareWord(node.Value) {
+		buf.Myprintf("%v", StrVal{Val: node.Value})
+		return
+	}
+	buf.Myprintf("%s", node.Value)
+}
+
+// isBareWord reports whether s can be written without quotes
+// as the value of a table option.
+func isBareWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if ch := uint16(s[i]); !isLetter(ch) && !isDigit(ch) {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	AST_TABLE    = "table"
+	AST_VIEW     = "view"
+	AST_DATABASE = "database"
+)
+
+const (
+	AST_CREATE   = "create"
+	AST_ALTER    = "alter"
+	AST_DROP     = "drop"
+	AST_RENAME   = "rename"
+	AST_TRUNCATE = "truncate"
+)
+
+func (node *DDL) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Kind == AST_INDEX {
+		node.formatIndex(buf)
+		return
+	}
+	if node.Kind == AST_VIEW && node.Select != nil {
+		node.formatView(buf)
+		return
+	}
+	buf.Myprintf("%s ", node.Action)
+	if node.Temporary {
+		buf.Myprintf("temporary ")
+	}
+	buf.Myprintf("%s ", node.Kind)
+	if node.IfExists {
+		buf.Myprintf("if exists ")
+	}
+	if node.IfNotExists {
+		buf.Myprintf("if not exists ")
+	}
+	switch {
+	case len(node.Tables) != 0:
+		prefix := ""
+		for _, table := range node.Tables {
+			buf.Myprintf("%s%v", prefix, table)
+			prefix = ", "
+		}
+	case len(node.Renames) != 0:
+		prefix := ""
+		for _, rename := range node.Renames {
+			buf.Myprintf("%s%v", prefix, rename)
+			prefix = ", "
+		}
+	default:
+		buf.Myprintf("%v", node.Table)
+		if node.Action == AST_RENAME {
+			buf.Myprintf(" to %v", node.NewName)
+		}
+	}
+}
+
+func (node *DDL) formatView(buf *TrackedBuffer) {
+	buf.Myprintf("%s ", node.Action)
+	if node.OrReplace {
+		buf.Myprintf("or replace ")
+	}
+	buf.Myprintf("view %v", node.Table)
+	formatColumnAliases(buf, node.Columns)
+	buf.Myprintf(" as %v", node.Select)
+	if node.CheckOption != "" {
+		buf.Myprintf(" %s", node.CheckOption)
+	}
+}
+
+func (node *DDL) formatIndex(buf *TrackedBuffer) {
+	if node.Action != AST_CREATE || node.Index == nil {
+		buf.Myprintf("%s index ", node.Action)
+		if node.IfExists {
+			buf.Myprintf("if exists ")
+		}
+		buf.Myprintf("%v on %v", node.IndexName, node.Table)
+		return
+	}
+	buf.Myprintf("create ")
+	if node.Index.Type == AST_UNIQUE_KEY {
+		buf.Myprintf("unique ")
+	}
+	buf.Myprintf("index ")
+	if node.IfNotExists {
+		buf.Myprintf("if not exists ")
+	}
+	buf.Myprintf("%v", node.IndexName)
+	if node.Index.Using != "" {
+		buf.Myprintf(" using %s", node.Index.Using)
+	}
+	buf.Myprintf(" on %v %v%v", node.Table, node.Index.Columns, node.Index.Options)
+}
+
+// AlterTable represents an ALTER TABLE statement that adds, drops,
+// modifies or renames columns and indexes, or sets table options.
+// ALTER TABLE statements
+// with other operations are parsed as a DDL, as are those that
+// rename the table.
+type AlterTable struct {
+	Ignore bool
+	Table  TableIdent
+	Specs  []*AlterSpec
+}
+
+func (node *AlterTable) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("alter ")
+	if node.Ignore {
+		buf.Myprintf("ignore ")
+	}
+	buf.Myprintf("table %v", node.Table)
+	prefix := " "
+	for _, spec := range node.Specs {
+		buf.Myprintf("%s%v", prefix, spec)
+		prefix = ", "
+	}
+}
+
+// AlterSpec represents one operation of an ALTER TABLE statement.
+// Column holds the definition of an added, modified or changed
+// column, and Index that of an added index or key constraint.
+// Name is the column or index that is dropped, changed or renamed,
+// and NewName its new name. First and After give the position of
+// an added, modified or changed column. Option is the table option
+// set by a spec such as ENGINE=InnoDB.
+type AlterSpec struct {
+	Action  string
+	Column  *ColumnDefinition
+	Index   *IndexDefinition
+	Name    ColIdent
+	NewName ColIdent
+	First   bool
+	After   ColIdent
+	Option  *TableOption
+}
+
+// AlterSpec.Action
+const (
+	AST_ADD_COLUMN       = "add column"
+	AST_ADD_INDEX        = "add"
+	AST_DROP_COLUMN      = "drop column"
+	AST_DROP_INDEX       = "drop index"
+	AST_DROP_PRIMARY_KEY = "drop primary key"
+	AST_DROP_FOREIGN_KEY = "drop foreign key"
+	AST_MODIFY_COLUMN    = "modify column"
+	AST_CHANGE_COLUMN    = "change column"
+	AST_RENAME_COLUMN    = "rename column"
+	AST_RENAME_INDEX     = "rename index"
+	AST_TABLE_OPTION     = "table option"
+)
+
+func (node *AlterSpec) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Action == AST_TABLE_OPTION {
+		buf.Myprintf("%v", node.Option)
+		return
+	}
+	buf.Myprintf("%s", node.Action)
+	switch node.Action {
+	case AST_ADD_COLUMN, AST_MODIFY_COLUMN:
+		buf.Myprintf(" %v", node.Column)
+	case AST_CHANGE_COLUMN:
+		buf.Myprintf(" %v %v", node.Name, node.Column)
+	case AST_ADD_INDEX:
+		buf.Myprintf(" %v", node.Index)
+	case AST_DROP_COLUMN, AST_DROP_INDEX, AST_DROP_FOREIGN_KEY:
+		buf.Myprintf(" %v", node.Name)
+	case AST_RENAME_COLUMN, AST_RENAME_INDEX:
+		buf.Myprintf(" %v to %v", node.Name, node.NewName)
+	}
+	if node.First {
+		buf.Myprintf(" first")
+	} else if !node.After.IsEmpty() {
+		buf.Myprintf(" after %v", node.After)
+	}
+}
+
+// Sequence represents a CREATE, ALTER or DROP SEQUENCE statement.
+// Action is AST_CREATE, AST_ALTER or AST_DROP. IfNotExists is set
+// for CREATE SEQUENCE IF NOT EXISTS, and IfExists for DROP SEQUENCE
+// IF EXISTS.
+type Sequence struct {
+	Action      string
+	IfNotExists bool
+	IfExists    bool
+	Name        *TableName
+	Options     SequenceOptions
+}
+
+func (node *Sequence) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	var exists string
+	switch {
+	case node.IfNotExists:
+		exists = " if not exists"
+	case node.IfExists:
+		exists = " if exists"
+	}
+	buf.Myprintf("%s sequence%s %v%v", node.Action, exists, node.Name, node.Options)
+}
+
+// CreateRoutine represents a CREATE PROCEDURE or CREATE FUNCTION
+// statement. Kind is AST_PROCEDURE or AST_FUNCTION. The routine
+// is not parsed: Body holds the rest of the statement after the
+// name as written, starting with the parameter list.
+type CreateRoutine struct {
+	Kind        string
+	IfNotExists bool
+	Name        *TableName
+	Body        string
+}
+
+// CreateRoutine.Kind
+const (
+	AST_PROCEDURE = "procedure"
+	AST_FUNCTION  = "function"
+)
+
+func (node *CreateRoutine) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	var exists string
+	if node.IfNotExists {
+		exists = " if not exists"
+	}
+	buf.Myprintf("create %s%s %v%s", node.Kind, exists, node.Name, node.Body)
+}
+
+// SequenceOptions represents the options of a sequence, in the
+// order they were specified.
+type SequenceOptions []*SequenceOption
+
+func (node SequenceOptions) Format(buf *TrackedBuffer) {
+	for _, n := range node {
+		buf.Myprintf(" %v", n)
+	}
+}
+
+// SequenceOption represents a single sequence option.
+// Value is empty for options that do not take one.
+type SequenceOption struct {
+	Name  string
+	Value NumVal
+}
+
+// SequenceOption.Name
+const (
+	AST_SEQ_START       = "start"
+	AST_SEQ_INCREMENT   = "increment"
+	AST_SEQ_RESTART     = "restart"
+	AST_SEQ_MINVALUE    = "minvalue"
+	AST_SEQ_NO_MINVALUE = "no minvalue"
+	AST_SEQ_MAXVALUE    = "maxvalue"
+	AST_SEQ_NO_MAXVALUE = "no maxvalue"
+	AST_SEQ_CACHE       = "cache"
+	AST_SEQ_NOCACHE     = "nocache"
+	AST_SEQ_CYCLE       = "cycle"
+	AST_SEQ_NO_CYCLE    = "no cycle"
+)
+
+func (node *SequenceOption) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%s", node.Name)
+	if node.Value == "" {
+		return
+	}
+	switch node.Name {
+	case AST_SEQ_START, AST_SEQ_RESTART:
+		buf.Myprintf(" with")
+	case AST_SEQ_INCREMENT:
+		buf.Myprintf(" by")
+	}
+	buf.Myprintf(" %v", node.Value)
+}
+
+// newSequenceOptions builds SequenceOptions out of the words and
+// numbers following a sequence name. Noise words such as WITH, BY
+// and = are dropped, and NOMINVALUE, NOMAXVALUE and NOCYCLE are
+// normalized to their two word form.
+func newSequenceOptions(items []string) (SequenceOptions, error) {
+	var options SequenceOptions
+	isNumber := func(i int) bool {
+		return i < len(items) && items[i] != "" && (isDigit(uint16(items[i][0])) || items[i][0] == '-')
+	}
+	for i := 0; i < len(items); i++ {
+		option := &SequenceOption{Name: items[i]}
+		switch items[i] {
+		case "no":
+			if i+1 < len(items) {
+				i++
+				switch items[i] {
+				case AST_SEQ_MINVALUE, AST_SEQ_MAXVALUE, AST_SEQ_CYCLE:
+					option.Name = "no " + items[i]
+				case AST_SEQ_CACHE:
+					option.Name = AST_SEQ_NOCACHE
+				default:
+					return nil, fmt.Errorf("unexpected sequence option no %s", items[i])
+				}
+			} else {
+				return nil, errors.New("unexpected end of sequence options")
+			}
+		case "nominvalue", "nomaxvalue", "nocycle":
+			option.Name = "no " + items[i][2:]
+		case AST_SEQ_NOCACHE, AST_SEQ_CYCLE:
+		case AST_SEQ_START, AST_SEQ_INCREMENT, AST_SEQ_RESTART, AST_SEQ_MINVALUE, AST_SEQ_MAXVALUE, AST_SEQ_CACHE:
+			if i+1 < len(items) {
+				switch next := items[i+1]; {
+				case next == "=",
+					next == "with" && (option.Name == AST_SEQ_START || option.Name == AST_SEQ_RESTART),
+					next == "by" && option.Name == AST_SEQ_INCREMENT:
+					i++
+					if !isNumber(i + 1) {
+						return nil, fmt.Errorf("missing value for sequence option %s", option.Name)
+					}
+				}
+			}
+			if isNumber(i + 1) {
+				i++
+				option.Value = NumVal(items[i])
+			} else if option.Name != AST_SEQ_RESTART {
+				return nil, fmt.Errorf("missing value for sequence option %s", option.Name)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected sequence option %s", items[i])
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+// DeclareCursor represents a DECLARE ... CURSOR FOR statement.
+type DeclareCursor struct {
+	Name   ColIdent
+	Select SelectStatement
+}
+
+func (node *DeclareCursor) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("declare %v cursor for %v", node.Name, node.Select)
+}
+
+// OpenCursor represents an OPEN statement.
+type OpenCursor struct {
+	Name ColIdent
+}
+
+// Cursor statements
+const (
+	AST_OPEN  = "open"
+	AST_CLOSE = "close"
+)
+
+func (node *OpenCursor) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("open %v", node.Name)
+}
+
+// FetchCursor represents a FETCH ... INTO statement. The names
+// of user variables in Into keep their @.
+type FetchCursor struct {
+	Name ColIdent
+	Into []ColIdent
+}
+
+func (node *FetchCursor) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("fetch %v into ", node.Name)
+	prefix := ""
+	for _, n := range node.Into {
+		buf.Myprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// CloseCursor represents a CLOSE statement.
+type CloseCursor struct {
+	Name ColIdent
+}
+
+func (node *CloseCursor) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("close %v", node.Name)
+}
+
+// Prepare represents a PREPARE statement. Stmt is the text
+// of the prepared statement, given as a StrVal or a *UserVar.
+type Prepare struct {
+	Name ColIdent
+	Stmt ValExpr
+}
+
+func (node *Prepare) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("prepare %v from %v", node.Name, node.Stmt)
+}
+
+// Execute represents an EXECUTE statement of a prepared
+// statement, with the user variables of its USING clause.
+type Execute struct {
+	Name  ColIdent
+	Using []*UserVar
+}
+
+func (node *Execute) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("execute %v", node.Name)
+	prefix := " using "
+	for _, v := range node.Using {
+		buf.Myprintf("%s%v", prefix, v)
+		prefix = ", "
+	}
+}
+
+// Deallocate represents a DEALLOCATE PREPARE statement, or
+// the DROP PREPARE statement that is its synonym.
+type Deallocate struct {
+	Name ColIdent
+}
+
+func (node *Deallocate) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("deallocate prepare %v", node.Name)
+}
+
+// Call represents a CALL statement.
+type Call struct {
+	Name *TableName
+	Args ValExprs
+}
+
+func (node *Call) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("call %v(%v)", node.Name, node.Args)
+}
+
+// Statements represents the statements of a compound
+// statement. Each statement is followed by a semicolon.
+type Statements []Statement
+
+func (node Statements) Format(buf *TrackedBuffer) {
+	for _, n := range node {
+		buf.Myprintf("%v; ", n)
+	}
+}
+
+// formatLabel formats the label preceding a compound statement.
+func formatLabel(buf *TrackedBuffer, label ColIdent) {
+	if !label.IsEmpty() {
+		buf.Myprintf("%v: ", label)
+	}
+}
+
+// formatEndLabel formats the label following a compound statement.
+func formatEndLabel(buf *TrackedBuffer, label ColIdent) {
+	if !label.IsEmpty() {
+		buf.Myprintf(" %v", label)
+	}
+}
+
+// Block represents a BEGIN ... END compound statement.
+type Block struct {
+	Label      ColIdent
+	Statements Statements
+	EndLabel   ColIdent
+}
+
+func (node *Block) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	formatLabel(buf, node.Label)
+	buf.Myprintf("begin %vend", node.Statements)
+	formatEndLabel(buf, node.EndLabel)
+}
+
+// IfStatement represents an IF statement. Else is nil
+// if there is no ELSE branch.
+type IfStatement struct {
+	Cond    BoolExpr
+	Then    Statements
+	ElseIfs []*ElseIf
+	Else    Statements
+}
+
+func (node *IfStatement) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("if %v then %v", node.Cond, node.Then)
+	for _, n := range node.ElseIfs {
+		buf.Myprintf("%v", n)
+	}
+	if node.Else != nil {
+		buf.Myprintf("else %v", node.Else)
+	}
+	buf.Myprintf("end if")
+}
+
+// ElseIf represents an ELSEIF branch of an IF statement.
+type ElseIf struct {
+	Cond BoolExpr
+	Then Statements
+}
+
+func (node *ElseIf) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("elseif %v then %v", node.Cond, node.Then)
+}
+
+// While represents a WHILE ... DO loop.
+type While struct {
+	Label    ColIdent
+	Cond     BoolExpr
+	Body     Statements
+	EndLabel ColIdent
+}
+
+func (node *While) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	formatLabel(buf, node.Label)
+	buf.Myprintf("while %v do %vend while", node.Cond, node.Body)
+	formatEndLabel(buf, node.EndLabel)
+}
+
+// Loop represents a LOOP statement.
+type Loop struct {
+	Label    ColIdent
+	Body     Statements
+	EndLabel ColIdent
+}
+
+func (node *Loop) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	formatLabel(buf, node.Label)
+	buf.Myprintf("loop %vend loop", node.Body)
+	formatEndLabel(buf, node.EndLabel)
+}
+
+// Repeat represents a REPEAT ... UNTIL loop.
+type Repeat struct {
+	Label    ColIdent
+	Body     Statements
+	Until    BoolExpr
+	EndLabel ColIdent
+}
+
+func (node *Repeat) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	formatLabel(buf, node.Label)
+	buf.Myprintf("repeat %vuntil %v end repeat", node.Body, node.Until)
+	formatEndLabel(buf, node.EndLabel)
+}
+
+// Leave represents a LEAVE statement.
+type Leave struct {
+	Label ColIdent
+}
+
+func (node *Leave) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
 	}
-	return tokenizer.ParseTree, nil
+	buf.Myprintf("leave %v", node.Label)
 }
 
-// SQLNode defines the interface for all nodes
-// generated by the parser.
-type SQLNode interface {
-	Format(buf *TrackedBuffer)
+// Iterate represents an ITERATE statement.
+type Iterate struct {
+	Label ColIdent
 }
 
-// String returns a string representation of an SQLNode.
-func String(node SQLNode) string {
-	buf := NewTrackedBuffer(nil)
-	buf.Myprintf("%v", node)
-	return buf.String()
+func (node *Iterate) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("iterate %v", node.Label)
 }
 
-// Statement represents a statement.
-type Statement interface {
-	IStatement()
-	SQLNode
+// DeclareVars represents a DECLARE statement for local variables.
+type DeclareVars struct {
+	Names   []ColIdent
+	Type    ColumnType
+	Default ValExpr
 }
 
-func (*Union) IStatement()  {}
-func (*Select) IStatement() {}
-func (*Insert) IStatement() {}
-func (*Update) IStatement() {}
-func (*Delete) IStatement() {}
-func (*Set) IStatement()    {}
-func (*DDL) IStatement()    {}
-func (*Other) IStatement()  {}
-
-// SelectStatement any SELECT statement.
-type SelectStatement interface {
-	ISelectStatement()
-	IStatement()
-	IInsertRows()
-	SQLNode
+func (node *DeclareVars) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("declare ")
+	prefix := ""
+	for _, n := range node.Names {
+		buf.Myprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+	buf.Myprintf(" %v", node.Type)
+	if node.Default != nil {
+		buf.Myprintf(" default %v", node.Default)
+	}
 }
 
-func (*Select) ISelectStatement() {}
-func (*Union) ISelectStatement()  {}
-
-// Select represents a SELECT statement.
-type Select struct {
-	Comments    Comments
-	Distinct    string
-	SelectExprs SelectExprs
-	From        TableExprs
-	Where       *Where
-	TimeRange   *TimeRange
-	GroupBy     SelectExprs
-	Having      *Where
-	OrderBy     OrderBy
-	Limit       *Limit
-	Lock        string
+// DeclareHandler represents a DECLARE ... HANDLER statement.
+type DeclareHandler struct {
+	Action     string
+	Conditions []*HandlerCondition
+	Body       Statement
 }
 
-// Select.Distinct
+// DeclareHandler.Action
 const (
-	AST_DISTINCT = "distinct "
+	AST_CONTINUE = "continue"
+	AST_EXIT     = "exit"
 )
 
-// Select.Lock
+func (node *DeclareHandler) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("declare %s handler for ", node.Action)
+	prefix := ""
+	for _, n := range node.Conditions {
+		buf.Myprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+	buf.Myprintf(" %v", node.Body)
+}
+
+// HandlerCondition represents a condition of a handler. Value is
+// set for AST_SQLSTATE, AST_ERROR_CODE and AST_CONDITION_NAME.
+type HandlerCondition struct {
+	Type  string
+	Value string
+}
+
+// HandlerCondition.Type
 const (
-	AST_FOR_UPDATE = " for update"
-	AST_SHARE_MODE = " lock in share mode"
+	AST_SQLEXCEPTION   = "sqlexception"
+	AST_SQLWARNING     = "sqlwarning"
+	AST_NOT_FOUND      = "not found"
+	AST_SQLSTATE       = "sqlstate"
+	AST_ERROR_CODE     = "error code"
+	AST_CONDITION_NAME = "condition name"
 )
 
-func (node *Select) Format(buf *TrackedBuffer) {
-	buf.Myprintf("select %v%s%v from %v%v%v", node.Comments, node.Distinct,
-		node.SelectExprs, node.From, node.TimeRange, node.Where)
-	if len(node.GroupBy) > 0 {
-		buf.Myprintf(" group by %v", node.GroupBy)
+func (node *HandlerCondition) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	switch node.Type {
+	case AST_SQLSTATE:
+		buf.Myprintf("sqlstate %v", StrVal{Val: node.Value, Quote: '\''})
+	case AST_ERROR_CODE:
+		buf.Myprintf("%s", node.Value)
+	case AST_CONDITION_NAME:
+		buf.Myprintf("%v", NewColIdent(node.Value))
+	default:
+		buf.Myprintf("%s", node.Type)
 	}
-	buf.Myprintf("%v%v%v%s", node.Having, node.OrderBy, node.Limit, node.Lock)
 }
 
-// Union represents a UNION statement.
-type Union struct {
-	Type        string
-	Left, Right SelectStatement
+// Signal represents a SIGNAL or RESIGNAL statement. Condition is
+// an AST_SQLSTATE or AST_CONDITION_NAME condition, and is nil for
+// a RESIGNAL that passes on the condition being handled.
+type Signal struct {
+	Action    string
+	Condition *HandlerCondition
+	Items     []*SignalItem
 }
 
-// Union.Type
+// Signal.Action
 const (
-	AST_UNION     = "union"
-	AST_UNION_ALL = "union all"
-	AST_SET_MINUS = "minus"
-	AST_EXCEPT    = "except"
-	AST_INTERSECT = "intersect"
+	AST_SIGNAL   = "signal"
+	AST_RESIGNAL = "resignal"
 )
 
-func (node *Union) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%v %s %v", node.Left, node.Type, node.Right)
+func (node *Signal) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%s", node.Action)
+	if node.Condition != nil {
+		buf.Myprintf(" %v", node.Condition)
+	}
+	prefix := " set "
+	for _, n := range node.Items {
+		buf.Myprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
 }
 
-// Insert represents an INSERT statement.
-type Insert struct {
-	Comments Comments
-	Table    *TableName
-	Columns  Columns
-	Rows     InsertRows
-	OnDup    OnDup
+// SignalItem represents a condition information item set by a
+// SIGNAL or RESIGNAL statement, such as MESSAGE_TEXT. Name is
+// lower case.
+type SignalItem struct {
+	Name  string
+	Value ValExpr
 }
 
-func (node *Insert) Format(buf *TrackedBuffer) {
-	buf.Myprintf("insert %vinto %v%v %v%v",
-		node.Comments,
-		node.Table, node.Columns, node.Rows, node.OnDup)
+// signalItemNames is the set of condition information
+// items that SIGNAL and RESIGNAL can set.
+var signalItemNames = map[string]bool{
+	"class_origin":       true,
+	"subclass_origin":    true,
+	"message_text":       true,
+	"mysql_errno":        true,
+	"constraint_catalog": true,
+	"constraint_schema":  true,
+	"constraint_name":    true,
+	"catalog_name":       true,
+	"schema_name":        true,
+	"table_name":         true,
+	"column_name":        true,
+	"cursor_name":        true,
 }
 
-// InsertRows represents the rows for an INSERT statement.
-type InsertRows interface {
-	IInsertRows()
-	SQLNode
+func (node *SignalItem) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%s = %v", node.Name, node.Value)
 }
 
-func (*Select) IInsertRows() {}
-func (*Union) IInsertRows()  {}
-func (Values) IInsertRows()  {}
-
-// Update represents an UPDATE statement.
-type Update struct {
-	Comments Comments
-	Table    *TableName
-	Exprs    UpdateExprs
-	Where    *Where
-	OrderBy  OrderBy
-	Limit    *Limit
-}
+// Other represents a statement that is recognized but not
+// parsed. It should be used only as an indicator. Parse no
+// longer returns it: SHOW, DESCRIBE and EXPLAIN statements
+// are parsed into Show, Describe and Explain.
+type Other struct{}
 
-func (node *Update) Format(buf *TrackedBuffer) {
-	buf.Myprintf("update %v%v set %v%v%v%v",
-		node.Comments, node.Table,
-		node.Exprs, node.Where, node.OrderBy, node.Limit)
+func (node *Other) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString("other")
 }
 
-// Delete represents a DELETE statement.
-type Delete struct {
-	Comments Comments
+// Show represents a SHOW statement. Type holds the words naming
+// what is shown in lower case, such as "tables", "status" or
+// "engine innodb status", with the synonyms FIELDS, INDEXES, KEYS
+// and SCHEMAS replaced by the Show.Type values below. Full is
+// set by SHOW FULL, and Scope by SHOW GLOBAL and SHOW SESSION.
+// Table is set for SHOW COLUMNS, SHOW INDEX and SHOW CREATE, and
+// Database by the FROM clause of the other forms.
+type Show struct {
+	Type     string
+	Full     bool
+	Scope    string
 	Table    *TableName
-	Where    *Where
-	OrderBy  OrderBy
-	Limit    *Limit
+	Database TableIdent
+	Filter   *ShowFilter
 }
 
-func (node *Delete) Format(buf *TrackedBuffer) {
-	buf.Myprintf("delete %vfrom %v%v%v%v",
-		node.Comments,
-		node.Table, node.Where, node.OrderBy, node.Limit)
-}
+// Show.Type
+const (
+	AST_SHOW_TABLES       = "tables"
+	AST_SHOW_DATABASES    = "databases"
+	AST_SHOW_COLUMNS      = "columns"
+	AST_SHOW_INDEX        = "index"
+	AST_SHOW_STATUS       = "status"
+	AST_SHOW_VARIABLES    = "variables"
+	AST_SHOW_CREATE_TABLE = "create table"
+)
 
-// Set represents a SET statement.
-type Set struct {
-	Comments Comments
-	Exprs    UpdateExprs
+func (node *Show) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("show ")
+	if node.Full {
+		buf.Myprintf("full ")
+	}
+	if node.Scope != "" {
+		buf.Myprintf("%s ", node.Scope)
+	}
+	buf.Myprintf("%s", node.Type)
+	if node.Table != nil {
+		if strings.HasPrefix(node.Type, AST_CREATE+" ") {
+			buf.Myprintf(" %v", node.Table)
+		} else {
+			buf.Myprintf(" from %v", node.Table)
+		}
+	}
+	if !node.Database.IsEmpty() {
+		if strings.HasPrefix(node.Type, AST_CREATE+" ") {
+			buf.Myprintf(" %v", node.Database)
+		} else {
+			buf.Myprintf(" from %v", node.Database)
+		}
+	}
+	buf.Myprintf("%v", node.Filter)
 }
 
-func (node *Set) Format(buf *TrackedBuffer) {
-	buf.Myprintf("set %v%v", node.Comments, node.Exprs)
+// newShow builds a Show out of the words following SHOW and the
+// names of its FROM clauses. The first names the table of SHOW
+// COLUMNS and SHOW INDEX, and the database otherwise. The second,
+// db, names the database of the table.
+func newShow(words []string, from *TableName, db TableIdent) (*Show, error) {
+	node := &Show{}
+	if len(words) > 1 && words[0] == "full" {
+		node.Full, words = true, words[1:]
+	}
+	if len(words) > 1 && (words[0] == AST_GLOBAL || words[0] == AST_SESSION) {
+		node.Scope, words = words[0], words[1:]
+	}
+	node.Type = strings.Join(words, " ")
+	switch node.Type {
+	case "fields":
+		node.Type = AST_SHOW_COLUMNS
+	case "indexes", "keys":
+		node.Type = AST_SHOW_INDEX
+	case "schemas":
+		node.Type = AST_SHOW_DATABASES
+	}
+	switch {
+	case node.Type == AST_SHOW_COLUMNS || node.Type == AST_SHOW_INDEX:
+		if from == nil {
+			return nil, fmt.Errorf("show %s requires a table", node.Type)
+		}
+		if !db.IsEmpty() {
+			if !from.Qualifier.IsEmpty() {
+				return nil, fmt.Errorf("database specified twice for %v", String(from))
+			}
+			from.Qualifier = db
+		}
+		node.Table = from
+	case !db.IsEmpty():
+		return nil, fmt.Errorf("unexpected from %v", String(db))
+	case from != nil:
+		if !from.Qualifier.IsEmpty() {
+			return nil, fmt.Errorf("invalid database name %v", String(from))
+		}
+		node.Database = from.Name
+	}
+	return node, nil
 }
 
-// DDL represents a CREATE, ALTER, DROP or RENAME statement.
-// Table is set for AST_ALTER, AST_DROP, AST_RENAME.
-// NewName is set for AST_ALTER, AST_CREATE, AST_RENAME.
-type DDL struct {
-	Action  string
-	Table   []byte
-	NewName []byte
+// ShowFilter represents the LIKE or WHERE clause of a SHOW
+// statement. Only one of Like and Filter is set.
+type ShowFilter struct {
+	Like   string
+	Filter BoolExpr
 }
 
-type ColumnAtts []string
-
-func (node ColumnAtts) Format(buf *TrackedBuffer) {
-	prefix := " "
-	for _, v := range node {
-		if v != "" {
-			buf.Myprintf("%s%s", prefix, v)
-		}
+func (node *ShowFilter) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Filter != nil {
+		buf.Myprintf(" where %v", node.Filter)
+		return
 	}
+	buf.Myprintf(" like %v", StrVal{Val: node.Like})
 }
 
-type ColumnDefinition struct {
-	ColName    string
-	ColType    string
-	ColumnAtts ColumnAtts
+// Describe represents a DESCRIBE, DESC or EXPLAIN statement
+// applied to a table.
+type Describe struct {
+	Table *TableName
 }
 
-func (node ColumnDefinition) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%s %s%v", node.ColName, node.ColType, node.ColumnAtts)
+func (node *Describe) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("describe %v", node.Table)
 }
 
-type ColumnDefinitions []*ColumnDefinition
+// Explain represents an EXPLAIN, DESCRIBE or DESC statement
+// applied to a SELECT, INSERT, UPDATE or DELETE statement.
+// OutputFormat is set by FORMAT = name, in lower case.
+type Explain struct {
+	Statement    Statement
+	OutputFormat string
+}
 
-func (node ColumnDefinitions) Format(buf *TrackedBuffer) {
-	prefix := ""
-	buf.Myprintf("(\n")
-	for i := 0; i < len(node); i++ {
-		buf.Myprintf("%s\t%v", prefix, node[i])
-		prefix = ",\n"
+func (node *Explain) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
 	}
-	buf.Myprintf("\n)")
+	buf.Myprintf("explain ")
+	if node.OutputFormat != "" {
+		buf.Myprintf("format = %s ", node.OutputFormat)
+	}
+	buf.Myprintf("%v", node.Statement)
 }
 
-type CreateTable struct {
-	Name              []byte
-	ColumnDefinitions ColumnDefinitions
-}
+// Comments represents a list of comments.
+type Comments []string
 
-func (node *CreateTable) Format(buf *TrackedBuffer) {
-	buf.Myprintf("create table %s %v", node.Name, node.ColumnDefinitions)
+func (node Comments) Format(buf *TrackedBuffer) {
+	for _, c := range node {
+		buf.Myprintf("%s ", c)
+	}
 }
-func (node *CreateTable) IStatement() {}
-
-const (
-	AST_TABLE = "table"
-	AST_VIEW  = "view"
-)
 
-const (
-	AST_CREATE = "create"
-	AST_ALTER  = "alter"
-	AST_DROP   = "drop"
-	AST_RENAME = "rename"
-)
+// Hints represents the hint comments of a statement, which
+// follow its other comments.
+type Hints []*Hint
 
-func (node *DDL) Format(buf *TrackedBuffer) {
-	switch node.Action {
-	case AST_CREATE:
-		buf.Myprintf("%s table %s", node.Action, node.NewName)
-	case AST_RENAME:
-		buf.Myprintf("%s table %s %s", node.Action, node.Table, node.NewName)
-	default:
-		buf.Myprintf("%s table %s", node.Action, node.Table)
+func (node Hints) Format(buf *TrackedBuffer) {
+	for _, h := range node {
+		buf.Myprintf("%v ", h)
 	}
 }
 
-// Other represents a SHOW, DESCRIBE, or EXPLAIN statement.
-// It should be used only as an indicator. It does not contain
-// the full AST for the statement.
-type Other struct{}
+// Hint represents an optimizer hint comment, /*+ ... */, or
+// a MySQL executable comment, /*! ... */. The hints of an
+// optimizer hint comment are parsed into Items. Executable is set
+// for an executable comment, whose SQL is kept as Text, and which
+// applies from the server version Version, if it is set.
+type Hint struct {
+	Executable bool
+	Version    string
+	Text       string
+	Items      []*HintItem
+}
 
-func (node *Other) Format(buf *TrackedBuffer) {
-	buf.WriteString("other")
+func (node *Hint) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Executable {
+		buf.Myprintf("/*!%s %s */", node.Version, node.Text)
+		return
+	}
+	buf.Myprintf("/*+")
+	for _, item := range node.Items {
+		buf.Myprintf(" %v", item)
+	}
+	buf.Myprintf(" */")
 }
 
-// Comments represents a list of comments.
-type Comments [][]byte
+// HintItem represents one hint of an optimizer hint comment,
+// such as BKA(t1, t2). Args is the text between the parentheses.
+type HintItem struct {
+	Name string
+	Args string
+}
 
-func (node Comments) Format(buf *TrackedBuffer) {
-	for _, c := range node {
-		buf.Myprintf("%s ", c)
+func (node *HintItem) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%s", node.Name)
+	if node.Args != "" {
+		buf.Myprintf("(%s)", node.Args)
 	}
 }
 
@@ -297,6 +2826,26 @@ func (node SelectExprs) Format(buf *TrackedBuffer) {
 	}
 }
 
+// ResolvePosition returns the expression of node at pos, a column
+// position of ORDER BY or GROUP BY as returned by ColumnPosition.
+// It returns an error if node has no column at pos, or if a star
+// expression, whose columns are not known, is at or before it.
+func (node SelectExprs) ResolvePosition(pos int) (*NonStarExpr, error) {
+	if pos < 1 || pos > len(node) {
+		return nil, fmt.Errorf("unknown column position %d", pos)
+	}
+	for _, expr := range node[:pos] {
+		if _, ok := expr.(*StarExpr); ok {
+			return nil, fmt.Errorf("column position %d follows %s", pos, String(expr))
+		}
+	}
+	expr, ok := node[pos-1].(*NonStarExpr)
+	if !ok {
+		return nil, fmt.Errorf("column position %d is not an expression", pos)
+	}
+	return expr, nil
+}
+
 // SelectExpr represents a SELECT expression.
 type SelectExpr interface {
 	ISelectExpr()
@@ -305,15 +2854,19 @@ type SelectExpr interface {
 
 func (*StarExpr) ISelectExpr()    {}
 func (*NonStarExpr) ISelectExpr() {}
+func (*Nextval) ISelectExpr()     {}
 
 // StarExpr defines a '*' or 'table.*' expression.
 type StarExpr struct {
-	TableName []byte
+	TableName TableIdent
 }
 
 func (node *StarExpr) Format(buf *TrackedBuffer) {
-	if node.TableName != nil {
-		buf.Myprintf("%s.", node.TableName)
+	if node == nil {
+		return
+	}
+	if !node.TableName.IsEmpty() {
+		buf.Myprintf("%v.", node.TableName)
 	}
 	buf.Myprintf("*")
 }
@@ -321,13 +2874,16 @@ func (node *StarExpr) Format(buf *TrackedBuffer) {
 // NonStarExpr defines a non-'*' select expr.
 type NonStarExpr struct {
 	Expr Expr
-	As   []byte
+	As   ColIdent
 }
 
 func (node *NonStarExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("%v", node.Expr)
-	if node.As != nil {
-		buf.Myprintf(" as %s", node.As)
+	if !node.As.IsEmpty() {
+		buf.Myprintf(" as %v", node.As)
 	}
 }
 
@@ -364,19 +2920,36 @@ type TableExpr interface {
 func (*AliasedTableExpr) ITableExpr() {}
 func (*ParenTableExpr) ITableExpr()   {}
 func (*JoinTableExpr) ITableExpr()    {}
+func (*PivotTableExpr) ITableExpr()   {}
+func (*UnpivotTableExpr) ITableExpr() {}
 
 // AliasedTableExpr represents a table expression
 // coupled with an optional alias or index hint.
 type AliasedTableExpr struct {
-	Expr  SimpleTableExpr
-	As    []byte
-	Hints *IndexHints
+	Expr       SimpleTableExpr
+	Partitions Partitions
+	SystemTime *SystemTime
+	As         TableIdent
+	// Columns renames the columns of the table, as in
+	// (select 1, 2) as t(a, b). It is only set with As.
+	Columns []ColIdent
+	Hints   *IndexHints
+	// Lateral is set for LATERAL derived tables, which can
+	// refer to the tables before them in the FROM clause.
+	Lateral bool
 }
 
 func (node *AliasedTableExpr) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%v", node.Expr)
-	if node.As != nil {
-		buf.Myprintf(" as %s", node.As)
+	if node == nil {
+		return
+	}
+	if node.Lateral {
+		buf.WriteString("lateral ")
+	}
+	buf.Myprintf("%v%v%v", node.Expr, node.Partitions, node.SystemTime)
+	if !node.As.IsEmpty() {
+		buf.Myprintf(" as %v", node.As)
+		formatColumnAliases(buf, node.Columns)
 	}
 	if node.Hints != nil {
 		// Hint node provides the space padding.
@@ -384,26 +2957,183 @@ func (node *AliasedTableExpr) Format(buf *TrackedBuffer) {
 	}
 }
 
+// Partitions represents an explicit PARTITION selection.
+type Partitions []ColIdent
+
+func (node Partitions) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	prefix := " partition ("
+	for _, n := range node {
+		buf.Myprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+	buf.Myprintf(")")
+}
+
+// SystemTime represents a FOR SYSTEM_TIME clause, which
+// queries a system-versioned table at a point or period in time.
+// To is only set for AST_SYSTEM_TIME_FROM_TO and
+// AST_SYSTEM_TIME_BETWEEN, and From is not set for
+// AST_SYSTEM_TIME_ALL.
+type SystemTime struct {
+	Type     string
+	From, To ValExpr
+}
+
+// SystemTime.Type
+const (
+	AST_SYSTEM_TIME_AS_OF   = "as of"
+	AST_SYSTEM_TIME_FROM_TO = "from"
+	AST_SYSTEM_TIME_BETWEEN = "between"
+	AST_SYSTEM_TIME_ALL     = "all"
+)
+
+func (node *SystemTime) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	switch node.Type {
+	case AST_SYSTEM_TIME_FROM_TO:
+		buf.Myprintf(" for system_time from %v to %v", node.From, node.To)
+	case AST_SYSTEM_TIME_BETWEEN:
+		buf.Myprintf(" for system_time between %v and %v", node.From, node.To)
+	case AST_SYSTEM_TIME_ALL:
+		buf.Myprintf(" for system_time all")
+	default:
+		buf.Myprintf(" for system_time as of %v", node.From)
+	}
+}
+
 // SimpleTableExpr represents a simple table expression.
 type SimpleTableExpr interface {
 	ISimpleTableExpr()
 	SQLNode
 }
 
-func (*TableName) ISimpleTableExpr() {}
-func (*Subquery) ISimpleTableExpr()  {}
+func (*TableName) ISimpleTableExpr()     {}
+func (*Subquery) ISimpleTableExpr()      {}
+func (*FuncExpr) ISimpleTableExpr()      {}
+func (*JSONTableExpr) ISimpleTableExpr() {}
+
+// JSONTableExpr represents a JSON_TABLE call, which turns the
+// JSON document Expr into a table with a row for each match of
+// Path, and the Columns.
+type JSONTableExpr struct {
+	Expr    ValExpr
+	Path    StrVal
+	Columns []*JSONTableColumn
+}
+
+func (node *JSONTableExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("json_table(%v, %v", node.Expr, node.Path)
+	formatJSONTableColumns(buf, node.Columns)
+	buf.WriteByte(')')
+}
+
+func formatJSONTableColumns(buf *TrackedBuffer, cols []*JSONTableColumn) {
+	buf.WriteString(" columns (")
+	for i, col := range cols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.Myprintf("%v", col)
+	}
+	buf.WriteByte(')')
+}
+
+// JSONTableColumn represents a column of JSON_TABLE. Type, Path,
+// OnEmpty and OnError are not set for AST_FOR_ORDINALITY, and
+// for AST_NESTED_PATH, which sets Columns instead of Name and
+// Type.
+type JSONTableColumn struct {
+	Kind    string
+	Name    ColIdent
+	Type    ColumnType
+	Path    StrVal
+	OnEmpty *JSONTableResponse
+	OnError *JSONTableResponse
+	Columns []*JSONTableColumn
+}
+
+// JSONTableColumn.Kind
+const (
+	AST_FOR_ORDINALITY = "for ordinality"
+	AST_PATH           = "path"
+	AST_EXISTS_PATH    = "exists path"
+	AST_NESTED_PATH    = "nested path"
+)
+
+func (node *JSONTableColumn) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case AST_FOR_ORDINALITY:
+		buf.Myprintf("%v %s", node.Name, node.Kind)
+	case AST_NESTED_PATH:
+		buf.Myprintf("%s %v", node.Kind, node.Path)
+		formatJSONTableColumns(buf, node.Columns)
+	default:
+		buf.Myprintf("%v %v %s %v", node.Name, node.Type, node.Kind, node.Path)
+		if node.OnEmpty != nil {
+			buf.Myprintf(" %v on empty", node.OnEmpty)
+		}
+		if node.OnError != nil {
+			buf.Myprintf(" %v on error", node.OnError)
+		}
+	}
+}
+
+// JSONTableResponse represents what a JSON_TABLE column holds
+// when its path matches nothing or an error occurs. Default is
+// only set for AST_DEFAULT.
+type JSONTableResponse struct {
+	Type    string
+	Default StrVal
+}
+
+// JSONTableResponse.Type, besides AST_NULL and AST_DEFAULT
+const (
+	AST_ERROR = "error"
+)
+
+func (node *JSONTableResponse) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString(node.Type)
+	if node.Type == AST_DEFAULT {
+		buf.Myprintf(" %v", node.Default)
+	}
+}
 
 // TableName represents a table  name.
 type TableName struct {
-	Name, Qualifier []byte
+	Name, Qualifier TableIdent
 }
 
 func (node *TableName) Format(buf *TrackedBuffer) {
-	if node.Qualifier != nil {
-		escape(buf, node.Qualifier)
-		buf.Myprintf(".")
+	if node == nil {
+		return
+	}
+	if !node.Qualifier.IsEmpty() {
+		buf.Myprintf("%v.", node.Qualifier)
 	}
-	escape(buf, node.Name)
+	buf.Myprintf("%v", node.Name)
+}
+
+// AST_DUAL is the name of MySQL's dummy table.
+const AST_DUAL = "dual"
+
+// IsDual returns true if the table name refers to the DUAL
+// dummy table, as in "select 1 from dual".
+func (node *TableName) IsDual() bool {
+	return node != nil && node.Qualifier.IsEmpty() && node.Name.EqualString(AST_DUAL)
 }
 
 // ParenTableExpr represents a parenthesized TableExpr.
@@ -412,6 +3142,9 @@ type ParenTableExpr struct {
 }
 
 func (node *ParenTableExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("(%v)", node.Expr)
 }
 
@@ -421,6 +3154,8 @@ type JoinTableExpr struct {
 	Join      string
 	RightExpr TableExpr
 	On        BoolExpr
+	// Using is set by the USING clause instead of On.
+	Using Columns
 }
 
 // JoinTableExpr.Join
@@ -434,16 +3169,64 @@ const (
 )
 
 func (node *JoinTableExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("%v %s %v", node.LeftExpr, node.Join, node.RightExpr)
 	if node.On != nil {
 		buf.Myprintf(" on %v", node.On)
 	}
+	if node.Using != nil {
+		buf.Myprintf(" using %v", node.Using)
+	}
+}
+
+// PivotTableExpr represents a PIVOT table operator, which
+// turns the values listed in In into columns holding the
+// Aggregates computed for each of them.
+type PivotTableExpr struct {
+	Expr       TableExpr
+	Aggregates SelectExprs
+	For        *ColName
+	In         SelectExprs
+	As         TableIdent
+}
+
+func (node *PivotTableExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v pivot (%v for %v in (%v))", node.Expr, node.Aggregates, node.For, node.In)
+	if !node.As.IsEmpty() {
+		buf.Myprintf(" as %v", node.As)
+	}
+}
+
+// UnpivotTableExpr represents an UNPIVOT table operator, which
+// turns the columns listed in In into rows of Value, with For
+// holding the name of the originating column.
+type UnpivotTableExpr struct {
+	Expr  TableExpr
+	Value *ColName
+	For   *ColName
+	In    SelectExprs
+	As    TableIdent
+}
+
+func (node *UnpivotTableExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v unpivot (%v for %v in (%v))", node.Expr, node.Value, node.For, node.In)
+	if !node.As.IsEmpty() {
+		buf.Myprintf(" as %v", node.As)
+	}
 }
 
 // IndexHints represents a list of index hints.
 type IndexHints struct {
 	Type    string
-	Indexes [][]byte
+	Indexes []ColIdent
 }
 
 const (
@@ -453,10 +3236,13 @@ const (
 )
 
 func (node *IndexHints) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf(" %s index ", node.Type)
 	prefix := "("
 	for _, n := range node.Indexes {
-		buf.Myprintf("%s%s", prefix, n)
+		buf.Myprintf("%s%v", prefix, n)
 		prefix = ", "
 	}
 	buf.Myprintf(")")
@@ -470,11 +3256,12 @@ type Where struct {
 
 // Where.Type
 const (
-	AST_WHERE  = "where"
-	AST_HAVING = "having"
+	AST_WHERE   = "where"
+	AST_HAVING  = "having"
+	AST_QUALIFY = "qualify"
 )
 
-// NewWhere creates a WHERE or HAVING clause out
+// NewWhere creates a WHERE, HAVING or QUALIFY clause out
 // of a BoolExpr. If the expression is nil, it returns nil.
 func NewWhere(typ string, expr BoolExpr) *Where {
 	if expr == nil {
@@ -490,19 +3277,77 @@ func (node *Where) Format(buf *TrackedBuffer) {
 	buf.Myprintf(" %s %v", node.Type, node.Expr)
 }
 
-// TimeRange represents a TIMERANGE clause.
+// TimeRange represents a TIMERANGE clause, ASOF From [UNTIL To].
+// From and To are usually timestamp strings, but may be relative
+// expressions such as now() - interval 1 hour, bare durations or
+// bind variables. Resolve turns them into concrete times.
 type TimeRange struct {
-	From, To string
+	From, To ValExpr
 }
 
 func (node *TimeRange) Format(buf *TrackedBuffer) {
 	if node == nil {
 		return
 	}
-	buf.Myprintf(" ASOF '%s'", node.From)
-	if node.To != "" {
-		buf.Myprintf(" UNTIL '%s'", node.To)
+	buf.Myprintf(" ASOF %v", node.From)
+	if node.To != nil {
+		buf.Myprintf(" UNTIL %v", node.To)
+	}
+}
+
+// Clauses represents the custom clauses of a SELECT statement.
+type Clauses []*Clause
+
+func (node Clauses) Format(buf *TrackedBuffer) {
+	for _, clause := range node {
+		buf.Myprintf("%v", clause)
+	}
+}
+
+// Clause represents a custom clause, defined by a ClauseDef of
+// the Options a statement was parsed with. Its parts are in order,
+// and the keyword of the first names the clause.
+type Clause struct {
+	Parts []*ClausePart
+}
+
+func (node *Clause) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	for _, part := range node.Parts {
+		buf.Myprintf("%v", part)
+	}
+}
+
+// Name returns the keyword starting the clause.
+func (node *Clause) Name() string {
+	return node.Parts[0].Keyword
+}
+
+// Part returns the expression following keyword in the clause,
+// or nil if the clause does not have the keyword.
+func (node *Clause) Part(keyword string) ValExpr {
+	for _, part := range node.Parts {
+		if strings.EqualFold(part.Keyword, keyword) {
+			return part.Expr
+		}
+	}
+	return nil
+}
+
+// ClausePart represents a keyword of a custom clause and the
+// expression following it. Keyword is spelled as in its ClauseDef.
+type ClausePart struct {
+	Keyword string
+	Expr    ValExpr
+}
+
+func (node *ClausePart) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
 	}
+	buf.Myprintf(" %s %v", node.Keyword, node.Expr)
 }
 
 // Expr represents an expression.
@@ -511,27 +3356,48 @@ type Expr interface {
 	SQLNode
 }
 
-func (*AndExpr) IExpr()        {}
-func (*OrExpr) IExpr()         {}
-func (*NotExpr) IExpr()        {}
-func (*ParenBoolExpr) IExpr()  {}
-func (*ComparisonExpr) IExpr() {}
-func (*RangeCond) IExpr()      {}
-func (*NullCheck) IExpr()      {}
-func (*ExistsExpr) IExpr()     {}
-func (StrVal) IExpr()          {}
-func (NumVal) IExpr()          {}
-func (ValArg) IExpr()          {}
-func (*NullVal) IExpr()        {}
-func (*ColName) IExpr()        {}
-func (ValTuple) IExpr()        {}
-func (*Subquery) IExpr()       {}
-func (ListArg) IExpr()         {}
-func (*BinaryExpr) IExpr()     {}
-func (*UnaryExpr) IExpr()      {}
-func (*FuncExpr) IExpr()       {}
-func (*CaseExpr) IExpr()       {}
-func (*StarExpr) IExpr()       {}
+func (*AndExpr) IExpr()          {}
+func (*OrExpr) IExpr()           {}
+func (*NotExpr) IExpr()          {}
+func (*ParenBoolExpr) IExpr()    {}
+func (*ParenExpr) IExpr()        {}
+func (*ComparisonExpr) IExpr()   {}
+func (*RangeCond) IExpr()        {}
+func (*NullCheck) IExpr()        {}
+func (*IsExpr) IExpr()           {}
+func (*MatchExpr) IExpr()        {}
+func (*GroupingExpr) IExpr()     {}
+func (BoolVal) IExpr()           {}
+func (HexVal) IExpr()            {}
+func (BitVal) IExpr()            {}
+func (*IntroducerExpr) IExpr()   {}
+func (*CollateExpr) IExpr()      {}
+func (*ExistsExpr) IExpr()       {}
+func (StrVal) IExpr()            {}
+func (NumVal) IExpr()            {}
+func (ValArg) IExpr()            {}
+func (*NullVal) IExpr()          {}
+func (*ColName) IExpr()          {}
+func (ValTuple) IExpr()          {}
+func (*Subquery) IExpr()         {}
+func (ListArg) IExpr()           {}
+func (*BinaryExpr) IExpr()       {}
+func (*UnaryExpr) IExpr()        {}
+func (*FuncExpr) IExpr()         {}
+func (*NextValExpr) IExpr()      {}
+func (*ValuesFuncExpr) IExpr()   {}
+func (*UserVar) IExpr()          {}
+func (*AssignExpr) IExpr()       {}
+func (*ArrayExpr) IExpr()        {}
+func (*StructExpr) IExpr()       {}
+func (*SubscriptExpr) IExpr()    {}
+func (*JSONExtractExpr) IExpr()  {}
+func (*CastExpr) IExpr()         {}
+func (*ConvertExpr) IExpr()      {}
+func (*ConvertUsingExpr) IExpr() {}
+func (*IntervalExpr) IExpr()     {}
+func (*CaseExpr) IExpr()         {}
+func (*StarExpr) IExpr()         {}
 
 // BoolExpr represents a boolean expression.
 type BoolExpr interface {
@@ -546,6 +3412,8 @@ func (*ParenBoolExpr) IBoolExpr()  {}
 func (*ComparisonExpr) IBoolExpr() {}
 func (*RangeCond) IBoolExpr()      {}
 func (*NullCheck) IBoolExpr()      {}
+func (*IsExpr) IBoolExpr()         {}
+func (*MatchExpr) IBoolExpr()      {}
 func (*ExistsExpr) IBoolExpr()     {}
 
 // AndExpr represents an AND expression.
@@ -554,7 +3422,12 @@ type AndExpr struct {
 }
 
 func (node *AndExpr) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%v and %v", node.Left, node.Right)
+	if node == nil {
+		return
+	}
+	formatOperand(buf, node, node.Left, true)
+	buf.Myprintf(" and ")
+	formatOperand(buf, node, node.Right, false)
 }
 
 // OrExpr represents an OR expression.
@@ -563,7 +3436,12 @@ type OrExpr struct {
 }
 
 func (node *OrExpr) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%v or %v", node.Left, node.Right)
+	if node == nil {
+		return
+	}
+	formatOperand(buf, node, node.Left, true)
+	buf.Myprintf(" or ")
+	formatOperand(buf, node, node.Right, false)
 }
 
 // NotExpr represents a NOT expression.
@@ -572,7 +3450,11 @@ type NotExpr struct {
 }
 
 func (node *NotExpr) Format(buf *TrackedBuffer) {
-	buf.Myprintf("not %v", node.Expr)
+	if node == nil {
+		return
+	}
+	buf.Myprintf("not ")
+	formatOperand(buf, node, node.Expr, true)
 }
 
 // ParenBoolExpr represents a parenthesized boolean expression.
@@ -581,6 +3463,9 @@ type ParenBoolExpr struct {
 }
 
 func (node *ParenBoolExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("(%v)", node.Expr)
 }
 
@@ -588,25 +3473,74 @@ func (node *ParenBoolExpr) Format(buf *TrackedBuffer) {
 type ComparisonExpr struct {
 	Operator    string
 	Left, Right ValExpr
+	// Escape is the escape character of a LIKE pattern,
+	// or nil for the default backslash.
+	Escape ValExpr
+	// Quantifier is set for a comparison with the rows of a
+	// subquery, as in a > ALL (select b from t), and is one of
+	// the ComparisonExpr.Quantifier values below.
+	Quantifier string
 }
 
 // ComparisonExpr.Operator
 const (
-	AST_EQ       = "="
-	AST_LT       = "<"
-	AST_GT       = ">"
-	AST_LE       = "<="
-	AST_GE       = ">="
-	AST_NE       = "!="
-	AST_NSE      = "<=>"
-	AST_IN       = "in"
-	AST_NOT_IN   = "not in"
-	AST_LIKE     = "like"
-	AST_NOT_LIKE = "not like"
+	AST_EQ        = "="
+	AST_LT        = "<"
+	AST_GT        = ">"
+	AST_LE        = "<="
+	AST_GE        = ">="
+	AST_NE        = "!="
+	AST_NSE       = "<=>"
+	AST_IN        = "in"
+	AST_NOT_IN    = "not in"
+	AST_LIKE      = "like"
+	AST_NOT_LIKE  = "not like"
+	AST_ILIKE     = "ilike"
+	AST_NOT_ILIKE = "not ilike"
+
+	AST_REGEXP      = "regexp"
+	AST_NOT_REGEXP  = "not regexp"
+	AST_SOUNDS_LIKE = "sounds like"
+)
+
+// ComparisonExpr.Quantifier
+const (
+	AST_ANY  = "any"
+	AST_SOME = "some"
+	AST_ALL  = "all"
 )
 
 func (node *ComparisonExpr) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%v %s %v", node.Left, node.Operator, node.Right)
+	if node == nil {
+		return
+	}
+	if node.Quantifier != "" {
+		buf.Myprintf("%v %s %s %v", node.Left, node.Operator, node.Quantifier, node.Right)
+		return
+	}
+	operator := node.Operator
+	if buf.dialect == Postgres {
+		// MySQL matches regular expressions case-insensitively.
+		switch operator {
+		case AST_REGEXP:
+			operator = "~*"
+		case AST_NOT_REGEXP:
+			operator = "!~*"
+		}
+	}
+	if (operator == AST_ILIKE || operator == AST_NOT_ILIKE) && buf.formatsFor(MySQL, MariaDB, BigQuery) {
+		// Only Postgres has ILIKE, which is LIKE ignoring case.
+		operator = AST_LIKE
+		if node.Operator == AST_NOT_ILIKE {
+			operator = AST_NOT_LIKE
+		}
+		buf.Myprintf("lower(%v) %s lower(%v)", node.Left, operator, node.Right)
+	} else {
+		buf.Myprintf("%v %s %v", node.Left, operator, node.Right)
+	}
+	if node.Escape != nil {
+		buf.Myprintf(" escape %v", node.Escape)
+	}
 }
 
 // RangeCond represents a BETWEEN or a NOT BETWEEN expression.
@@ -623,6 +3557,9 @@ const (
 )
 
 func (node *RangeCond) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("%v %s %v and %v", node.Left, node.Operator, node.From, node.To)
 }
 
@@ -639,15 +3576,116 @@ const (
 )
 
 func (node *NullCheck) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("%v %s", node.Expr, node.Operator)
 }
 
+// GroupingExpr represents a ROLLUP, CUBE or GROUPING SETS
+// element of a GROUP BY clause. The Exprs of GROUPING SETS are
+// its sets, each a ValTuple or a single expression.
+type GroupingExpr struct {
+	Type  string
+	Exprs ValExprs
+}
+
+// GroupingExpr.Type
+const (
+	AST_ROLLUP        = "rollup"
+	AST_CUBE          = "cube"
+	AST_GROUPING_SETS = "grouping sets"
+)
+
+func (node *GroupingExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%s(%v)", node.Type, node.Exprs)
+}
+
+// groupingFunc turns the ROLLUP(...) and CUBE(...) elements
+// of a GROUP BY clause, which parse as function calls, into
+// GroupingExprs.
+func groupingFunc(expr Expr) Expr {
+	fn, ok := expr.(*FuncExpr)
+	if !ok || fn.Distinct || fn.Over != nil || !fn.Name.EqualString(AST_ROLLUP) && !fn.Name.EqualString(AST_CUBE) {
+		return expr
+	}
+	grouping := &GroupingExpr{Type: fn.Name.Lowered()}
+	for _, arg := range fn.Exprs {
+		arg, ok := arg.(*NonStarExpr)
+		if !ok || !arg.As.IsEmpty() {
+			return expr
+		}
+		val, ok := arg.Expr.(ValExpr)
+		if !ok {
+			return expr
+		}
+		grouping.Exprs = append(grouping.Exprs, val)
+	}
+	return grouping
+}
+
+// MatchExpr represents a MATCH (columns) AGAINST (expr option)
+// full-text search. It is a BoolExpr, as in a WHERE clause, and
+// a ValExpr, the relevance of a row, as in an ORDER BY clause.
+type MatchExpr struct {
+	Columns Columns
+	Expr    ValExpr
+	Option  string
+}
+
+// MatchExpr.Option
+const (
+	AST_NATURAL_LANGUAGE_MODE                      = " in natural language mode"
+	AST_NATURAL_LANGUAGE_MODE_WITH_QUERY_EXPANSION = " in natural language mode with query expansion"
+	AST_BOOLEAN_MODE                               = " in boolean mode"
+	AST_QUERY_EXPANSION                            = " with query expansion"
+)
+
+func (node *MatchExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("match%v against(%v%s)", node.Columns, node.Expr, node.Option)
+}
+
+// IsExpr represents an IS TRUE, IS FALSE or IS UNKNOWN
+// expression, or its negation with IS NOT. Expr is a ValExpr
+// or a BoolExpr.
+type IsExpr struct {
+	Operator string
+	Expr     Expr
+}
+
+// IsExpr.Operator
+const (
+	AST_IS_TRUE        = "is true"
+	AST_IS_NOT_TRUE    = "is not true"
+	AST_IS_FALSE       = "is false"
+	AST_IS_NOT_FALSE   = "is not false"
+	AST_IS_UNKNOWN     = "is unknown"
+	AST_IS_NOT_UNKNOWN = "is not unknown"
+)
+
+func (node *IsExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	formatOperand(buf, node, node.Expr, true)
+	buf.Myprintf(" %s", node.Operator)
+}
+
 // ExistsExpr represents an EXISTS expression.
 type ExistsExpr struct {
 	Subquery *Subquery
 }
 
 func (node *ExistsExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("exists %v", node.Subquery)
 }
 
@@ -657,68 +3695,434 @@ type ValExpr interface {
 	Expr
 }
 
-func (StrVal) IValExpr()      {}
-func (NumVal) IValExpr()      {}
-func (ValArg) IValExpr()      {}
-func (*NullVal) IValExpr()    {}
-func (*ColName) IValExpr()    {}
-func (ValTuple) IValExpr()    {}
-func (*Subquery) IValExpr()   {}
-func (ListArg) IValExpr()     {}
-func (*BinaryExpr) IValExpr() {}
-func (*UnaryExpr) IValExpr()  {}
-func (*FuncExpr) IValExpr()   {}
-func (*CaseExpr) IValExpr()   {}
-func (*StarExpr) IValExpr()   {}
-
-// StrVal represents a string value.
-type StrVal []byte
+func (StrVal) IValExpr()            {}
+func (NumVal) IValExpr()            {}
+func (ValArg) IValExpr()            {}
+func (*NullVal) IValExpr()          {}
+func (BoolVal) IValExpr()           {}
+func (HexVal) IValExpr()            {}
+func (BitVal) IValExpr()            {}
+func (*IntroducerExpr) IValExpr()   {}
+func (*CollateExpr) IValExpr()      {}
+func (*MatchExpr) IValExpr()        {}
+func (*GroupingExpr) IValExpr()     {}
+func (*ColName) IValExpr()          {}
+func (ValTuple) IValExpr()          {}
+func (*Subquery) IValExpr()         {}
+func (ListArg) IValExpr()           {}
+func (*BinaryExpr) IValExpr()       {}
+func (*UnaryExpr) IValExpr()        {}
+func (*FuncExpr) IValExpr()         {}
+func (*NextValExpr) IValExpr()      {}
+func (*ValuesFuncExpr) IValExpr()   {}
+func (*UserVar) IValExpr()          {}
+func (*AssignExpr) IValExpr()       {}
+func (*ArrayExpr) IValExpr()        {}
+func (*StructExpr) IValExpr()       {}
+func (*SubscriptExpr) IValExpr()    {}
+func (*JSONExtractExpr) IValExpr()  {}
+func (*CastExpr) IValExpr()         {}
+func (*ConvertExpr) IValExpr()      {}
+func (*ConvertUsingExpr) IValExpr() {}
+func (*IntervalExpr) IValExpr()     {}
+func (*CaseExpr) IValExpr()         {}
+func (*StarExpr) IValExpr()         {}
+func (*ParenExpr) IValExpr()        {}
+
+// StrVal represents a string value. Quote is the quote
+// character the literal was written with, and Doubled reports
+// whether embedded quotes were escaped by doubling them rather
+// than with a backslash. A zero Quote formats the value as a
+// canonical single-quoted literal.
+type StrVal struct {
+	Val     string
+	Quote   byte
+	Doubled bool
+}
 
 func (node StrVal) Format(buf *TrackedBuffer) {
-	s := sqltypes.MakeString([]byte(node))
-	s.EncodeSql(buf)
+	if buf.dialect == Postgres {
+		node.formatPostgres(buf)
+		return
+	}
+	if node.Quote == 0 {
+		s := sqltypes.MakeString([]byte(node.Val))
+		s.EncodeSql(buf)
+		return
+	}
+	buf.WriteByte(node.Quote)
+	for i := 0; i < len(node.Val); i++ {
+		ch := node.Val[i]
+		switch {
+		case ch == node.Quote && node.Doubled:
+			buf.WriteByte(ch)
+			buf.WriteByte(ch)
+		case ch == '\'' || ch == '"':
+			// Only the enclosing quote needs escaping.
+			if ch == node.Quote {
+				buf.WriteByte('\\')
+			}
+			buf.WriteByte(ch)
+		case ch == '\\' && i+1 < len(node.Val) && (node.Val[i+1] == '%' || node.Val[i+1] == '_'):
+			// \% and \_ are read back with their backslash.
+			buf.WriteByte(ch)
+		case sqltypes.SqlEncodeMap[ch] != sqltypes.DONTESCAPE:
+			buf.WriteByte('\\')
+			buf.WriteByte(sqltypes.SqlEncodeMap[ch])
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	buf.WriteByte(node.Quote)
+}
+
+// formatPostgres writes the value as a Postgres string. Double
+// quotes enclose identifiers in Postgres, and backslashes are
+// literal in standard strings, so a value with control characters
+// is written as an escape string, as in E'a\nb'.
+func (node StrVal) formatPostgres(buf *TrackedBuffer) {
+	escape := strings.IndexAny(node.Val, "\x00\b\n\r\t") >= 0
+	if strings.IndexByte(node.Val, 0) >= 0 {
+		buf.unsupported("a string holding a NUL character")
+	}
+	if escape {
+		buf.WriteByte('E')
+	}
+	buf.WriteByte('\'')
+	for i := 0; i < len(node.Val); i++ {
+		ch := node.Val[i]
+		switch {
+		case ch == '\'':
+			buf.WriteString("''")
+		case escape && (ch == '\\' || ch == '\x00' || ch == '\b' || ch == '\n' || ch == '\r' || ch == '\t'):
+			buf.WriteByte('\\')
+			buf.WriteByte(sqltypes.SqlEncodeMap[ch])
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	buf.WriteByte('\'')
+}
+
+// Bytes returns the value as a byte slice, for code written
+// against the earlier []byte representation.
+func (node StrVal) Bytes() []byte {
+	return []byte(node.Val)
 }
 
 // NumVal represents a number.
-type NumVal []byte
+type NumVal string
 
 func (node NumVal) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%s", []byte(node))
+	buf.Myprintf("%s", string(node))
+}
+
+// Bytes returns the number as a byte slice.
+func (node NumVal) Bytes() []byte {
+	return []byte(node)
 }
 
 // ValArg represents a named bind var argument.
-type ValArg []byte
+type ValArg string
 
 func (node ValArg) Format(buf *TrackedBuffer) {
 	buf.WriteArg(string(node))
 }
 
+// Bytes returns the argument name as a byte slice.
+func (node ValArg) Bytes() []byte {
+	return []byte(node)
+}
+
+// HexVal represents a hexadecimal string literal such as X'1F',
+// holding its digits. Hexadecimal numbers such as 0x1F are NumVals.
+type HexVal string
+
+func (node HexVal) Format(buf *TrackedBuffer) {
+	buf.Myprintf("X'%s'", string(node))
+}
+
+// BitVal represents a bit-value literal such as b'1010', holding
+// its digits. Binary numbers such as 0b1010 are NumVals.
+type BitVal string
+
+func (node BitVal) Format(buf *TrackedBuffer) {
+	buf.Myprintf("b'%s'", string(node))
+}
+
+// IntroducerExpr represents a literal preceded by a character
+// set introducer, such as _binary 'abc'. CharacterSet is lower
+// case and does not include the underscore.
+type IntroducerExpr struct {
+	CharacterSet string
+	Expr         ValExpr
+}
+
+func (node *IntroducerExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("_%s %v", node.CharacterSet, node.Expr)
+}
+
+// CollateExpr represents an expr COLLATE collation expression.
+type CollateExpr struct {
+	Expr      ValExpr
+	Collation string
+}
+
+func (node *CollateExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	formatOperand(buf, node, node.Expr, true)
+	buf.Myprintf(" collate %s", node.Collation)
+}
+
+// BoolVal represents a TRUE or FALSE literal.
+type BoolVal bool
+
+// BoolVal literals, and the values tested by IsExpr.
+const (
+	AST_TRUE    = "true"
+	AST_FALSE   = "false"
+	AST_UNKNOWN = "unknown"
+)
+
+func (node BoolVal) Format(buf *TrackedBuffer) {
+	if node {
+		buf.Myprintf(AST_TRUE)
+	} else {
+		buf.Myprintf(AST_FALSE)
+	}
+}
+
 // NullVal represents a NULL value.
 type NullVal struct{}
 
 func (node *NullVal) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("null")
 }
 
-// ColName represents a column name.
-type ColName struct {
-	Name, Qualifier []byte
+// ColName represents a column name.
+type ColName struct {
+	Name      ColIdent
+	Qualifier TableIdent
+}
+
+func (node *ColName) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if !node.Qualifier.IsEmpty() {
+		buf.Myprintf("%v.", node.Qualifier)
+	}
+	buf.Myprintf("%v", node.Name)
+}
+
+// ColIdent is a column, alias, index or function name. It keeps
+// the casing it was written with, but compares case-insensitively.
+type ColIdent struct {
+	val string
+	// lowered is val in lower case, if that differs from val.
+	lowered string
+	quoted  bool
+}
+
+// NewColIdent makes a new ColIdent.
+func NewColIdent(str string) ColIdent {
+	return ColIdent{val: str, lowered: lowerIfChanged(str)}
+}
+
+func makeColIdent(str string, quoted bool) ColIdent {
+	return ColIdent{val: str, lowered: lowerIfChanged(str), quoted: quoted}
+}
+
+func (node ColIdent) Format(buf *TrackedBuffer) {
+	formatID(buf, node.val, node.quoted)
+}
+
+// IsEmpty returns true if the name is empty.
+func (node ColIdent) IsEmpty() bool {
+	return node.val == ""
+}
+
+// String returns the unescaped name as it was written.
+func (node ColIdent) String() string {
+	return node.val
+}
+
+// Lowered returns a lower-cased name, for use in comparisons
+// and map lookups.
+func (node ColIdent) Lowered() string {
+	if node.lowered != "" {
+		return node.lowered
+	}
+	return node.val
+}
+
+// Equal performs a case-insensitive compare.
+func (node ColIdent) Equal(in ColIdent) bool {
+	return node.Lowered() == in.Lowered()
+}
+
+// EqualString performs a case-insensitive compare with str.
+func (node ColIdent) EqualString(str string) bool {
+	return strings.EqualFold(node.val, str)
+}
+
+// lowerIfChanged returns str in lower case, or "" if that is str.
+// Lowering a string that has no upper case letters does not
+// allocate, so identifiers only keep a second string if needed.
+func lowerIfChanged(str string) string {
+	if lowered := strings.ToLower(str); lowered != str {
+		return lowered
+	}
+	return ""
+}
+
+// TableIdent is a table, view or database name. Like ColIdent,
+// it keeps its original casing and compares case-insensitively.
+type TableIdent struct {
+	val string
+	// lowered is val in lower case, if that differs from val.
+	lowered string
+	quoted  bool
+}
+
+// NewTableIdent makes a new TableIdent.
+func NewTableIdent(str string) TableIdent {
+	return TableIdent{val: str, lowered: lowerIfChanged(str)}
+}
+
+func makeTableIdent(str string, quoted bool) TableIdent {
+	return TableIdent{val: str, lowered: lowerIfChanged(str), quoted: quoted}
+}
+
+func (node TableIdent) Format(buf *TrackedBuffer) {
+	formatID(buf, node.val, node.quoted)
+}
+
+// IsEmpty returns true if the name is empty.
+func (node TableIdent) IsEmpty() bool {
+	return node.val == ""
+}
+
+// String returns the unescaped name as it was written.
+func (node TableIdent) String() string {
+	return node.val
+}
+
+// Lowered returns a lower-cased name, for use in comparisons
+// and map lookups.
+func (node TableIdent) Lowered() string {
+	if node.lowered != "" {
+		return node.lowered
+	}
+	return node.val
+}
+
+// Equal performs a case-insensitive compare.
+func (node TableIdent) Equal(in TableIdent) bool {
+	return node.Lowered() == in.Lowered()
+}
+
+// EqualString performs a case-insensitive compare with str.
+func (node TableIdent) EqualString(str string) bool {
+	return strings.EqualFold(node.val, str)
+}
+
+// formatID writes an identifier, quoting it with backticks, or
+// double quotes in the Postgres dialect, as required by the
+// buffer's IDQuoting policy. quoted reports whether the identifier
+// was quoted in the parsed input. Embedded quotes are doubled.
+func formatID(buf *TrackedBuffer, original string, quoted bool) {
+	var quote bool
+	switch buf.idQuoting {
+	case QuoteAlways:
+		quote = true
+	case QuoteReserved:
+		quote = needsQuoting(original) || reservedWords[strings.ToLower(original)]
+	case QuoteOriginal:
+		quote = quoted || needsQuoting(original)
+	default:
+		quote = needsQuoting(original)
+	}
+	if !quote && buf.dialect == Postgres {
+		// Postgres folds unquoted identifiers to lower case.
+		_, quote = postgresKeywords[strings.ToLower(original)]
+		quote = quote || quoted && strings.ToLower(original) != original
+	}
+	if !quote {
+		buf.WriteString(original)
+		return
+	}
+	delim := byte('`')
+	if buf.dialect == Postgres {
+		delim = '"'
+	}
+	buf.WriteByte(delim)
+	for i := 0; i < len(original); i++ {
+		if original[i] == delim {
+			buf.WriteByte(delim)
+		}
+		buf.WriteByte(original[i])
+	}
+	buf.WriteByte(delim)
+}
+
+// needsQuoting returns true if name would not be read back
+// by the tokenizer as the same plain identifier.
+func needsQuoting(name string) bool {
+	if !isPlainID(name) {
+		return true
+	}
+	lowered := strings.ToLower(name)
+	if _, ok := keywords[lowered]; ok {
+		return true
+	}
+	// Charset introducers such as _utf8mb4 are not identifiers.
+	return strings.HasPrefix(lowered, "_") && charsets[lowered[1:]]
+}
+
+// formatCharsetName writes the name of a character set or
+// collation, which is written as a string unless it is DEFAULT
+// or a plain name that is not a keyword.
+func formatCharsetName(buf *TrackedBuffer, name string) {
+	if name == AST_DEFAULT || !needsQuoting(name) {
+		buf.WriteString(name)
+		return
+	}
+	buf.Myprintf("%v", StrVal{Val: name})
 }
 
-func (node *ColName) Format(buf *TrackedBuffer) {
-	if node.Qualifier != nil {
-		escape(buf, node.Qualifier)
-		buf.Myprintf(".")
+// isPlainID returns true if name consists only of characters
+// that the tokenizer accepts in an unquoted identifier.
+func isPlainID(name string) bool {
+	if name == "" || !isLetter(uint16(name[0])) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if ch := uint16(name[i]); !isLetter(ch) && !isDigit(ch) {
+			return false
+		}
 	}
-	escape(buf, node.Name)
+	return true
 }
 
-func escape(buf *TrackedBuffer, name []byte) {
-	if _, ok := keywords[string(name)]; ok {
-		buf.Myprintf("`%s`", name)
-	} else {
-		buf.Myprintf("%s", name)
+// ParenExpr represents a parenthesized value expression.
+type ParenExpr struct {
+	Expr ValExpr
+}
+
+func (node *ParenExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
 	}
+	buf.Myprintf("(%v)", node.Expr)
 }
 
 // ColTuple represents a list of column values.
@@ -757,16 +4161,24 @@ type Subquery struct {
 }
 
 func (node *Subquery) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("(%v)", node.Select)
 }
 
 // ListArg represents a named list argument.
-type ListArg []byte
+type ListArg string
 
 func (node ListArg) Format(buf *TrackedBuffer) {
 	buf.WriteArg(string(node))
 }
 
+// Bytes returns the argument name as a byte slice.
+func (node ListArg) Bytes() []byte {
+	return []byte(node)
+}
+
 // BinaryExpr represents a binary value expression.
 type BinaryExpr struct {
 	Operator    byte
@@ -786,7 +4198,16 @@ const (
 )
 
 func (node *BinaryExpr) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%v%c%v", node.Left, node.Operator, node.Right)
+	if node == nil {
+		return
+	}
+	formatOperand(buf, node, node.Left, true)
+	buf.Myprintf("%c", node.Operator)
+	if node.Operator == AST_MINUS && !parenthesizeOperand(node, node.Right, false) && startsWithMinus(node.Right) {
+		// Two minuses would start a comment.
+		buf.WriteByte(' ')
+	}
+	formatOperand(buf, node, node.Right, false)
 }
 
 // UnaryExpr represents a unary value expression.
@@ -803,22 +4224,236 @@ const (
 )
 
 func (node *UnaryExpr) Format(buf *TrackedBuffer) {
-	buf.Myprintf("%c%v", node.Operator, node.Expr)
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%c", node.Operator)
+	if node.Operator == AST_UMINUS && !parenthesizeOperand(node, node.Expr, true) && startsWithMinus(node.Expr) {
+		// Two minuses would start a comment.
+		buf.WriteByte(' ')
+	}
+	formatOperand(buf, node, node.Expr, true)
+}
+
+// startsWithMinus reports whether expr is formatted starting with
+// a minus, as a negative number, a negation, or an operation whose
+// left operand starts with one.
+func startsWithMinus(expr Expr) bool {
+	switch expr := expr.(type) {
+	case NumVal:
+		return strings.HasPrefix(string(expr), "-")
+	case *UnaryExpr:
+		return expr.Operator == AST_UMINUS
+	case *BinaryExpr:
+		return !parenthesizeOperand(expr, expr.Left, true) && startsWithMinus(expr.Left)
+	}
+	return false
+}
+
+// Operator precedence levels, from loosest to tightest binding.
+// They mirror the precedence declarations in sql.y.
+const (
+	precAssign = iota + 1
+	precOr
+	precAnd
+	precNot
+	precCompare
+	precBit
+	precAdd
+	precMult
+	precUnary
+	precCollate
+	precAtom
+)
+
+// precedence returns the binding strength of the operator
+// at the root of node.
+func precedence(node Expr) int {
+	switch node := node.(type) {
+	case *AssignExpr:
+		return precAssign
+	case *OrExpr:
+		return precOr
+	case *AndExpr:
+		return precAnd
+	case *NotExpr:
+		return precNot
+	case *ComparisonExpr, *RangeCond, *NullCheck, *IsExpr:
+		return precCompare
+	case *BinaryExpr:
+		switch node.Operator {
+		case AST_BITAND, AST_BITOR, AST_BITXOR:
+			return precBit
+		case AST_PLUS, AST_MINUS:
+			return precAdd
+		default:
+			return precMult
+		}
+	case *UnaryExpr:
+		return precUnary
+	case *CollateExpr:
+		return precCollate
+	}
+	return precAtom
+}
+
+// formatOperand formats child as an operand of parent, adding
+// parentheses if the child binds more loosely than its parent.
+// All binary operators are left associative, so a right operand
+// of equal precedence is parenthesized as well.
+func formatOperand(buf *TrackedBuffer, parent, child Expr, left bool) {
+	if parenthesizeOperand(parent, child, left) {
+		buf.Myprintf("(%v)", child)
+		return
+	}
+	buf.Myprintf("%v", child)
+}
+
+// parenthesizeOperand reports whether formatOperand parenthesizes
+// child.
+func parenthesizeOperand(parent, child Expr, left bool) bool {
+	cp, pp := precedence(child), precedence(parent)
+	return cp < pp || (cp == pp && !left && cp != precAtom)
 }
 
 // FuncExpr represents a function call.
 type FuncExpr struct {
-	Name     []byte
+	Name     ColIdent
 	Distinct bool
 	Exprs    SelectExprs
+	// Over is set for window function calls.
+	Over *WindowSpec
 }
 
 func (node *FuncExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	var distinct string
 	if node.Distinct {
 		distinct = "distinct "
 	}
-	buf.Myprintf("%s(%s%v)", node.Name, distinct, node.Exprs)
+	// Function names such as IF and VALUES are keywords, and
+	// are only quoted if they were, or are not plain identifiers.
+	if node.Name.quoted || !isPlainID(node.Name.val) {
+		formatID(buf, node.Name.val, node.Name.quoted)
+	} else {
+		buf.WriteString(node.Name.val)
+	}
+	buf.Myprintf("(%s%v)", distinct, node.Exprs)
+	if node.Over != nil {
+		if node.Over.isReference() {
+			buf.Myprintf(" over %v", node.Over.Name)
+		} else {
+			buf.Myprintf(" over %v", node.Over)
+		}
+	}
+}
+
+// WindowSpec represents the window of a window function call,
+// or of a named window. Name refers to a named window that the
+// rest of the specification refines, as in OVER (w ORDER BY a).
+// OVER w is a WindowSpec with only a Name.
+type WindowSpec struct {
+	Name        ColIdent
+	PartitionBy ValExprs
+	OrderBy     OrderBy
+	Frame       *WindowFrame
+}
+
+func (node *WindowSpec) isReference() bool {
+	return len(node.PartitionBy) == 0 && len(node.OrderBy) == 0 && node.Frame == nil && !node.Name.IsEmpty()
+}
+
+func (node *WindowSpec) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("(")
+	sep := ""
+	if !node.Name.IsEmpty() {
+		buf.Myprintf("%v", node.Name)
+		sep = " "
+	}
+	if len(node.PartitionBy) != 0 {
+		buf.Myprintf("%spartition by %v", sep, node.PartitionBy)
+		sep = " "
+	}
+	if len(node.OrderBy) != 0 {
+		prefix := sep + "order by "
+		for _, order := range node.OrderBy {
+			buf.Myprintf("%s%v", prefix, order)
+			prefix = ", "
+		}
+		sep = " "
+	}
+	if node.Frame != nil {
+		buf.Myprintf("%s%v", sep, node.Frame)
+	}
+	buf.Myprintf(")")
+}
+
+// WindowFrame represents the frame clause of a WindowSpec,
+// ROWS|RANGE Start or ROWS|RANGE BETWEEN Start AND End.
+type WindowFrame struct {
+	Unit       string
+	Start, End *FrameBound
+}
+
+// WindowFrame.Unit
+const (
+	AST_ROWS  = "rows"
+	AST_RANGE = "range"
+)
+
+func (node *WindowFrame) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.End == nil {
+		buf.Myprintf("%s %v", node.Unit, node.Start)
+		return
+	}
+	buf.Myprintf("%s between %v and %v", node.Unit, node.Start, node.End)
+}
+
+// FrameBound represents a bound of a WindowFrame. Expr is set
+// for the AST_PRECEDING and AST_FOLLOWING types.
+type FrameBound struct {
+	Type string
+	Expr ValExpr
+}
+
+// FrameBound.Type
+const (
+	AST_UNBOUNDED_PRECEDING = "unbounded preceding"
+	AST_PRECEDING           = "preceding"
+	AST_CURRENT_ROW         = "current row"
+	AST_FOLLOWING           = "following"
+	AST_UNBOUNDED_FOLLOWING = "unbounded following"
+)
+
+func (node *FrameBound) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Expr != nil {
+		buf.Myprintf("%v ", node.Expr)
+	}
+	buf.Myprintf("%s", node.Type)
+}
+
+// NamedWindow represents a window of a WINDOW clause.
+type NamedWindow struct {
+	Name ColIdent
+	Spec *WindowSpec
+}
+
+func (node *NamedWindow) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%v as %v", node.Name, node.Spec)
 }
 
 // Aggregates is a map of all aggregate functions.
@@ -841,8 +4476,330 @@ var Aggregates = map[string]bool{
 	"variance":     true,
 }
 
+// IsAggregate reports whether node calls an aggregate function.
+// An aggregate called with OVER is a window function instead.
 func (node *FuncExpr) IsAggregate() bool {
-	return Aggregates[string(node.Name)]
+	return Aggregates[node.Name.Lowered()] && node.Over == nil
+}
+
+// IntervalExpr represents an INTERVAL expr unit expression,
+// as used in date arithmetic. Unit is lower case. The units
+// made of two parts, such as hour_minute, take a string such
+// as '1:30' giving both.
+type IntervalExpr struct {
+	Expr ValExpr
+	Unit string
+}
+
+// IntervalExpr.Unit
+const (
+	AST_MICROSECOND = "microsecond"
+	AST_SECOND      = "second"
+	AST_MINUTE      = "minute"
+	AST_HOUR        = "hour"
+	AST_DAY         = "day"
+	AST_WEEK        = "week"
+	AST_MONTH       = "month"
+	AST_QUARTER     = "quarter"
+	AST_YEAR_UNIT   = "year"
+
+	AST_SECOND_MICROSECOND = "second_microsecond"
+	AST_MINUTE_MICROSECOND = "minute_microsecond"
+	AST_MINUTE_SECOND      = "minute_second"
+	AST_HOUR_MICROSECOND   = "hour_microsecond"
+	AST_HOUR_SECOND        = "hour_second"
+	AST_HOUR_MINUTE        = "hour_minute"
+	AST_DAY_MICROSECOND    = "day_microsecond"
+	AST_DAY_SECOND         = "day_second"
+	AST_DAY_MINUTE         = "day_minute"
+	AST_DAY_HOUR           = "day_hour"
+	AST_YEAR_MONTH         = "year_month"
+)
+
+// intervalUnits is the set of valid IntervalExpr units.
+var intervalUnits = map[string]bool{
+	AST_MICROSECOND: true,
+	AST_SECOND:      true,
+	AST_MINUTE:      true,
+	AST_HOUR:        true,
+	AST_DAY:         true,
+	AST_WEEK:        true,
+	AST_MONTH:       true,
+	AST_QUARTER:     true,
+	AST_YEAR_UNIT:   true,
+
+	AST_SECOND_MICROSECOND: true,
+	AST_MINUTE_MICROSECOND: true,
+	AST_MINUTE_SECOND:      true,
+	AST_HOUR_MICROSECOND:   true,
+	AST_HOUR_SECOND:        true,
+	AST_HOUR_MINUTE:        true,
+	AST_DAY_MICROSECOND:    true,
+	AST_DAY_SECOND:         true,
+	AST_DAY_MINUTE:         true,
+	AST_DAY_HOUR:           true,
+	AST_YEAR_MONTH:         true,
+}
+
+func (node *IntervalExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("interval %v %s", node.Expr, node.Unit)
+}
+
+// ConvertExpr represents a CAST(expr AS type) or a
+// CONVERT(expr, type) expression. Name is AST_CAST or
+// AST_CONVERT.
+type ConvertExpr struct {
+	Name string
+	Expr ValExpr
+	Type *ConvertType
+}
+
+// ConvertExpr.Name
+const (
+	AST_CAST    = "cast"
+	AST_CONVERT = "convert"
+)
+
+func (node *ConvertExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if node.Name == AST_CAST {
+		buf.Myprintf("cast(%v as %v)", node.Expr, node.Type)
+		return
+	}
+	buf.Myprintf("convert(%v, %v)", node.Expr, node.Type)
+}
+
+// ConvertType is the type of a ConvertExpr, such as char(10),
+// decimal(10, 2) or signed. SIGNED INTEGER and UNSIGNED INTEGER
+// are parsed as signed and unsigned.
+type ConvertType struct {
+	Type    string
+	Length  NumVal
+	Scale   NumVal
+	Charset string
+}
+
+const AST_SIGNED = "signed"
+
+func (node *ConvertType) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%s", node.Type)
+	switch {
+	case node.Scale != "":
+		buf.Myprintf("(%s, %s)", string(node.Length), string(node.Scale))
+	case node.Length != "":
+		buf.Myprintf("(%s)", string(node.Length))
+	}
+	if node.Charset != "" {
+		buf.Myprintf(" character set %s", node.Charset)
+	}
+}
+
+// ConvertUsingExpr represents a CONVERT(expr USING charset)
+// expression.
+type ConvertUsingExpr struct {
+	Expr    ValExpr
+	Charset string
+}
+
+func (node *ConvertUsingExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("convert(%v using %s)", node.Expr, node.Charset)
+}
+
+// NextValExpr represents a NEXT VALUE FOR expression, which
+// returns the next value of a sequence. In the MariaDB dialect
+// NEXTVAL(seq) and seq.nextval are parsed as NextValExpr too.
+type NextValExpr struct {
+	Sequence *TableName
+}
+
+func (node *NextValExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("next value for %v", node.Sequence)
+}
+
+// Nextval represents the select expression of SELECT NEXT n
+// VALUES FROM seq, by which sharding middleware takes n values from
+// seq, a sequence table, to hand out as auto-increment ids. Expr is
+// n, a number or a bind variable. SELECT NEXT VALUE FOR seq is a
+// NextValExpr instead.
+type Nextval struct {
+	Expr ValExpr
+}
+
+func (node *Nextval) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("next %v values", node.Expr)
+}
+
+// ValuesFuncExpr represents a VALUES(col) reference, by which an
+// ON DUPLICATE KEY UPDATE clause refers to the value the INSERT
+// would have put in the column.
+type ValuesFuncExpr struct {
+	Name *ColName
+}
+
+func (node *ValuesFuncExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("values(%v)", node.Name)
+}
+
+// UserVar represents a reference to a user variable, as in @var.
+// Name does not include the @.
+type UserVar struct {
+	Name ColIdent
+}
+
+func (node *UserVar) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("@%v", node.Name)
+}
+
+// AssignExpr represents an assignment to a user variable
+// within an expression, as in @var := expr.
+type AssignExpr struct {
+	Var  *UserVar
+	Expr ValExpr
+}
+
+func (node *AssignExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	// Assignment binds the loosest, so the operand
+	// never needs parentheses.
+	buf.Myprintf("%v %s %v", node.Var, AST_ASSIGN, node.Expr)
+}
+
+// ArrayExpr represents an ARRAY[...] constructor.
+type ArrayExpr struct {
+	Elems ValExprs
+}
+
+func (node *ArrayExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if buf.formatsFor(MySQL, MariaDB) {
+		buf.unsupported("array[...]")
+	}
+	buf.Myprintf("array[%v]", node.Elems)
+}
+
+// StructExpr represents a STRUCT(...) constructor. Fields
+// may carry an alias naming the field.
+type StructExpr struct {
+	Fields SelectExprs
+}
+
+func (node *StructExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("struct(%v)", node.Fields)
+}
+
+// SubscriptExpr represents an element access such as arr[1].
+type SubscriptExpr struct {
+	Expr  ValExpr
+	Index ValExpr
+}
+
+func (node *SubscriptExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if buf.formatsFor(MySQL, MariaDB) {
+		buf.unsupported("a subscript")
+	}
+	formatOperand(buf, node, node.Expr, true)
+	buf.Myprintf("[%v]", node.Index)
+}
+
+// JSONExtractExpr represents a MySQL JSON extraction such as
+// doc->'$.a', or doc->>'$.a' if Unquote is set.
+type JSONExtractExpr struct {
+	Expr    ValExpr
+	Path    StrVal
+	Unquote bool
+}
+
+func (node *JSONExtractExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	formatOperand(buf, node, node.Expr, true)
+	if node.Unquote {
+		buf.Myprintf("->>%v", node.Path)
+	} else {
+		buf.Myprintf("->%v", node.Path)
+	}
+}
+
+// CastExpr represents a Postgres type cast such as a::int.
+// Formatted for MySQL or MariaDB, it is written as the CAST
+// that converts to the same type, such as cast(a as signed).
+type CastExpr struct {
+	Expr ValExpr
+	Type ColumnType
+}
+
+func (node *CastExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	if buf.formatsFor(MySQL, MariaDB, BigQuery) {
+		if typ := mysqlConvertType(node.Type); typ != nil && !buf.formatsFor(BigQuery) {
+			buf.Myprintf("%v", &ConvertExpr{Name: AST_CAST, Expr: node.Expr, Type: typ})
+			return
+		}
+		buf.unsupported("::" + String(node.Type))
+	}
+	formatOperand(buf, node, node.Expr, true)
+	buf.Myprintf("::%v", node.Type)
+}
+
+// mysqlConvertType returns the type a MySQL CAST converts to for
+// typ, the type of a Postgres cast, or nil if there is none.
+func mysqlConvertType(typ ColumnType) *ConvertType {
+	switch typ.Type {
+	case AST_TINYINT, AST_SMALLINT, AST_MEDIUMINT, AST_INT, AST_INTEGER, AST_BIGINT:
+		if typ.Unsigned {
+			return &ConvertType{Type: AST_UNSIGNED}
+		}
+		return &ConvertType{Type: AST_SIGNED}
+	case AST_DECIMAL, AST_NUMERIC:
+		return &ConvertType{Type: AST_DECIMAL, Length: typ.Length, Scale: typ.Scale}
+	case AST_REAL, AST_DOUBLE, AST_FLOAT:
+		return &ConvertType{Type: typ.Type}
+	case AST_CHAR, AST_VARCHAR, AST_TEXT:
+		return &ConvertType{Type: AST_CHAR, Length: typ.Length, Charset: typ.Charset}
+	case AST_DATE, AST_TIME, AST_DATETIME:
+		return &ConvertType{Type: typ.Type, Length: typ.Length}
+	case AST_TIMESTAMP:
+		return &ConvertType{Type: AST_DATETIME, Length: typ.Length}
+	case "json":
+		return &ConvertType{Type: typ.Type}
+	}
+	return nil
 }
 
 // CaseExpr represents a CASE expression.
@@ -853,6 +4810,9 @@ type CaseExpr struct {
 }
 
 func (node *CaseExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("case ")
 	if node.Expr != nil {
 		buf.Myprintf("%v ", node.Expr)
@@ -873,6 +4833,9 @@ type When struct {
 }
 
 func (node *When) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("when %v then %v", node.Cond, node.Val)
 }
 
@@ -900,18 +4863,53 @@ const (
 )
 
 func (node *Order) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("%v %s", node.Expr, node.Direction)
 }
 
-// Limit represents a LIMIT clause.
+// ColumnPosition reports whether expr, an expression of ORDER BY
+// or GROUP BY, stands for a column of the select expressions by
+// its position, as 2 does in ORDER BY 2, and returns the position,
+// which counts from 1. Other numbers, such as 1.5 or -1, are
+// constants. SelectExprs.ResolvePosition finds the column.
+func ColumnPosition(expr Expr) (int, bool) {
+	num, ok := expr.(NumVal)
+	if !ok || num == "" {
+		return 0, false
+	}
+	for _, ch := range []byte(num) {
+		if !isDigit(uint16(ch)) {
+			return 0, false
+		}
+	}
+	pos, err := strconv.Atoi(string(num))
+	if err != nil {
+		return 0, false
+	}
+	return pos, true
+}
+
+// Limit represents a LIMIT clause. It formats as LIMIT
+// rowcount OFFSET offset in the Postgres and BigQuery dialects,
+// which do not accept LIMIT offset, rowcount.
 type Limit struct {
 	Offset, Rowcount ValExpr
 }
 
+// AST_OFFSET introduces the offset of a LIMIT clause that
+// follows its rowcount.
+const AST_OFFSET = "offset"
+
 func (node *Limit) Format(buf *TrackedBuffer) {
 	if node == nil {
 		return
 	}
+	if node.Offset != nil && (buf.dialect == Postgres || buf.dialect == BigQuery) {
+		buf.Myprintf(" limit %v "+AST_OFFSET+" %v", node.Rowcount, node.Offset)
+		return
+	}
 	buf.Myprintf(" limit ")
 	if node.Offset != nil {
 		buf.Myprintf("%v, ", node.Offset)
@@ -1000,6 +4998,9 @@ type UpdateExpr struct {
 }
 
 func (node *UpdateExpr) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
 	buf.Myprintf("%v = %v", node.Name, node.Expr)
 }
 
@@ -1010,6 +5011,11 @@ func (node OnDup) Format(buf *TrackedBuffer) {
 	if node == nil {
 		return
 	}
+	if buf.dialect == Postgres {
+		// ON CONFLICT needs the constraint the row conflicts
+		// with, which ON DUPLICATE KEY UPDATE leaves implicit.
+		buf.unsupported("on duplicate key update")
+	}
 	buf.Myprintf(" on duplicate key update %v", UpdateExprs(node))
 }
 
@@ -1038,6 +5044,8 @@ const (
 	AST_CHAR    = "char"
 	AST_VARCHAR = "varchar"
 	AST_TEXT    = "text"
+	AST_ENUM    = "enum"
+	AST_SET     = "set"
 
 	AST_DATE      = "date"
 	AST_TIME      = "time"
@@ -1049,7 +5057,10 @@ const (
 
 	AST_UNIQUE_KEY     = "unique key"
 	AST_AUTO_INCREMENT = "auto_increment"
+	AST_NULL           = "null"
 	AST_NOT_NULL       = "not null"
 	AST_DEFAULT        = "default"
+	AST_ON_UPDATE      = "on update"
 	AST_KEY            = "key"
+	AST_COMMENT        = "comment"
 )
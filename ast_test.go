@@ -4,7 +4,10 @@
 
 package sqlparser
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestLimits(t *testing.T) {
 	var l *Limit
@@ -27,9 +30,9 @@ func TestLimits(t *testing.T) {
 		t.Errorf("got %v, want %s", err, wantErr)
 	}
 
-	l = &Limit{Offset: StrVal([]byte("2"))}
+	l = &Limit{Offset: StrVal{Val: "2"}}
 	_, _, err = l.Limits()
-	wantErr = "unexpected node for offset: [50]"
+	wantErr = "unexpected node for offset: {Val:2 Quote:0 Doubled:false}"
 	if err == nil || err.Error() != wantErr {
 		t.Errorf("got %v, want %s", err, wantErr)
 	}
@@ -79,3 +82,424 @@ func TestLimits(t *testing.T) {
 		t.Errorf("got %v, want %s", err, wantErr)
 	}
 }
+
+func TestPrecedenceFormat(t *testing.T) {
+	a, b, c := &ColName{Name: NewColIdent("a")}, &ColName{Name: NewColIdent("b")}, &ColName{Name: NewColIdent("c")}
+	cmp := func(v ValExpr) BoolExpr {
+		return &ComparisonExpr{Left: v, Operator: AST_EQ, Right: NumVal([]byte("1"))}
+	}
+	tcases := []struct {
+		node SQLNode
+		want string
+	}{{
+		&BinaryExpr{Left: &BinaryExpr{Left: a, Operator: AST_PLUS, Right: b}, Operator: AST_MULT, Right: c},
+		"(a+b)*c",
+	}, {
+		&BinaryExpr{Left: a, Operator: AST_PLUS, Right: &BinaryExpr{Left: b, Operator: AST_MULT, Right: c}},
+		"a+b*c",
+	}, {
+		&BinaryExpr{Left: a, Operator: AST_MINUS, Right: &BinaryExpr{Left: b, Operator: AST_MINUS, Right: c}},
+		"a-(b-c)",
+	}, {
+		&BinaryExpr{Left: &BinaryExpr{Left: a, Operator: AST_MINUS, Right: b}, Operator: AST_MINUS, Right: c},
+		"a-b-c",
+	}, {
+		&UnaryExpr{Operator: AST_TILDA, Expr: &BinaryExpr{Left: a, Operator: AST_BITAND, Right: b}},
+		"~(a&b)",
+	}, {
+		&AndExpr{Left: &OrExpr{Left: cmp(a), Right: cmp(b)}, Right: cmp(c)},
+		"(a = 1 or b = 1) and c = 1",
+	}, {
+		&OrExpr{Left: &AndExpr{Left: cmp(a), Right: cmp(b)}, Right: cmp(c)},
+		"a = 1 and b = 1 or c = 1",
+	}, {
+		&NotExpr{Expr: &AndExpr{Left: cmp(a), Right: cmp(b)}},
+		"not (a = 1 and b = 1)",
+	}}
+	for _, tcase := range tcases {
+		if got := String(tcase.node); got != tcase.want {
+			t.Errorf("String(%#v): %s, want %s", tcase.node, got, tcase.want)
+		}
+	}
+}
+
+func TestIdentEqual(t *testing.T) {
+	if !NewColIdent("Col").Equal(NewColIdent("cOL")) {
+		t.Error("ColIdent.Equal should be case-insensitive")
+	}
+	if !NewTableIdent("Tbl").EqualString("tbl") {
+		t.Error("TableIdent.EqualString should be case-insensitive")
+	}
+	if got, want := NewColIdent("Col").Lowered(), "col"; got != want {
+		t.Errorf("Lowered: %s, want %s", got, want)
+	}
+	tree, err := Parse("select `Col` from Tbl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sel := tree.(*Select)
+	col := sel.SelectExprs[0].(*NonStarExpr).Expr.(*ColName).Name
+	table := sel.From[0].(*AliasedTableExpr).Expr.(*TableName).Name
+	if !col.Equal(NewColIdent("COL")) || table.Lowered() != "tbl" {
+		t.Errorf("parsed identifiers: %s, %s", col.Lowered(), table.Lowered())
+	}
+	// The lower-cased name is kept, so Lowered does not allocate.
+	if allocs := testing.AllocsPerRun(10, func() { col.Lowered() }); allocs != 0 {
+		t.Errorf("Lowered allocates %v times", allocs)
+	}
+}
+
+func TestNilFormat(t *testing.T) {
+	var table *TableName
+	tcases := []struct {
+		node SQLNode
+		want string
+	}{
+		{nil, ""},
+		{table, ""},
+		{&Select{SelectExprs: SelectExprs{&StarExpr{}}}, "select *"},
+		{&Update{Exprs: UpdateExprs{{Name: &ColName{Name: NewColIdent("a")}}}}, "update  set a = "},
+		{&ComparisonExpr{Operator: AST_EQ, Right: NumVal("1")}, " = 1"},
+		{&AndExpr{Right: &NotExpr{}}, " and not "},
+		{&Insert{Rows: Values{nil}}, "insert into  values "},
+	}
+	for _, tcase := range tcases {
+		if got := String(tcase.node); got != tcase.want {
+			t.Errorf("String(%#v): %q, want %q", tcase.node, got, tcase.want)
+		}
+	}
+}
+
+func TestIsDual(t *testing.T) {
+	tree, err := Parse("select 1 from DUAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := tree.(*Select).From[0].(*AliasedTableExpr).Expr.(*TableName)
+	if !table.IsDual() {
+		t.Errorf("IsDual(%s): false, want true", String(table))
+	}
+	tree, err = Parse("select 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from := tree.(*Select).From; from != nil {
+		t.Errorf("From: %v, want nil", from)
+	}
+}
+
+func TestIDQuoting(t *testing.T) {
+	tree, err := Parse("select `a`, `date`, `call`, `my col`, b from `t`")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tcases := []struct {
+		policy IDQuoting
+		want   string
+	}{
+		{QuoteWhenNeeded, "select a, `date`, call, `my col`, b from t"},
+		{QuoteAlways, "select `a`, `date`, `call`, `my col`, `b` from `t`"},
+		{QuoteReserved, "select a, `date`, `call`, `my col`, b from t"},
+		{QuoteOriginal, "select `a`, `date`, `call`, `my col`, b from `t`"},
+	}
+	for _, tcase := range tcases {
+		buf := NewTrackedBuffer(nil)
+		buf.SetIDQuoting(tcase.policy)
+		buf.Myprintf("%v", tree)
+		if got := buf.String(); got != tcase.want {
+			t.Errorf("policy %d: %s, want %s", tcase.policy, got, tcase.want)
+		}
+	}
+}
+
+func TestIDQuotingRoundTrip(t *testing.T) {
+	tree, err := Parse("select `weird``name`, `a\"b`, `has space` as `select`, `café`, `1e5` from `my table` as `order`")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, policy := range []IDQuoting{QuoteWhenNeeded, QuoteAlways, QuoteReserved, QuoteOriginal} {
+		for _, dialect := range []Dialect{MySQL, Postgres} {
+			buf := NewTrackedBuffer(nil)
+			buf.SetIDQuoting(policy)
+			buf.SetDialect(dialect)
+			buf.Myprintf("%v", tree)
+			reparsed, err := ParseWithOptions(buf.String(), Options{Dialect: dialect})
+			if err != nil {
+				t.Errorf("policy %d, dialect %d: %s: %v", policy, dialect, buf.String(), err)
+				continue
+			}
+			if got, want := String(reparsed), String(tree); got != want {
+				t.Errorf("policy %d, dialect %d: %s, want %s", policy, dialect, got, want)
+			}
+		}
+	}
+}
+
+func TestIDQuotingKeywords(t *testing.T) {
+	words := []string{"session", "pivot", "modify", "escape", "begin", "qualify", "window", "dual", "local"}
+	for word := range keywords {
+		words = append(words, word)
+	}
+	for word := range reservedWords {
+		words = append(words, word)
+	}
+	for word := range postgresKeywords {
+		words = append(words, word)
+	}
+	for _, word := range words {
+		input := fmt.Sprintf("select `%s`, `%s`.`%s`, `%s`(1) from `%s` as `%s`", word, word, word, word, word, word)
+		tree, err := Parse(input)
+		if err != nil {
+			t.Errorf("%s: %v", input, err)
+			continue
+		}
+		for _, policy := range []IDQuoting{QuoteWhenNeeded, QuoteAlways, QuoteReserved, QuoteOriginal} {
+			for _, dialect := range []Dialect{MySQL, Postgres} {
+				buf := NewTrackedBuffer(nil)
+				buf.SetIDQuoting(policy)
+				buf.SetDialect(dialect)
+				buf.Myprintf("%v", tree)
+				reparsed, err := ParseWithOptions(buf.String(), Options{Dialect: dialect})
+				if err != nil {
+					t.Errorf("policy %d, dialect %d: %s: %v", policy, dialect, buf.String(), err)
+					continue
+				}
+				if got, want := String(reparsed), String(tree); got != want {
+					t.Errorf("policy %d, dialect %d: %s, want %s", policy, dialect, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestInsertDuplicateValues(t *testing.T) {
+	tree, err := Parse("insert into t(a) values (1) as new on duplicate key update a = values(a), b = coalesce(values(b), new.a)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ins := tree.(*Insert)
+	if ins.RowAlias == nil || ins.RowAlias.Name.String() != "new" {
+		t.Errorf("RowAlias: %v, want new", ins.RowAlias)
+	}
+	if values, ok := ins.OnDup[0].Expr.(*ValuesFuncExpr); !ok || values.Name.Name.String() != "a" {
+		t.Errorf("OnDup[0]: %#v, want VALUES(a)", ins.OnDup[0].Expr)
+	}
+	fn := ins.OnDup[1].Expr.(*FuncExpr)
+	if _, ok := fn.Exprs[0].(*NonStarExpr).Expr.(*ValuesFuncExpr); !ok {
+		t.Errorf("coalesce argument: %#v, want VALUES(b)", fn.Exprs[0])
+	}
+
+	tree, err = Parse("insert into t select a from u as new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias := tree.(*Insert).RowAlias; alias != nil {
+		t.Errorf("RowAlias: %v, want the alias of u", alias)
+	}
+}
+
+func TestColumnPosition(t *testing.T) {
+	tree, err := Parse("select a, b + 1 as c, d from t group by 2, a collate latin1_bin order by 3 desc, 1.5, -1, 0x1, 'x'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sel := tree.(*Select)
+	var positions []int
+	for _, order := range sel.OrderBy {
+		if pos, ok := ColumnPosition(order.Expr); ok {
+			positions = append(positions, pos)
+		}
+	}
+	for _, expr := range sel.GroupBy {
+		if pos, ok := ColumnPosition(expr.(*NonStarExpr).Expr); ok {
+			positions = append(positions, pos)
+		}
+	}
+	if len(positions) != 2 || positions[0] != 3 || positions[1] != 2 {
+		t.Errorf("positions: %v, want [3 2]", positions)
+	}
+
+	expr, err := sel.SelectExprs.ResolvePosition(2)
+	if err != nil || String(expr) != "b+1 as c" {
+		t.Errorf("ResolvePosition(2): %v, %v, want b+1 as c", String(expr), err)
+	}
+	for _, pos := range []int{0, 4} {
+		if _, err := sel.SelectExprs.ResolvePosition(pos); err == nil {
+			t.Errorf("ResolvePosition(%d): no error", pos)
+		}
+	}
+
+	tree, err = Parse("select a, t.*, b from t order by 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tree.(*Select).SelectExprs.ResolvePosition(1); err != nil {
+		t.Errorf("ResolvePosition(1): %v", err)
+	}
+	_, err = tree.(*Select).SelectExprs.ResolvePosition(3)
+	if err == nil || err.Error() != "column position 3 follows t.*" {
+		t.Errorf("ResolvePosition(3): %v, want column position 3 follows t.*", err)
+	}
+}
+
+func TestFormatDialect(t *testing.T) {
+	tree, err := Parse("select `a b`, 'it\\'s', true from `t` where c <> false limit 5, 10 lock in share mode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tcases := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{MySQL, "select `a b`, 'it\\'s', true from t where c != false limit 5, 10 lock in share mode"},
+		{MariaDB, "select `a b`, 'it\\'s', true from t where c != false limit 5, 10 lock in share mode"},
+		{Postgres, `select "a b", 'it''s', true from t where c != false limit 10 offset 5 for share`},
+		{BigQuery, "select `a b`, 'it\\'s', true from t where c != false limit 10 offset 5 lock in share mode"},
+	}
+	for _, tcase := range tcases {
+		got, err := FormatDialect(tree, tcase.dialect)
+		if err != nil {
+			t.Errorf("dialect %d: %v", tcase.dialect, err)
+			continue
+		}
+		if got != tcase.want {
+			t.Errorf("dialect %d: %s, want %s", tcase.dialect, got, tcase.want)
+			continue
+		}
+		reparsed, err := ParseWithOptions(got, Options{Dialect: tcase.dialect})
+		if err != nil {
+			t.Errorf("dialect %d: %s: %v", tcase.dialect, got, err)
+			continue
+		}
+		if again, _ := FormatDialect(reparsed, tcase.dialect); again != got {
+			t.Errorf("dialect %d: %s, want %s", tcase.dialect, again, got)
+		}
+	}
+}
+
+func TestFormatDialectPostgres(t *testing.T) {
+	tcases := []struct {
+		input, output string
+		reparse       bool
+	}{{
+		input:   `select 'x\ny', 'a\\b', 'it\'s', '5\_%', "q" from t`,
+		output:  `select E'x\ny', 'a\b', 'it''s', '5\_%', 'q' from t`,
+		reparse: true,
+	}, {
+		input:   `select 'a\tb\\c\'d\%' from t`,
+		output:  `select E'a\tb\\c''d\\%' from t`,
+		reparse: true,
+	}, {
+		input:   "select a from t where b regexp 'x' and c not regexp 'y'",
+		output:  "select a from t where b ~* 'x' and c !~* 'y'",
+		reparse: true,
+	}}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		got, err := FormatDialect(tree, Postgres)
+		if err != nil {
+			t.Errorf("%s: %v", tcase.input, err)
+			continue
+		}
+		if got != tcase.output {
+			t.Errorf("%s: %s, want %s", tcase.input, got, tcase.output)
+			continue
+		}
+		if !tcase.reparse {
+			continue
+		}
+		reparsed, err := ParseWithOptions(got, Options{Dialect: Postgres})
+		if err != nil {
+			t.Errorf("%s: %v", got, err)
+			continue
+		}
+		if again, _ := FormatDialect(reparsed, Postgres); again != got {
+			t.Errorf("%s: %s, want %s", got, again, got)
+		}
+	}
+
+	for _, tcase := range []struct {
+		input, err string
+	}{{
+		input: "insert into t(a) values (1) on duplicate key update a = 2",
+		err:   "on duplicate key update cannot be expressed in postgres",
+	}, {
+		input: `select 'a\0b' from t`,
+		err:   "a string holding a NUL character cannot be expressed in postgres",
+	}} {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if _, err := FormatDialect(tree, Postgres); err == nil || err.Error() != tcase.err {
+			t.Errorf("%s: %v, want %s", tcase.input, err, tcase.err)
+		}
+	}
+}
+
+func TestFormatDialectFromPostgres(t *testing.T) {
+	tcases := []struct {
+		input, output string
+	}{{
+		input:  "select a::int, b::varchar(10), c::numeric(5, 2), d::timestamp from t",
+		output: "select cast(a as signed), cast(b as char(10)), cast(c as decimal(5, 2)), cast(d as datetime) from t",
+	}, {
+		input:  "select a from t where b ilike 'x%' and c not ilike 'y%'",
+		output: "select a from t where lower(b) like lower('x%') and lower(c) not like lower('y%')",
+	}, {
+		input:  "select a from t where b = $1 and c = $2",
+		output: "select a from t where b = ? and c = ?",
+	}, {
+		input:  "select a from t where b ~* 'x' and c !~* 'y'",
+		output: "select a from t where b regexp 'x' and c not regexp 'y'",
+	}}
+	for _, tcase := range tcases {
+		tree, err := ParseWithOptions(tcase.input, Options{Dialect: Postgres})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		got, err := FormatDialect(tree, MySQL)
+		if err != nil {
+			t.Errorf("%s: %v", tcase.input, err)
+			continue
+		}
+		if got != tcase.output {
+			t.Errorf("%s: %s, want %s", tcase.input, got, tcase.output)
+			continue
+		}
+		if _, err := Parse(got); err != nil {
+			t.Errorf("%s: %v", got, err)
+		}
+	}
+
+	for _, tcase := range []struct {
+		input, err string
+	}{{
+		input: "select a from t where b = $2 and c = $1",
+		err:   "positional parameter $2 out of order cannot be expressed in mysql",
+	}, {
+		input: "insert into t(a) values (1) returning a",
+		err:   "returning cannot be expressed in mysql",
+	}, {
+		input: "select array[1, 2] from t",
+		err:   "array[...] cannot be expressed in mysql",
+	}, {
+		input: "select a::bytea from t",
+		err:   "::bytea cannot be expressed in mysql",
+	}} {
+		tree, err := ParseWithOptions(tcase.input, Options{Dialect: Postgres})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if _, err := FormatDialect(tree, MySQL); err == nil || err.Error() != tcase.err {
+			t.Errorf("%s: %v, want %s", tcase.input, err, tcase.err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// benchmarkCorpus holds queries representative of the traffic
+// of a proxy, from point lookups to reports and DDL.
+var benchmarkCorpus = []struct {
+	name string
+	sql  string
+}{{
+	name: "point",
+	sql:  "select id, name, email from users where id = :id",
+}, {
+	name: "upper",
+	sql:  "SELECT ID, NAME, EMAIL FROM USERS WHERE ID = 42 AND STATUS = 'active' LIMIT 1",
+}, {
+	name: "join",
+	sql: "select o.id, o.total, c.name, count(i.id) as items from orders as o " +
+		"join customers as c on o.customer_id = c.id left join order_items as i on i.order_id = o.id " +
+		"where o.created_at >= '2020-01-01' and c.country in ('US', 'CA', 'MX') " +
+		"group by o.id, o.total, c.name having count(i.id) > 2 order by o.total desc limit 100",
+}, {
+	name: "subquery",
+	sql: "select a, b from t where a in (select a from u where b = 1) and exists " +
+		"(select 1 from v where v.c = t.c) union all select a, b from w order by a",
+}, {
+	name: "insert",
+	sql: "insert into events(id, kind, payload, created_at) values " +
+		"(1, 'click', '{\"x\": 1}', now()), (2, 'view', 'it\\'s', now()), (3, 'buy', 'a''b', now()) " +
+		"on duplicate key update payload = values(payload)",
+}, {
+	name: "update",
+	sql:  "update accounts set balance = balance - 10.50, updated_at = now() where id = 7 and balance >= 10.50",
+}, {
+	name: "delete",
+	sql:  "delete from sessions where expires_at < now() - interval 1 day limit 1000",
+}, {
+	name: "comments",
+	sql: "/* trace_id=abc123 shard=7 */ select `order`, `group` -- columns\n" +
+		"from `my table` where x = 0x1F and y = b'1010' and z = 1.5e3",
+}, {
+	name: "ddl",
+	sql: "create table t (id bigint not null auto_increment, name varchar(255) not null default '', " +
+		"price decimal(10, 2), created_at datetime, primary key (id), key idx_name (name))",
+}}
+
+func BenchmarkParseCorpus(b *testing.B) {
+	for _, query := range benchmarkCorpus {
+		query := query
+		b.Run(query.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(query.sql)))
+			for i := 0; i < b.N; i++ {
+				if _, err := Parse(query.sql); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTokenizeCorpus(b *testing.B) {
+	for _, query := range benchmarkCorpus {
+		query := query
+		b.Run(query.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(query.sql)))
+			for i := 0; i < b.N; i++ {
+				tkn := NewStringTokenizer(query.sql)
+				var lval yySymType
+				for {
+					typ := tkn.Lex(&lval)
+					if typ == 0 {
+						break
+					}
+					if typ == LEX_ERROR {
+						b.Fatal(tkn.LastError)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestTokenizeReader checks that tokenizing from a reader, which
+// copies the values of tokens, gives the same tokens as slicing
+// them from a string.
+func TestTokenizeReader(t *testing.T) {
+	inputs := []string{
+		"select 'it''s', 'a\\'b\\n', \"q\"\"q\", `a``b`, @`u``v`, @w.x, @@global.y from t",
+		"select next value for s, a sounds like b, x'1F', b'01', 0x1f, .5e-3, :a, ::b, ? from t",
+		"select * from t where a = any (select b from u) group by grouping sets ((a)) -- c\n",
+		"SELECT `Select` FROM t WITH CASCADED CHECK OPTION /* trailing",
+		"select 'unterminated",
+	}
+	for _, query := range benchmarkCorpus {
+		inputs = append(inputs, query.sql)
+	}
+	type token struct {
+		typ    int
+		str    string
+		strVal StrVal
+	}
+	tokenize := func(tkn *Tokenizer) []token {
+		var tokens []token
+		for {
+			var lval yySymType
+			typ := tkn.Lex(&lval)
+			tokens = append(tokens, token{typ, lval.str, lval.strVal})
+			if typ == 0 || typ == LEX_ERROR {
+				return tokens
+			}
+		}
+	}
+	for _, sql := range inputs {
+		want := tokenize(NewStringTokenizer(sql))
+		assert.Equal(t, want, tokenize(NewTokenizer(strings.NewReader(sql))), sql)
+	}
+}
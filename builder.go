@@ -0,0 +1,161 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// builder.go helps build parse trees in code, for generating
+// queries without assembling their nodes by hand.
+
+import (
+	"strconv"
+)
+
+// NewSelect returns a SELECT statement of the select expressions
+// exprs from the tables of from. Clauses can be added with the
+// methods of Select, which return it for chaining:
+//
+//	sel := NewSelect(SelectExprs{NewSelectExpr(NewColName("a"), "")}, NewAliasedTable("t", "")).
+//		AddWhere(NewComparison(AST_EQ, NewColName("b"), NewValArg("b"))).
+//		AddOrder(NewColName("a"), AST_DESC).
+//		SetLimit(10, 0)
+func NewSelect(exprs SelectExprs, from ...TableExpr) *Select {
+	return &Select{SelectExprs: exprs, From: TableExprs(from)}
+}
+
+// NewSelectExpr returns a select expression of expr, aliased as
+// as unless it is empty.
+func NewSelectExpr(expr Expr, as string) SelectExpr {
+	return &NonStarExpr{Expr: expr, As: NewColIdent(as)}
+}
+
+// NewColName returns a column name that is not qualified by its
+// table.
+func NewColName(name string) *ColName {
+	return &ColName{Name: NewColIdent(name)}
+}
+
+// NewQualifiedColName returns the column name of the table
+// or alias table.
+func NewQualifiedColName(table, name string) *ColName {
+	return &ColName{Name: NewColIdent(name), Qualifier: NewTableIdent(table)}
+}
+
+// NewTableName returns a table name that is not qualified by its
+// database.
+func NewTableName(name string) *TableName {
+	return &TableName{Name: NewTableIdent(name)}
+}
+
+// NewAliasedTable returns a table of a FROM clause, aliased as as
+// unless it is empty.
+func NewAliasedTable(name, as string) *AliasedTableExpr {
+	return &AliasedTableExpr{Expr: NewTableName(name), As: NewTableIdent(as)}
+}
+
+// NewComparison returns the comparison of left and right with
+// operator, one of the ComparisonExpr operators such as AST_EQ.
+func NewComparison(operator string, left, right ValExpr) *ComparisonExpr {
+	return &ComparisonExpr{Operator: operator, Left: left, Right: right}
+}
+
+// NewStrVal returns a string literal.
+func NewStrVal(s string) StrVal {
+	return StrVal{Val: s}
+}
+
+// NewIntVal returns an integer literal.
+func NewIntVal(n int64) NumVal {
+	return NumVal(strconv.FormatInt(n, 10))
+}
+
+// NewValArg returns the bind variable called name, which is
+// given without its ':' prefix.
+func NewValArg(name string) ValArg {
+	return ValArg(":" + name)
+}
+
+// NewFuncExpr returns a call of the function name with args.
+func NewFuncExpr(name string, args ...Expr) *FuncExpr {
+	exprs := make(SelectExprs, len(args))
+	for i, arg := range args {
+		exprs[i] = &NonStarExpr{Expr: arg}
+	}
+	return &FuncExpr{Name: NewColIdent(name), Exprs: exprs}
+}
+
+// andWhere returns where with expr ANDed to its condition, or a
+// new clause of type typ if where is nil.
+func andWhere(where *Where, typ string, expr BoolExpr) *Where {
+	if where == nil {
+		return NewWhere(typ, expr)
+	}
+	where.Expr = &AndExpr{Left: where.Expr, Right: expr}
+	return where
+}
+
+// AddSelectExpr adds the select expression expr, aliased as as
+// unless it is empty.
+func (node *Select) AddSelectExpr(expr Expr, as string) *Select {
+	node.SelectExprs = append(node.SelectExprs, NewSelectExpr(expr, as))
+	return node
+}
+
+// AddFrom adds tables to the FROM clause.
+func (node *Select) AddFrom(tables ...TableExpr) *Select {
+	node.From = append(node.From, tables...)
+	return node
+}
+
+// AddWhere adds expr to the WHERE clause, ANDing it with the
+// conditions already there.
+func (node *Select) AddWhere(expr BoolExpr) *Select {
+	node.Where = andWhere(node.Where, AST_WHERE, expr)
+	return node
+}
+
+// AddGroupBy adds exprs to the GROUP BY clause.
+func (node *Select) AddGroupBy(exprs ...Expr) *Select {
+	for _, expr := range exprs {
+		node.GroupBy = append(node.GroupBy, &NonStarExpr{Expr: expr})
+	}
+	return node
+}
+
+// AddHaving adds expr to the HAVING clause, ANDing it with the
+// conditions already there.
+func (node *Select) AddHaving(expr BoolExpr) *Select {
+	node.Having = andWhere(node.Having, AST_HAVING, expr)
+	return node
+}
+
+// AddOrder adds expr to the ORDER BY clause, in the direction
+// AST_ASC or AST_DESC.
+func (node *Select) AddOrder(expr ValExpr, direction string) *Select {
+	node.OrderBy = append(node.OrderBy, &Order{Expr: expr, Direction: direction})
+	return node
+}
+
+// SetLimit sets the LIMIT clause to rowcount rows, skipping
+// offset rows unless it is 0.
+func (node *Select) SetLimit(rowcount, offset int64) *Select {
+	node.Limit = &Limit{Rowcount: NewIntVal(rowcount)}
+	if offset != 0 {
+		node.Limit.Offset = NewIntVal(offset)
+	}
+	return node
+}
+
+// AddWhere adds expr to the WHERE clause, ANDing it with the
+// conditions already there.
+func (node *Update) AddWhere(expr BoolExpr) *Update {
+	node.Where = andWhere(node.Where, AST_WHERE, expr)
+	return node
+}
+
+// AddWhere adds expr to the WHERE clause, ANDing it with the
+// conditions already there.
+func (node *Delete) AddWhere(expr BoolExpr) *Delete {
+	node.Where = andWhere(node.Where, AST_WHERE, expr)
+	return node
+}
@@ -0,0 +1,45 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	sel := NewSelect(SelectExprs{NewSelectExpr(NewQualifiedColName("x", "a"), "")}, NewAliasedTable("t", "x")).
+		AddSelectExpr(NewFuncExpr("count", NewColName("b")), "n").
+		AddWhere(NewComparison(AST_EQ, NewColName("c"), NewValArg("c"))).
+		AddWhere(&OrExpr{
+			Left:  NewComparison(AST_GT, NewColName("d"), NewIntVal(1)),
+			Right: NewComparison(AST_LIKE, NewColName("e"), NewStrVal("it's%")),
+		}).
+		AddGroupBy(NewQualifiedColName("x", "a")).
+		AddHaving(NewComparison(AST_GT, NewColName("n"), NewIntVal(2))).
+		AddOrder(NewColName("n"), AST_DESC).
+		SetLimit(10, 20)
+	want := "select x.a, count(b) as n from t as x where c = :c and (d > 1 or e like 'it\\'s%') group by x.a having n > 2 order by n desc limit 20, 10"
+	assert.Equal(t, want, String(sel))
+	tree, err := Parse(want)
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, String(tree))
+	}
+
+	upd, err := Parse("update t set a = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upd.(*Update).AddWhere(NewComparison(AST_EQ, NewColName("id"), NewIntVal(3)))
+	assert.Equal(t, "update t set a = 1 where id = 3", String(upd))
+
+	del, err := Parse("delete from t where a = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	del.(*Delete).AddWhere(NewComparison(AST_NE, NewColName("b"), NewStrVal("x")))
+	assert.Equal(t, "delete from t where a = 1 and b != 'x'", String(del))
+}
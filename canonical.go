@@ -0,0 +1,170 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// canonical.go formats statements in a canonical form.
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CanonicalString formats node in a canonical form, so that
+// statements that differ only in how they are written format the
+// same, for diffing queries and as keys of caches. Keywords and the
+// names of functions are in lower case, identifiers are quoted only
+// where needed, parentheses that do not change the meaning of an
+// expression are dropped, and the columns of an INSERT are sorted
+// by name, with the values of its rows to match. Identifiers keep
+// their case, as table names may be case sensitive. node itself is
+// left as it is.
+func CanonicalString(node SQLNode) string {
+	node = Clone(node)
+	canonicalize(reflect.ValueOf(node), nil)
+	buf := NewTrackedBuffer(formatCanonical)
+	buf.Myprintf("%v", node)
+	return buf.String()
+}
+
+func formatCanonical(buf *TrackedBuffer, node SQLNode) {
+	if node, ok := node.(*TimeRange); ok && node != nil {
+		buf.Myprintf(" asof %v", node.From)
+		if node.To != nil {
+			buf.Myprintf(" until %v", node.To)
+		}
+		return
+	}
+	node.Format(buf)
+}
+
+// canonicalize puts the nodes reachable from val, which are held
+// by the node parent, in canonical form.
+func canonicalize(val reflect.Value, parent SQLNode) {
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		for val.CanSet() {
+			inner := parenthesized(val.Elem().Interface())
+			if inner == nil || !reflect.TypeOf(inner).Implements(val.Type()) || !redundantParens(parent, inner) {
+				break
+			}
+			val.Set(reflect.ValueOf(inner))
+		}
+		if node, ok := val.Elem().Interface().(SQLNode); ok && val.Elem().Kind() != reflect.Ptr {
+			parent = node
+		}
+		canonicalize(val.Elem(), parent)
+	case reflect.Ptr:
+		if val.IsNil() {
+			return
+		}
+		switch node := val.Interface().(type) {
+		case *FuncExpr:
+			node.Name = NewColIdent(node.Name.Lowered())
+		case *ClausePart:
+			node.Keyword = strings.ToLower(node.Keyword)
+		case *Insert:
+			sortInsertColumns(node)
+		}
+		if node, ok := val.Interface().(SQLNode); ok {
+			parent = node
+		}
+		canonicalize(val.Elem(), parent)
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			canonicalize(val.Index(i), parent)
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			canonicalize(val.Field(i), parent)
+		}
+	}
+}
+
+// parenthesized returns the expression node encloses in
+// parentheses, or nil if it is not a parenthesized expression.
+func parenthesized(node interface{}) Expr {
+	switch node := node.(type) {
+	case *ParenExpr:
+		return node.Expr
+	case *ParenBoolExpr:
+		return node.Expr
+	}
+	return nil
+}
+
+// redundantParens reports whether the parentheses enclosing expr
+// can be dropped where parent holds it: if it is an atom, or where
+// the formatter adds the parentheses it needs, or where it is a
+// whole expression of its own.
+func redundantParens(parent SQLNode, expr Expr) bool {
+	switch expr := expr.(type) {
+	case *UnaryExpr, *AssignExpr, ValTuple:
+		// -(-a) would read as a comment, and ((a, b)) is
+		// a tuple of a tuple.
+		return false
+	case NumVal:
+		if strings.HasPrefix(string(expr), "-") {
+			return false
+		}
+	}
+	if precedence(expr) == precAtom {
+		return true
+	}
+	switch parent.(type) {
+	case *AndExpr, *OrExpr, *NotExpr, *BinaryExpr, *UnaryExpr, *CollateExpr,
+		*SubscriptExpr, *JSONExtractExpr, *CastExpr:
+		return true
+	case *Where, *NonStarExpr, *Order, *JoinTableExpr, *When, ValTuple:
+		return true
+	case *ComparisonExpr, *RangeCond:
+		return precedence(expr) > precCompare
+	}
+	return false
+}
+
+// sortInsertColumns sorts the columns of stmt by name, and the
+// values of its rows to match, unless its rows are not all tuples
+// of a value for each column.
+func sortInsertColumns(stmt *Insert) {
+	rows, ok := stmt.Rows.(Values)
+	if !ok || len(stmt.Columns) == 0 {
+		return
+	}
+	for _, row := range rows {
+		if tuple, ok := row.(ValTuple); !ok || len(tuple) != len(stmt.Columns) {
+			return
+		}
+	}
+	order := make([]int, len(stmt.Columns))
+	names := make([]string, len(stmt.Columns))
+	for i, col := range stmt.Columns {
+		order[i], names[i] = i, strings.ToLower(String(col))
+	}
+	sort.SliceStable(order, func(i, j int) bool { return names[order[i]] < names[order[j]] })
+	columns := make(Columns, len(order))
+	for i, j := range order {
+		columns[i] = stmt.Columns[j]
+	}
+	stmt.Columns = columns
+	if alias := stmt.RowAlias; alias != nil && len(alias.Columns) == len(order) {
+		columns := make(Columns, len(order))
+		for i, j := range order {
+			columns[i] = alias.Columns[j]
+		}
+		alias.Columns = columns
+	}
+	for k, row := range rows {
+		tuple := row.(ValTuple)
+		sorted := make(ValTuple, len(order))
+		for i, j := range order {
+			sorted[i] = tuple[j]
+		}
+		rows[k] = sorted
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalString(t *testing.T) {
+	tcases := []struct {
+		input, output string
+	}{{
+		input:  "SELECT COUNT(*), (a), ((b+1))*2, a - (b - c), (a - b) - c FROM `T` JOIN u ON (t.a = u.a) WHERE NOT (a = 1) AND (b = 1 OR c = 1) AND (d+1) = 2 AND e BETWEEN (f) AND (g+1) ORDER BY (a+1) DESC",
+		output: "select count(*), a, (b+1)*2, a-(b-c), a-b-c from T join u on t.a = u.a where not a = 1 and (b = 1 or c = 1) and d+1 = 2 and e between f and g+1 order by a+1 desc",
+	}, {
+		input:  "select -(-1), (@a := 1), (select 1), x in ((1, 2)), (a) collate utf8mb4_bin from t",
+		output: "select -(-1), (@a := 1), (select 1), x in ((1, 2)), a collate utf8mb4_bin from t",
+	}, {
+		input:  "insert into t (C, a, `b`) values (1, 2, 3), (4, 5, 6)",
+		output: "insert into t(a, b, C) values (2, 3, 1), (5, 6, 4)",
+	}, {
+		input:  "insert into t (c, a) values (1, 2) as new (x, y) on duplicate key update c = y",
+		output: "insert into t(a, c) values (2, 1) as new(y, x) on duplicate key update c = y",
+	}, {
+		input:  "insert into t (c, a) select c, a from u",
+		output: "insert into t(c, a) select c, a from u",
+	}, {
+		input:  "select * from t ASOF NOW() UNTIL NOW()",
+		output: "select * from t asof now() until now()",
+	}}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.input)
+		if !assert.NoError(t, err, tcase.input) {
+			continue
+		}
+		before := String(tree)
+		out := CanonicalString(tree)
+		assert.Equal(t, tcase.output, out)
+		assert.Equal(t, before, String(tree), "changed %s", tcase.input)
+		reparsed, err := Parse(out)
+		if assert.NoError(t, err, out) {
+			assert.Equal(t, out, CanonicalString(reparsed))
+		}
+	}
+
+	tree, err := Parse("select 1 from t where not a = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if not, ok := tree.(*Select).Where.Expr.(*NotExpr); assert.True(t, ok) {
+		assert.IsType(t, &ComparisonExpr{}, not.Expr)
+	}
+	assert.Equal(t, "", CanonicalString(nil))
+}
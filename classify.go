@@ -0,0 +1,175 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// classify.go classifies statements, such as to route reads and
+// writes to different servers.
+
+// StatementType is the kind of a statement, as returned by
+// StmtType.
+type StatementType int
+
+const (
+	// StmtOther is any statement not of the types below, such
+	// as CALL, PREPARE and the statements of stored routines.
+	StmtOther StatementType = iota
+	// StmtSelect is a query: SELECT, UNION or VALUES.
+	StmtSelect
+	// StmtDML is an INSERT, UPDATE, DELETE or LOAD DATA.
+	StmtDML
+	// StmtDDL is a statement that creates, alters or drops
+	// tables, views, indexes, sequences or routines.
+	StmtDDL
+	// StmtDCL is a GRANT, REVOKE or a statement managing users.
+	StmtDCL
+	// StmtSet is a SET of variables.
+	StmtSet
+	// StmtShow is a SHOW, DESCRIBE or EXPLAIN.
+	StmtShow
+	// StmtTCL is a transaction control statement: BEGIN,
+	// COMMIT, ROLLBACK, SAVEPOINT or SET TRANSACTION.
+	StmtTCL
+)
+
+var statementTypeNames = [...]string{
+	StmtOther:  "OTHER",
+	StmtSelect: "SELECT",
+	StmtDML:    "DML",
+	StmtDDL:    "DDL",
+	StmtDCL:    "DCL",
+	StmtSet:    "SET",
+	StmtShow:   "SHOW",
+	StmtTCL:    "TCL",
+}
+
+func (typ StatementType) String() string {
+	if typ < 0 || int(typ) >= len(statementTypeNames) {
+		return "OTHER"
+	}
+	return statementTypeNames[typ]
+}
+
+// StmtType returns the type of stmt.
+func StmtType(stmt Statement) StatementType {
+	switch stmt.(type) {
+	case SelectStatement:
+		return StmtSelect
+	case *Insert, *Update, *Delete, *LoadData:
+		return StmtDML
+	case *DDL, *CreateTable, *AlterTable, *Sequence, *CreateRoutine:
+		return StmtDDL
+	case *Grant, *Revoke, *CreateUser, *AlterUser, *SetPassword:
+		return StmtDCL
+	case *Set:
+		return StmtSet
+	case *Show, *Describe, *Explain:
+		return StmtShow
+	case *Begin, *Commit, *Rollback, *Savepoint, *ReleaseSavepoint, *SetTransaction:
+		return StmtTCL
+	}
+	return StmtOther
+}
+
+// IsDML reports whether stmt changes the rows of tables: whether
+// it is an INSERT, UPDATE, DELETE or LOAD DATA.
+func IsDML(stmt Statement) bool {
+	return StmtType(stmt) == StmtDML
+}
+
+// HasSubquery reports whether node contains a subquery, including
+// derived tables of FROM clauses.
+func HasSubquery(node SQLNode) bool {
+	found := false
+	Walk(func(node SQLNode) (bool, error) {
+		if _, ok := node.(*Subquery); ok {
+			found = true
+		}
+		return !found, nil
+	}, node)
+	return found
+}
+
+// IsAggregateQuery reports whether stmt is a query whose rows
+// are groups: a SELECT with a GROUP BY clause, or calling an
+// aggregate function such as COUNT in its select expressions or
+// HAVING clause, outside subqueries. A UNION is one if any of
+// its queries is.
+func IsAggregateQuery(stmt Statement) bool {
+	switch stmt := stmt.(type) {
+	case *Select:
+		if len(stmt.GroupBy) != 0 {
+			return true
+		}
+		return hasAggregate(stmt.SelectExprs) || hasAggregate(stmt.Having)
+	case *Union:
+		return IsAggregateQuery(stmt.Left) || IsAggregateQuery(stmt.Right)
+	case *ParenSelect:
+		return IsAggregateQuery(stmt.Select)
+	}
+	return false
+}
+
+func hasAggregate(node SQLNode) bool {
+	found := false
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Subquery:
+			return false, nil
+		case *FuncExpr:
+			if node.IsAggregate() {
+				found = true
+			}
+		}
+		return !found, nil
+	}, node)
+	return found
+}
+
+// MustUseMaster reports whether stmt must be run on the master
+// rather than on a replica: whether it is anything other than a
+// query or a SHOW, DESCRIBE or EXPLAIN statement, or a query that
+// locks the rows it reads with FOR UPDATE or LOCK IN SHARE MODE,
+// or that advances a sequence.
+func MustUseMaster(stmt Statement) bool {
+	switch StmtType(stmt) {
+	case StmtSelect:
+		return locksRows(stmt) || advancesSequence(stmt)
+	case StmtShow:
+		return false
+	}
+	return true
+}
+
+// locksRows reports whether any query of node locks its rows.
+func locksRows(node SQLNode) bool {
+	found := false
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Select:
+			found = found || node.Lock != ""
+		case *Union:
+			found = found || node.Lock != ""
+		}
+		return !found, nil
+	}, node)
+	return found
+}
+
+// advancesSequence reports whether node takes values from a
+// sequence, as SELECT NEXT n VALUES, NEXT VALUE FOR and NEXTVAL()
+// do.
+func advancesSequence(node SQLNode) bool {
+	found := false
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Nextval, *NextValExpr:
+			found = true
+		case *FuncExpr:
+			found = node.Name.Lowered() == "nextval" || node.Name.Lowered() == "setval"
+		}
+		return !found, nil
+	}, node)
+	return found
+}
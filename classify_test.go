@@ -0,0 +1,61 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tcases := []struct {
+		sql       string
+		typ       StatementType
+		subquery  bool
+		aggregate bool
+		master    bool
+	}{
+		{sql: "select a from t", typ: StmtSelect},
+		{sql: "select count(*) from t", typ: StmtSelect, aggregate: true},
+		{sql: "select a from t group by a", typ: StmtSelect, aggregate: true},
+		{sql: "select a from t having max(b) > 1", typ: StmtSelect, aggregate: true},
+		{sql: "select a, count(*) over (partition by b) from t", typ: StmtSelect},
+		{sql: "select a from t where b in (select count(*) from u)", typ: StmtSelect, subquery: true},
+		{sql: "select a from (select a from t) as x", typ: StmtSelect, subquery: true},
+		{sql: "select a from t union select count(*) from u", typ: StmtSelect, aggregate: true},
+		{sql: "select a from t for update", typ: StmtSelect, master: true},
+		{sql: "select a from t where b in (select b from u lock in share mode)", typ: StmtSelect, subquery: true, master: true},
+		{sql: "select next 5 values from s", typ: StmtSelect, master: true},
+		{sql: "select next value for s", typ: StmtSelect, master: true},
+		{sql: "select nextval(s)", typ: StmtSelect, master: true},
+		{sql: "select a from t where b = nextval(s)", typ: StmtSelect, master: true},
+		{sql: "insert into t values (1)", typ: StmtDML, master: true},
+		{sql: "update t set a = 1 where b = (select max(b) from u)", typ: StmtDML, subquery: true, master: true},
+		{sql: "delete from t", typ: StmtDML, master: true},
+		{sql: "create table t (a int)", typ: StmtDDL, master: true},
+		{sql: "alter table t add column b int", typ: StmtDDL, master: true},
+		{sql: "grant select on t to u", typ: StmtDCL, master: true},
+		{sql: "set autocommit = 1", typ: StmtSet, master: true},
+		{sql: "show tables", typ: StmtShow},
+		{sql: "explain select a from t", typ: StmtShow},
+		{sql: "begin", typ: StmtTCL, master: true},
+		{sql: "commit", typ: StmtTCL, master: true},
+		{sql: "call p()", typ: StmtOther, master: true},
+	}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Errorf("%s: %v", tcase.sql, err)
+			continue
+		}
+		assert.Equal(t, tcase.typ, StmtType(tree), tcase.sql)
+		assert.Equal(t, tcase.typ == StmtDML, IsDML(tree), tcase.sql)
+		assert.Equal(t, tcase.subquery, HasSubquery(tree), tcase.sql)
+		assert.Equal(t, tcase.aggregate, IsAggregateQuery(tree), tcase.sql)
+		assert.Equal(t, tcase.master, MustUseMaster(tree), tcase.sql)
+	}
+	assert.Equal(t, "DML", StmtDML.String())
+}
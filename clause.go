@@ -0,0 +1,100 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// clause.go parses custom clauses of SELECT statements, which
+// are defined by the Options of a parse rather than by the grammar.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClauseDef defines a custom clause of SELECT statements, which
+// follows the FROM clause and any TIMERANGE clause. It is made of
+// keywords, each followed by an expression, as in
+//
+//	select * from t SAMPLE 10 SEED 42 where a = 1
+//
+// for Keywords {"SAMPLE", "SEED"}. The keywords are parsed into a
+// Clause, which formats itself as the keywords spelled here, each
+// followed by its expression. To format it differently, as for
+// another database, pass a node formatter to NewTrackedBuffer.
+type ClauseDef struct {
+	// Keywords are the keywords of the clause. The first starts
+	// it, and the others may each follow it once, in order. They
+	// are matched regardless of case, and can no longer be used as
+	// unquoted identifiers.
+	Keywords []string
+	// Check, if not nil, checks each clause parsed. An error it
+	// returns is reported as a syntax error.
+	Check func(*Clause) error
+}
+
+// clauseKeyword reports whether word is a keyword of one of the
+// custom clauses.
+func (opts *Options) clauseKeyword(word string) bool {
+	for i := range opts.Clauses {
+		for _, keyword := range opts.Clauses[i].Keywords {
+			if strings.EqualFold(keyword, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// clauseDef returns the definition of the custom clause started by
+// keyword, or nil if there is none.
+func (opts *Options) clauseDef(keyword string) *ClauseDef {
+	for i := range opts.Clauses {
+		if keywords := opts.Clauses[i].Keywords; len(keywords) != 0 && strings.EqualFold(keywords[0], keyword) {
+			return &opts.Clauses[i]
+		}
+	}
+	return nil
+}
+
+// addClausePart adds keyword and the expression expr following it
+// to clauses: as a new clause if keyword starts one, or else to
+// the last clause, if keyword may follow the part it ends with.
+func (opts *Options) addClausePart(clauses Clauses, keyword string, expr ValExpr) (Clauses, error) {
+	if def := opts.clauseDef(keyword); def != nil {
+		for _, clause := range clauses {
+			if strings.EqualFold(clause.Name(), keyword) {
+				return nil, fmt.Errorf("duplicate %s clause", def.Keywords[0])
+			}
+		}
+		return append(clauses, &Clause{Parts: []*ClausePart{{Keyword: def.Keywords[0], Expr: expr}}}), nil
+	}
+	if len(clauses) != 0 {
+		last := clauses[len(clauses)-1]
+		keywords := opts.clauseDef(last.Name()).Keywords
+		i := 0
+		for !strings.EqualFold(keywords[i], last.Parts[len(last.Parts)-1].Keyword) {
+			i++
+		}
+		for i++; i < len(keywords); i++ {
+			if strings.EqualFold(keywords[i], keyword) {
+				last.Parts = append(last.Parts, &ClausePart{Keyword: keywords[i], Expr: expr})
+				return clauses, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s", keyword)
+}
+
+// checkClauses checks clauses with the Check functions of their
+// definitions.
+func (opts *Options) checkClauses(clauses Clauses) error {
+	for _, clause := range clauses {
+		if check := opts.clauseDef(clause.Name()).Check; check != nil {
+			if err := check(clause); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
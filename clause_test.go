@@ -0,0 +1,71 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomClauses(t *testing.T) {
+	opts := Options{Clauses: []ClauseDef{{
+		Keywords: []string{"SAMPLE", "SEED"},
+	}, {
+		Keywords: []string{"TTL"},
+		Check: func(clause *Clause) error {
+			if _, ok := clause.Part("ttl").(NumVal); !ok {
+				return errors.New("TTL must be a number")
+			}
+			return nil
+		},
+	}}}
+	tree, err := ParseWithOptions("select * from t asof '2020-01-01' sample 10+1 Seed :s ttl 60 where a = 1", opts)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "select * from t ASOF '2020-01-01' SAMPLE 10+1 SEED :s TTL 60 where a = 1", String(tree))
+	clauses := tree.(*Select).Clauses
+	if assert.Len(t, clauses, 2) {
+		assert.Equal(t, "SAMPLE", clauses[0].Name())
+		assert.Equal(t, ValArg(":s"), clauses[0].Part("seed"))
+		assert.Nil(t, clauses[1].Part("seed"))
+	}
+
+	for _, sql := range []string{
+		"select * from t seed 1",
+		"select * from t sample 1 seed 2 seed 3",
+		"select * from t sample 1 ttl 2 seed 3",
+		"select * from t sample 1 sample 2",
+		"select * from t ttl 'a'",
+		"select * from t where a = 1 sample 1",
+		"select sample from t",
+	} {
+		_, err := ParseWithOptions(sql, opts)
+		assert.Error(t, err, sql)
+	}
+
+	// The keywords are identifiers without the options.
+	tree, err = Parse("select sample, seed from t")
+	if assert.NoError(t, err) {
+		assert.Nil(t, tree.(*Select).Clauses)
+	}
+}
+
+func TestNoTimeRange(t *testing.T) {
+	opts := Options{NoTimeRange: true}
+	tree, err := ParseWithOptions("select asof, until from t where asof < until", opts)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "select `asof`, `until` from t where `asof` < `until`", String(tree))
+	}
+	_, err = ParseWithOptions("select * from t asof '2020-01-01'", opts)
+	assert.Error(t, err)
+
+	tree, err = Parse("select * from t asof '2020-01-01'")
+	if assert.NoError(t, err) {
+		assert.NotNil(t, tree.(*Select).TimeRange)
+	}
+}
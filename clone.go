@@ -0,0 +1,89 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// clone.go deep-copies parse trees.
+
+import (
+	"reflect"
+)
+
+// Clone returns a deep copy of node, which shares no pointers,
+// slices or maps with it, so that one can be changed, as by
+// Normalize or Rewrite, while the other is kept, as in a cache of
+// parse results. Nodes that appear more than once in node appear
+// as many times in the copy, as the same copied node.
+func Clone(node SQLNode) SQLNode {
+	if node == nil {
+		return nil
+	}
+	c := &cloner{seen: make(map[spanKey]reflect.Value)}
+	return c.clone(reflect.ValueOf(node)).Interface().(SQLNode)
+}
+
+// CloneStatement returns a deep copy of stmt, like Clone.
+func CloneStatement(stmt Statement) Statement {
+	if stmt == nil {
+		return nil
+	}
+	return Clone(stmt).(Statement)
+}
+
+type cloner struct {
+	// seen maps the pointers copied so far to their copies.
+	seen map[spanKey]reflect.Value
+}
+
+func (c *cloner) clone(val reflect.Value) reflect.Value {
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			return val
+		}
+		return c.clone(val.Elem())
+	case reflect.Ptr:
+		if val.IsNil() {
+			return val
+		}
+		key := spanKey{val.Type(), val.Pointer()}
+		if cp, ok := c.seen[key]; ok {
+			return cp
+		}
+		cp := reflect.New(val.Type().Elem())
+		c.seen[key] = cp
+		cp.Elem().Set(c.clone(val.Elem()))
+		return cp
+	case reflect.Slice:
+		if val.IsNil() {
+			return val
+		}
+		cp := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		for i := 0; i < val.Len(); i++ {
+			cp.Index(i).Set(c.clone(val.Index(i)))
+		}
+		return cp
+	case reflect.Map:
+		if val.IsNil() {
+			return val
+		}
+		cp := reflect.MakeMapWithSize(val.Type(), val.Len())
+		for _, key := range val.MapKeys() {
+			cp.SetMapIndex(key, c.clone(val.MapIndex(key)))
+		}
+		return cp
+	case reflect.Struct:
+		// Copy the struct first, for its unexported fields,
+		// which only hold strings and bools.
+		cp := reflect.New(val.Type()).Elem()
+		cp.Set(val)
+		for i := 0; i < val.NumField(); i++ {
+			if field := cp.Field(i); field.CanSet() {
+				field.Set(c.clone(val.Field(i)))
+			}
+		}
+		return cp
+	}
+	return val
+}
@@ -0,0 +1,56 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+	for _, tcase := range validSQL {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := String(tree)
+		cp := CloneStatement(tree)
+		assert.Equal(t, tree, cp, tcase.input)
+		assert.Equal(t, want, String(cp), tcase.input)
+
+		// The copy shares no pointer nodes with the original,
+		// other than empty ones, which share their address.
+		ptrs := make(map[uintptr]bool)
+		Walk(func(node SQLNode) (bool, error) {
+			if val := reflect.ValueOf(node); val.Kind() == reflect.Ptr {
+				ptrs[val.Pointer()] = true
+			}
+			return true, nil
+		}, tree)
+		Walk(func(node SQLNode) (bool, error) {
+			if val := reflect.ValueOf(node); val.Kind() == reflect.Ptr && val.Type().Elem().Size() != 0 && ptrs[val.Pointer()] {
+				t.Errorf("%s: %T shared with the copy", tcase.input, node)
+			}
+			return true, nil
+		}, cp)
+	}
+}
+
+func TestCloneMutate(t *testing.T) {
+	tree, err := Parse("select a, b from t where c = 'x' and d in (1, 2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := CloneStatement(tree)
+	Normalize(cp, make(map[string]interface{}), "v")
+	cp.(*Select).SelectExprs[0].(*NonStarExpr).Expr.(*ColName).Name = NewColIdent("z")
+	assert.Equal(t, "select a, b from t where c = 'x' and d in (1, 2)", String(tree))
+	assert.Equal(t, "select z, b from t where c = :v1 and d in (:v2, :v3)", String(cp))
+
+	assert.Nil(t, Clone(nil))
+	assert.Nil(t, CloneStatement(nil))
+}
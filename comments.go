@@ -0,0 +1,226 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// comments.go collects the comments of statements, and parses
+// hint comments.
+
+import (
+	"strings"
+)
+
+// Comment is a comment of the parsed sql. Text is the comment as
+// written, sql[Span.Start:Span.End], including its delimiters and
+// the newline ending a -- or // comment.
+type Comment struct {
+	Text string
+	Span Span
+}
+
+// ParseWithComments parses sql like ParseWithOptions, and also
+// returns all its comments, in order. The parse tree only keeps
+// the comments that follow the first keyword of a SELECT, INSERT,
+// UPDATE, DELETE or SET statement; the others, such as those
+// between expressions, are only returned here.
+func ParseWithComments(sql string, opts Options) (Statement, []*Comment, error) {
+	tokenizer := NewStringTokenizer(sql)
+	tokenizer.opts = opts
+	tokenizer.recordComments = true
+	if yyParse(tokenizer) != 0 {
+		return nil, nil, tokenizer.parseError()
+	}
+	return tokenizer.ParseTree, tokenizer.comments, nil
+}
+
+// CommentDirectives maps the keys of the key=value directives
+// of comments, such as shard=us-east in /* shard=us-east */, to
+// their values.
+type CommentDirectives map[string]string
+
+// Directives returns the key=value directives of the comments.
+// Directives are separated by whitespace, and their values may
+// be quoted with ' or ". Words that are not directives are
+// ignored, and a key given more than once takes its last value.
+func (node Comments) Directives() CommentDirectives {
+	directives := make(CommentDirectives)
+	for _, c := range node {
+		body := commentBody(c)
+		for len(body) != 0 {
+			var word string
+			word, body = nextDirectiveWord(strings.TrimLeft(body, " \t\r\n"))
+			i := strings.IndexByte(word, '=')
+			if i <= 0 {
+				continue
+			}
+			value := word[i+1:]
+			if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+			directives[word[:i]] = value
+		}
+	}
+	return directives
+}
+
+// nextDirectiveWord splits s, which starts with a word, into the
+// word and the rest of s. A word ends at whitespace outside quotes.
+func nextDirectiveWord(s string) (string, string) {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n':
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// commentBody returns the text of comment without its delimiters.
+func commentBody(comment string) string {
+	switch {
+	case strings.HasPrefix(comment, "/*"):
+		return strings.TrimSuffix(comment[2:], "*/")
+	case strings.HasPrefix(comment, "--"), strings.HasPrefix(comment, "//"):
+		return comment[2:]
+	}
+	return comment
+}
+
+// LeadingComments splits sql into the comments before its first
+// token and the rest of it, which starts with that token. The sql
+// is only tokenized up to the first token, not parsed.
+func LeadingComments(sql string) (Comments, string) {
+	tkn := NewStringTokenizer(sql)
+	var comments Comments
+	for {
+		typ, val := tkn.scan()
+		if typ != COMMENT {
+			return comments, sql[tkn.start:]
+		}
+		comments = append(comments, strings.TrimRight(val, "\n"))
+	}
+}
+
+// ExtractCommentDirectives returns the directives of the leading
+// comments of sql, such as shard and priority in
+//
+//	/* shard=us-east priority=low */ select ...
+//
+// which lets proxies route queries without parsing them.
+func ExtractCommentDirectives(sql string) CommentDirectives {
+	comments, _ := LeadingComments(sql)
+	return comments.Directives()
+}
+
+// splitHints separates the hint comments of comments, parsed
+// into Hints, from the others.
+func splitHints(comments []string) (Comments, Hints) {
+	var others Comments
+	var hints Hints
+	for _, c := range comments {
+		if hint := parseHint(c); hint != nil {
+			hints = append(hints, hint)
+		} else {
+			others = append(others, c)
+		}
+	}
+	return others, hints
+}
+
+// parseHint parses comment if it is an optimizer hint comment or
+// an executable comment, and returns nil otherwise. Optimizer hint
+// comments that cannot be parsed are left as plain comments.
+func parseHint(comment string) *Hint {
+	if len(comment) < 5 || !strings.HasPrefix(comment, "/*") || !strings.HasSuffix(comment, "*/") {
+		return nil
+	}
+	body := comment[3 : len(comment)-2]
+	switch comment[2] {
+	case '!':
+		i := 0
+		for i < len(body) && body[i] >= '0' && body[i] <= '9' {
+			i++
+		}
+		return &Hint{Executable: true, Version: body[:i], Text: strings.TrimSpace(body[i:])}
+	case '+':
+		items, ok := parseHintItems(body)
+		if !ok {
+			return nil
+		}
+		return &Hint{Items: items}
+	}
+	return nil
+}
+
+// parseHintItems parses the hints of an optimizer hint comment,
+// which are names optionally followed by arguments in parentheses.
+func parseHintItems(body string) ([]*HintItem, bool) {
+	var items []*HintItem
+	for i := 0; ; {
+		for i < len(body) && isHintSpace(body[i]) {
+			i++
+		}
+		if i == len(body) {
+			return items, len(items) != 0
+		}
+		start := i
+		for i < len(body) && isHintNameChar(body[i]) {
+			i++
+		}
+		if i == start {
+			return nil, false
+		}
+		item := &HintItem{Name: body[start:i]}
+		for i < len(body) && isHintSpace(body[i]) {
+			i++
+		}
+		if i < len(body) && body[i] == '(' {
+			end := hintArgsEnd(body, i)
+			if end < 0 {
+				return nil, false
+			}
+			item.Args = strings.TrimSpace(body[i+1 : end])
+			i = end + 1
+		}
+		items = append(items, item)
+	}
+}
+
+// hintArgsEnd returns the index of the parenthesis closing the one
+// at body[open], skipping quoted strings, or -1 if there is none.
+func hintArgsEnd(body string, open int) int {
+	depth := 0
+	for i := open; i < len(body); i++ {
+		switch ch := body[i]; ch {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return i
+			}
+		case '\'', '"', '`':
+			end := strings.IndexByte(body[i+1:], ch)
+			if end < 0 {
+				return -1
+			}
+			i += end + 1
+		}
+	}
+	return -1
+}
+
+func isHintNameChar(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || '0' <= ch && ch <= '9' || ch == '_'
+}
+
+func isHintSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
@@ -0,0 +1,84 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHints(t *testing.T) {
+	sql := "select /* q1 */ /*+ BKA(t1, t2) NO_ICP(t1 idx) QB_NAME(qb)  MAX_EXECUTION_TIME( 1000 ) */ /*!40001 SQL_NO_CACHE */ a from t1, t2"
+	tree, err := Parse(sql)
+	assert.Nil(t, err)
+	sel := tree.(*Select)
+	assert.Equal(t, Comments{"/* q1 */"}, sel.Comments)
+	assert.Equal(t, Hints{
+		{Items: []*HintItem{
+			{Name: "BKA", Args: "t1, t2"},
+			{Name: "NO_ICP", Args: "t1 idx"},
+			{Name: "QB_NAME", Args: "qb"},
+			{Name: "MAX_EXECUTION_TIME", Args: "1000"},
+		}},
+		{Executable: true, Version: "40001", Text: "SQL_NO_CACHE"},
+	}, sel.Hints)
+	want := "select /* q1 */ /*+ BKA(t1, t2) NO_ICP(t1 idx) QB_NAME(qb) MAX_EXECUTION_TIME(1000) */ /*!40001 SQL_NO_CACHE */ a from t1, t2"
+	assert.Equal(t, want, String(tree))
+	tree, err = Parse(want)
+	assert.Nil(t, err)
+	assert.Equal(t, want, String(tree))
+
+	for _, sql := range []string{
+		"insert /*+ SET_VAR(foreign_key_checks = OFF) */ into t(a) values (1)",
+		"update /*! LOW_PRIORITY */ t set a = 1",
+		"delete /*+ NO_MERGE(t) */ from t where a = 1",
+	} {
+		tree, err := Parse(sql)
+		assert.Nil(t, err, sql)
+		assert.Equal(t, sql, String(tree))
+	}
+
+	// Malformed optimizer hints are plain comments.
+	tree, err = Parse("select /*+ 'x' */ a from t")
+	assert.Nil(t, err)
+	assert.Equal(t, Comments{"/*+ 'x' */"}, tree.(*Select).Comments)
+	assert.Nil(t, tree.(*Select).Hints)
+}
+
+func TestParseWithComments(t *testing.T) {
+	sql := "select /* a */ a, -- b\n b /* c */ from t"
+	tree, comments, err := ParseWithComments(sql, Options{})
+	assert.Nil(t, err)
+	assert.Equal(t, "select /* a */ a, b from t", String(tree))
+	assert.Equal(t, []*Comment{
+		{Text: "/* a */", Span: Span{7, 14}},
+		{Text: "-- b\n", Span: Span{18, 23}},
+		{Text: "/* c */", Span: Span{26, 33}},
+	}, comments)
+	for _, c := range comments {
+		assert.Equal(t, c.Text, sql[c.Span.Start:c.Span.End])
+	}
+
+	_, _, err = ParseWithComments("select /* a */ from", Options{})
+	assert.NotNil(t, err)
+}
+
+func TestCommentDirectives(t *testing.T) {
+	sql := "/* shard=us-east priority=low */ -- user='bob smith' trace=\"x\"\n select /* a=1 b */ a from t"
+	comments, rest := LeadingComments(sql)
+	assert.Equal(t, Comments{"/* shard=us-east priority=low */", "-- user='bob smith' trace=\"x\""}, comments)
+	assert.Equal(t, "select /* a=1 b */ a from t", rest)
+	assert.Equal(t, CommentDirectives{"shard": "us-east", "priority": "low", "user": "bob smith", "trace": "x"}, ExtractCommentDirectives(sql))
+
+	tree, err := Parse(rest)
+	assert.Nil(t, err)
+	assert.Equal(t, CommentDirectives{"a": "1"}, tree.(*Select).Comments.Directives())
+
+	comments, rest = LeadingComments("select 1")
+	assert.Nil(t, comments)
+	assert.Equal(t, "select 1", rest)
+	assert.Equal(t, CommentDirectives{}, ExtractCommentDirectives("select 1"))
+}
@@ -0,0 +1,200 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// complete.go reports what may follow a prefix of a statement, for
+// autocompletion.
+
+import (
+	"sort"
+)
+
+// Keywords returns the keywords reserved by the grammar, in lower
+// case and sorted. They must be quoted to be used as identifiers.
+func Keywords() []string {
+	words := make([]string, 0, len(keywords))
+	for word := range keywords {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// phraseTokens are the tokens the tokenizer makes of words that
+// are not in keywords: multi-word phrases, and words that are only
+// keywords where the grammar needs them to be.
+var phraseTokens = map[int]string{
+	NEXT_VALUE_FOR:    "next value for",
+	NEXT:              "next",
+	SOUNDS_LIKE:       "sounds like",
+	GROUPING_SETS:     "grouping sets",
+	FOR_SYSTEM_TIME:   "for system_time",
+	CREATE_USER:       "create user",
+	ALTER_USER:        "alter user",
+	SET_PASSWORD:      "set password",
+	WITH_CHECK_OPTION: AST_CHECK_OPTION,
+	CAST:              "cast",
+	JSON_TABLE:        "json_table",
+	ANY:               "any",
+	GLOBAL:            AST_GLOBAL,
+	SESSION:           AST_SESSION,
+	LOCAL:             AST_LOCAL,
+	QUALIFY:           "qualify",
+	PIVOT:             "pivot",
+	UNPIVOT:           "unpivot",
+	BEGIN:             "begin",
+	MODIFY:            "modify",
+	CHARSET:           "charset",
+	ESCAPE:            "escape",
+}
+
+// dialectTokens are the tokens the tokenizer only makes for some
+// dialects, or for custom clauses.
+var dialectTokens = map[int]bool{
+	ILIKE:          true,
+	RETURNING:      true,
+	ARRAY:          true,
+	STRUCT:         true,
+	TYPECAST:       true,
+	CLAUSE_KEYWORD: true,
+}
+
+// tokenTexts maps the names of the parser's tokens to the texts
+// that make them: keywords, phrases and operators.
+var tokenTexts = func() map[string][]string {
+	texts := make(map[string][]string)
+	add := func(tok int, text string) {
+		if tok >= yyPrivate && tok-yyPrivate < len(yyTok2) {
+			name := tokenName(int(yyTok2[tok-yyPrivate]))
+			texts[name] = append(texts[name], text)
+		}
+	}
+	for _, word := range Keywords() {
+		add(keywords[word], word)
+	}
+	for tok, text := range phraseTokens {
+		add(tok, text)
+	}
+	for tok, text := range operatorTokens {
+		add(tok, text)
+	}
+	for tok := range dialectTokens {
+		add(tok, "")
+	}
+	return texts
+}()
+
+// SuggestNextTokens returns what may follow prefixSQL, the start of
+// a statement up to the cursor of an editor, as the next token:
+// keywords and phrases in lower case, such as "from" and "group",
+// punctuation and operators, such as "(" and "<=", and the names of
+// the other kinds of tokens, such as ID for identifiers, STRING and
+// NUMBER. A word being typed at the end of prefixSQL is taken to be
+// complete, so it should be left out to complete it. The result is
+// nil if prefixSQL has a syntax error.
+//
+// The parser's tables are run on the tokens of prefixSQL, without
+// building a parse tree, so the checks made while building it, such
+// as those of hints and options, do not apply.
+func SuggestNextTokens(prefixSQL string) []string {
+	tokenizer := NewStringTokenizer(prefixSQL)
+	stack := []int{0}
+	for {
+		var lval yySymType
+		char := tokenizer.Lex(&lval)
+		if char == 0 {
+			break
+		}
+		var ok bool
+		if stack, ok = lrShift(stack, internalToken(char)); !ok {
+			return nil
+		}
+	}
+	var suggestions []string
+	for tok := 4; tok-1 < len(yyToknames); tok++ {
+		if _, ok := lrShift(append([]int(nil), stack...), tok); !ok {
+			continue
+		}
+		name := tokenName(tok)
+		if len(name) == 3 && name[0] == '\'' {
+			suggestions = append(suggestions, name[1:2])
+			continue
+		}
+		texts, ok := tokenTexts[name]
+		if !ok {
+			suggestions = append(suggestions, name)
+			continue
+		}
+		for _, text := range texts {
+			if text != "" {
+				suggestions = append(suggestions, text)
+			}
+		}
+	}
+	return suggestions
+}
+
+// internalToken returns the number of the parser's tables for
+// char, a token returned by Lex, as yylex1 does.
+func internalToken(char int) int {
+	switch {
+	case char <= 0:
+		return int(yyTok1[0])
+	case char < len(yyTok1):
+		return int(yyTok1[char])
+	case char >= yyPrivate && char < yyPrivate+len(yyTok2):
+		return int(yyTok2[char-yyPrivate])
+	}
+	for i := 0; i < len(yyTok3); i += 2 {
+		if int(yyTok3[i]) == char {
+			return int(yyTok3[i+1])
+		}
+	}
+	return int(yyTok2[1])
+}
+
+// lrShift runs the parser's tables on stack, a stack of states,
+// until tok is shifted, as yyParse does, and returns the resulting
+// stack. It reports false if tok is a syntax error there. The
+// stack may be modified.
+func lrShift(stack []int, tok int) ([]int, bool) {
+	for {
+		state := stack[len(stack)-1]
+		n := int(yyPact[state])
+		if n > yyFlag {
+			if i := n + tok; i >= 0 && i < yyLast {
+				if next := int(yyAct[i]); int(yyChk[next]) == tok {
+					return append(stack, next), true
+				}
+			}
+		}
+		n = int(yyDef[state])
+		if n == -2 {
+			i := 0
+			for yyExca[i] != -1 || int(yyExca[i+1]) != state {
+				i += 2
+			}
+			for i += 2; yyExca[i] >= 0 && int(yyExca[i]) != tok; i += 2 {
+			}
+			if n = int(yyExca[i+1]); n < 0 {
+				// Accepted, which only the end of input is.
+				return stack, false
+			}
+		}
+		if n == 0 {
+			return stack, false
+		}
+		stack = stack[:len(stack)-int(yyR2[n])]
+		lhs := int(yyR1[n])
+		g := int(yyPgo[lhs])
+		next := int(yyAct[g])
+		if i := g + stack[len(stack)-1] + 1; i < yyLast {
+			if s := int(yyAct[i]); int(yyChk[s]) == -lhs {
+				next = s
+			}
+		}
+		stack = append(stack, next)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeywords(t *testing.T) {
+	words := Keywords()
+	assert.True(t, sort.StringsAreSorted(words))
+	assert.Contains(t, words, "select")
+	assert.Contains(t, words, "where")
+	assert.NotContains(t, words, "next value for")
+}
+
+func TestSuggestNextTokens(t *testing.T) {
+	tcases := []struct {
+		prefix   string
+		contains []string
+		excludes []string
+	}{{
+		prefix:   "",
+		contains: []string{"select", "insert", "create user", "("},
+		excludes: []string{"from", "where"},
+	}, {
+		prefix:   "select a ",
+		contains: []string{"from", "where", "as", "ID", ",", "+", "<=", "union", ";"},
+		excludes: []string{"select", "on"},
+	}, {
+		prefix:   "select a from t join u ",
+		contains: []string{"on", "using", "where", "join", "ID"},
+	}, {
+		prefix:   "select a from t where ",
+		contains: []string{"not", "exists", "ID", "STRING", "NUMBER", "next value for", "case", "("},
+		excludes: []string{"from", "group"},
+	}, {
+		prefix:   "select a from t where b ",
+		contains: []string{"in", "like", "sounds like", "between", "=", "<=>"},
+		excludes: []string{"ilike", "::"},
+	}, {
+		prefix:   "select * from t order ",
+		contains: []string{"by"},
+	}, {
+		prefix:   "insert /* comment */ into t ",
+		contains: []string{"select", "values", "set", "("},
+	}}
+	for _, tcase := range tcases {
+		got := SuggestNextTokens(tcase.prefix)
+		for _, want := range tcase.contains {
+			assert.Contains(t, got, want, tcase.prefix)
+		}
+		for _, unwanted := range tcase.excludes {
+			assert.NotContains(t, got, unwanted, tcase.prefix)
+		}
+	}
+	assert.Equal(t, []string{"by"}, SuggestNextTokens("select * from t order "))
+	assert.Nil(t, SuggestNextTokens("select a, from"))
+	assert.Nil(t, SuggestNextTokens("select 'a"))
+}
@@ -0,0 +1,487 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// docfilter.go translates boolean expressions into the filter
+// languages of document stores.
+
+import (
+	"fmt"
+)
+
+// MongoFilter translates expr into a MongoDB query filter document,
+// such as {"a": {"$gt": 1}}. Bind variables are bound to bindVars.
+//
+// The supported subset is AND, OR, NOT, comparisons, IN, LIKE,
+// BETWEEN and IS [NOT] NULL, where one side of each comparison is
+// a column and the other a constant. A column's name, including
+// any qualifier, is used as the field path, so a.b refers to the
+// field b embedded in a. Other expressions return an error.
+//
+// As in SQL, a document where a field is missing or null matches
+// neither a comparison of the field nor its negation, so negated
+// comparisons are guarded to require the field. A comparison with
+// NULL, which is never true, returns an error.
+func MongoFilter(expr BoolExpr, bindVars map[string]interface{}) (map[string]interface{}, error) {
+	f := &docFilter{predicateCompiler{bindVars: bindVars}}
+	return f.mongo(expr)
+}
+
+// ElasticsearchQuery translates expr into an Elasticsearch query
+// DSL clause, such as {"range": {"a": {"gt": 1}}}, which can be
+// encoded as JSON and used as the query of a search request. It
+// supports the same subset of expressions as MongoFilter, and
+// treats missing and null fields the same way. Column names map
+// to field names, and LIKE maps to a wildcard query.
+func ElasticsearchQuery(expr BoolExpr, bindVars map[string]interface{}) (map[string]interface{}, error) {
+	f := &docFilter{predicateCompiler{bindVars: bindVars}}
+	return f.elastic(expr)
+}
+
+type docFilter struct {
+	predicateCompiler
+}
+
+// mongoOperators maps comparison operators to MongoDB operators.
+var mongoOperators = map[string]string{
+	AST_EQ:       "$eq",
+	AST_NE:       "$ne",
+	AST_LT:       "$lt",
+	AST_LE:       "$lte",
+	AST_GT:       "$gt",
+	AST_GE:       "$gte",
+	AST_IN:       "$in",
+	AST_NOT_IN:   "$nin",
+	AST_LIKE:     "$regex",
+	AST_NOT_LIKE: "$regex",
+}
+
+func (f *docFilter) mongo(expr BoolExpr) (map[string]interface{}, error) {
+	switch expr := expr.(type) {
+	case *AndExpr:
+		return f.mongoList("$and", expr)
+	case *OrExpr:
+		return f.mongoList("$or", expr)
+	case *NotExpr:
+		neg, err := negate(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return f.mongo(neg)
+	case *ParenBoolExpr:
+		return f.mongo(expr.Expr)
+	case *ComparisonExpr:
+		field, op, val, err := f.comparison(expr)
+		if err != nil {
+			return nil, err
+		}
+		var cond map[string]interface{}
+		switch op {
+		case AST_LIKE:
+			cond = map[string]interface{}{"$regex": likeToRegexp(valueToString(val), '\\').String()}
+		case AST_NOT_LIKE:
+			regex := map[string]interface{}{"$regex": likeToRegexp(valueToString(val), '\\').String()}
+			cond = map[string]interface{}{"$not": regex, "$ne": nil}
+		case AST_NE:
+			cond = map[string]interface{}{"$nin": []interface{}{val, nil}}
+		case AST_NOT_IN:
+			cond = map[string]interface{}{"$nin": append(val.([]interface{}), nil)}
+		default:
+			cond = map[string]interface{}{mongoOperators[op]: val}
+		}
+		return map[string]interface{}{field: cond}, nil
+	case *RangeCond:
+		field, from, to, err := f.rangeCond(expr)
+		if err != nil {
+			return nil, err
+		}
+		cond := map[string]interface{}{"$gte": from, "$lte": to}
+		if expr.Operator == AST_NOT_BETWEEN {
+			cond = map[string]interface{}{"$not": cond, "$ne": nil}
+		}
+		return map[string]interface{}{field: cond}, nil
+	case *NullCheck:
+		field, err := f.field(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		if expr.Operator == AST_IS_NOT_NULL {
+			return map[string]interface{}{field: map[string]interface{}{"$ne": nil}}, nil
+		}
+		return map[string]interface{}{field: nil}, nil
+	}
+	return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+}
+
+// mongoList translates a chain of ANDs or ORs into a single
+// $and or $or array.
+func (f *docFilter) mongoList(op string, expr BoolExpr) (map[string]interface{}, error) {
+	var list []interface{}
+	for _, e := range flattenBoolExpr(expr) {
+		m, err := f.mongo(e)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+	return map[string]interface{}{op: list}, nil
+}
+
+// elasticRanges maps comparison operators to range query parameters.
+var elasticRanges = map[string]string{
+	AST_LT: "lt",
+	AST_LE: "lte",
+	AST_GT: "gt",
+	AST_GE: "gte",
+}
+
+func (f *docFilter) elastic(expr BoolExpr) (map[string]interface{}, error) {
+	switch expr := expr.(type) {
+	case *AndExpr:
+		return f.elasticList("filter", expr)
+	case *OrExpr:
+		return f.elasticList("should", expr)
+	case *NotExpr:
+		neg, err := negate(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return f.elastic(neg)
+	case *ParenBoolExpr:
+		return f.elastic(expr.Expr)
+	case *ComparisonExpr:
+		field, op, val, err := f.comparison(expr)
+		if err != nil {
+			return nil, err
+		}
+		var query map[string]interface{}
+		switch op {
+		case AST_EQ, AST_NE:
+			query = map[string]interface{}{"term": map[string]interface{}{field: val}}
+		case AST_IN, AST_NOT_IN:
+			query = map[string]interface{}{"terms": map[string]interface{}{field: val}}
+		case AST_LIKE, AST_NOT_LIKE:
+			query = map[string]interface{}{"wildcard": map[string]interface{}{
+				field: map[string]interface{}{"value": likeToWildcard(valueToString(val))},
+			}}
+		default:
+			query = map[string]interface{}{"range": map[string]interface{}{
+				field: map[string]interface{}{elasticRanges[op]: val},
+			}}
+		}
+		switch op {
+		case AST_NE, AST_NOT_IN, AST_NOT_LIKE:
+			return elasticExcept(field, query), nil
+		}
+		return query, nil
+	case *RangeCond:
+		field, from, to, err := f.rangeCond(expr)
+		if err != nil {
+			return nil, err
+		}
+		query := map[string]interface{}{"range": map[string]interface{}{
+			field: map[string]interface{}{"gte": from, "lte": to},
+		}}
+		if expr.Operator == AST_NOT_BETWEEN {
+			return elasticExcept(field, query), nil
+		}
+		return query, nil
+	case *NullCheck:
+		field, err := f.field(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		query := map[string]interface{}{"exists": map[string]interface{}{"field": field}}
+		if expr.Operator == AST_IS_NULL {
+			return elasticNot(query), nil
+		}
+		return query, nil
+	}
+	return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+}
+
+// elasticList translates a chain of ANDs or ORs into a single
+// bool query with the given occurrence type.
+func (f *docFilter) elasticList(occur string, expr BoolExpr) (map[string]interface{}, error) {
+	var list []interface{}
+	for _, e := range flattenBoolExpr(expr) {
+		q, err := f.elastic(e)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, q)
+	}
+	query := map[string]interface{}{occur: list}
+	if occur == "should" {
+		query["minimum_should_match"] = 1
+	}
+	return map[string]interface{}{"bool": query}, nil
+}
+
+func elasticNot(query map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"bool": map[string]interface{}{
+		"must_not": []interface{}{query},
+	}}
+}
+
+// elasticExcept returns a query matching the documents that have
+// field but do not match query.
+func elasticExcept(field string, query map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"bool": map[string]interface{}{
+		"filter":   []interface{}{map[string]interface{}{"exists": map[string]interface{}{"field": field}}},
+		"must_not": []interface{}{query},
+	}}
+}
+
+// invertedOperators maps comparison operators to the operator
+// that is true where the original is false.
+var invertedOperators = map[string]string{
+	AST_EQ:       AST_NE,
+	AST_NE:       AST_EQ,
+	AST_LT:       AST_GE,
+	AST_LE:       AST_GT,
+	AST_GT:       AST_LE,
+	AST_GE:       AST_LT,
+	AST_IN:       AST_NOT_IN,
+	AST_NOT_IN:   AST_IN,
+	AST_LIKE:     AST_NOT_LIKE,
+	AST_NOT_LIKE: AST_LIKE,
+}
+
+// negate returns an expression that is true where expr is false,
+// moving the NOT down to the comparisons. Unlike NOT expr, it is
+// not true where expr is unknown because a column is null.
+func negate(expr BoolExpr) (BoolExpr, error) {
+	switch expr := expr.(type) {
+	case *AndExpr, *OrExpr:
+		var list []BoolExpr
+		for _, e := range flattenBoolExpr(expr) {
+			neg, err := negate(e)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, neg)
+		}
+		neg := list[0]
+		for _, e := range list[1:] {
+			if _, ok := expr.(*AndExpr); ok {
+				neg = &OrExpr{Left: neg, Right: e}
+			} else {
+				neg = &AndExpr{Left: neg, Right: e}
+			}
+		}
+		return neg, nil
+	case *NotExpr:
+		return expr.Expr, nil
+	case *ParenBoolExpr:
+		return negate(expr.Expr)
+	case *ComparisonExpr:
+		if op, ok := invertedOperators[expr.Operator]; ok {
+			neg := *expr
+			neg.Operator = op
+			return &neg, nil
+		}
+	case *RangeCond:
+		neg := *expr
+		neg.Operator = AST_NOT_BETWEEN
+		if expr.Operator == AST_NOT_BETWEEN {
+			neg.Operator = AST_BETWEEN
+		}
+		return &neg, nil
+	case *NullCheck:
+		neg := *expr
+		neg.Operator = AST_IS_NOT_NULL
+		if expr.Operator == AST_IS_NOT_NULL {
+			neg.Operator = AST_IS_NULL
+		}
+		return &neg, nil
+	}
+	return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+}
+
+// likeToWildcard converts a LIKE pattern into the pattern
+// of an Elasticsearch wildcard query.
+func likeToWildcard(pattern string) string {
+	var buf []byte
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '%':
+			buf = append(buf, '*')
+		case '_':
+			buf = append(buf, '?')
+		case '\\', '*', '?':
+			if ch == '\\' && i+1 < len(pattern) {
+				i++
+				ch = pattern[i]
+			}
+			if ch == '*' || ch == '?' || ch == '\\' {
+				buf = append(buf, '\\')
+			}
+			buf = append(buf, ch)
+		default:
+			buf = append(buf, ch)
+		}
+	}
+	return string(buf)
+}
+
+// flattenBoolExpr returns the operands of a chain of the same
+// AND or OR operator, looking through parentheses.
+func flattenBoolExpr(expr BoolExpr) []BoolExpr {
+	var list []BoolExpr
+	var walk func(e BoolExpr)
+	walk = func(e BoolExpr) {
+		for {
+			paren, ok := e.(*ParenBoolExpr)
+			if !ok {
+				break
+			}
+			e = paren.Expr
+		}
+		switch e := e.(type) {
+		case *AndExpr:
+			if _, ok := expr.(*AndExpr); ok {
+				walk(e.Left)
+				walk(e.Right)
+				return
+			}
+		case *OrExpr:
+			if _, ok := expr.(*OrExpr); ok {
+				walk(e.Left)
+				walk(e.Right)
+				return
+			}
+		}
+		list = append(list, e)
+	}
+	walk(expr)
+	return list
+}
+
+// reversedOperators maps comparison operators to the operator
+// that gives the same result with the operands swapped.
+var reversedOperators = map[string]string{
+	AST_EQ: AST_EQ,
+	AST_NE: AST_NE,
+	AST_LT: AST_GT,
+	AST_LE: AST_GE,
+	AST_GT: AST_LT,
+	AST_GE: AST_LE,
+}
+
+// comparison returns the field, operator and constant of a
+// comparison between a column and a constant. The operator is
+// reversed if the constant comes first. The constant of an IN
+// comparison is a []interface{}.
+func (f *docFilter) comparison(expr *ComparisonExpr) (field, op string, val interface{}, err error) {
+	op = expr.Operator
+	// Both filter languages support the operators of mongoOperators.
+	if _, ok := mongoOperators[op]; !ok || expr.Escape != nil {
+		return "", "", nil, fmt.Errorf("unsupported expression: %s", String(expr))
+	}
+	left, right := expr.Left, expr.Right
+	_, leftIsCol := left.(*ColName)
+	_, rightIsCol := right.(*ColName)
+	if !leftIsCol && rightIsCol {
+		reversed, ok := reversedOperators[op]
+		if !ok {
+			return "", "", nil, fmt.Errorf("unsupported expression: %s", String(expr))
+		}
+		op, left, right = reversed, right, left
+	}
+	if field, err = f.field(left); err != nil {
+		return "", "", nil, err
+	}
+	if op != AST_IN && op != AST_NOT_IN {
+		if val, err = f.constant(right); err != nil {
+			return "", "", nil, err
+		}
+		if val == nil {
+			return "", "", nil, fmt.Errorf("unsupported comparison with null: %s", String(expr))
+		}
+		return field, op, val, nil
+	}
+	var vals []interface{}
+	switch right := right.(type) {
+	case ValTuple:
+		for _, e := range right {
+			v, err := f.constant(e)
+			if err != nil {
+				return "", "", nil, err
+			}
+			vals = append(vals, v)
+		}
+	case ListArg:
+		bound, _, err := FetchBindVar(string(right), f.bindVars)
+		if err != nil {
+			return "", "", nil, err
+		}
+		for _, v := range bound.([]interface{}) {
+			v, err := normalizeValue(v)
+			if err != nil {
+				return "", "", nil, err
+			}
+			vals = append(vals, v)
+		}
+	default:
+		return "", "", nil, fmt.Errorf("unsupported expression: %s", String(right))
+	}
+	// A null in the list never matches, and makes NOT IN unknown
+	// where it would be true.
+	list := []interface{}{}
+	for _, v := range vals {
+		if v == nil {
+			if op == AST_NOT_IN {
+				return "", "", nil, fmt.Errorf("unsupported comparison with null: %s", String(expr))
+			}
+			continue
+		}
+		list = append(list, v)
+	}
+	return field, op, list, nil
+}
+
+func (f *docFilter) rangeCond(expr *RangeCond) (field string, from, to interface{}, err error) {
+	if field, err = f.field(expr.Left); err != nil {
+		return "", nil, nil, err
+	}
+	if from, err = f.constant(expr.From); err != nil {
+		return "", nil, nil, err
+	}
+	if to, err = f.constant(expr.To); err != nil {
+		return "", nil, nil, err
+	}
+	if from == nil || to == nil {
+		return "", nil, nil, fmt.Errorf("unsupported comparison with null: %s", String(expr))
+	}
+	return field, from, to, nil
+}
+
+func (f *docFilter) field(expr ValExpr) (string, error) {
+	col, ok := expr.(*ColName)
+	if !ok {
+		return "", fmt.Errorf("expected a column, got %s", String(expr))
+	}
+	if col.Qualifier.IsEmpty() {
+		return col.Name.String(), nil
+	}
+	return col.Qualifier.String() + "." + col.Name.String(), nil
+}
+
+// constant evaluates an expression that does not refer to columns.
+func (f *docFilter) constant(expr ValExpr) (interface{}, error) {
+	if _, ok := expr.(*ColName); ok {
+		return nil, fmt.Errorf("expected a constant, got %s", String(expr))
+	}
+	fn, err := f.valExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	v, err := fn(nil)
+	if err != nil {
+		return nil, fmt.Errorf("expected a constant, got %s", String(expr))
+	}
+	return v, nil
+}
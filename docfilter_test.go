@@ -0,0 +1,114 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func whereExpr(t *testing.T, where string) BoolExpr {
+	tree, err := Parse("select * from t where " + where)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree.(*Select).Where.Expr
+}
+
+func TestMongoFilter(t *testing.T) {
+	bindVars := map[string]interface{}{"x": 3, "list": []interface{}{"a", "b"}}
+	tcases := []struct {
+		where string
+		want  string
+	}{
+		{"a = 1", `{"a":{"$eq":1}}`},
+		{"1 < a", `{"a":{"$gt":1}}`},
+		{"a.b != 'x'", `{"a.b":{"$nin":["x",null]}}`},
+		{"a >= :x and (b < -2 and c <= 1.5)", `{"$and":[{"a":{"$gte":3}},{"b":{"$lt":-2}},{"c":{"$lte":1.5}}]}`},
+		{"a = 1 or b = 2 or c = 3", `{"$or":[{"a":{"$eq":1}},{"b":{"$eq":2}},{"c":{"$eq":3}}]}`},
+		{"a = 1 and (b = 2 or c = 3)", `{"$and":[{"a":{"$eq":1}},{"$or":[{"b":{"$eq":2}},{"c":{"$eq":3}}]}]}`},
+		{"not a = 1", `{"a":{"$nin":[1,null]}}`},
+		{"not (a > 1 and b in (1, 2) or not c is null)", `{"$and":[{"$or":[{"a":{"$lte":1}},{"b":{"$nin":[1,2,null]}}]},{"c":null}]}`},
+		{"a in (1, 2)", `{"a":{"$in":[1,2]}}`},
+		{"a in (null)", `{"a":{"$in":[]}}`},
+		{"a not in ::list", `{"a":{"$nin":["a","b",null]}}`},
+		{"a like 'x%'", `{"a":{"$regex":"(?s)^x.*$"}}`},
+		{"a not like 'x_'", `{"a":{"$ne":null,"$not":{"$regex":"(?s)^x.$"}}}`},
+		{"a between 1 and 5", `{"a":{"$gte":1,"$lte":5}}`},
+		{"a not between 1 and 5", `{"a":{"$ne":null,"$not":{"$gte":1,"$lte":5}}}`},
+		{"not (a between 1 and 5)", `{"a":{"$ne":null,"$not":{"$gte":1,"$lte":5}}}`},
+		{"a is null", `{"a":null}`},
+		{"a is not null", `{"a":{"$ne":null}}`},
+	}
+	for _, tcase := range tcases {
+		filter, err := MongoFilter(whereExpr(t, tcase.where), bindVars)
+		if !assert.Nil(t, err, tcase.where) {
+			continue
+		}
+		out, _ := json.Marshal(filter)
+		assert.Equal(t, tcase.want, string(out), tcase.where)
+	}
+}
+
+func TestElasticsearchQuery(t *testing.T) {
+	tcases := []struct {
+		where string
+		want  string
+	}{
+		{"a = 1", `{"term":{"a":1}}`},
+		{"a != 'x'", `{"bool":{"filter":[{"exists":{"field":"a"}}],"must_not":[{"term":{"a":"x"}}]}}`},
+		{"5 >= a", `{"range":{"a":{"lte":5}}}`},
+		{"a > 1 and b < 2", `{"bool":{"filter":[{"range":{"a":{"gt":1}}},{"range":{"b":{"lt":2}}}]}}`},
+		{"a = 1 or b = 2", `{"bool":{"minimum_should_match":1,"should":[{"term":{"a":1}},{"term":{"b":2}}]}}`},
+		{"not (a = 1)", `{"bool":{"filter":[{"exists":{"field":"a"}}],"must_not":[{"term":{"a":1}}]}}`},
+		{"not (a = 1 or b <= 2)", `{"bool":{"filter":[{"bool":{"filter":[{"exists":{"field":"a"}}],"must_not":[{"term":{"a":1}}]}},{"range":{"b":{"gt":2}}}]}}`},
+		{"not (a is null)", `{"exists":{"field":"a"}}`},
+		{"a in ('x', 'y')", `{"terms":{"a":["x","y"]}}`},
+		{"a not in (1)", `{"bool":{"filter":[{"exists":{"field":"a"}}],"must_not":[{"terms":{"a":[1]}}]}}`},
+		{"a not like 'x%'", `{"bool":{"filter":[{"exists":{"field":"a"}}],"must_not":[{"wildcard":{"a":{"value":"x*"}}}]}}`},
+		{`a like 'x%y_z\\%*'`, `{"wildcard":{"a":{"value":"x*y?z%\\*"}}}`},
+		{"a between 1 and 5", `{"range":{"a":{"gte":1,"lte":5}}}`},
+		{"a not between 1 and 5", `{"bool":{"filter":[{"exists":{"field":"a"}}],"must_not":[{"range":{"a":{"gte":1,"lte":5}}}]}}`},
+		{"a is not null", `{"exists":{"field":"a"}}`},
+		{"a is null", `{"bool":{"must_not":[{"exists":{"field":"a"}}]}}`},
+	}
+	for _, tcase := range tcases {
+		query, err := ElasticsearchQuery(whereExpr(t, tcase.where), nil)
+		if !assert.Nil(t, err, tcase.where) {
+			continue
+		}
+		out, _ := json.Marshal(query)
+		assert.Equal(t, tcase.want, string(out), tcase.where)
+	}
+}
+
+func TestDocFilterErrors(t *testing.T) {
+	tcases := []struct {
+		where string
+		err   string
+	}{
+		{"a = b", "expected a constant, got b"},
+		{"1 = 2", "expected a column, got 1"},
+		{"'x' like a", "unsupported expression: 'x' like a"},
+		{"a in (select b from u)", "unsupported expression: (select b from u)"},
+		{"exists (select b from u)", "unsupported expression: exists (select b from u)"},
+		{"a = lower('x')", "unsupported expression: lower('x')"},
+		{"a = :missing", "missing bind var missing"},
+		{"a = null", "unsupported comparison with null: a = null"},
+		{"not (a != null)", "unsupported comparison with null: a = null"},
+		{"a not in (1, null)", "unsupported comparison with null: a not in (1, null)"},
+		{"not (a in (1, null))", "unsupported comparison with null: a not in (1, null)"},
+		{"a between null and 5", "unsupported comparison with null: a between null and 5"},
+		{"not exists (select b from u)", "unsupported expression: exists (select b from u)"},
+	}
+	for _, tcase := range tcases {
+		_, err := MongoFilter(whereExpr(t, tcase.where), nil)
+		assert.Equal(t, tcase.err, err.Error(), tcase.where)
+		_, err = ElasticsearchQuery(whereExpr(t, tcase.where), nil)
+		assert.Equal(t, tcase.err, err.Error(), tcase.where)
+	}
+}
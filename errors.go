@@ -0,0 +1,151 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// errors.go describes the errors found while parsing.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is the error returned for sql that does not parse.
+// Its message gives the byte offset at which the error was found,
+// as in "syntax error at position 12 near from". Line and Column,
+// both counted from 1, locate the start of Token, the token the
+// error was found at, which is empty at the end of the input.
+// Expected holds the tokens that could have taken its place, as
+// named by the grammar, such as FROM, ID or ',', if the error is
+// a syntax error.
+type ParseError struct {
+	Message  string
+	Position int
+	Line     int
+	Column   int
+	Token    string
+	Expected []string
+}
+
+func (err *ParseError) Error() string {
+	return err.Message
+}
+
+// LimitError is the error returned for sql that exceeds one of
+// the limits set by Options or applied by ParseUntrusted. Limit
+// names the limit, and Max is its value. Length is the length of
+// sql exceeding the LimitLength limit.
+type LimitError struct {
+	Limit  string
+	Max    int
+	Length int
+}
+
+// LimitError.Limit
+const (
+	LimitLength  = "length"
+	LimitTokens  = "tokens"
+	LimitDepth   = "depth"
+	LimitQueries = "queries"
+)
+
+func (err *LimitError) Error() string {
+	switch err.Limit {
+	case LimitLength:
+		return fmt.Sprintf("sql is %d bytes long, exceeding the limit of %d", err.Length, err.Max)
+	case LimitTokens:
+		return fmt.Sprintf("sql has more than the limit of %d tokens", err.Max)
+	case LimitDepth:
+		return fmt.Sprintf("sql is nested deeper than the limit of %d", err.Max)
+	case LimitQueries:
+		return fmt.Sprintf("sql combines more than the limit of %d queries", err.Max)
+	}
+	return fmt.Sprintf("sql exceeds the %s limit of %d", err.Limit, err.Max)
+}
+
+// parseError returns the error recorded by Error, or the
+// LimitError that stopped the parse.
+func (tkn *Tokenizer) parseError() error {
+	if tkn.limitErr != nil {
+		return tkn.limitErr
+	}
+	return &ParseError{
+		Message:  tkn.LastError,
+		Position: tkn.errPosition,
+		Line:     tkn.errLine,
+		Column:   tkn.errColumn,
+		Token:    tkn.errorTokenText(),
+		Expected: tkn.expected,
+	}
+}
+
+// operatorTokens holds the text of the operators
+// that Scan returns without a value.
+var operatorTokens = map[int]string{
+	NE:                      "!=",
+	LE:                      "<=",
+	GE:                      ">=",
+	NULL_SAFE_EQUAL:         "<=>",
+	ASSIGN:                  ":=",
+	JSON_EXTRACT_OP:         "->",
+	JSON_UNQUOTE_EXTRACT_OP: "->>",
+}
+
+// errorTokenText returns the text of the token
+// the error was found at.
+func (tkn *Tokenizer) errorTokenText() string {
+	switch {
+	case tkn.errorToken != "":
+		return tkn.errorToken
+	case tkn.lastToken > 0 && tkn.lastToken < 256:
+		return string(rune(tkn.lastToken))
+	}
+	return operatorTokens[tkn.lastToken]
+}
+
+// recordErrorState records the tokens the parser expected when
+// it found a syntax error in state. It is called from yyParse,
+// see the Makefile.
+func recordErrorState(yylex interface{}, state int) {
+	tkn, ok := yylex.(*Tokenizer)
+	if !ok {
+		return
+	}
+	tkn.expected = expectedTokens(state)
+}
+
+// expectedTokens returns the names of the tokens the parser can
+// shift or reduce in state, like yyErrorMessage but without its
+// limit of four.
+func expectedTokens(state int) []string {
+	const tokStart = 4
+	var expected []string
+	base := int(yyPact[state])
+	for tok := tokStart; tok-1 < len(yyToknames); tok++ {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
+			expected = append(expected, tokenName(tok))
+		}
+	}
+	if yyDef[state] == -2 {
+		i := 0
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
+			i += 2
+		}
+		for i += 2; yyExca[i] >= 0; i += 2 {
+			if tok := int(yyExca[i]); tok >= tokStart && yyExca[i+1] != 0 {
+				expected = append(expected, tokenName(tok))
+			}
+		}
+	}
+	return expected
+}
+
+// tokenName returns the name of a token of the parser's tables.
+func tokenName(tok int) string {
+	name := yyTokname(tok)
+	if strings.HasPrefix(name, "$") {
+		return "end of input"
+	}
+	return name
+}
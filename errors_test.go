@@ -0,0 +1,86 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	_, err := Parse("select a\nfrom t\nwhere b = = 1")
+	perr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("got %T, want *ParseError", err)
+	}
+	assert.Equal(t, "syntax error at position 28", perr.Error())
+	assert.Equal(t, 28, perr.Position)
+	assert.Equal(t, 3, perr.Line)
+	assert.Equal(t, 11, perr.Column)
+	assert.Equal(t, "=", perr.Token)
+	assert.Contains(t, perr.Expected, "ID")
+	assert.Contains(t, perr.Expected, "'('")
+	assert.NotContains(t, perr.Expected, "FROM")
+
+	_, err = Parse("select a from")
+	perr = err.(*ParseError)
+	assert.Equal(t, 1, perr.Line)
+	assert.Equal(t, 14, perr.Column)
+	assert.Equal(t, "", perr.Token)
+	assert.Contains(t, perr.Expected, "ID")
+
+	_, err = Parse("select a from t\n  where")
+	perr = err.(*ParseError)
+	assert.Equal(t, 2, perr.Line)
+	assert.Equal(t, 8, perr.Column)
+
+	_, err = Parse("create table t (a int, foreign key (a) references u (b) on delete nothing)")
+	perr = err.(*ParseError)
+	assert.Equal(t, "syntax error near nothing at position 75", perr.Error())
+	assert.Equal(t, ")", perr.Token)
+	assert.Equal(t, 74, perr.Column)
+	assert.Nil(t, perr.Expected)
+}
+
+func TestParseLimits(t *testing.T) {
+	tcases := []struct {
+		sql  string
+		opts Options
+		err  string
+	}{{
+		sql:  "select 1 from dual",
+		opts: Options{MaxLength: 10},
+		err:  "sql is 18 bytes long, exceeding the limit of 10",
+	}, {
+		sql:  "select a from t where a in (select b from u where b = (1 + (2)))",
+		opts: Options{MaxDepth: 2},
+		err:  "sql is nested deeper than the limit of 2",
+	}, {
+		sql:  "select a from t where a in (select b from u) and (c) = (1)",
+		opts: Options{MaxDepth: 1},
+	}, {
+		sql:  "select 1 union select 2 union all select 3",
+		opts: Options{MaxQueries: 2},
+		err:  "sql combines more than the limit of 2 queries",
+	}, {
+		sql:  "select 1 union select 2 intersect select 3",
+		opts: Options{MaxQueries: 3, MaxDepth: 1, MaxLength: 100},
+	}}
+	for _, tcase := range tcases {
+		_, err := ParseWithOptions(tcase.sql, tcase.opts)
+		if tcase.err == "" {
+			assert.Nil(t, err, tcase.sql)
+			continue
+		}
+		assert.EqualError(t, err, tcase.err, tcase.sql)
+		_, ok := err.(*LimitError)
+		assert.True(t, ok, tcase.sql)
+	}
+
+	_, err := ParseUntrusted("select " + strings.Repeat("1+", UntrustedMaxTokens) + "1 from dual")
+	assert.Equal(t, &LimitError{Limit: LimitTokens, Max: UntrustedMaxTokens}, err)
+}
@@ -0,0 +1,539 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// eval.go evaluates constant expressions, and folds them into
+// literals in statements.
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/getlantern/sqlparser/dependency/sqltypes"
+)
+
+// Evaluate evaluates expr, which may contain literals, bind
+// variables, arithmetic, CASE and calls of common functions: ABS,
+// CEIL, FLOOR, ROUND, MOD, POWER, SIGN, GREATEST, LEAST, COALESCE,
+// IFNULL, NULLIF, IF, CONCAT, LOWER, UPPER, TRIM, LTRIM, RTRIM,
+// LENGTH, CHAR_LENGTH, REPLACE and SUBSTRING. Comparisons and other
+// boolean expressions, as in the first argument of IF, evaluate to
+// 1, 0 or NULL. Values compare and convert as in CompilePredicate.
+// The result is NULL, numeric, fractional or a string.
+//
+// Columns, subqueries and functions that need a database or are
+// not deterministic, such as NOW, are rejected with an error.
+func Evaluate(expr ValExpr, bindVars map[string]sqltypes.Value) (sqltypes.Value, error) {
+	vars := make(map[string]interface{}, len(bindVars))
+	for name, v := range bindVars {
+		vars[name] = v
+	}
+	c := &predicateCompiler{bindVars: vars, funcs: true}
+	fn, err := c.valExpr(expr)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	v, err := fn(nil)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	return sqltypes.BuildValue(v)
+}
+
+// FoldConstants replaces the constant expressions of stmt, those
+// Evaluate can evaluate without bind variables, with their values,
+// so that 1+2 becomes 3 and concat('a', 'b') becomes 'ab'. Constant
+// conditions are dropped from AND and OR where they do not decide
+// the result, and a WHERE or HAVING clause that is always true is
+// removed. Expressions that ORDER BY and GROUP BY would take for a
+// column position, if folded to a number, are left as they are, as
+// are comparisons and matches of strings, such as 'a' = 'A', whose
+// results depend on the collation of the database. So are
+// expressions with fractional values, such as 0.1 + 0.2 or 1 / 3,
+// which Evaluate computes as float64s rather than with the exact
+// DECIMAL arithmetic of the database, and integer arithmetic that
+// overflows.
+func FoldConstants(stmt Statement) {
+	f := &folder{
+		compiler: &predicateCompiler{funcs: true, collated: true},
+		keep:     make(map[uintptr]bool),
+	}
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Order:
+			f.keepExpr(&node.Expr)
+		case *Select:
+			for _, expr := range node.GroupBy {
+				if expr, ok := expr.(*NonStarExpr); ok {
+					f.keepExpr(&expr.Expr)
+				}
+			}
+		}
+		return true, nil
+	}, stmt)
+	f.fold(reflect.ValueOf(stmt))
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Select:
+			node.Where = f.foldWhere(node.Where)
+			node.Having = f.foldWhere(node.Having)
+		case *Update:
+			node.Where = f.foldWhere(node.Where)
+		case *Delete:
+			node.Where = f.foldWhere(node.Where)
+		}
+		return true, nil
+	}, stmt)
+}
+
+type folder struct {
+	compiler *predicateCompiler
+	// keep holds the addresses of the expressions
+	// to leave as they are.
+	keep map[uintptr]bool
+}
+
+func (f *folder) keepExpr(ptr interface{}) {
+	f.keep[reflect.ValueOf(ptr).Elem().UnsafeAddr()] = true
+}
+
+func (f *folder) fold(val reflect.Value) {
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		if val.CanSet() && !f.keep[val.UnsafeAddr()] {
+			if lit, ok := f.literal(val.Elem().Interface()); ok && reflect.TypeOf(lit).AssignableTo(val.Type()) {
+				val.Set(reflect.ValueOf(lit))
+				return
+			}
+		}
+		f.fold(val.Elem())
+		if val.CanSet() {
+			if expr, ok := val.Elem().Interface().(BoolExpr); ok {
+				if simplified := f.simplify(expr); reflect.TypeOf(simplified).AssignableTo(val.Type()) {
+					val.Set(reflect.ValueOf(simplified))
+				}
+			}
+		}
+	case reflect.Ptr:
+		if !val.IsNil() {
+			f.fold(val.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			f.fold(val.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			f.fold(val.Field(i))
+		}
+	}
+}
+
+// literal returns the value of node as a literal, if node is a
+// constant expression that is not a literal already.
+func (f *folder) literal(node interface{}) (ValExpr, bool) {
+	switch node := node.(type) {
+	case *UnaryExpr:
+		// -1 is as simple as it gets.
+		if num, ok := node.Expr.(NumVal); ok && !strings.HasPrefix(string(num), "-") {
+			return nil, false
+		}
+	case *BinaryExpr, *FuncExpr, *CaseExpr, *ParenExpr:
+	default:
+		return nil, false
+	}
+	if f.fractional(node.(ValExpr)) {
+		return nil, false
+	}
+	fn, err := f.compiler.valExpr(node.(ValExpr))
+	if err != nil {
+		return nil, false
+	}
+	v, err := fn(nil)
+	if err != nil {
+		return nil, false
+	}
+	return valueLiteral(v), true
+}
+
+// fractional reports whether node, or any of the constant
+// expressions in it, evaluates to a float64: a fractional number,
+// the result of a division, or an integer too large for an int64.
+// These differ from the exact values of the database.
+func (f *folder) fractional(node SQLNode) bool {
+	found := false
+	Walk(func(node SQLNode) (bool, error) {
+		if expr, ok := node.(ValExpr); ok && !found {
+			if fn, err := f.compiler.valExpr(expr); err == nil {
+				if v, err := fn(nil); err == nil {
+					_, found = v.(float64)
+				}
+			}
+		}
+		return !found, nil
+	}, node)
+	return found
+}
+
+// valueLiteral returns a literal for v, a normalized value.
+func valueLiteral(v interface{}) ValExpr {
+	switch v := v.(type) {
+	case nil:
+		return &NullVal{}
+	case int64, float64:
+		if f, ok := v.(float64); ok && (math.IsInf(f, 0) || math.IsNaN(f)) {
+			return &NullVal{}
+		}
+		return NumVal(valueToString(v))
+	case time.Time:
+		return StrVal{Val: v.Format("2006-01-02 15:04:05")}
+	}
+	return StrVal{Val: v.(string)}
+}
+
+// constantTruth returns the truth of expr, if it is constant.
+func (f *folder) constantTruth(expr BoolExpr) (truth, bool) {
+	if f.fractional(expr) {
+		return sqlUnknown, false
+	}
+	fn, err := f.compiler.boolExpr(expr)
+	if err != nil {
+		return sqlUnknown, false
+	}
+	t, err := fn(nil)
+	if err != nil {
+		return sqlUnknown, false
+	}
+	return t, true
+}
+
+// simplify drops the constant operands of AND and OR that do not
+// decide the result.
+func (f *folder) simplify(expr BoolExpr) BoolExpr {
+	switch expr := expr.(type) {
+	case *AndExpr:
+		if t, ok := f.constantTruth(expr.Left); ok && t == sqlTrue {
+			return expr.Right
+		}
+		if t, ok := f.constantTruth(expr.Right); ok && t == sqlTrue {
+			return expr.Left
+		}
+	case *OrExpr:
+		if t, ok := f.constantTruth(expr.Left); ok && t == sqlFalse {
+			return expr.Right
+		}
+		if t, ok := f.constantTruth(expr.Right); ok && t == sqlFalse {
+			return expr.Left
+		}
+	}
+	return expr
+}
+
+func (f *folder) foldWhere(where *Where) *Where {
+	if where == nil {
+		return nil
+	}
+	if t, ok := f.constantTruth(where.Expr); ok && t == sqlTrue {
+		return nil
+	}
+	return where
+}
+
+// sqlFunc is a function Evaluate can call. Its arguments number
+// from min to max, or at least min if max is -1. Unless nulls is
+// set, the function returns NULL if any argument is NULL.
+type sqlFunc struct {
+	min, max int
+	nulls    bool
+	call     func(args []interface{}) (interface{}, error)
+}
+
+var sqlFuncs = map[string]sqlFunc{
+	"abs": {1, 1, false, func(args []interface{}) (interface{}, error) {
+		n, err := toNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := n.(int64); ok {
+			if i == math.MinInt64 {
+				return nil, fmt.Errorf("BIGINT value is out of range in abs(%d)", i)
+			}
+			if i < 0 {
+				return -i, nil
+			}
+			return i, nil
+		}
+		return math.Abs(n.(float64)), nil
+	}},
+	"ceil":    {1, 1, false, roundFunc(math.Ceil)},
+	"ceiling": {1, 1, false, roundFunc(math.Ceil)},
+	"floor":   {1, 1, false, roundFunc(math.Floor)},
+	"round": {1, 2, false, func(args []interface{}) (interface{}, error) {
+		if len(args) == 1 {
+			return roundFunc(math.Round)(args)
+		}
+		n, err := toNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		d, err := toNumber(args[1])
+		if err != nil {
+			return nil, err
+		}
+		scale := math.Pow(10, float64(toInt64(d)))
+		return math.Round(toFloat64(n)*scale) / scale, nil
+	}},
+	"mod": {2, 2, false, func(args []interface{}) (interface{}, error) {
+		return arithmetic(AST_MOD, args[0], args[1])
+	}},
+	"power": {2, 2, false, powFunc},
+	"pow":   {2, 2, false, powFunc},
+	"sign": {1, 1, false, func(args []interface{}) (interface{}, error) {
+		cmp, err := compareValues(args[0], int64(0))
+		return int64(cmp), err
+	}},
+	"greatest": {1, -1, false, extremeFunc(1)},
+	"least":    {1, -1, false, extremeFunc(-1)},
+	"coalesce": {1, -1, true, func(args []interface{}) (interface{}, error) {
+		for _, arg := range args {
+			if arg != nil {
+				return arg, nil
+			}
+		}
+		return nil, nil
+	}},
+	"ifnull": {2, 2, true, func(args []interface{}) (interface{}, error) {
+		if args[0] != nil {
+			return args[0], nil
+		}
+		return args[1], nil
+	}},
+	"nullif": {2, 2, true, func(args []interface{}) (interface{}, error) {
+		if args[0] == nil || args[1] == nil {
+			return args[0], nil
+		}
+		cmp, err := compareValues(args[0], args[1])
+		if err != nil || cmp == 0 {
+			return nil, err
+		}
+		return args[0], nil
+	}},
+	"if": {3, 3, true, func(args []interface{}) (interface{}, error) {
+		if args[0] != nil {
+			cmp, err := compareValues(args[0], int64(0))
+			if err != nil {
+				return nil, err
+			}
+			if cmp != 0 {
+				return args[1], nil
+			}
+		}
+		return args[2], nil
+	}},
+	"concat": {1, -1, false, func(args []interface{}) (interface{}, error) {
+		var buf strings.Builder
+		for _, arg := range args {
+			buf.WriteString(valueToString(arg))
+		}
+		return buf.String(), nil
+	}},
+	"lower": {1, 1, false, stringFunc(strings.ToLower)},
+	"lcase": {1, 1, false, stringFunc(strings.ToLower)},
+	"upper": {1, 1, false, stringFunc(strings.ToUpper)},
+	"ucase": {1, 1, false, stringFunc(strings.ToUpper)},
+	"trim": {1, 1, false, stringFunc(func(s string) string {
+		return strings.Trim(s, " ")
+	})},
+	"ltrim": {1, 1, false, stringFunc(func(s string) string {
+		return strings.TrimLeft(s, " ")
+	})},
+	"rtrim": {1, 1, false, stringFunc(func(s string) string {
+		return strings.TrimRight(s, " ")
+	})},
+	"length": {1, 1, false, func(args []interface{}) (interface{}, error) {
+		return int64(len(valueToString(args[0]))), nil
+	}},
+	"char_length": {1, 1, false, func(args []interface{}) (interface{}, error) {
+		return int64(utf8.RuneCountInString(valueToString(args[0]))), nil
+	}},
+	"replace": {3, 3, false, func(args []interface{}) (interface{}, error) {
+		return strings.Replace(valueToString(args[0]), valueToString(args[1]), valueToString(args[2]), -1), nil
+	}},
+	"substring": {2, 3, false, substringFunc},
+	"substr":    {2, 3, false, substringFunc},
+}
+
+// comparingFuncs are the functions of sqlFuncs that compare their
+// arguments.
+var comparingFuncs = map[string]bool{
+	"nullif":   true,
+	"greatest": true,
+	"least":    true,
+}
+
+// roundFunc returns a function that rounds its argument to an
+// integer with round.
+func roundFunc(round func(float64) float64) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		n, err := toNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := n.(float64); ok {
+			if f = round(f); math.Abs(f) < math.MaxInt64 {
+				return int64(f), nil
+			}
+			return f, nil
+		}
+		return n, nil
+	}
+}
+
+func powFunc(args []interface{}) (interface{}, error) {
+	base, err := toNumber(args[0])
+	if err != nil {
+		return nil, err
+	}
+	exp, err := toNumber(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return math.Pow(toFloat64(base), toFloat64(exp)), nil
+}
+
+// extremeFunc returns a function that returns the argument that
+// compares as sign with all the others: the greatest for 1, the
+// least for -1.
+func extremeFunc(sign int) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			cmp, err := compareValues(arg, result)
+			if err != nil {
+				return nil, err
+			}
+			if cmp == sign {
+				result = arg
+			}
+		}
+		return result, nil
+	}
+}
+
+func stringFunc(fn func(string) string) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		return fn(valueToString(args[0])), nil
+	}
+}
+
+// substringFunc implements SUBSTRING(s, pos[, len]). Positions
+// count characters from 1, or from the end if negative.
+func substringFunc(args []interface{}) (interface{}, error) {
+	s := []rune(valueToString(args[0]))
+	n, err := toNumber(args[1])
+	if err != nil {
+		return nil, err
+	}
+	pos := toInt64(n)
+	switch {
+	case pos > 0:
+		pos--
+	case pos < 0:
+		pos += int64(len(s))
+	}
+	if pos < 0 || pos >= int64(len(s)) || toInt64(n) == 0 {
+		return "", nil
+	}
+	s = s[pos:]
+	if len(args) == 3 {
+		n, err := toNumber(args[2])
+		if err != nil {
+			return nil, err
+		}
+		length := toInt64(n)
+		if length < 0 {
+			length = 0
+		}
+		if length < int64(len(s)) {
+			s = s[:length]
+		}
+	}
+	return string(s), nil
+}
+
+func (c *predicateCompiler) funcExpr(expr *FuncExpr) (valueFunc, error) {
+	name := expr.Name.Lowered()
+	fn, ok := sqlFuncs[name]
+	if !ok || expr.Distinct || expr.Over != nil {
+		return nil, fmt.Errorf("unsupported function: %s", String(expr))
+	}
+	if len(expr.Exprs) < fn.min || fn.max >= 0 && len(expr.Exprs) > fn.max {
+		return nil, fmt.Errorf("wrong number of arguments: %s", String(expr))
+	}
+	args := make([]valueFunc, 0, len(expr.Exprs))
+	for _, arg := range expr.Exprs {
+		nonStar, ok := arg.(*NonStarExpr)
+		if !ok || !nonStar.As.IsEmpty() {
+			return nil, fmt.Errorf("unsupported expression: %s", String(arg))
+		}
+		argFn, err := c.expr(nonStar.Expr)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, argFn)
+	}
+	return func(row map[string]interface{}) (interface{}, error) {
+		vals := make([]interface{}, len(args))
+		for i, arg := range args {
+			v, err := arg(row)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil && !fn.nulls {
+				return nil, nil
+			}
+			vals[i] = v
+		}
+		if comparingFuncs[name] {
+			if err := c.checkCollation(vals...); err != nil {
+				return nil, err
+			}
+		}
+		return fn.call(vals)
+	}, nil
+}
+
+// expr compiles a value or a boolean expression. Boolean
+// expressions evaluate to 1, 0 or NULL.
+func (c *predicateCompiler) expr(expr Expr) (valueFunc, error) {
+	if expr, ok := expr.(ValExpr); ok {
+		return c.valExpr(expr)
+	}
+	cond, ok := expr.(BoolExpr)
+	if !ok {
+		return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+	}
+	fn, err := c.boolExpr(cond)
+	if err != nil {
+		return nil, err
+	}
+	return func(row map[string]interface{}) (interface{}, error) {
+		t, err := fn(row)
+		switch {
+		case err != nil, t == sqlUnknown:
+			return nil, err
+		case t == sqlTrue:
+			return int64(1), nil
+		}
+		return int64(0), nil
+	}, nil
+}
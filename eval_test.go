@@ -0,0 +1,138 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/getlantern/sqlparser/dependency/sqltypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	bindVars := map[string]sqltypes.Value{
+		"n": sqltypes.MakeNumeric([]byte("4")),
+		"s": sqltypes.MakeString([]byte("abc")),
+	}
+	tcases := []struct {
+		expr string
+		want string
+		err  string
+	}{
+		{expr: "1 + 2 * 3", want: "7"},
+		{expr: "7 / 2", want: "3.5"},
+		{expr: "1 / 0", want: "NULL"},
+		{expr: ":n - 1", want: "3"},
+		{expr: "-(2 + 3)", want: "-5"},
+		{expr: "abs(-3) + floor(2.7) + ceil(1.2)", want: "7"},
+		{expr: "round(2.345, 2)", want: "2.35"},
+		{expr: "greatest(1, 5, 3) - least(4, 2)", want: "3"},
+		{expr: "coalesce(null, null, 'x')", want: "x"},
+		{expr: "concat(upper(:s), '-', substring('hello', 2, 3))", want: "ABC-ell"},
+		{expr: "concat('a', null)", want: "NULL"},
+		{expr: "if(:n > 3, 'big', 'small')", want: "big"},
+		{expr: "case when 1 = 2 then 'a' else 'b' end", want: "b"},
+		{expr: "length(trim('  ab  ')) + mod(7, 3)", want: "3"},
+		{expr: "a + 1", err: "unknown column a"},
+		{expr: "now()", err: "unsupported function: now()"},
+		{expr: "abs(1, 2)", err: "wrong number of arguments: abs(1, 2)"},
+		{expr: ":missing", err: "missing bind var missing"},
+		{expr: "010 + 1", want: "11"},
+		{expr: "9223372036854775807 + 1", err: "BIGINT value is out of range in 9223372036854775807 + 1"},
+		{expr: "-(-9223372036854775807 - 1)", err: "BIGINT value is out of range in -(-9223372036854775808)"},
+		{expr: "4611686018427387904 * 2", err: "BIGINT value is out of range in 4611686018427387904 * 2"},
+		{expr: "abs(-9223372036854775807 - 1)", err: "BIGINT value is out of range in abs(-9223372036854775808)"},
+	}
+	for _, tcase := range tcases {
+		tree, err := Parse("select " + tcase.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expr := tree.(*Select).SelectExprs[0].(*NonStarExpr).Expr.(ValExpr)
+		v, err := Evaluate(expr, bindVars)
+		if tcase.err != "" {
+			assert.EqualError(t, err, tcase.err, tcase.expr)
+			continue
+		}
+		assert.NoError(t, err, tcase.expr)
+		got := v.String()
+		if v.IsNull() {
+			got = "NULL"
+		}
+		assert.Equal(t, tcase.want, got, tcase.expr)
+	}
+}
+
+func TestFoldConstants(t *testing.T) {
+	tcases := []struct {
+		sql  string
+		want string
+	}{{
+		sql:  "select a + (1 + 2) * 3 from t where b = concat('x', 'y')",
+		want: "select a+9 from t where b = 'xy'",
+	}, {
+		sql:  "select a from t where 1 = 1 and b > 2 - 1",
+		want: "select a from t where b > 1",
+	}, {
+		sql:  "select a from t where 1 = 0 or b = 1",
+		want: "select a from t where b = 1",
+	}, {
+		sql:  "select a from t where 2 > 1",
+		want: "select a from t",
+	}, {
+		sql:  "select a from t where 1 = 0",
+		want: "select a from t where 1 = 0",
+	}, {
+		sql:  "select a, -1, :v + 1, now() from t order by 1 + 1, a * (2 - 1)",
+		want: "select a, -1, :v+1, now() from t order by 1+1 asc, a*1 asc",
+	}, {
+		sql:  "select a from t group by 1 + 1 having count(*) > 1 + 1",
+		want: "select a from t group by 1+1 having count(*) > 2",
+	}, {
+		sql:  "update t set a = 2 * 3 where b in (select c from u where d = 1 + 1)",
+		want: "update t set a = 6 where b in (select c from u where d = 2)",
+	}, {
+		sql:  "select 010 + 1, 0x10 + 1, - -1",
+		want: "select 11, 17, 1",
+	}, {
+		sql:  "select 9223372036854775807 + 1, -9223372036854775807 - 2, 4611686018427387904 * 2",
+		want: "select 9223372036854775807+1, -9223372036854775807-2, 4611686018427387904*2",
+	}, {
+		sql:  "select -(-9223372036854775808), 9223372036854775808 - 1, 0xffffffffffffffff + 0",
+		want: "select - -9223372036854775808, 9223372036854775808-1, 0xffffffffffffffff+0",
+	}, {
+		sql:  "select abs(-9223372036854775808), abs(-9223372036854775807 - 1), abs(-3)",
+		want: "select abs(-9223372036854775808), abs(-9223372036854775808), 3",
+	}, {
+		sql:  "select a from t where a = 1 or 'a' = 'A'",
+		want: "select a from t where a = 1 or 'a' = 'A'",
+	}, {
+		sql:  "select a from t where 'a' = 'a ' or 'A' like 'a' or 'b' in ('B') or 'b' regexp 'B'",
+		want: "select a from t where 'a' = 'a ' or 'A' like 'a' or 'b' in ('B') or 'b' regexp 'B'",
+	}, {
+		sql:  "select if('a' = 'A', 1, 2), nullif('a', 'A'), greatest('a', 'B'), if(1 = 1, 1, 2), 12 like '1%'",
+		want: "select if('a' = 'A', 1, 2), nullif('a', 'A'), greatest('a', 'B'), 1, 12 like '1%'",
+	}, {
+		sql:  "select a from t where 2 like 2 and null = 'a' and b = 1",
+		want: "select a from t where null = 'a' and b = 1",
+	}, {
+		sql:  "select a from t where a = 0.1 + 0.2 and b = 1 / 3 and c = 4 / 2",
+		want: "select a from t where a = 0.1+0.2 and b = 1/3 and c = 4/2",
+	}, {
+		sql:  "select round(1.005, 2), concat(1.50, 'x'), 1e308 * 10, '0.1' + 1, concat(1 + 2, 'x')",
+		want: "select round(1.005, 2), concat(1.50, 'x'), 1e308*10, '0.1'+1, '3x'",
+	}, {
+		sql:  "select a from t where 0.1 + 0.2 = 0.3 or b = 1",
+		want: "select a from t where 0.1+0.2 = 0.3 or b = 1",
+	}}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		FoldConstants(tree)
+		assert.Equal(t, tcase.want, String(tree), tcase.sql)
+	}
+}
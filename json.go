@@ -0,0 +1,273 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// json.go encodes parse trees as JSON and decodes them back.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// EncodeJSON encodes node as JSON, so that it can be sent to
+// another process and decoded with DecodeJSON. Nodes are encoded
+// as objects with their exported fields, and lists as arrays.
+// Where a field can hold nodes of different types, as a ValExpr
+// field can, and at the top, the node is wrapped in an envelope
+// naming its type:
+//
+//	{"Type": "ComparisonExpr", "Node": {"Operator": "=", ...}}
+//
+// ColIdents and TableIdents are encoded as strings, which do not
+// record whether they were quoted.
+func EncodeJSON(node SQLNode) ([]byte, error) {
+	v, err := encodeJSON(reflect.ValueOf(&node).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// DecodeJSON decodes a node encoded by EncodeJSON.
+func DecodeJSON(data []byte) (SQLNode, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	var node SQLNode
+	if err := decodeJSON(v, reflect.ValueOf(&node).Elem()); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// jsonTypes maps the names used in envelopes to node types.
+var jsonTypes = make(map[string]reflect.Type)
+
+func init() {
+	for _, node := range []SQLNode{
+		&Account{}, &AliasedTableExpr{}, &AlterSpec{}, &AlterTable{}, &AlterUser{}, &AndExpr{}, &ArrayExpr{}, &AssignExpr{}, &Begin{},
+		&BinaryExpr{}, BitVal(""), &Block{}, BoolVal(false), &Call{}, &CaseExpr{}, &CastExpr{}, &Clause{}, &ClausePart{}, Clauses{}, &CloseCursor{}, ColIdent{}, &ColName{}, &CollateExpr{},
+		ColumnAtts{}, &ColumnDefinition{}, ColumnDefinitions{}, Columns{}, ColumnType{},
+		Comments{}, &Commit{}, &CommonTableExpr{}, &ComparisonExpr{}, &ConvertExpr{},
+		&ConvertType{}, &ConvertUsingExpr{},
+		&CreateRoutine{}, &CreateTable{}, &CreateUser{}, &DDL{}, &DeclareCursor{}, &DeclareHandler{}, &DeclareVars{},
+		&Deallocate{}, &Delete{}, &Describe{}, &ElseIf{}, &Execute{}, &ExistsExpr{}, &Explain{}, &FetchCursor{}, &FrameBound{}, &FuncExpr{}, &Grant{}, &GrantObject{}, &GroupingExpr{},
+		&HandlerCondition{}, HexVal(""), &Hint{}, &HintItem{}, Hints{}, &IfStatement{}, &IndexColumn{}, IndexColumns{},
+		&IndexDefinition{}, &IndexHints{}, &IndexOption{}, IndexOptions{}, &Insert{}, &IntervalExpr{}, &IntroducerExpr{}, &IsExpr{}, &Iterate{},
+		&JSONExtractExpr{}, &JSONTableColumn{}, &JSONTableExpr{}, &JSONTableResponse{},
+		&JoinTableExpr{}, &Leave{}, &Limit{}, ListArg(""), &LoadData{}, &LoadFields{}, &LoadLines{}, &Loop{}, &MatchExpr{}, &NamedWindow{},
+		&NextValExpr{}, &Nextval{}, &NonStarExpr{}, &NotExpr{}, &NullCheck{}, &NullVal{}, NumVal(""),
+		OnDup{}, &OpenCursor{}, &Order{}, OrderBy{}, &OrExpr{}, &Other{},
+		&ParenBoolExpr{}, &ParenExpr{}, &ParenSelect{}, &ParenTableExpr{}, Partitions{},
+		&PivotTableExpr{}, &Prepare{}, &Privilege{}, &RangeCond{}, &References{}, &ReleaseSavepoint{}, &Repeat{}, &RowAlias{}, &Revoke{}, &Rollback{}, &Savepoint{}, &Select{},
+		SelectExprs{}, &Sequence{}, &SequenceOption{}, SequenceOptions{}, &Set{},
+		&SetExpr{}, SetExprs{}, &SetPassword{}, &SetTransaction{}, &Show{}, &ShowFilter{}, &Signal{}, &SignalItem{}, &StarExpr{}, Statements{},
+		&StructExpr{}, StrVal{}, &Subquery{}, &SubscriptExpr{}, &SystemTime{},
+		TableExprs{}, TableIdent{}, &TableName{}, &TableOption{}, TableOptions{}, &TableRename{},
+		&TimeRange{}, &UnaryExpr{}, &Union{}, &UnpivotTableExpr{}, &Update{}, &ValuesFuncExpr{},
+		&UpdateExpr{}, UpdateExprs{}, &UserSpec{}, &UserVar{}, ValArg(""), ValExprs{}, ValTuple{}, Values{}, &ValuesStatement{},
+		&When{}, &Where{}, &While{}, &WindowFrame{}, &WindowSpec{}, &With{},
+	} {
+		typ := reflect.TypeOf(node)
+		jsonTypes[jsonTypeName(typ)] = typ
+	}
+}
+
+func jsonTypeName(typ reflect.Type) string {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Name()
+}
+
+// jsonEnvelope is the encoding of a node held in an interface.
+type jsonEnvelope struct {
+	Type string
+	Node interface{}
+}
+
+// encodeJSON returns the value of val to be encoded with
+// encoding/json.
+func encodeJSON(val reflect.Value) (interface{}, error) {
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			return nil, nil
+		}
+		elem := val.Elem()
+		name := jsonTypeName(elem.Type())
+		if jsonTypes[name] != elem.Type() {
+			return nil, fmt.Errorf("cannot encode node of type %v", elem.Type())
+		}
+		node, err := encodeJSON(elem)
+		if err != nil {
+			return nil, err
+		}
+		return jsonEnvelope{Type: name, Node: node}, nil
+	case reflect.Ptr:
+		if val.IsNil() {
+			return nil, nil
+		}
+		return encodeJSON(val.Elem())
+	case reflect.Slice:
+		if val.IsNil() {
+			return nil, nil
+		}
+		list := make([]interface{}, val.Len())
+		for i := range list {
+			v, err := encodeJSON(val.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = v
+		}
+		return list, nil
+	case reflect.Struct:
+		switch id := val.Interface().(type) {
+		case ColIdent:
+			return id.String(), nil
+		case TableIdent:
+			return id.String(), nil
+		}
+		obj := make(map[string]interface{}, val.NumField())
+		for i := 0; i < val.NumField(); i++ {
+			field := val.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			v, err := encodeJSON(val.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			obj[field.Name] = v
+		}
+		return obj, nil
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return val.Interface(), nil
+	}
+	return nil, fmt.Errorf("cannot encode value of type %v", val.Type())
+}
+
+// decodeJSON sets val, which must be settable, to the
+// value decoded from v.
+func decodeJSON(v interface{}, val reflect.Value) error {
+	typ := val.Type()
+	if v == nil {
+		val.Set(reflect.Zero(typ))
+		return nil
+	}
+	switch typ.Kind() {
+	case reflect.Interface:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected a node, got %v", v)
+		}
+		name, _ := obj["Type"].(string)
+		nodeType, ok := jsonTypes[name]
+		if !ok {
+			return fmt.Errorf("unknown node type %q", name)
+		}
+		if !nodeType.Implements(typ) {
+			return fmt.Errorf("node type %s is not a %v", name, typ)
+		}
+		node := reflect.New(nodeType).Elem()
+		if err := decodeJSON(obj["Node"], node); err != nil {
+			return err
+		}
+		val.Set(node)
+		return nil
+	case reflect.Ptr:
+		elem := reflect.New(typ.Elem())
+		if err := decodeJSON(v, elem.Elem()); err != nil {
+			return err
+		}
+		val.Set(elem)
+		return nil
+	case reflect.Slice:
+		list, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected a list for %v, got %v", typ, v)
+		}
+		slice := reflect.MakeSlice(typ, len(list), len(list))
+		for i, item := range list {
+			if err := decodeJSON(item, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		val.Set(slice)
+		return nil
+	case reflect.Struct:
+		if typ == typeOfColIdent || typ == typeOfTableIdent {
+			s, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("expected a name, got %v", v)
+			}
+			if typ == typeOfColIdent {
+				val.Set(reflect.ValueOf(NewColIdent(s)))
+			} else {
+				val.Set(reflect.ValueOf(NewTableIdent(s)))
+			}
+			return nil
+		}
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected an object for %v, got %v", typ, v)
+		}
+		for name, fv := range obj {
+			field, ok := typ.FieldByName(name)
+			if !ok || field.PkgPath != "" {
+				return fmt.Errorf("unknown field %s of %v", name, typ)
+			}
+			if err := decodeJSON(fv, val.FieldByIndex(field.Index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected a string for %v, got %v", typ, v)
+		}
+		val.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expected a boolean for %v, got %v", typ, v)
+		}
+		val.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := v.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected a number for %v, got %v", typ, v)
+		}
+		i, err := n.Int64()
+		if err != nil || val.OverflowInt(i) {
+			return fmt.Errorf("invalid %v %v", typ, v)
+		}
+		val.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := v.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected a number for %v, got %v", typ, v)
+		}
+		i, err := n.Int64()
+		if err != nil || i < 0 || val.OverflowUint(uint64(i)) {
+			return fmt.Errorf("invalid %v %v", typ, v)
+		}
+		val.SetUint(uint64(i))
+		return nil
+	}
+	return fmt.Errorf("cannot decode value of type %v", typ)
+}
@@ -0,0 +1,54 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, tcase := range validSQL {
+		tree, err := Parse(tcase.input)
+		if err != nil || tree == nil {
+			continue
+		}
+		data, err := EncodeJSON(tree)
+		if err != nil {
+			t.Errorf("EncodeJSON(%q): %v", tcase.input, err)
+			continue
+		}
+		node, err := DecodeJSON(data)
+		if err != nil {
+			t.Errorf("DecodeJSON(%q): %v\n%s", tcase.input, err, data)
+			continue
+		}
+		if got, want := String(node), String(tree); got != want {
+			t.Errorf("round trip of %q = %q, want %q", tcase.input, got, want)
+		}
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	tree, err := Parse("select a from t where b = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := EncodeJSON(tree.(*Select).Where.Expr)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Type":"ComparisonExpr","Node":{"Escape":null,"Left":{"Type":"ColName","Node":{"Name":"b","Qualifier":""}},"Operator":"=","Quantifier":"","Right":{"Type":"NumVal","Node":"1"}}}`, string(data))
+
+	for _, data := range []string{
+		`{"Type":"Nope","Node":{}}`,
+		`{"Type":"ColIdent","Node":1}`,
+		`{"Type":"Select","Node":{"Nope":1}}`,
+		`{"Type":"Select","Node":{"Distinct":1}}`,
+		`[1]`,
+	} {
+		_, err := DecodeJSON([]byte(data))
+		assert.NotNil(t, err, data)
+	}
+}
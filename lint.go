@@ -0,0 +1,280 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// lint.go checks statements for common query mistakes.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LintRule checks a statement for one kind of problem.
+type LintRule interface {
+	// Name identifies the rule in findings.
+	Name() string
+	// Check returns the problems the rule finds in stmt.
+	Check(stmt Statement) []LintFinding
+}
+
+// LintFinding is a problem found by a LintRule. Node is the
+// node the problem was found in, which can be formatted with
+// String to show the offending part of the statement. Pos and
+// End are the byte offsets of the text of Node in the sql, as
+// those of a Span, if the statement was linted by LintSQL, and
+// -1 otherwise or if Node has no span.
+type LintFinding struct {
+	Rule     string
+	Message  string
+	Node     SQLNode
+	Pos, End int
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Rule, f.Message, String(f.Node))
+}
+
+// DefaultLintRules is the bundled rule set used by Lint
+// when no rules are given.
+var DefaultLintRules = []LintRule{
+	SelectStarRule{},
+	MissingWhereRule{},
+	ImplicitCrossJoinRule{},
+	LeadingWildcardRule{},
+	NonSargableRule{},
+}
+
+// Lint checks stmt with rules, or DefaultLintRules if none are
+// given, and returns the findings of all rules in order.
+func Lint(stmt Statement, rules ...LintRule) []LintFinding {
+	if len(rules) == 0 {
+		rules = DefaultLintRules
+	}
+	var findings []LintFinding
+	for _, rule := range rules {
+		findings = append(findings, rule.Check(stmt)...)
+	}
+	for i := range findings {
+		findings[i].Pos, findings[i].End = -1, -1
+	}
+	return findings
+}
+
+// LintSQL parses sql with opts and lints the statement like Lint,
+// and sets the offsets of the findings in sql.
+func LintSQL(sql string, opts Options, rules ...LintRule) ([]LintFinding, error) {
+	stmt, spans, err := ParseWithSpans(sql, opts)
+	if err != nil {
+		return nil, err
+	}
+	findings := Lint(stmt, rules...)
+	for i := range findings {
+		if span, ok := lintSpan(spans, findings[i].Node); ok {
+			findings[i].Pos, findings[i].End = span.Start, span.End
+		}
+	}
+	return findings, nil
+}
+
+// lintSpan returns the span of node, or for a list of tables,
+// which has no span of its own, the span from its first table to
+// its last.
+func lintSpan(spans *Spans, node SQLNode) (Span, bool) {
+	if tables, ok := node.(TableExprs); ok && len(tables) != 0 {
+		first, ok := spans.Span(tables[0])
+		if !ok {
+			return Span{}, false
+		}
+		last, ok := spans.Span(tables[len(tables)-1])
+		return Span{first.Start, last.End}, ok
+	}
+	return spans.Span(node)
+}
+
+// lintVisit calls visit on every node of stmt, including
+// those in subqueries.
+func lintVisit(stmt Statement, visit func(SQLNode)) {
+	Walk(func(node SQLNode) (bool, error) {
+		visit(node)
+		return true, nil
+	}, stmt)
+}
+
+// SelectStarRule reports SELECT * and SELECT t.*, which fetch
+// columns the query may not need and break when columns change.
+// The * of COUNT(*) and of EXISTS subqueries, which fetch no
+// columns, are not reported.
+type SelectStarRule struct{}
+
+func (SelectStarRule) Name() string { return "select-star" }
+
+func (r SelectStarRule) Check(stmt Statement) []LintFinding {
+	var findings []LintFinding
+	// Nodes are visited before their children, so the stars to
+	// skip are known when they are visited.
+	skip := make(map[*StarExpr]bool)
+	lintVisit(stmt, func(node SQLNode) {
+		switch node := node.(type) {
+		case *FuncExpr:
+			skipStars(node.Exprs, skip)
+		case *ExistsExpr:
+			if node.Subquery != nil {
+				skipSelectStars(node.Subquery.Select, skip)
+			}
+		case *StarExpr:
+			if !skip[node] {
+				findings = append(findings, LintFinding{Rule: r.Name(), Message: "avoid selecting all columns", Node: node})
+			}
+		}
+	})
+	return findings
+}
+
+// skipSelectStars adds to skip the stars selected by sel,
+// or by the selects it is made of.
+func skipSelectStars(sel SelectStatement, skip map[*StarExpr]bool) {
+	switch sel := sel.(type) {
+	case *Select:
+		skipStars(sel.SelectExprs, skip)
+	case *Union:
+		skipSelectStars(sel.Left, skip)
+		skipSelectStars(sel.Right, skip)
+	case *ParenSelect:
+		skipSelectStars(sel.Select, skip)
+	}
+}
+
+func skipStars(exprs SelectExprs, skip map[*StarExpr]bool) {
+	for _, expr := range exprs {
+		if star, ok := expr.(*StarExpr); ok {
+			skip[star] = true
+		}
+	}
+}
+
+// MissingWhereRule reports UPDATE and DELETE statements
+// without a WHERE clause, which change every row.
+type MissingWhereRule struct{}
+
+func (MissingWhereRule) Name() string { return "missing-where" }
+
+func (r MissingWhereRule) Check(stmt Statement) []LintFinding {
+	var findings []LintFinding
+	lintVisit(stmt, func(node SQLNode) {
+		switch node := node.(type) {
+		case *Update:
+			if node.Where == nil {
+				findings = append(findings, LintFinding{Rule: r.Name(), Message: "update without where changes every row", Node: node})
+			}
+		case *Delete:
+			if node.Where == nil {
+				findings = append(findings, LintFinding{Rule: r.Name(), Message: "delete without where removes every row", Node: node})
+			}
+		}
+	})
+	return findings
+}
+
+// ImplicitCrossJoinRule reports tables joined with a comma or
+// with a JOIN that has no ON or USING clause, which easily
+// produce an unintended cartesian product.
+type ImplicitCrossJoinRule struct{}
+
+func (ImplicitCrossJoinRule) Name() string { return "implicit-cross-join" }
+
+func (r ImplicitCrossJoinRule) Check(stmt Statement) []LintFinding {
+	var findings []LintFinding
+	lintVisit(stmt, func(node SQLNode) {
+		switch node := node.(type) {
+		case *Select:
+			if len(node.From) > 1 {
+				findings = append(findings, LintFinding{Rule: r.Name(), Message: "tables joined with a comma", Node: node.From})
+			}
+		case *JoinTableExpr:
+			if node.On == nil && node.Using == nil && (node.Join == AST_JOIN || node.Join == AST_STRAIGHT_JOIN) {
+				findings = append(findings, LintFinding{Rule: r.Name(), Message: "join without on", Node: node})
+			}
+		}
+	})
+	return findings
+}
+
+// LeadingWildcardRule reports LIKE patterns starting with a
+// wildcard, which cannot use an index.
+type LeadingWildcardRule struct{}
+
+func (LeadingWildcardRule) Name() string { return "leading-wildcard" }
+
+func (r LeadingWildcardRule) Check(stmt Statement) []LintFinding {
+	var findings []LintFinding
+	lintVisit(stmt, func(node SQLNode) {
+		cmp, ok := node.(*ComparisonExpr)
+		if !ok || (cmp.Operator != AST_LIKE && cmp.Operator != AST_NOT_LIKE) {
+			return
+		}
+		if pattern, ok := cmp.Right.(StrVal); ok && strings.IndexAny(pattern.Val, "%_") == 0 {
+			findings = append(findings, LintFinding{Rule: r.Name(), Message: "like pattern starts with a wildcard", Node: cmp})
+		}
+	})
+	return findings
+}
+
+// NonSargableRule reports conditions in WHERE and ON clauses
+// that apply a function or an operator to a column, as in
+// month(created) = 1, which prevents the use of an index
+// on the column.
+type NonSargableRule struct{}
+
+func (NonSargableRule) Name() string { return "non-sargable" }
+
+func (r NonSargableRule) Check(stmt Statement) []LintFinding {
+	var findings []LintFinding
+	check := func(cond BoolExpr) {
+		Walk(func(node SQLNode) (bool, error) {
+			var operands []ValExpr
+			switch node := node.(type) {
+			case *Subquery:
+				// Checked as a statement of its own.
+				return false, nil
+			case *ComparisonExpr:
+				operands = []ValExpr{node.Left, node.Right}
+			case *RangeCond:
+				operands = []ValExpr{node.Left}
+			default:
+				return true, nil
+			}
+			for _, operand := range operands {
+				if wrapsColumn(operand) {
+					findings = append(findings, LintFinding{Rule: r.Name(), Message: "column wrapped in an expression", Node: node})
+					break
+				}
+			}
+			return true, nil
+		}, cond)
+	}
+	lintVisit(stmt, func(node SQLNode) {
+		switch node := node.(type) {
+		case *Where:
+			if node.Type == AST_WHERE {
+				check(node.Expr)
+			}
+		case *JoinTableExpr:
+			check(node.On)
+		}
+	})
+	return findings
+}
+
+// wrapsColumn reports whether expr is a function call or an
+// operator expression with a column as one of its arguments.
+func wrapsColumn(expr ValExpr) bool {
+	switch expr := expr.(type) {
+	case *FuncExpr, *BinaryExpr, *UnaryExpr, *ConvertExpr, *ConvertUsingExpr, *CaseExpr, *CastExpr:
+		return len(referencedColumns(expr)) != 0
+	case *ParenExpr:
+		return wrapsColumn(expr.Expr)
+	}
+	return false
+}
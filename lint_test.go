@@ -0,0 +1,108 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	tcases := []struct {
+		sql  string
+		want []string
+	}{{
+		sql:  "select a from t where id = 1",
+		want: nil,
+	}, {
+		sql:  "select * from t where a in (select u.* from u)",
+		want: []string{"select-star: avoid selecting all columns: *", "select-star: avoid selecting all columns: u.*"},
+	}, {
+		sql:  "select count(*) from t where exists (select * from u union select u.* from u) and b in (select * from v)",
+		want: []string{"select-star: avoid selecting all columns: *"},
+	}, {
+		sql:  "update t set a = 1",
+		want: []string{"missing-where: update without where changes every row: update t set a = 1"},
+	}, {
+		sql:  "delete from t",
+		want: []string{"missing-where: delete without where removes every row: delete from t"},
+	}, {
+		sql: "select a from t, u join v where t.id = u.id",
+		want: []string{
+			"implicit-cross-join: tables joined with a comma: t, u join v",
+			"implicit-cross-join: join without on: u join v",
+		},
+	}, {
+		sql:  "select a from t join u using (id)",
+		want: nil,
+	}, {
+		sql:  "select a from t where b like '%x' and c not like '_y' and d like 'z%'",
+		want: []string{"leading-wildcard: like pattern starts with a wildcard: b like '%x'", "leading-wildcard: like pattern starts with a wildcard: c not like '_y'"},
+	}, {
+		sql: "select a from t join u on lower(t.b) = u.b where month(c) = 2 and d+1 between 1 and 2 and e = abs(-1) having sum(f) > 1",
+		want: []string{
+			"non-sargable: column wrapped in an expression: lower(t.b) = u.b",
+			"non-sargable: column wrapped in an expression: month(c) = 2",
+			"non-sargable: column wrapped in an expression: d+1 between 1 and 2",
+		},
+	}}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, f := range Lint(tree) {
+			got = append(got, f.String())
+		}
+		assert.Equal(t, tcase.want, got, tcase.sql)
+	}
+}
+
+func TestLintRules(t *testing.T) {
+	tree, err := Parse("select * from t, u")
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings := Lint(tree, ImplicitCrossJoinRule{})
+	assert.Equal(t, 1, len(findings))
+	assert.Equal(t, "implicit-cross-join", findings[0].Rule)
+	assert.Equal(t, tree.(*Select).From, findings[0].Node)
+}
+
+func TestLintSQL(t *testing.T) {
+	sql := "select * from t, u where b like '%x' and month(c) = 2"
+	findings, err := LintSQL(sql, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Rule+": "+sql[f.Pos:f.End])
+	}
+	assert.Equal(t, []string{
+		"select-star: *",
+		"implicit-cross-join: t, u",
+		"leading-wildcard: b like '%x'",
+		"non-sargable: month(c) = 2",
+	}, got)
+
+	tree, err := Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range Lint(tree) {
+		assert.Equal(t, -1, f.Pos, f.String())
+		assert.Equal(t, -1, f.End, f.String())
+	}
+
+	findings, err = LintSQL("select count(*) from t", Options{})
+	assert.NoError(t, err)
+	assert.Empty(t, findings)
+
+	_, err = LintSQL("select from", Options{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,230 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// normalize.go replaces the literals of statements with bind
+// variables or placeholders.
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getlantern/sqlparser/dependency/sqltypes"
+)
+
+// Normalize replaces the string and number literals of a SELECT,
+// INSERT, UPDATE or DELETE statement with bind variables named
+// prefix1, prefix2 and so on, and adds their values to bindVars.
+// Strings are added as strings, integers as int64 and other
+// numbers as fractional sqltypes.Values. Equal literals share a
+// bind variable, and names already in bindVars are not reused.
+// Other statements, the column positions of ORDER BY and GROUP BY
+// and numbers that are not decimal, such as 0x1f, are left as they
+// are.
+//
+// Normalized statements that differ only in their literals format
+// to the same query, which makes them suitable as cache keys.
+func Normalize(stmt Statement, bindVars map[string]interface{}, prefix string) {
+	switch stmt.(type) {
+	case SelectStatement, *Insert, *Update, *Delete:
+	default:
+		return
+	}
+	n := &normalizer{
+		bindVars: bindVars,
+		prefix:   prefix,
+		names:    make(map[string]string),
+		keep:     make(map[uintptr]bool),
+	}
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Order:
+			n.keepPosition(&node.Expr)
+		case *Select:
+			for _, expr := range node.GroupBy {
+				if expr, ok := expr.(*NonStarExpr); ok {
+					n.keepPosition(&expr.Expr)
+				}
+			}
+		}
+		return true, nil
+	}, stmt)
+	n.normalize(reflect.ValueOf(stmt))
+}
+
+type normalizer struct {
+	bindVars map[string]interface{}
+	prefix   string
+	counter  int
+	// names maps the keys of the literals replaced so far
+	// to their bind variables.
+	names map[string]string
+	// keep holds the addresses of the expressions
+	// to leave as they are.
+	keep map[uintptr]bool
+}
+
+// keepPosition keeps the expression at ptr if it is a
+// number, which stands for a column position.
+func (n *normalizer) keepPosition(ptr interface{}) {
+	val := reflect.ValueOf(ptr).Elem()
+	if _, ok := val.Interface().(NumVal); ok {
+		n.keep[val.UnsafeAddr()] = true
+	}
+}
+
+func (n *normalizer) normalize(val reflect.Value) {
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		if val.CanSet() && !n.keep[val.UnsafeAddr()] {
+			if v, key, ok := literalValue(val.Elem().Interface()); ok {
+				val.Set(reflect.ValueOf(ValArg(":" + n.bindVar(v, key))))
+				return
+			}
+		}
+		n.normalize(val.Elem())
+	case reflect.Ptr:
+		if !val.IsNil() {
+			n.normalize(val.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			n.normalize(val.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			n.normalize(val.Field(i))
+		}
+	}
+}
+
+// bindVar returns the name of the bind variable holding the
+// literal with the given key, adding one with the value v to
+// bindVars if there is none yet.
+func (n *normalizer) bindVar(v interface{}, key string) string {
+	if name, ok := n.names[key]; ok {
+		return name
+	}
+	for {
+		n.counter++
+		name := fmt.Sprintf("%s%d", n.prefix, n.counter)
+		if _, ok := n.bindVars[name]; !ok {
+			n.bindVars[name] = v
+			n.names[key] = name
+			return name
+		}
+	}
+}
+
+// literalValue returns the value of node if it is a literal that
+// can be replaced by a bind variable, and a key that is the same
+// for equal literals.
+func literalValue(node interface{}) (v interface{}, key string, ok bool) {
+	switch node := node.(type) {
+	case StrVal:
+		return node.Val, "s" + node.Val, true
+	case NumVal:
+		if i, err := strconv.ParseInt(string(node), 10, 64); err == nil {
+			return i, "n" + string(node), true
+		}
+		if _, err := strconv.ParseFloat(string(node), 64); err == nil {
+			return sqltypes.MakeFractional([]byte(node)), "n" + string(node), true
+		}
+	}
+	return nil, "", false
+}
+
+// Fingerprint parses sql and returns the statement in a canonical
+// form, for grouping queries that differ only in their literals,
+// as in slow query analysis. The statement is formatted without
+// comments and in lower case, with literals and bind variables
+// replaced by ?. Lists of literals, as in IN (1, 2, 3), become
+// (?+), and repeated rows of INSERT ... VALUES are written once.
+func Fingerprint(sql string) (string, error) {
+	stmt, err := Parse(sql)
+	if err != nil {
+		return "", err
+	}
+	return fingerprintOf(stmt), nil
+}
+
+// fingerprintOf returns the fingerprint of stmt.
+func fingerprintOf(stmt Statement) string {
+	buf := NewTrackedBuffer(formatFingerprint)
+	buf.Myprintf("%v", stmt)
+	return strings.ToLower(buf.String())
+}
+
+func formatFingerprint(buf *TrackedBuffer, node SQLNode) {
+	switch node := node.(type) {
+	case Comments, Hints:
+		return
+	case StrVal, NumVal, HexVal, BitVal, ValArg:
+		buf.WriteByte('?')
+		return
+	case ListArg:
+		buf.WriteString("(?+)")
+		return
+	case ValTuple:
+		if isLiteralList(node) {
+			buf.WriteString("(?+)")
+			return
+		}
+	case Values:
+		var last string
+		prefix := "values "
+		for _, row := range node {
+			rowBuf := NewTrackedBuffer(formatFingerprint)
+			rowBuf.Myprintf("%v", row)
+			if s := rowBuf.String(); s != last {
+				buf.Myprintf("%s%s", prefix, s)
+				prefix, last = ", ", s
+			}
+		}
+		return
+	}
+	node.Format(buf)
+}
+
+// RedactSQLQuery parses sql and returns the statement with its
+// string, number, hex and bit literals replaced by ?, so that it
+// can be logged without the data it carries. Identifiers, bind
+// variables and comments are kept.
+func RedactSQLQuery(sql string) (string, error) {
+	stmt, err := Parse(sql)
+	if err != nil {
+		return "", err
+	}
+	buf := NewTrackedBuffer(formatRedacted)
+	buf.Myprintf("%v", stmt)
+	return buf.String(), nil
+}
+
+func formatRedacted(buf *TrackedBuffer, node SQLNode) {
+	switch node.(type) {
+	case StrVal, NumVal, HexVal, BitVal:
+		buf.WriteByte('?')
+		return
+	}
+	node.Format(buf)
+}
+
+// isLiteralList reports whether list consists of
+// literals and bind variables.
+func isLiteralList(list ValTuple) bool {
+	for _, expr := range list {
+		switch expr.(type) {
+		case StrVal, NumVal, HexVal, BitVal, ValArg, *NullVal:
+		default:
+			return false
+		}
+	}
+	return len(list) != 0
+}
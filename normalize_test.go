@@ -0,0 +1,110 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/getlantern/sqlparser/dependency/sqltypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tcases := []struct {
+		in       string
+		given    map[string]interface{}
+		out      string
+		bindVars map[string]interface{}
+	}{{
+		in:       "select a, 'x' from t where b = 'x' and c > 1.50 and d in (1, 0x1f) order by 2 limit 10",
+		out:      "select a, :bv1 from t where b = :bv1 and c > :bv2 and d in (:bv3, 0x1f) order by 2 asc limit :bv4",
+		bindVars: map[string]interface{}{"bv1": "x", "bv2": sqltypes.MakeFractional([]byte("1.50")), "bv3": int64(1), "bv4": int64(10)},
+	}, {
+		in:       "select a, count(*) from t where b = :bv1 group by 1 having count(*) > 5",
+		given:    map[string]interface{}{"bv1": "given"},
+		out:      "select a, count(*) from t where b = :bv1 group by 1 having count(*) > :bv2",
+		bindVars: map[string]interface{}{"bv1": "given", "bv2": int64(5)},
+	}, {
+		in:       "insert into t(a, b) values (1, 'a'), (2, null)",
+		out:      "insert into t(a, b) values (:bv1, :bv2), (:bv3, null)",
+		bindVars: map[string]interface{}{"bv1": int64(1), "bv2": "a", "bv3": int64(2)},
+	}, {
+		in:       "update t set a = a + 1 where b in (select c from u where d = 'y')",
+		out:      "update t set a = a+:bv1 where b in (select c from u where d = :bv2)",
+		bindVars: map[string]interface{}{"bv1": int64(1), "bv2": "y"},
+	}, {
+		in:       "create table t (a int default 1)",
+		out:      "create table t (\n\ta int default 1\n)",
+		bindVars: map[string]interface{}{},
+	}}
+	for _, tcase := range tcases {
+		stmt, err := Parse(tcase.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bindVars := make(map[string]interface{})
+		for k, v := range tcase.given {
+			bindVars[k] = v
+		}
+		Normalize(stmt, bindVars, "bv")
+		assert.Equal(t, tcase.out, String(stmt), tcase.in)
+		assert.Equal(t, tcase.bindVars, bindVars, tcase.in)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	tcases := []struct {
+		in, out string
+	}{{
+		in:  "SELECT /* q1 */ a,  B FROM T WHERE x = 'abc' AND y IN (1, 2, 3) and z = :id limit 10",
+		out: "select a, b from t where x = ? and y in (?+) and z = ? limit ?",
+	}, {
+		in:  "select a from t where y in ::ids and z is null and w in (a, 1)",
+		out: "select a from t where y in (?+) and z is null and w in (a, ?)",
+	}, {
+		in:  "insert into t(a, b) values (1, 'x'), (2, 'y'), (3, now())",
+		out: "insert into t(a, b) values (?+), (?, now())",
+	}, {
+		in:  "update t set a = 'b' where c = -1.5",
+		out: "update t set a = ? where c = ?",
+	}, {
+		in:  "select a from t where b in (X'1F', b'101') and c = _binary 'x'",
+		out: "select a from t where b in (?+) and c = _binary ?",
+	}}
+	for _, tcase := range tcases {
+		got, err := Fingerprint(tcase.in)
+		assert.Nil(t, err, tcase.in)
+		assert.Equal(t, tcase.out, got, tcase.in)
+	}
+
+	_, err := Fingerprint("select from")
+	assert.NotNil(t, err)
+}
+
+func TestRedactSQLQuery(t *testing.T) {
+	tcases := []struct {
+		in, out string
+	}{{
+		in:  "SELECT /* q1 */ a, B FROM T WHERE x = 'abc' AND y IN (1, 2) and z = :id limit 10",
+		out: "select /* q1 */ a, B from T where x = ? and y in (?, ?) and z = :id limit ?",
+	}, {
+		in:  "insert into t(a, b) values (1, 'x'), (X'1F', null)",
+		out: "insert into t(a, b) values (?, ?), (?, null)",
+	}, {
+		in:  "update t set a = 'b' where c = -1.5 and d = _binary 'x'",
+		out: "update t set a = ? where c = ? and d = _binary ?",
+	}, {
+		in:  "create user 'u'@'%' identified by 'secret'",
+		out: "create user ?@? identified by ?",
+	}}
+	for _, tcase := range tcases {
+		got, err := RedactSQLQuery(tcase.in)
+		assert.Nil(t, err, tcase.in)
+		assert.Equal(t, tcase.out, got, tcase.in)
+	}
+
+	_, err := RedactSQLQuery("select from")
+	assert.NotNil(t, err)
+}
@@ -5,9 +5,14 @@
 package sqlparser
 
 import (
+	"errors"
 	"fmt"
-	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGen(t *testing.T) {
@@ -51,6 +56,11 @@ func TestCreatTable2(t *testing.T) {
 	ID int primary key not null auto_increment,
 	LastName varchar(255),
 	FirstName varchar(255)
+)`
+	output := `create table t1 (
+	ID int not null primary key auto_increment,
+	LastName varchar(255),
+	FirstName varchar(255)
 )`
 	tree, err := Parse(sql)
 	if err != nil {
@@ -58,7 +68,7 @@ func TestCreatTable2(t *testing.T) {
 	}
 	s := String(tree)
 
-	assert.Equal(t, sql, s)
+	assert.Equal(t, output, s)
 }
 
 func TestCreatTable3(t *testing.T) {
@@ -66,6 +76,11 @@ func TestCreatTable3(t *testing.T) {
 	ID int unique key not null auto_increment,
 	LastName varchar(255),
 	FirstName varchar(255)
+)`
+	output := `create table t1 (
+	ID int not null unique key auto_increment,
+	LastName varchar(255),
+	FirstName varchar(255)
 )`
 	tree, err := Parse(sql)
 	if err != nil {
@@ -73,7 +88,7 @@ func TestCreatTable3(t *testing.T) {
 	}
 	s := String(tree)
 
-	assert.Equal(t, sql, s)
+	assert.Equal(t, output, s)
 }
 
 func TestCreatTable4(t *testing.T) {
@@ -90,12 +105,18 @@ func TestCreatTable4(t *testing.T) {
 	LastName varchar(255),
 	FirstName varchar(255),
 	Balance %s%s
+)`, p, precision)
+			output := fmt.Sprintf(`create table t1 (
+	ID int not null unique key auto_increment,
+	LastName varchar(255),
+	FirstName varchar(255),
+	Balance %s%s
 )`, p, precision)
 			tree, err := Parse(sql)
 			assert.Nil(t, err)
 			s := String(tree)
 
-			assert.Equal(t, sql, s)
+			assert.Equal(t, output, s)
 		}
 	}
 
@@ -112,12 +133,18 @@ func TestCreatTable4(t *testing.T) {
 	LastName varchar(255),
 	FirstName varchar(255),
 	Balance %s%s
+)`, p, length)
+			output := fmt.Sprintf(`create table t1 (
+	ID int not null unique key auto_increment,
+	LastName varchar(255),
+	FirstName varchar(255),
+	Balance %s%s
 )`, p, length)
 			tree, err := Parse(sql)
 			assert.Nil(t, err)
 			s := String(tree)
 
-			assert.Equal(t, sql, s)
+			assert.Equal(t, output, s)
 		}
 	}
 }
@@ -130,12 +157,353 @@ func TestCreatTable5(t *testing.T) {
 	LastName varchar(255),
 	FirstName varchar(255),
 	LastUpdated %s
+)`, time)
+		output := fmt.Sprintf(`create table t1 (
+	ID int not null unique key auto_increment,
+	LastName varchar(255),
+	FirstName varchar(255),
+	LastUpdated %s
 )`, time)
 		tree, err := Parse(sql)
 		assert.Nil(t, err, "fail to parse:\n%s", sql)
 		s := String(tree)
-		assert.Equal(t, sql, s)
+		assert.Equal(t, output, s)
+	}
+}
+
+func TestColumnTypes(t *testing.T) {
+	sql := `create table t1 (
+	a int(11) unsigned zerofill,
+	b decimal(32, 8),
+	c float(10),
+	d varchar(255) CHARSET utf8mb4,
+	e enum('a', 'b''c'),
+	f set('x', 'y'),
+	g datetime
+)`
+	tree, err := Parse(sql)
+	assert.Nil(t, err)
+	cols := tree.(*CreateTable).ColumnDefinitions
+
+	assert.Equal(t, ColumnType{Type: AST_INT, Length: "11", Unsigned: true, Zerofill: true}, cols[0].ColType)
+	assert.Equal(t, ColumnType{Type: AST_DECIMAL, Length: "32", Scale: "8"}, cols[1].ColType)
+	assert.Equal(t, ColumnType{Type: AST_FLOAT, Length: "10"}, cols[2].ColType)
+	assert.Equal(t, ColumnType{Type: AST_VARCHAR, Length: "255", Charset: "utf8mb4"}, cols[3].ColType)
+	assert.Equal(t, ColumnType{Type: AST_ENUM, EnumValues: []string{"a", "b'c"}}, cols[4].ColType)
+	assert.Equal(t, ColumnType{Type: AST_SET, EnumValues: []string{"x", "y"}}, cols[5].ColType)
+	assert.Equal(t, ColumnType{Type: AST_DATETIME}, cols[6].ColType)
+
+	assert.Equal(t, `create table t1 (
+	a int(11) unsigned zerofill,
+	b decimal(32, 8),
+	c float(10),
+	d varchar(255) character set utf8mb4,
+	e enum('a', 'b\'c'),
+	f set('x', 'y'),
+	g datetime
+)`, String(tree))
+
+	_, err = Parse("create table t1 (a foo('a'))")
+	assert.NotNil(t, err)
+}
+
+func TestIndexDefinitions(t *testing.T) {
+	sql := `create table t1 (
+	a int,
+	b varchar(255),
+	PRIMARY KEY (a),
+	constraint uk unique key b_idx (b(10) desc, a) using BTREE,
+	key (b),
+	index a_idx (a asc)
+)`
+	tree, err := Parse(sql)
+	assert.Nil(t, err)
+	indexes := tree.(*CreateTable).Indexes
+	assert.Equal(t, 4, len(indexes))
+
+	assert.Equal(t, AST_PRIMARY_KEY, indexes[0].Type)
+	assert.Equal(t, "a", indexes[0].Columns[0].Column.String())
+
+	assert.Equal(t, AST_UNIQUE_KEY, indexes[1].Type)
+	assert.Equal(t, "uk", indexes[1].Constraint.String())
+	assert.Equal(t, "b_idx", indexes[1].Name.String())
+	assert.Equal(t, &IndexColumn{Column: NewColIdent("b"), Length: "10", Direction: AST_DESC}, indexes[1].Columns[0])
+	assert.Equal(t, "btree", indexes[1].Using)
+
+	assert.Equal(t, AST_INDEX, indexes[2].Type)
+	assert.True(t, indexes[2].Name.IsEmpty())
+
+	assert.Equal(t, `create table t1 (
+	a int,
+	b varchar(255),
+	primary key (a),
+	constraint uk unique key b_idx (b(10) desc, a) using btree,
+	index (b),
+	index a_idx (a asc)
+)`, String(tree))
+
+	for _, sql := range []string{
+		"create table t1 (a int, primary key ())",
+		"create table t1 (a int, constraint c index (a))",
+		"create table t1 (a int, key a_idx)",
+	} {
+		_, err := Parse(sql)
+		assert.NotNil(t, err, sql)
+	}
+}
+
+func TestTableConstraintsAndOptions(t *testing.T) {
+	sql := `create table t1 (
+	a int default 0,
+	b int,
+	constraint fk_b foreign key b_idx (b) references t2 (id) on update no action on delete restrict
+) engine InnoDB, collate = utf8mb4_bin`
+	tree, err := Parse(sql)
+	assert.Nil(t, err)
+	create := tree.(*CreateTable)
+
+	assert.Equal(t, NumVal("0"), create.ColumnDefinitions[0].DefaultValue)
+
+	fk := create.Indexes[0]
+	assert.Equal(t, AST_FOREIGN_KEY, fk.Type)
+	assert.Equal(t, "fk_b", fk.Constraint.String())
+	assert.Equal(t, "b_idx", fk.Name.String())
+	assert.Equal(t, &References{
+		Table:    NewTableIdent("t2"),
+		Columns:  IndexColumns{{Column: NewColIdent("id")}},
+		OnDelete: AST_RESTRICT,
+		OnUpdate: AST_NO_ACTION,
+	}, fk.References)
+
+	assert.Equal(t, TableOptions{
+		{Name: AST_ENGINE, Value: "InnoDB"},
+		{Name: AST_COLLATE, Value: "utf8mb4_bin"},
+	}, create.Options)
+
+	assert.Equal(t, `create table t1 (
+	a int default 0,
+	b int,
+	constraint fk_b foreign key b_idx (b) references t2 (id) on delete restrict on update no action
+) engine=InnoDB collate=utf8mb4_bin`, String(tree))
+}
+
+func TestMysqldumpCreateTable(t *testing.T) {
+	sql := "CREATE TABLE `t1` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'the name',\n" +
+		"  `body` text,\n" +
+		"  `created_at` datetime(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),\n" +
+		"  `updated_at` timestamp(6) NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `name` (`name`),\n" +
+		"  KEY `created_at` (`created_at`),\n" +
+		"  FULLTEXT KEY `body` (`body`),\n" +
+		"  CONSTRAINT `t1_chk_1` CHECK ((`id` > 0))\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='things'\n" +
+		"PARTITION BY RANGE (`id`)\n(PARTITION p0 VALUES LESS THAN (10) ENGINE = InnoDB,\n PARTITION p1 VALUES LESS THAN MAXVALUE ENGINE = InnoDB)"
+	tree, err := Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := tree.(*CreateTable)
+
+	name := create.ColumnDefinitions[1]
+	assert.Equal(t, "the name", name.Comment)
+	created := create.ColumnDefinitions[3]
+	assert.Equal(t, ColumnType{Type: AST_DATETIME, Length: "6"}, created.ColType)
+	assert.Equal(t, "CURRENT_TIMESTAMP(6)", String(created.DefaultValue))
+	updated := create.ColumnDefinitions[4]
+	assert.Equal(t, "CURRENT_TIMESTAMP(6)", String(updated.OnUpdate))
+
+	assert.Equal(t, AST_FULLTEXT_KEY, create.Indexes[3].Type)
+	assert.Equal(t, "body", create.Indexes[3].Name.String())
+	check := create.Indexes[4]
+	assert.Equal(t, AST_CHECK, check.Type)
+	assert.Equal(t, "t1_chk_1", check.Constraint.String())
+	assert.Equal(t, "(id > 0)", String(check.Check))
+	assert.False(t, check.NotEnforced)
+
+	assert.Equal(t, "RANGE (`id`)\n(PARTITION p0 VALUES LESS THAN (10) ENGINE = InnoDB,\n PARTITION p1 VALUES LESS THAN MAXVALUE ENGINE = InnoDB)", create.Partitions)
+
+	want := "create table t1 (\n" +
+		"\tid bigint unsigned not null auto_increment,\n" +
+		"\tname varchar(255) collate utf8mb4_unicode_ci not null default '' comment 'the name',\n" +
+		"\tbody text,\n" +
+		"\tcreated_at datetime(6) not null default CURRENT_TIMESTAMP(6),\n" +
+		"\tupdated_at timestamp(6) null default CURRENT_TIMESTAMP(6) on update CURRENT_TIMESTAMP(6),\n" +
+		"\tprimary key (id),\n" +
+		"\tunique key name (name),\n" +
+		"\tindex created_at (created_at),\n" +
+		"\tfulltext key body (body),\n" +
+		"\tconstraint t1_chk_1 check ((id > 0))\n" +
+		") engine=InnoDB auto_increment=3 character set=utf8mb4 collate=utf8mb4_unicode_ci comment='things' " +
+		"partition by RANGE (`id`)\n(PARTITION p0 VALUES LESS THAN (10) ENGINE = InnoDB,\n PARTITION p1 VALUES LESS THAN MAXVALUE ENGINE = InnoDB)"
+	assert.Equal(t, want, String(tree))
+	again, err := Parse(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, want, String(again))
+}
+
+func TestAlterTable(t *testing.T) {
+	tree, err := Parse("alter table t add b int after a, add unique (b), change c d text first, rename column e to f, drop g")
+	assert.Nil(t, err)
+	alter := tree.(*AlterTable)
+	assert.Equal(t, "t", alter.Table.String())
+	assert.Equal(t, 5, len(alter.Specs))
+
+	specs := alter.Specs
+	assert.Equal(t, AST_ADD_COLUMN, specs[0].Action)
+	assert.Equal(t, "b", specs[0].Column.ColName)
+	assert.Equal(t, AST_INT, specs[0].Column.ColType.Type)
+	assert.Equal(t, "a", specs[0].After.String())
+
+	assert.Equal(t, AST_ADD_INDEX, specs[1].Action)
+	assert.Equal(t, AST_UNIQUE_KEY, specs[1].Index.Type)
+
+	assert.Equal(t, AST_CHANGE_COLUMN, specs[2].Action)
+	assert.Equal(t, "c", specs[2].Name.String())
+	assert.Equal(t, "d", specs[2].Column.ColName)
+	assert.True(t, specs[2].First)
+
+	assert.Equal(t, &AlterSpec{Action: AST_RENAME_COLUMN, Name: NewColIdent("e"), NewName: NewColIdent("f")}, specs[3])
+	assert.Equal(t, &AlterSpec{Action: AST_DROP_COLUMN, Name: NewColIdent("g")}, specs[4])
+}
+
+func TestDDL(t *testing.T) {
+	tree, err := Parse("drop table if exists t")
+	assert.Nil(t, err)
+	assert.Equal(t, &DDL{Action: AST_DROP, Kind: AST_TABLE, IfExists: true, Table: NewTableIdent("t"), Tables: []TableIdent{NewTableIdent("t")}}, tree)
+
+	tree, err = Parse("drop table if exists a, b, c")
+	assert.Nil(t, err)
+	assert.Equal(t, []TableIdent{NewTableIdent("a"), NewTableIdent("b"), NewTableIdent("c")}, tree.(*DDL).Tables)
+
+	tree, err = Parse("rename table a to b, c to d")
+	assert.Nil(t, err)
+	assert.Equal(t, []*TableRename{{From: NewTableIdent("a"), To: NewTableIdent("b")}, {From: NewTableIdent("c"), To: NewTableIdent("d")}}, tree.(*DDL).Renames)
+
+	tree, err = Parse("drop temporary table t")
+	assert.Nil(t, err)
+	assert.True(t, tree.(*DDL).Temporary)
+
+	tree, err = Parse("create temporary table if not exists t (a int)")
+	assert.Nil(t, err)
+	assert.True(t, tree.(*CreateTable).Temporary)
+	assert.True(t, tree.(*CreateTable).IfNotExists)
+
+	tree, err = Parse("truncate t")
+	assert.Nil(t, err)
+	assert.Equal(t, &DDL{Action: AST_TRUNCATE, Kind: AST_TABLE, Table: NewTableIdent("t")}, tree)
+
+	tree, err = Parse("create unique index a_idx on t (a)")
+	assert.Nil(t, err)
+	assert.Equal(t, &DDL{
+		Action:    AST_CREATE,
+		Kind:      AST_INDEX,
+		Table:     NewTableIdent("t"),
+		IndexName: NewColIdent("a_idx"),
+		Index: &IndexDefinition{
+			Type:    AST_UNIQUE_KEY,
+			Name:    NewColIdent("a_idx"),
+			Columns: IndexColumns{{Column: NewColIdent("a")}},
+		},
+	}, tree)
+
+	tree, err = Parse("create table if not exists t (a int)")
+	assert.Nil(t, err)
+	assert.True(t, tree.(*CreateTable).IfNotExists)
+
+	tree, err = Parse("create temporary table t (like db.u)")
+	assert.Nil(t, err)
+	assert.Equal(t, &CreateTable{
+		Temporary: true,
+		Name:      NewTableIdent("t"),
+		LikeTable: &TableName{Qualifier: NewTableIdent("db"), Name: NewTableIdent("u")},
+	}, tree)
+
+	tree, err = Parse("create table t (a int) engine=innodb select a from u")
+	assert.Nil(t, err)
+	create := tree.(*CreateTable)
+	assert.Len(t, create.ColumnDefinitions, 1)
+	assert.Len(t, create.Options, 1)
+	assert.Equal(t, "select a from u", String(create.Select))
+
+	for _, sql := range []string{
+		"create table t",
+		"create table t like",
+		"create table t like u (a int)",
+		"create table t (select a from u)",
+	} {
+		_, err = Parse(sql)
+		assert.Error(t, err, sql)
+	}
+}
+
+func TestColumnTypeClasses(t *testing.T) {
+	tree, err := Parse("create table t (a bigint(20) unsigned, b decimal(10, 2), c varchar(255) collate utf8mb4_bin, d enum('x', 'y'), e datetime on update current_timestamp, f float)")
+	if !assert.NoError(t, err) {
+		return
+	}
+	cols := tree.(*CreateTable).ColumnDefinitions
+	for _, tcase := range []struct {
+		numeric, textual, temporal bool
+		length, scale              int
+	}{
+		{numeric: true, length: 20},
+		{numeric: true, length: 10, scale: 2},
+		{textual: true, length: 255},
+		{textual: true},
+		{temporal: true},
+		{numeric: true},
+	} {
+		typ := cols[0].ColType
+		cols = cols[1:]
+		assert.Equal(t, tcase.numeric, typ.IsNumeric(), typ.Type)
+		assert.Equal(t, tcase.textual, typ.IsTextual(), typ.Type)
+		assert.Equal(t, tcase.temporal, typ.IsTemporal(), typ.Type)
+		length, scale := typ.Size()
+		assert.Equal(t, tcase.length, length, typ.Type)
+		assert.Equal(t, tcase.scale, scale, typ.Type)
 	}
+
+	cols = tree.(*CreateTable).ColumnDefinitions
+	assert.Equal(t, "current_timestamp()", String(cols[4].OnUpdate))
+	assert.Nil(t, cols[0].OnUpdate)
+}
+
+func TestColumnDefaults(t *testing.T) {
+	sql := `create table t1 (
+	a int,
+	b int null default null,
+	c varchar(10) not null default 'x',
+	d int default 0 not null
+)`
+	tree, err := Parse(sql)
+	assert.Nil(t, err)
+	cols := tree.(*CreateTable).ColumnDefinitions
+
+	assert.Equal(t, NullUnspecified, cols[0].Nullable)
+	assert.False(t, cols[0].HasDefault)
+	assert.Nil(t, cols[0].DefaultValue)
+
+	assert.Equal(t, NullAllowed, cols[1].Nullable)
+	assert.True(t, cols[1].HasDefault)
+	assert.Equal(t, &NullVal{}, cols[1].DefaultValue)
+
+	assert.Equal(t, NullNotAllowed, cols[2].Nullable)
+	assert.Equal(t, "'x'", String(cols[2].DefaultValue))
+
+	assert.Equal(t, NullNotAllowed, cols[3].Nullable)
+	assert.Equal(t, NumVal("0"), cols[3].DefaultValue)
+
+	assert.Equal(t, `create table t1 (
+	a int,
+	b int null default null,
+	c varchar(10) not null default 'x',
+	d int not null default 0
+)`, String(tree))
 }
 
 func BenchmarkParse1(b *testing.B) {
@@ -164,3 +532,1667 @@ type testCase struct {
 	input  string
 	output string
 }
+
+func TestParenExpr(t *testing.T) {
+	tree, err := Parse("select (a+b)*c from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expr := tree.(*Select).SelectExprs[0].(*NonStarExpr).Expr.(*BinaryExpr)
+	if _, ok := expr.Left.(*ParenExpr); !ok {
+		t.Errorf("got %T, want *ParenExpr", expr.Left)
+	}
+}
+
+func TestSetOperationPrecedence(t *testing.T) {
+	tree, err := Parse("select a from t union distinct select b from u intersect all select c from v")
+	assert.Nil(t, err)
+	union := tree.(*Union)
+	assert.Equal(t, AST_UNION_DISTINCT, union.Type)
+	assert.Equal(t, AST_INTERSECT_ALL, union.Right.(*Union).Type)
+
+	tree, err = Parse("select a from t intersect select b from u except all select c from v")
+	assert.Nil(t, err)
+	union = tree.(*Union)
+	assert.Equal(t, AST_EXCEPT_ALL, union.Type)
+	assert.Equal(t, AST_INTERSECT, union.Left.(*Union).Type)
+
+	tree, err = Parse("select a from t union select b from u except select c from v")
+	assert.Nil(t, err)
+	union = tree.(*Union)
+	assert.Equal(t, AST_EXCEPT, union.Type)
+	assert.Equal(t, AST_UNION, union.Left.(*Union).Type)
+}
+
+func TestWindowFunctions(t *testing.T) {
+	tree, err := Parse("select sum(a) over (w partition by b rows between 2 preceding and current row), sum(a) from t window w as (order by c)")
+	assert.Nil(t, err)
+	sel := tree.(*Select)
+	windowed := sel.SelectExprs[0].(*NonStarExpr).Expr.(*FuncExpr)
+	assert.False(t, windowed.IsAggregate())
+	assert.Equal(t, "w", windowed.Over.Name.String())
+	assert.Equal(t, AST_ROWS, windowed.Over.Frame.Unit)
+	assert.Equal(t, &FrameBound{Type: AST_PRECEDING, Expr: NumVal("2")}, windowed.Over.Frame.Start)
+	assert.Equal(t, &FrameBound{Type: AST_CURRENT_ROW}, windowed.Over.Frame.End)
+	assert.True(t, sel.SelectExprs[1].(*NonStarExpr).Expr.(*FuncExpr).IsAggregate())
+	assert.Equal(t, "w as (order by c asc)", String(sel.Windows[0]))
+}
+
+func TestWith(t *testing.T) {
+	tree, err := Parse("with recursive a(x) as (select 1 from dual), b as (select 2 from dual) select x from a union select * from b")
+	assert.Nil(t, err)
+	union := tree.(*Union)
+	assert.True(t, union.With.Recursive)
+	assert.Equal(t, 2, len(union.With.CTEs))
+	assert.Equal(t, "a(x) as (select 1 from dual)", String(union.With.CTEs[0]))
+	assert.Nil(t, union.Left.(*Select).With)
+
+	tree, err = Parse("with a as (select 1 from dual) select * from a")
+	assert.Nil(t, err)
+	with := tree.(*Select).With
+	assert.False(t, with.Recursive)
+	assert.Equal(t, "a", with.CTEs[0].Name.String())
+}
+
+func TestUnionClauses(t *testing.T) {
+	tree, err := Parse("select a from t1 union select a from t2 order by a limit 10")
+	assert.Nil(t, err)
+	union := tree.(*Union)
+	assert.Equal(t, " order by a asc", String(union.OrderBy))
+	assert.Equal(t, " limit 10", String(union.Limit))
+	right := union.Right.(*Select)
+	assert.Nil(t, right.OrderBy)
+	assert.Nil(t, right.Limit)
+
+	tree, err = Parse("(select a from t1 limit 1) union (select a from t2)")
+	assert.Nil(t, err)
+	union = tree.(*Union)
+	assert.Nil(t, union.Limit)
+	assert.Equal(t, " limit 1", String(union.Left.(*ParenSelect).Select.(*Select).Limit))
+}
+
+func TestConvertExpr(t *testing.T) {
+	tree, err := Parse("select cast(a as decimal(10, 2)), convert(b, char(4) charset latin1) from t")
+	assert.Nil(t, err)
+	exprs := tree.(*Select).SelectExprs
+	cast := exprs[0].(*NonStarExpr).Expr.(*ConvertExpr)
+	assert.Equal(t, AST_CAST, cast.Name)
+	assert.Equal(t, &ConvertType{Type: AST_DECIMAL, Length: "10", Scale: "2"}, cast.Type)
+	convert := exprs[1].(*NonStarExpr).Expr.(*ConvertExpr)
+	assert.Equal(t, AST_CONVERT, convert.Name)
+	assert.Equal(t, &ConvertType{Type: AST_CHAR, Length: "4", Charset: "latin1"}, convert.Type)
+}
+
+func TestMatchExpr(t *testing.T) {
+	tree, err := Parse("select a from t where match(title, body) against('db' in boolean mode)")
+	assert.Nil(t, err)
+	match := tree.(*Select).Where.Expr.(*MatchExpr)
+	assert.Equal(t, "(title, body)", String(match.Columns))
+	assert.Equal(t, StrVal{Val: "db", Quote: '\''}, match.Expr)
+	assert.Equal(t, AST_BOOLEAN_MODE, match.Option)
+}
+
+func TestGroupBy(t *testing.T) {
+	tree, err := Parse("select a from t group by a, rollup(b, c), grouping sets ((a, b), ()) with rollup")
+	assert.Nil(t, err)
+	sel := tree.(*Select)
+	assert.True(t, sel.WithRollup)
+	rollup := sel.GroupBy[1].(*NonStarExpr).Expr.(*GroupingExpr)
+	assert.Equal(t, AST_ROLLUP, rollup.Type)
+	assert.Equal(t, "b, c", String(rollup.Exprs))
+	sets := sel.GroupBy[2].(*NonStarExpr).Expr.(*GroupingExpr)
+	assert.Equal(t, AST_GROUPING_SETS, sets.Type)
+	assert.Equal(t, ValExprs{ValTuple{&ColName{Name: NewColIdent("a")}, &ColName{Name: NewColIdent("b")}}, ValTuple{}}, sets.Exprs)
+}
+
+func TestValid(t *testing.T) {
+	for _, tcase := range validSQL {
+		if tcase.output == "" {
+			tcase.output = tcase.input
+		}
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q) err: %v", tcase.input, err)
+			continue
+		}
+		if out := String(tree); out != tcase.output {
+			t.Errorf("Parse(%q) = %q, want %q", tcase.input, out, tcase.output)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	for _, sql := range []string{"", " ", ";", " ;; ", "/* comment */", "-- comment\n;"} {
+		tree, err := Parse(sql)
+		if err != nil {
+			t.Errorf("Parse(%q) err: %v", sql, err)
+			continue
+		}
+		if tree != nil {
+			t.Errorf("Parse(%q): %v, want nil", sql, String(tree))
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	for _, sql := range invalidSQL {
+		if _, err := Parse(sql); err == nil {
+			t.Errorf("Parse(%q): want error", sql)
+		}
+	}
+}
+
+var invalidSQL = []string{
+	"select * from t where a = all (1, 2)",
+	"update delayed t set a = 1",
+	"insert ignore low_priority into t values (1)",
+	"delete high_priority from t",
+	"select * from t for update wait",
+	"select * from t for share skip",
+	"select doc->a from t",
+	"select doc->>1 from t",
+	"set global @@x = 1",
+	"set @@foo.x = 1",
+	"set @ = 1",
+	"select 1; select 2",
+	"select `a from t",
+	"select `` from t",
+	"select convert(a using) from t",
+	"select next value for from dual",
+	"create sequence s start",
+	"create sequence s increment by",
+	"create sequence s foo 1",
+	"create sequences s",
+	"drop sequence s cycle",
+	"alter sequence s no",
+	"select * from t for system_time as 1",
+	"select * from t for system_time",
+	"select * from t partition ()",
+	"insert into t partition values (1)",
+	"select a from t as x qualify",
+	"select * from t pivot (sum(a) for b)",
+	"select * from t unpivot (sum(a) for b in (c))",
+	"select sql_cache sql_no_cache a from t",
+	"select sql_small_result sql_big_result a from t",
+	"select max_statement_time = a from t",
+	"shut c",
+	"fetch prev from c into a",
+	"declare c cursor for insert into t values (1)",
+	"fetch c",
+	"begin select 1 from dual end",
+	"declare continue foo for sqlexception set a = 1",
+	"if a = 1 then end",
+	"declare exit handler for not exists set a = 1",
+	"while a do end loop",
+	"create index a_idx on t",
+	"create index a_idx on t (a) bogus",
+	"create index a_idx on t (a) a000000 ''",
+	"create table t (a int) foo bar",
+	"alter table t foo = 1",
+	"drop database",
+	"signal",
+	"signal sqlstate 45000",
+	"signal sqlstate '45000' set foo = 1",
+	"resignal set message_text",
+	"select now() - interval 1 fortnight from dual",
+	"with a select 1 from dual",
+	"with a as select 1 from dual",
+	"with select 1 from dual",
+	"select sum(a) over (rows between 1 after and current row) from t",
+	"select sum(a) over (rows current rows) from t",
+	"select sum(a) over (rows a preceding) from t",
+	"select sum(a) over from t",
+	"alter table t add a int last",
+	"alter table t modify a int after",
+	"create table t (a int, foreign key (a) references u (b) on delete nothing)",
+	"set transaction isolation level bogus",
+	"start foo",
+	"commitx",
+	"show create foo x",
+	"show columns",
+	"show tables from db.x",
+	"show status from t from d",
+	"show `my thing`",
+	"show `tables` from db",
+	"select cast(a as unsigned char) from t",
+	"select cast(a, char) from t",
+	"select convert(a) from t",
+	"select a - interval 1 hour_day from t",
+	"select a from t where b is maybe",
+	"select a from t where a like b escape",
+	"select a from t where a sounds b",
+	"select a from t where match(a) against('x' in foo mode)",
+	"select a from t where match(a) for('x')",
+	"select X'1' from t",
+	"select b'102' from t",
+	"select X'1F from t",
+	"select _binary from t",
+	"select a collate from t",
+	"select a, next 10 values from seq",
+	"select next 10 values from seq where a = 1",
+	"select next 1.5 from seq",
+	"select _utf8 from t",
+	"select a from t with rollup",
+	"select a from t group by a with cube",
+	"select a from t group by grouping sets ()",
+	"load table infile 'x' into table t",
+	"load data high_priority infile 'x' into table t",
+	"load data infile 'x' merge into table t",
+	"load data infile 'x' into table t fields",
+	"load data infile 'x' into table t lines",
+	"load data infile 'x' into table t rows terminated by ','",
+	"select @",
+	"select @`` from t",
+	"set global @a = 1",
+	"set global names utf8",
+	"set session character set utf8",
+	"set names",
+	"set foo utf8",
+	"select * from a join b using ()",
+	"select * from a join b using x",
+	"select * from a join b on a.x = b.x using (x)",
+	"select * from (select 1) as t()",
+	"select * from lateral t",
+	"select * from json_table(doc, '$' columns ()) as j",
+	"select * from json_table(doc, '$' columns (a int path '$' error on error null on empty)) as j",
+	"select * from json_table(doc, '$' columns (a for rank)) as j",
+	"select * from json_table(doc, '$' fields (a int path '$')) as j",
+	"values foo(1)",
+	"prepare s from 1",
+	"execute s using a",
+	"deallocate s",
+	"call p(",
+	"create procedure p",
+	"create view v",
+	"create or update view v as select 1",
+	"truncate table",
+	"rename table a to b,",
+	"create temp table t (a int)",
+	"grant select on t",
+	"grant select on event e to u",
+	"set password = 1",
+	"delete t1 from t1 join t2 on t1.id = t2.id limit 1",
+}
+
+var validSQL = []struct {
+	input  string
+	output string
+}{{
+	input: "select (a+b)*c from t",
+}, {
+	input: "select a from t where a = (b)",
+}, {
+	input: "select ((a+b), c) from t",
+}, {
+	input: "select a from t where a in (1)",
+}, {
+	input: "insert into t values (1)",
+}, {
+	input: "select MyCol as `select`, T.* from Db.T as `from` use index (Idx) where T.`order` = 1",
+}, {
+	input:  "select if(a, 1, 2) from t lock in SHARE MODE",
+	output: "select if(a, 1, 2) from t lock in share mode",
+}, {
+	input: "create table t like db.u",
+}, {
+	input:  "create table t (like u)",
+	output: "create table t like u",
+}, {
+	input:  "create table if not exists t select a, b from u where c = 1",
+	output: "create table if not exists t as select a, b from u where c = 1",
+}, {
+	input: "create table t engine=InnoDB as (select a from u) union (select b from v)",
+}, {
+	input:  "create table t (a int, primary key (a)) engine=innodb as select a from u",
+	output: "create table t (\n\ta int,\n\tprimary key (a)\n) engine=innodb as select a from u",
+}, {
+	input:  "select a from t limit 10 OFFSET 20 for share",
+	output: "select a from t limit 20, 10 lock in share mode",
+}, {
+	input: "set a = 1, global max_connections = 100, @@session.sql_mode = 'ansi', @@autocommit = 0, @x := a+1",
+}, {
+	input: "select session, global, local from local where session = 1",
+}, {
+	input: "set session = 1, local = 2",
+}, {
+	input: "insert into global(local) values (1)",
+}, {
+	input:  "set @@GLOBAL.x = (1)",
+	output: "set @@global.x = (1)",
+}, {
+	input: "select 1",
+}, {
+	input: "select `a``b`, `my col`, `1`, `1a`, a1, `a-b`, @x from t.`table` as `a.b`",
+}, {
+	input:  "select `a` from `t`",
+	output: "select a from t",
+}, {
+	input: "select now() where 1 = 1 union select 2",
+}, {
+	input: "select 1 from dual",
+}, {
+	input:  "select 1;",
+	output: "select 1",
+}, {
+	input:  ";select 1 from t;; ",
+	output: "select 1 from t",
+}, {
+	input: `select 'it''s', "say ""hi""", "it's", 'say "hi"', 'it\'s', 'a\nb' from t`,
+}, {
+	input: "select convert(a using utf8mb4) from t",
+}, {
+	input:  "select CONVERT(a, b) from t",
+	output: "select convert(a, b) from t",
+}, {
+	input: "select * from t where convert(name using latin1) = 'x'",
+}, {
+	input:  "insert into t(id, a) values (NEXT VALUE FOR db.s, 1)",
+	output: "insert into t(id, a) values (next value for db.s, 1)",
+}, {
+	input: "select next, value from t",
+}, {
+	input:  "select next value from t",
+	output: "select next as value from t",
+}, {
+	input:  "select `next` `value` from t",
+	output: "select next as value from t",
+}, {
+	input: "select next value for s from dual",
+}, {
+	input: "select next 10 values from seq",
+}, {
+	input:  "SELECT /* c */ NEXT :n VALUES FROM ks.seq",
+	output: "select /* c */ next :n values from ks.seq",
+}, {
+	input:  "select next ? values from seq",
+	output: "select next :v1 values from seq",
+}, {
+	input:  "select a from t where b = any (?) and c = ?",
+	output: "select a from t where b = any(:v1) and c = :v2",
+}, {
+	input:  "CREATE SEQUENCE s START WITH 1 INCREMENT BY 2 MINVALUE 1 MAXVALUE 100 CACHE 10 CYCLE",
+	output: "create sequence s start with 1 increment by 2 minvalue 1 maxvalue 100 cache 10 cycle",
+}, {
+	input:  "create sequence if not exists db.s start = -5 increment -1 nominvalue nomaxvalue nocache nocycle",
+	output: "create sequence if not exists db.s start with -5 increment by -1 no minvalue no maxvalue nocache no cycle",
+}, {
+	input: "create sequence s",
+}, {
+	input:  "alter sequence s restart",
+	output: "alter sequence s restart",
+}, {
+	input:  "alter sequence s restart = 10 no cycle no cache",
+	output: "alter sequence s restart with 10 no cycle nocache",
+}, {
+	input: "drop sequence if exists s",
+}, {
+	input: "drop sequence db.s",
+}, {
+	input: "select * from t for system_time as of '2016-10-09 08:07:06'",
+}, {
+	input:  "select * from t FOR SYSTEM_TIME AS OF now() - 1 AS x where a = 1",
+	output: "select * from t for system_time as of now()-1 as x where a = 1",
+}, {
+	input: "select * from db.t for system_time from '2016-01-01' to '2017-01-01' as x join u on x.id = u.id",
+}, {
+	input: "select * from t for system_time between :a and :b",
+}, {
+	input: "select * from t for system_time all",
+}, {
+	input: "select * from t where a = 1 for update",
+}, {
+	input: "select * from t for update",
+}, {
+	input: "select * from t partition (p0, p1)",
+}, {
+	input: "select * from t partition (p0) as x use index (i) where a = 1",
+}, {
+	input: "select * from t partition (p0) for system_time all as x",
+}, {
+	input: "insert into t partition (p2) values (1)",
+}, {
+	input: "insert into t partition (p2, p3)(a, b) values (1, 2)",
+}, {
+	input:  "insert into t partition (p2) set a = 1",
+	output: "insert into t partition (p2)(a) values (1)",
+}, {
+	input: "select a, row_number(b) as rn from t qualify rn = 1",
+}, {
+	input: "select qualify from qualify where qualify = 1",
+}, {
+	input: "select a from t as qualify qualify a > (select qualify from u)",
+}, {
+	input: "select a, count(*) from t group by a having count(*) > 1 qualify rank(a) <= 3 order by a asc limit 10",
+}, {
+	input: "select `a``b`(a), `my func`(a), `0`(a), if(a, 1, 2) from t",
+}, {
+	input:  "select `lower`(a) from t",
+	output: "select lower(a) from t",
+}, {
+	input: "select * from sales pivot (sum(amount) as total for quarter in ('Q1', 'Q2' as second)) as p",
+}, {
+	input:  "select * from sales PIVOT (sum(amount), count(*) FOR s.quarter IN (1, 2)) p where p.a = 1",
+	output: "select * from sales pivot (sum(amount), count(*) for s.quarter in (1, 2)) as p where p.a = 1",
+}, {
+	input: "select * from (select a, b from t) as s pivot (max(b) for a in (1, 2))",
+}, {
+	input: "select * from a join b on a.id = b.id pivot (sum(x) for y in (1))",
+}, {
+	input: "select * from t unpivot (amount for quarter in (q1, q2, q3)) as u",
+}, {
+	input: "select * from t unpivot (amount for quarter in (q1)) join u on t.a = u.a",
+}, {
+	input: "select pivot, unpivot(a) from pivot as unpivot where pivot.a = 1",
+}, {
+	input: "select sql_no_cache * from t",
+}, {
+	input: "select distinct sql_cache a from t",
+}, {
+	input:  "select SQL_NO_CACHE DISTINCT a from t",
+	output: "select distinct sql_no_cache a from t",
+}, {
+	input:  "select max_statement_time=1000 sql_cache a from t",
+	output: "select max_statement_time = 1000 sql_cache a from t",
+}, {
+	input: "select /*+ MAX_EXECUTION_TIME(1000) */ sql_no_cache a from t",
+}, {
+	input: "select distinctrow high_priority straight_join sql_small_result sql_buffer_result sql_no_cache sql_calc_found_rows a from t",
+}, {
+	input:  "select SQL_CALC_FOUND_ROWS STRAIGHT_JOIN SQL_BIG_RESULT a from t straight_join u limit 10",
+	output: "select straight_join sql_big_result sql_calc_found_rows a from t straight_join u limit 10",
+}, {
+	input: "declare c cursor for select a, b from t where x = 1",
+}, {
+	input:  "OPEN c",
+	output: "open c",
+}, {
+	input: "close c",
+}, {
+	input: "fetch c into a, b",
+}, {
+	input:  "fetch next from c into @a",
+	output: "fetch c into @a",
+}, {
+	input:  "fetch from c into a",
+	output: "fetch c into a",
+}, {
+	input: "select open, close from t",
+}, {
+	input: "begin end",
+}, {
+	input: "begin select 1 from dual; end",
+}, {
+	input:  "BEGIN DECLARE a, b INT DEFAULT 0; DECLARE done int; SET a = 1; END",
+	output: "begin declare a, b int default 0; declare done int; set a = 1; end",
+}, {
+	input: "if a = 1 then select 1 from dual; elseif a = 2 then select 2 from dual; else end if",
+}, {
+	input: "if a = 1 then end if",
+}, {
+	input: "outer_loop: while i < 10 do set i = i+1; if i = 5 then leave outer_loop; end if; end while outer_loop",
+}, {
+	input: "l: loop iterate l; end loop",
+}, {
+	input: "repeat fetch c into a; until done = 1 end repeat",
+}, {
+	input: "begin declare continue handler for not found set done = 1; declare exit handler for sqlexception, sqlwarning, 1062, sqlstate '23000', my_cond begin rollback_work: begin end; end; end",
+}, {
+	input:  "declare exit handler for sqlstate value '45000' set x = 1",
+	output: "declare exit handler for sqlstate '45000' set x = 1",
+}, {
+	input: "begin declare c cursor for select a from t; open c; fetch c into x; close c; end",
+}, {
+	input: "lbl: begin begin end; end lbl",
+}, {
+	input: "select begin from begin where begin.begin = 1",
+}, {
+	input: "begin select begin from t; if begin = 1 then begin end; end if; end",
+}, {
+	input: "insert into t(begin) values (1)",
+}, {
+	input: "select modify, charset from modify where charset = 'utf8'",
+}, {
+	input: "update charset set modify = 1, charset = 2",
+}, {
+	input:  "alter table modify modify column charset varchar(10) charset utf8",
+	output: "alter table modify modify column charset varchar(10) character set utf8",
+}, {
+	input: "create table if not exists t (\n\ta int\n)",
+}, {
+	input:  "create table t (`my col` int, `order` int, `a``b` int)",
+	output: "create table t (\n\t`my col` int,\n\t`order` int,\n\t`a``b` int\n)",
+}, {
+	input:  "alter table t add column `my col` int, modify `order` bigint",
+	output: "alter table t add column `my col` int, modify column `order` bigint",
+}, {
+	input: "create unique index a_idx using btree on t (a(10), b desc)",
+}, {
+	input:  "create index a_idx on t (a) algorithm = inplace",
+	output: "create index a_idx on t (a) algorithm=inplace",
+}, {
+	input:  "create index a_idx on t (a) using btree key_block_size 8 comment 'x' invisible with parser ngram lock default algorithm = 'copy'",
+	output: "create index a_idx using btree on t (a) key_block_size=8 comment 'x' invisible with parser ngram lock=default algorithm=copy",
+}, {
+	input:  "create index a_idx on t (a) comment '' engine_attribute '' SECONDARY_ENGINE_ATTRIBUTE '{}' algorithm = ''",
+	output: "create index a_idx on t (a) comment '' engine_attribute='' secondary_engine_attribute='{}' algorithm=''",
+}, {
+	input: "create view v as select * from t",
+}, {
+	input:  "CREATE OR REPLACE VIEW v (a, b) AS SELECT x, y FROM t WITH LOCAL CHECK OPTION",
+	output: "create or replace view v(a, b) as select x, y from t with local check option",
+}, {
+	input: "create view v as select a from t group by a with check option",
+}, {
+	input:  "create database if not exists db character set utf8",
+	output: "create database if not exists db",
+}, {
+	input:  "create schema db",
+	output: "create database db",
+}, {
+	input: "alter ignore table t add column a int",
+}, {
+	input:  "alter table t add a varchar(10) not null after b, add index a_idx (a)",
+	output: "alter table t add column a varchar(10) not null after b, add index a_idx (a)",
+}, {
+	input: "alter table t add column a int first",
+}, {
+	input: "alter table t add constraint pk primary key (a, b)",
+}, {
+	input:  "alter table t drop a, drop key a_idx, drop primary key",
+	output: "alter table t drop column a, drop index a_idx, drop primary key",
+}, {
+	input:  "alter table t modify a bigint unsigned",
+	output: "alter table t modify column a bigint unsigned",
+}, {
+	input:  "alter table t change column a b char(3) charset utf8 after c",
+	output: "alter table t change column a b char(3) character set utf8 after c",
+}, {
+	input: "alter table t rename column a to b, rename index a_idx to b_idx",
+}, {
+	input:  "alter table t engine = innodb",
+	output: "alter table t engine=innodb",
+}, {
+	input:  "alter table t add a int, engine InnoDB, default charset = utf8mb4, collate utf8mb4_bin, auto_increment = 10, comment = 'x y'",
+	output: "alter table t add column a int, engine=InnoDB, character set=utf8mb4, collate=utf8mb4_bin, auto_increment=10, comment='x y'",
+}, {
+	input:  "alter table t charset 0, default character set 'utf8mb4', collate 'my collation', row_format = dynamic",
+	output: "alter table t character set=0, character set=utf8mb4, collate='my collation', row_format=dynamic",
+}, {
+	input:  "create table t (a int comment 'x', b int, check (a > b) not enforced, constraint check (b > 0) enforced, spatial index (b)) comment 'y'",
+	output: "create table t (\n\ta int comment 'x',\n\tb int,\n\tcheck (a > b) not enforced,\n\tcheck (b > 0),\n\tspatial key (b)\n) comment='y'",
+}, {
+	input:  "alter table t comment 'x', add b time(3) comment 'y' first, add fulltext index ft (c), add d int",
+	output: "alter table t comment='x', add column b time(3) comment 'y' first, add fulltext key ft (c), add column d int",
+}, {
+	input: "create index i on t (a) comment 'x'",
+}, {
+	input:  "alter table t drop partition p1",
+	output: "alter table t",
+}, {
+	input:  "alter table t order by a",
+	output: "alter table t",
+}, {
+	input:  "alter table t rename as u",
+	output: "rename table t to u",
+}, {
+	input: "rename table t to u",
+}, {
+	input: "alter view v",
+}, {
+	input: "drop table if exists t",
+}, {
+	input: "drop index a_idx on t",
+}, {
+	input: "drop view if exists v",
+}, {
+	input: "drop database if exists db",
+}, {
+	input:  "drop schema db",
+	output: "drop database db",
+}, {
+	input:  "analyze table t",
+	output: "alter table t",
+}, {
+	input: "select database(), schema() from dual",
+}, {
+	input: "signal sqlstate '45000'",
+}, {
+	input:  "SIGNAL SQLSTATE VALUE '45000' SET MESSAGE_TEXT = 'bad value', MYSQL_ERRNO = 1644",
+	output: "signal sqlstate '45000' set message_text = 'bad value', mysql_errno = 1644",
+}, {
+	input: "signal not_found set message_text = concat('missing ', a)",
+}, {
+	input: "resignal",
+}, {
+	input: "resignal set message_text = 'wrapped'",
+}, {
+	input: "resignal sqlstate '23000'",
+}, {
+	input: "begin declare exit handler for sqlexception begin resignal; end; signal sqlstate '45000'; end",
+}, {
+	input: "select a from t union distinct select b from u",
+}, {
+	input: "select a from t except all select b from u",
+}, {
+	input: "select a from t except distinct select b from u",
+}, {
+	input: "select a from t intersect all select b from u",
+}, {
+	input: "select a from t intersect distinct select b from u",
+}, {
+	input: "select * from t ASOF '2020-01-01' where a = 1",
+}, {
+	input: "select * from t ASOF '2020-01-01' UNTIL '2020-02-01'",
+}, {
+	input:  "select * from t asof now()-interval 1 hour until now() where a = 1",
+	output: "select * from t ASOF now()-interval 1 hour UNTIL now() where a = 1",
+}, {
+	input: "select * from t ASOF :from UNTIL :to",
+}, {
+	input:  "select now() - INTERVAL 30 MINUTE from dual",
+	output: "select now()-interval 30 minute from dual",
+}, {
+	input:  "select date_add(a, interval 2 year) from t",
+	output: "select date_add(a, interval 2 year) from t",
+}, {
+	input: "with cte as (select a from t) select * from cte",
+}, {
+	input:  "WITH RECURSIVE cte (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM cte WHERE n < 5) SELECT n FROM cte",
+	output: "with recursive cte(n) as (select 1 union all select n+1 from cte where n < 5) select n from cte",
+}, {
+	input: "with a as (select 1 from dual), b(x, y) as (select 1, 2 from dual) select * from a join b",
+}, {
+	input: "with a as (select 1 from dual) select * from a union select * from b",
+}, {
+	input: "select * from (with a as (select 1 from dual) select * from a) as t",
+}, {
+	input: "select * from t where a in (with b as (select c from d) select c from b)",
+}, {
+	input:  "create sequence s start with 1",
+	output: "create sequence s start with 1",
+}, {
+	input:  "select ROW_NUMBER() OVER (PARTITION BY a ORDER BY b) from t",
+	output: "select ROW_NUMBER() over (partition by a order by b asc) from t",
+}, {
+	input: "select rank() over () from t",
+}, {
+	input: "select sum(a) over w from t window w as (partition by b)",
+}, {
+	input: "select sum(a) over (w order by c desc rows unbounded preceding) from t window w as (partition by b), w2 as (w)",
+}, {
+	input:  "select avg(a) over (order by b rows between 1 preceding and current row) from t",
+	output: "select avg(a) over (order by b asc rows between 1 preceding and current row) from t",
+}, {
+	input:  "select count(distinct a) over (partition by b, c range between interval 1 day preceding and unbounded following) from t",
+	output: "select count(distinct a) over (partition by b, c range between interval 1 day preceding and unbounded following) from t",
+}, {
+	input:  "select lag(a, 1) over (order by b asc rows between :x following and ? following) from t",
+	output: "select lag(a, 1) over (order by b asc rows between :x following and :v1 following) from t",
+}, {
+	input:  "create table t (a int default -1, b timestamp default current_timestamp(), c varchar(10) charset utf8mb4 collate utf8mb4_bin default 'x')",
+	output: "create table t (\n\ta int default -1,\n\tb timestamp default current_timestamp(),\n\tc varchar(10) character set utf8mb4 collate utf8mb4_bin default 'x'\n)",
+}, {
+	input:  "create table t (a timestamp not null default current_timestamp on update current_timestamp, b datetime on update now() null, c datetime default localtimestamp(6) on update LOCALTIME)",
+	output: "create table t (\n\ta timestamp not null default current_timestamp() on update current_timestamp(),\n\tb datetime null on update now(),\n\tc datetime default localtimestamp(6) on update LOCALTIME()\n)",
+}, {
+	input:  "create table t (a int) ENGINE=InnoDB AUTO_INCREMENT=10 DEFAULT CHARSET=utf8mb4 comment 'it''s t'",
+	output: "create table t (\n\ta int\n) engine=InnoDB auto_increment=10 character set=utf8mb4 comment='it\\'s t'",
+}, {
+	input: "alter table t add constraint fk foreign key (a) references u (b) on delete cascade on update set null, drop foreign key fk0",
+}, {
+	input: "begin",
+}, {
+	input:  "start transaction",
+	output: "begin",
+}, {
+	input: "start transaction read only",
+}, {
+	input: "commit",
+}, {
+	input:  "commit work",
+	output: "commit",
+}, {
+	input: "rollback",
+}, {
+	input:  "rollback work",
+	output: "rollback",
+}, {
+	input: "rollback to x",
+}, {
+	input:  "rollback to savepoint x",
+	output: "rollback to x",
+}, {
+	input:  "rollback work to savepoint x",
+	output: "rollback to x",
+}, {
+	input: "savepoint x",
+}, {
+	input:  "RELEASE SAVEPOINT x",
+	output: "release savepoint x",
+}, {
+	input: "set transaction isolation level read committed",
+}, {
+	input: "set global transaction isolation level serializable, read write",
+}, {
+	input:  "SET SESSION TRANSACTION READ ONLY",
+	output: "set session transaction read only",
+}, {
+	input: "show tables",
+}, {
+	input: "show full tables from db like 'a%'",
+}, {
+	input:  "show fields in t in db where field = 'x'",
+	output: "show columns from db.t where field = 'x'",
+}, {
+	input:  "show keys from t",
+	output: "show index from t",
+}, {
+	input: "show global status like 'x'",
+}, {
+	input: "show engine innodb status",
+}, {
+	input: "show table status from db",
+}, {
+	input: "show character set",
+}, {
+	input: "show create table db.t",
+}, {
+	input: "show create procedure p",
+}, {
+	input:  "desc db.t",
+	output: "describe db.t",
+}, {
+	input:  "explain t",
+	output: "describe t",
+}, {
+	input:  "explain format = JSON select a from t",
+	output: "explain format = json select a from t",
+}, {
+	input:  "describe delete from t",
+	output: "explain delete from t",
+}, {
+	input:  "(select a from t1) union (select a from t2) order by a limit 10",
+	output: "(select a from t1) union (select a from t2) order by a asc limit 10",
+}, {
+	input:  "(select a from t1 order by a limit 1) union all (select b from t2)",
+	output: "(select a from t1 order by a asc limit 1) union all (select b from t2)",
+}, {
+	input:  "select a from t1 union select a from t2 order by a limit 10",
+	output: "select a from t1 union select a from t2 order by a asc limit 10",
+}, {
+	input: "(select a from t1) union (select b from t2) union (select c from t3) for update",
+}, {
+	input: "select a from t1 union (select b from t2) intersect select c from t3 limit 1",
+}, {
+	input: "insert into x (select a from t1) union (select b from t2)",
+}, {
+	input: "select cast(a as char) from t",
+}, {
+	input:  "select CAST(a AS SIGNED INTEGER) from t",
+	output: "select cast(a as signed) from t",
+}, {
+	input:  "select cast(a as unsigned int) from t",
+	output: "select cast(a as unsigned) from t",
+}, {
+	input: "select convert(a, char(10) character set utf8mb4) from t",
+}, {
+	input:  "select convert(a, decimal(10,2)) from t",
+	output: "select convert(a, decimal(10, 2)) from t",
+}, {
+	input: "select cast(a as datetime(6)), cast(b as json), cast(c as binary(4)) from t",
+}, {
+	input: "select cast from t",
+}, {
+	input:  "select a from t where ts > NOW() - INTERVAL 30 MINUTE",
+	output: "select a from t where ts > NOW()-interval 30 minute",
+}, {
+	input: "select date_add(a, interval '1:2' hour_minute) from t",
+}, {
+	input:  "select a + interval '1 2' DAY_HOUR, a - interval '1-2' year_month from t",
+	output: "select a+interval '1 2' day_hour, a-interval '1-2' year_month from t",
+}, {
+	input: "select date_sub(a, interval '1.000002' second_microsecond) from t",
+}, {
+	input: "select true, false from t where a = true",
+}, {
+	input:  "select a from t where b IS TRUE and c is not FALSE and d is Unknown and e is not unknown",
+	output: "select a from t where b is true and c is not false and d is unknown and e is not unknown",
+}, {
+	input: "select a from t where (a = 1) is true",
+}, {
+	input: "select a from t where a = 1 is not false",
+}, {
+	input: "select a from t where (a = 1 or b = 2) is unknown",
+}, {
+	input: "select a from t where not a is true and b in (1, 2) is false",
+}, {
+	input: "select a from t where a like 'x!%' escape '!'",
+}, {
+	input:  `select a from t where a like 'a\_%' and b like "50\%" and c = 'x\\_\n'`,
+	output: `select a from t where a like 'a\_%' and b like "50\%" and c = 'x\_\n'`,
+}, {
+	input: "select escape from escape where escape like escape escape escape",
+}, {
+	input:  "select a from t where a not like b escape '|' and c rlike 'x' and d not regexp 'y'",
+	output: "select a from t where a not like b escape '|' and c regexp 'x' and d not regexp 'y'",
+}, {
+	input:  "select a from t where a SOUNDS  LIKE b",
+	output: "select a from t where a sounds like b",
+}, {
+	input: "select sounds from t",
+}, {
+	input:  "SELECT a FROM t WHERE MATCH (title, body) AGAINST ('+db -mysql' IN BOOLEAN MODE)",
+	output: "select a from t where match(title, body) against('+db -mysql' in boolean mode)",
+}, {
+	input: "select match(a) against('x') as score from t where match(a) against('x' in natural language mode) > 0.5 order by match(a) against('x' with query expansion) desc",
+}, {
+	input: "select a from t where match(t.a, b) against(:q in natural language mode with query expansion) and b = 1",
+}, {
+	input:  "select X'1F', x'', b'1010', B'', 0b101, 0x1f from t",
+	output: "select X'1F', X'', b'1010', b'', 0b101, 0x1f from t",
+}, {
+	input: "select 010, 09, 08.5 from t",
+}, {
+	input:  "select - -a, - -1, -(-1) from t",
+	output: "select - -a, 1, -(-1) from t",
+}, {
+	input:  "select a - -1, a - -b, a - -1 * b, a - - -b, a - (-1 - b), a + -1 from t where a - -1 > 0",
+	output: "select a- -1, a- -b, a- -1*b, a- - -b, a-(-1-b), a+-1 from t where a- -1 > 0",
+}, {
+	input: "select _binary 'abc', _binary X'00' from t where a = _binary 'x'",
+}, {
+	input:  "select _utf8'abc', _UTF8MB4 'x' collate utf8mb4_bin from t where a collate latin1_bin = 'x' order by b collate utf8mb4_unicode_ci desc",
+	output: "select _utf8 'abc', _utf8mb4 'x' collate utf8mb4_bin from t where a collate latin1_bin = 'x' order by b collate utf8mb4_unicode_ci desc",
+}, {
+	input:  "select a, b from t group by 2, a collate latin1_bin order by 1 desc, b collate utf8mb4_bin",
+	output: "select a, b from t group by 2, a collate latin1_bin order by 1 desc, b collate utf8mb4_bin asc",
+}, {
+	input:  "select -a collate x, (a + b) collate y, a + b collate y from t",
+	output: "select -a collate x, (a+b) collate y, a+b collate y from t",
+}, {
+	input:  "select `_utf8` from t",
+	output: "select `_utf8` from t",
+}, {
+	input: "select _foo from t",
+}, {
+	input: "select a, b, sum(c) from t group by a, b with rollup",
+}, {
+	input:  "select a from t group by ROLLUP(a, b), cube(c)",
+	output: "select a from t group by rollup(a, b), cube(c)",
+}, {
+	input:  "select a from t group by grouping sets ((a, b), (a), (), c) having a > 1",
+	output: "select a from t group by grouping sets((a, b), (a), (), c) having a > 1",
+}, {
+	input: "select grouping, rollup(a) from t",
+}, {
+	input: "load data infile '/tmp/t.csv' into table t",
+}, {
+	input:  `LOAD DATA LOW_PRIORITY LOCAL INFILE 'x.csv' REPLACE INTO TABLE db.t PARTITION (p0) CHARACTER SET utf8 FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' ESCAPED BY '\\' LINES STARTING BY 'x' TERMINATED BY '\n' IGNORE 1 LINES (a, b) SET c = a + b`,
+	output: `load data low_priority local infile 'x.csv' replace into table db.t partition (p0) character set utf8 fields terminated by ',' optionally enclosed by '"' escaped by '\\' lines starting by 'x' terminated by '\n' ignore 1 lines (a, b) set c = a+b`,
+}, {
+	input:  `load data concurrent infile 'x' ignore into table t columns enclosed by '"' ignore 2 rows`,
+	output: `load data concurrent infile 'x' ignore into table t fields enclosed by '"' ignore 2 lines`,
+}, {
+	input:  "INSERT LOW_PRIORITY IGNORE INTO t(a) VALUES (1)",
+	output: "insert low_priority ignore into t(a) values (1)",
+}, {
+	input:  "insert /* c */ delayed into t set a = 1",
+	output: "insert /* c */ delayed into t(a) values (1)",
+}, {
+	input: "insert high_priority ignore into t select * from u",
+}, {
+	input: "update low_priority ignore t set a = 1 where b = 2 order by c asc limit 1",
+}, {
+	input: "update ignore t join u on t.id = u.id set t.a = u.a",
+}, {
+	input: "delete low_priority ignore from t where a = 1",
+}, {
+	input: "delete ignore t from t join u on t.id = u.id",
+}, {
+	input: "delete low_priority from t using t join u on t.id = u.id",
+}, {
+	input:  "DELETE LOW_PRIORITY QUICK IGNORE FROM t WHERE a = 1",
+	output: "delete low_priority quick ignore from t where a = 1",
+}, {
+	input: "delete quick t from t join u on t.id = u.id",
+}, {
+	input: "delete quick, t from quick join t on quick.id = t.id",
+}, {
+	input: "select * from t for update nowait",
+}, {
+	input:  "select * from t for update SKIP LOCKED",
+	output: "select * from t for update skip locked",
+}, {
+	input: "select * from t for share nowait",
+}, {
+	input: "select * from t for share skip locked",
+}, {
+	input:  "select @a, @`a b`, @a.b from t where x = @a",
+	output: "select @a, @`a b`, @`a.b` from t where x = @a",
+}, {
+	input:  `select @'x', @"y" from t`,
+	output: "select @x, @y from t",
+}, {
+	input:  "select @n:=@n+1 from t",
+	output: "select @n := @n+1 from t",
+}, {
+	input: "select a from t where (@a := b) > 1",
+}, {
+	input:  "select @a := @b := 1",
+	output: "select @a := @b := 1",
+}, {
+	input: "set @`my var` = 1, @b := 2",
+}, {
+	input: "set names utf8mb4",
+}, {
+	input:  "SET NAMES 'utf8mb4' COLLATE utf8mb4_bin, @a = 1",
+	output: "set names utf8mb4 collate utf8mb4_bin, @a = 1",
+}, {
+	input:  "set names DEFAULT",
+	output: "set names default",
+}, {
+	input:  "set charset 'latin1'",
+	output: "set character set latin1",
+}, {
+	input: "set character set default",
+}, {
+	input: "set names '' collate 'my collation'",
+}, {
+	input:  "set names `utf8mb4` collate 'utf8mb4_bin'",
+	output: "set names utf8mb4 collate utf8mb4_bin",
+}, {
+	input:  "set character set 'binary'",
+	output: "set character set binary",
+}, {
+	input:  "create table t (a varchar(10) collate 'utf8mb4_bin', b text collate 'my collation')",
+	output: "create table t (\n\ta varchar(10) collate utf8mb4_bin,\n\tb text collate 'my collation'\n)",
+}, {
+	input: "select * from a join b using (x)",
+}, {
+	input:  "select * from a LEFT OUTER JOIN b USING (x, y) natural join c",
+	output: "select * from a left join b using (x, y) natural join c",
+}, {
+	input: "select * from a join b join c on b.x = c.x on a.y = b.y",
+}, {
+	input: "select * from a left join (b join c using (x)) on a.y = b.y",
+}, {
+	input:  "select * from (select 1, 2) t(a, b)",
+	output: "select * from (select 1, 2) as t(a, b)",
+}, {
+	input: "select t.a from t as x(a, b) join (select 1) as s(c) on x.a = s.c",
+}, {
+	input: "select * from t, lateral (select * from u where u.id = t.id) as x",
+}, {
+	input: "select * from t where (a, b) = (1, 2) and (c, d) in (select x, y from u)",
+}, {
+	input: "select * from t where a = any (select x from u) and b > all (select y from u)",
+}, {
+	input:  "select * from t where a <> SOME (with w as (select 1) select * from w)",
+	output: "select * from t where a != some (with w as (select 1) select * from w)",
+}, {
+	input: "select any, some from t where any = coalesce(some, 1) and any(a) > 1",
+}, {
+	input:  "select doc -> '$.a', t.doc->>'$.b[0]', doc->'$.c'->>'$.d' from t where doc->>'$.e' = 'x' and a-1 > a->'$'-1",
+	output: "select doc->'$.a', t.doc->>'$.b[0]', doc->'$.c'->>'$.d' from t where doc->>'$.e' = 'x' and a-1 > a->'$'-1",
+}, {
+	input: "select (a+b)->'$.a', -a->'$.a' from t",
+}, {
+	input:  "select * from t join LATERAL (select 1) x(a) on x.a = t.a",
+	output: "select * from t join lateral (select 1) as x(a) on x.a = t.a",
+}, {
+	input:  "select * from JSON_TABLE(doc, '$[*]' COLUMNS (id FOR ORDINALITY, v int PATH '$.v' DEFAULT '0' ON EMPTY NULL ON ERROR, e int EXISTS PATH '$.e', NESTED '$.b[*]' COLUMNS (b varchar(10) path '$'))) AS jt",
+	output: "select * from json_table(doc, '$[*]' columns (id for ordinality, v int path '$.v' default '0' on empty null on error, e int exists path '$.e', nested path '$.b[*]' columns (b varchar(10) path '$'))) as jt",
+}, {
+	input: "select * from generate_series(1, 3) as g(n)",
+}, {
+	input: "values row(1, 2), row(3, 4)",
+}, {
+	input:  "values (1, 2) order by 1 limit 1",
+	output: "values row(1, 2) order by 1 asc limit 1",
+}, {
+	input:  "select 1 union values row(2)",
+	output: "select 1 union values row(2)",
+}, {
+	input:  "select * from (values row(1, 'a')) as v (a, b)",
+	output: "select * from (values row(1, 'a')) as v(a, b)",
+}, {
+	input:  "insert into t values row(1), row(2)",
+	output: "insert into t values (1), (2)",
+}, {
+	input: "update t set a = (values(a))",
+}, {
+	input: "insert into t(a, b) values (1, 2) on duplicate key update a = values(a), b = values(t.b)+1",
+}, {
+	input:  "insert into t(a, b) values (1, 2) AS new on duplicate key update a = new.a+new.b",
+	output: "insert into t(a, b) values (1, 2) as new on duplicate key update a = new.a+new.b",
+}, {
+	input: "insert into t(a, b) values (1, 2), (3, 4) as new(m, n) on duplicate key update a = m+n",
+}, {
+	input:  "insert into t set a = 1 as new on duplicate key update a = new.a",
+	output: "insert into t(a) values (1) as new on duplicate key update a = new.a",
+}, {
+	input: "insert into t select a from u as new on duplicate key update a = new.a",
+}, {
+	input: "prepare s from 'select * from t where a = ?'",
+}, {
+	input: "prepare s from @q",
+}, {
+	input: "execute s",
+}, {
+	input: "execute s using @a, @b",
+}, {
+	input:  "DEALLOCATE PREPARE s",
+	output: "deallocate prepare s",
+}, {
+	input:  "DROP PREPARE s",
+	output: "deallocate prepare s",
+}, {
+	input:  "drop prepare `my stmt`",
+	output: "deallocate prepare `my stmt`",
+}, {
+	input:  "call p",
+	output: "call p()",
+}, {
+	input:  "call db.p",
+	output: "call db.p()",
+}, {
+	input: "call db.p(1, @a, 'x')",
+}, {
+	input: "begin call p(1); end",
+}, {
+	input:  "create procedure p (in a int) begin select a; end",
+	output: "create procedure p(in a int) begin select a; end",
+}, {
+	input: "create function if not exists db.f(a int) returns int deterministic return a + 1",
+}, {
+	input: "truncate table t",
+}, {
+	input:  "TRUNCATE t",
+	output: "truncate table t",
+}, {
+	input: "rename table a to b, c to d",
+}, {
+	input: "drop table if exists a, b, c",
+}, {
+	input:  "create temporary table if not exists t (a int)",
+	output: "create temporary table if not exists t (\n\ta int\n)",
+}, {
+	input: "drop temporary table if exists a, b",
+}, {
+	input: "create unique index if not exists a_idx on t (a)",
+}, {
+	input: "drop index if exists a_idx on t",
+}, {
+	input: "grant select, insert(a, b) on db.* to 'app'@'%' with grant option",
+}, {
+	input:  "GRANT ALL PRIVILEGES ON *.* TO root@localhost",
+	output: "grant all privileges on *.* to 'root'@'localhost'",
+}, {
+	input:  "grant execute on procedure db.p to `u`@`h`, bob",
+	output: "grant execute on procedure db.p to 'u'@'h', 'bob'",
+}, {
+	input: "revoke grant option, create view on table db.t from 'u'@'h'",
+}, {
+	input:  "create user if not exists u@'%' identified by 'pw', v",
+	output: "create user if not exists 'u'@'%' identified by 'pw', 'v'",
+}, {
+	input: "alter user if exists 'u'@'h' identified with mysql_native_password by 'x'",
+}, {
+	input: "create user 'u' identified with caching_sha2_password as 'hash'",
+}, {
+	input: "set password for 'u'@'h' = 'x'",
+}, {
+	input: "delete t1 from t1 join t2 on t1.id = t2.id where t2.x = 1",
+}, {
+	input:  "delete a, b.* from t as a, u as b where a.id = b.id",
+	output: "delete a, b from t as a, u as b where a.id = b.id",
+}, {
+	input:  "delete from t1, db.t2.* using t1 join db.t2 using (id) where t1.x = 1",
+	output: "delete from t1, db.t2 using t1 join db.t2 using (id) where t1.x = 1",
+}, {
+	input: "update t1 join t2 on t1.id = t2.id set t1.a = t2.b where t2.c = 1",
+}, {
+	input: "update t1 as a, t2 as b set a.x = b.y where a.id = b.id",
+}}
+
+func TestParseWithRowHandler(t *testing.T) {
+	var rows []string
+	tree, err := ParseWithRowHandler("insert into t(a, b) values (1, 'a'), (2, 'b'), (3, 'c')", func(row RowTuple) error {
+		rows = append(rows, String(row))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"(1, 'a')", "(2, 'b')", "(3, 'c')"}, rows)
+	assert.Equal(t, 0, len(tree.(*Insert).Rows.(Values)))
+
+	stop := errors.New("stop")
+	count := 0
+	_, err = ParseWithRowHandler("insert into t values (1), (2), (3)", func(row RowTuple) error {
+		count++
+		if count == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 2, count)
+
+	// Only the rows of the statement itself are streamed.
+	for _, sql := range []string{
+		"select * from (values row(1), row(2)) as t",
+		"insert into t select * from (values row(1), row(2)) as x",
+	} {
+		tree, err = ParseWithRowHandler(sql, func(row RowTuple) error {
+			t.Errorf("%s: unexpected row %s", sql, String(row))
+			return nil
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, sql, String(tree))
+		}
+	}
+	rows = nil
+	tree, err = ParseWithRowHandler("insert into t values ((select a from (values row(1), row(2)) as x)), (3)", func(row RowTuple) error {
+		rows = append(rows, String(row))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"((select a from (values row(1), row(2)) as x))", "(3)"}, rows)
+
+	_, err = ParseWithRowHandler("insert into t values row(1) union values row(2)", func(row RowTuple) error {
+		return nil
+	})
+	assert.Error(t, err)
+
+	// A panic is returned as an error, as by ParseUntrusted.
+	_, err = ParseWithRowHandler("insert into t values (1)", func(row RowTuple) error {
+		panic("row")
+	})
+	assert.EqualError(t, err, "internal error parsing sql: row")
+	tree, err = ParseWithRowHandler("insert into t values (1)", func(row RowTuple) error {
+		return nil
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(tree.(*Insert).Rows.(Values)))
+	}
+}
+
+func TestParseNext(t *testing.T) {
+	script := `select next value from t; ; -- comment
+insert into t values (?, ?);
+alter table t order by a; select 'a;b' from u;
+select * from ; select ?`
+	tokenizer := NewTokenizer(iotest.OneByteReader(strings.NewReader(script)))
+	var out []string
+	for {
+		stmt, err := ParseNext(tokenizer)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			out = append(out, "error")
+			continue
+		}
+		out = append(out, String(stmt))
+	}
+	assert.Equal(t, []string{
+		"select next as value from t",
+		"insert into t values (:v1, :v2)",
+		"alter table t",
+		"select 'a;b' from u",
+		"error",
+		"select :v1",
+	}, out)
+
+	readErr := errors.New("read failed")
+	tokenizer = NewTokenizer(io.MultiReader(strings.NewReader("select 1; select 2"), iotest.ErrReader(readErr)))
+	stmt, err := ParseNext(tokenizer)
+	assert.Nil(t, err)
+	assert.Equal(t, "select 1", String(stmt))
+	_, err = ParseNext(tokenizer)
+	assert.Equal(t, readErr, err)
+
+	script = `select 1 from dual;
+-- a routine, as mysqldump writes it
+DELIMITER ;;
+create procedure p() begin select 1 from dual; end ;;
+begin set a = 1; set b = 2; end;;
+select * from ;;
+DELIMITER ;
+select 2 from dual;
+delimiter $$
+select 3 from dual$$
+`
+	for _, tokenizer := range []*Tokenizer{
+		NewTokenizer(iotest.OneByteReader(strings.NewReader(script))),
+		NewStringTokenizer(script),
+	} {
+		out = nil
+		for {
+			stmt, err := ParseNext(tokenizer)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				out = append(out, "error")
+				continue
+			}
+			out = append(out, String(stmt))
+		}
+		assert.Equal(t, []string{
+			"select 1 from dual",
+			"create procedure p() begin select 1 from dual; end",
+			"begin set a = 1; set b = 2; end",
+			"error",
+			"select 2 from dual",
+			"select 3 from dual",
+		}, out)
+	}
+}
+
+func TestParseWithArena(t *testing.T) {
+	arena := NewArena()
+	for _, tcase := range validSQL {
+		if tcase.output == "" {
+			tcase.output = tcase.input
+		}
+		tree, err := ParseWithArena(tcase.input, arena)
+		if err != nil {
+			t.Errorf("input: %s, err: %v", tcase.input, err)
+			continue
+		}
+		if out := String(tree); out != tcase.output {
+			t.Errorf("out: %s, want %s", out, tcase.output)
+		}
+		arena.Reset()
+	}
+
+	sql := "select a, b, c from t where a = 1 and b > 2 or c + 1 < d * 2"
+	plain := testing.AllocsPerRun(100, func() { Parse(sql) })
+	pooled := testing.AllocsPerRun(100, func() {
+		ParseWithArena(sql, arena)
+		arena.Reset()
+	})
+	if pooled >= plain {
+		t.Errorf("arena allocs: %v, want fewer than %v", pooled, plain)
+	}
+}
+
+func TestNextVal(t *testing.T) {
+	for _, sql := range []string{"select nextval(s) from dual", "select s.nextval from dual"} {
+		tree, err := Parse(sql)
+		assert.Nil(t, err)
+		assert.Equal(t, sql, String(tree))
+
+		tree, err = ParseWithOptions(sql, Options{Dialect: MariaDB})
+		assert.Nil(t, err)
+		assert.Equal(t, "select next value for s from dual", String(tree))
+	}
+
+	tree, err := ParseWithOptions("select nextval(db.s) from dual", Options{Dialect: MariaDB})
+	assert.Nil(t, err)
+	assert.Equal(t, &NextValExpr{Sequence: &TableName{Qualifier: NewTableIdent("db"), Name: NewTableIdent("s")}}, tree.(*Select).SelectExprs[0].(*NonStarExpr).Expr)
+}
+
+func TestMariaDBReturning(t *testing.T) {
+	for _, sql := range []string{
+		"insert into t(a) values (1) returning id, a",
+		"delete from t where a = 1 returning *",
+	} {
+		tree, err := ParseWithOptions(sql, Options{Dialect: MariaDB})
+		assert.Nil(t, err)
+		assert.Equal(t, sql, String(tree))
+	}
+	tree, err := ParseWithOptions("insert into t(a) values (1) returning id", Options{Dialect: MariaDB})
+	assert.Nil(t, err)
+	assert.Equal(t, SelectExprs{&NonStarExpr{Expr: &ColName{Name: NewColIdent("id")}}}, tree.(*Insert).Returning)
+}
+
+func TestArrays(t *testing.T) {
+	tcases := []struct {
+		dialect Dialect
+		input   string
+		output  string
+	}{
+		{Postgres, "select ARRAY[1, 2, 3] from t", "select array[1, 2, 3] from t"},
+		{Postgres, "select array[] from t", ""},
+		{Postgres, "select a[1][2], (a + b)[1], -a[i + 1] from t", "select a[1][2], (a+b)[1], -a[i+1] from t"},
+		{Postgres, "select struct(a) from t", ""},
+		{BigQuery, "select struct(1 as a, 'x' as b), array[struct(c)][0] from t", ""},
+		{MySQL, "select array(1) from t", ""},
+	}
+	for _, tcase := range tcases {
+		if tcase.output == "" {
+			tcase.output = tcase.input
+		}
+		tree, err := ParseWithOptions(tcase.input, Options{Dialect: tcase.dialect})
+		if err != nil {
+			t.Errorf("input: %s, err: %v", tcase.input, err)
+			continue
+		}
+		assert.Equal(t, tcase.output, String(tree))
+	}
+
+	for _, sql := range []string{"select a[1] from t", "select array[1] from t"} {
+		_, err := Parse(sql)
+		assert.NotNil(t, err, sql)
+	}
+}
+
+func TestPostgres(t *testing.T) {
+	tcases := []struct {
+		input, output, pgOutput string
+	}{{
+		input:    `select "Foo".a, b::int, (a + b)::numeric(10, 2), -c::text, d::uuid from t`,
+		output:   "select Foo.a, b::int, (a+b)::numeric(10, 2), -c::text, d::uuid from t",
+		pgOutput: `select "Foo".a, b::int, (a+b)::numeric(10, 2), -c::text, d::uuid from t`,
+	}, {
+		input:  "select a from t where b ilike 'x%' and c not ilike $1 and d = $12",
+		output: "select a from t where b ilike 'x%' and c not ilike $1 and d = $12",
+	}, {
+		input:  "insert into t(a) values ($1) returning id, a",
+		output: "insert into t(a) values ($1) returning id, a",
+	}, {
+		input:  "update t set a = 1 where b = 2 returning *",
+		output: "update t set a = 1 where b = 2 returning *",
+	}, {
+		input:  "delete from t where a = 1 returning a as x",
+		output: "delete from t where a = 1 returning a as x",
+	}, {
+		input:    `select "a""b", "returning", 'it''s' from t`,
+		output:   "select `a\"b`, returning, 'it''s' from t",
+		pgOutput: `select "a""b", "returning", 'it''s' from t`,
+	}, {
+		input:    `select 'a\b', E'c\'d\n' from t`,
+		output:   `select 'a\\b', 'c\'d\n' from t`,
+		pgOutput: `select 'a\b', E'c''d\n' from t`,
+	}, {
+		input:    "values (1, 'a'), (2, 'b')",
+		output:   "values row(1, 'a'), row(2, 'b')",
+		pgOutput: "values (1, 'a'), (2, 'b')",
+	}}
+	for _, tcase := range tcases {
+		tree, err := ParseWithOptions(tcase.input, Options{Dialect: Postgres})
+		if err != nil {
+			t.Errorf("input: %s, err: %v", tcase.input, err)
+			continue
+		}
+		assert.Equal(t, tcase.output, String(tree))
+		if tcase.pgOutput == "" {
+			tcase.pgOutput = tcase.output
+		}
+		buf := NewTrackedBuffer(nil)
+		buf.SetDialect(Postgres)
+		buf.Myprintf("%v", tree)
+		assert.Equal(t, tcase.pgOutput, buf.String())
+	}
+
+	buf := NewTrackedBuffer(nil)
+	buf.SetDialect(Postgres)
+	buf.Myprintf("%v", &ColName{Name: NewColIdent("a b")})
+	assert.Equal(t, `"a b"`, buf.String())
+
+	for _, sql := range []string{
+		"select a from t where b ilike 'x'",
+		"select a::int from t",
+		"select $1 from t",
+		"delete from t returning a",
+	} {
+		_, err := Parse(sql)
+		assert.NotNil(t, err, sql)
+	}
+	tree, err := Parse(`select returning, ilike, "x" from t`)
+	assert.Nil(t, err)
+	assert.Equal(t, `select returning, ilike, "x" from t`, String(tree))
+}
+
+func TestTransactionStatements(t *testing.T) {
+	tcases := []struct {
+		input string
+		want  Statement
+	}{
+		{"BEGIN", &Begin{}},
+		{"start transaction read write", &Begin{AccessMode: AST_READ_WRITE}},
+		{"commit work", &Commit{}},
+		{"rollback to savepoint sp1", &Rollback{Savepoint: NewColIdent("sp1")}},
+		{"savepoint sp1", &Savepoint{Name: NewColIdent("sp1")}},
+		{"release savepoint sp1", &ReleaseSavepoint{Name: NewColIdent("sp1")}},
+		{"rollback work to savepoint sp1", &Rollback{Savepoint: NewColIdent("sp1")}},
+		{"set transaction isolation level repeatable read", &SetTransaction{IsolationLevel: AST_REPEATABLE_READ}},
+		{"set global transaction read only", &SetTransaction{Scope: AST_GLOBAL, AccessMode: AST_READ_ONLY}},
+	}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Errorf("input: %s, err: %v", tcase.input, err)
+			continue
+		}
+		assert.Equal(t, tcase.want, tree, tcase.input)
+	}
+}
+
+func TestShowStatements(t *testing.T) {
+	tcases := []struct {
+		input string
+		want  Statement
+	}{
+		{"show full columns from t from db like 'a%'", &Show{
+			Type:   AST_SHOW_COLUMNS,
+			Full:   true,
+			Table:  &TableName{Qualifier: NewTableIdent("db"), Name: NewTableIdent("t")},
+			Filter: &ShowFilter{Like: "a%"},
+		}},
+		{"show tables in db", &Show{Type: AST_SHOW_TABLES, Database: NewTableIdent("db")}},
+		{"show session variables", &Show{Type: AST_SHOW_VARIABLES, Scope: AST_SESSION}},
+		{"describe t", &Describe{Table: &TableName{Name: NewTableIdent("t")}}},
+	}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.input)
+		if err != nil {
+			t.Errorf("input: %s, err: %v", tcase.input, err)
+			continue
+		}
+		assert.Equal(t, tcase.want, tree, tcase.input)
+	}
+
+	tree, err := Parse("explain format = tree select a from t")
+	assert.Nil(t, err)
+	explain := tree.(*Explain)
+	assert.Equal(t, "tree", explain.OutputFormat)
+	assert.Equal(t, "select a from t", String(explain.Statement))
+}
+
+func TestSelectOptions(t *testing.T) {
+	tree, err := Parse("select max_statement_time = 10 sql_no_cache a from t")
+	assert.Nil(t, err)
+	sel := tree.(*Select)
+	assert.Equal(t, AST_SQL_NO_CACHE, sel.Cache)
+	assert.Equal(t, NumVal("10"), sel.MaxStatementTime)
+}
+
+func TestLoadData(t *testing.T) {
+	tree, err := Parse(`load data local infile '/tmp/t.csv' into table t fields terminated by ',' lines terminated by '\n' ignore 1 lines (a, b)`)
+	assert.Nil(t, err)
+	load := tree.(*LoadData)
+	assert.True(t, load.Local)
+	assert.Equal(t, "/tmp/t.csv", load.File.Val)
+	assert.Equal(t, "t", String(load.Table))
+	assert.Equal(t, ",", load.Fields.TerminatedBy.Val)
+	assert.Nil(t, load.Fields.EnclosedBy)
+	assert.Equal(t, "\n", load.Lines.TerminatedBy.Val)
+	assert.Equal(t, NumVal("1"), load.IgnoreLines)
+	assert.Equal(t, "(a, b)", String(load.Columns))
+}
+
+func TestUserVar(t *testing.T) {
+	tree, err := Parse("select @`my var`, @n := @n + 1 from t")
+	assert.Nil(t, err)
+	exprs := tree.(*Select).SelectExprs
+	assert.Equal(t, &UserVar{Name: ColIdent{val: "my var", quoted: true}}, exprs[0].(*NonStarExpr).Expr)
+	assign := exprs[1].(*NonStarExpr).Expr.(*AssignExpr)
+	assert.Equal(t, "n", assign.Var.Name.String())
+	assert.Equal(t, "@n+1", String(assign.Expr))
+}
+
+func TestSetNames(t *testing.T) {
+	tree, err := Parse("set names utf8mb4 collate utf8mb4_bin, global max_connections = 100")
+	assert.Nil(t, err)
+	exprs := tree.(*Set).Exprs
+	assert.Equal(t, &SetExpr{Kind: AST_NAMES, Charset: "utf8mb4", Collation: "utf8mb4_bin"}, exprs[0])
+	assert.Equal(t, AST_GLOBAL, exprs[1].Scope)
+	assert.Equal(t, "max_connections", exprs[1].Name.String())
+}
+
+func TestNestedJoin(t *testing.T) {
+	tree, err := Parse("select * from a join b join c using (x) on a.y = b.y")
+	assert.Nil(t, err)
+	join := tree.(*Select).From[0].(*JoinTableExpr)
+	assert.Equal(t, "a.y = b.y", String(join.On))
+	assert.Nil(t, join.Using)
+	inner := join.RightExpr.(*JoinTableExpr)
+	assert.Equal(t, "b join c using (x)", String(inner))
+	assert.Equal(t, "(x)", String(inner.Using))
+}
+
+func TestTableAliasColumns(t *testing.T) {
+	for _, dialect := range []Dialect{MySQL, Postgres} {
+		tree, err := ParseWithOptions("select a from (select 1, 2) as t(a, b)", Options{Dialect: dialect})
+		assert.Nil(t, err)
+		table := tree.(*Select).From[0].(*AliasedTableExpr)
+		assert.Equal(t, "t", table.As.String())
+		assert.Equal(t, []ColIdent{NewColIdent("a"), NewColIdent("b")}, table.Columns)
+	}
+}
+
+func TestJSONTable(t *testing.T) {
+	tree, err := Parse("select * from t, json_table(t.doc, '$' columns (n for ordinality, a int path '$.a' error on error, nested path '$.b' columns (b text path '$'))) as j")
+	assert.Nil(t, err)
+	table := tree.(*Select).From[1].(*AliasedTableExpr)
+	assert.Equal(t, "j", table.As.String())
+	jt := table.Expr.(*JSONTableExpr)
+	assert.Equal(t, "t.doc", String(jt.Expr))
+	assert.Equal(t, "$", jt.Path.Val)
+	assert.Equal(t, AST_FOR_ORDINALITY, jt.Columns[0].Kind)
+	assert.Equal(t, &JSONTableColumn{Kind: AST_PATH, Name: NewColIdent("a"), Type: ColumnType{Type: AST_INT}, Path: StrVal{Val: "$.a", Quote: '\''}, OnError: &JSONTableResponse{Type: AST_ERROR}}, jt.Columns[1])
+	assert.Equal(t, AST_NESTED_PATH, jt.Columns[2].Kind)
+	assert.Equal(t, "b text path '$'", String(jt.Columns[2].Columns[0]))
+
+	tree, err = Parse("select * from t, lateral (select * from u where u.id = t.id) as x")
+	assert.Nil(t, err)
+	assert.True(t, tree.(*Select).From[1].(*AliasedTableExpr).Lateral)
+}
+
+func TestValuesStatement(t *testing.T) {
+	tree, err := Parse("values row(1, 2), row(3, 4) order by 1 limit 1")
+	assert.Nil(t, err)
+	values := tree.(*ValuesStatement)
+	assert.Equal(t, Values{ValTuple{NumVal("1"), NumVal("2")}, ValTuple{NumVal("3"), NumVal("4")}}, values.Rows)
+	assert.Equal(t, "1", String(values.Limit.Rowcount))
+
+	tree, err = Parse("select 1 union values row(2) order by 1")
+	assert.Nil(t, err)
+	union := tree.(*Union)
+	assert.Nil(t, union.Right.(*ValuesStatement).OrderBy)
+	assert.Equal(t, " order by 1 asc", String(union.OrderBy))
+
+	tree, err = Parse("insert into t values row(1), row(2)")
+	assert.Nil(t, err)
+	assert.Equal(t, Values{ValTuple{NumVal("1")}, ValTuple{NumVal("2")}}, tree.(*Insert).Rows)
+
+	var rows []RowTuple
+	tree, err = ParseWithRowHandler("values row(1), row(2)", func(row RowTuple) error {
+		rows = append(rows, row)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+	assert.Empty(t, tree.(*ValuesStatement).Rows)
+}
+
+func TestPreparedStatements(t *testing.T) {
+	tree, err := Parse("prepare s from 'select ?'")
+	assert.Nil(t, err)
+	assert.Equal(t, &Prepare{Name: NewColIdent("s"), Stmt: StrVal{Val: "select ?", Quote: '\''}}, tree)
+
+	tree, err = Parse("execute s using @a, @`b`")
+	assert.Nil(t, err)
+	execute := tree.(*Execute)
+	assert.Equal(t, "s", execute.Name.String())
+	assert.Len(t, execute.Using, 2)
+	assert.Equal(t, "execute s using @a, @b", String(execute))
+
+	tree, err = Parse("deallocate prepare s")
+	assert.Nil(t, err)
+	assert.Equal(t, &Deallocate{Name: NewColIdent("s")}, tree)
+}
+
+func TestCallAndCreateRoutine(t *testing.T) {
+	tree, err := Parse("call db.p(1, @a)")
+	assert.Nil(t, err)
+	call := tree.(*Call)
+	assert.Equal(t, "db.p", String(call.Name))
+	assert.Equal(t, ValExprs{NumVal("1"), &UserVar{Name: NewColIdent("a")}}, call.Args)
+
+	tree, err = Parse("create procedure p(out n int)\nbegin\n  select count(*) into n from t;\nend\n")
+	assert.Nil(t, err)
+	routine := tree.(*CreateRoutine)
+	assert.Equal(t, AST_PROCEDURE, routine.Kind)
+	assert.Equal(t, "p", String(routine.Name))
+	assert.Equal(t, "(out n int)\nbegin\n  select count(*) into n from t;\nend", routine.Body)
+}
+
+func TestCreateView(t *testing.T) {
+	tree, err := Parse("create or replace view v(a) as select b from t where c = 1 with cascaded check option")
+	assert.Nil(t, err)
+	ddl := tree.(*DDL)
+	assert.True(t, ddl.OrReplace)
+	assert.Equal(t, []ColIdent{NewColIdent("a")}, ddl.Columns)
+	assert.Equal(t, "select b from t where c = 1", String(ddl.Select.(*Select)))
+	assert.Equal(t, AST_CASCADED_CHECK_OPTION, ddl.CheckOption)
+}
+
+func TestGrant(t *testing.T) {
+	tree, err := Parse("grant select (a), insert on db.* to 'app'@'%', bob@localhost with grant option")
+	assert.Nil(t, err)
+	grant := tree.(*Grant)
+	assert.Equal(t, []*Privilege{{Type: "select", Columns: []ColIdent{NewColIdent("a")}}, {Type: "insert"}}, grant.Privileges)
+	assert.Equal(t, &GrantObject{Database: NewTableIdent("db"), Name: NewTableIdent("*")}, grant.On)
+	assert.Equal(t, []*Account{{User: "app", Host: "%"}, {User: "bob", Host: "localhost"}}, grant.To)
+	assert.True(t, grant.WithGrantOption)
+
+	tree, err = Parse("create user 'u'@'h' identified by 'pw'")
+	assert.Nil(t, err)
+	assert.Equal(t, &UserSpec{Account: &Account{User: "u", Host: "h"}, Password: &StrVal{Val: "pw", Quote: '\''}}, tree.(*CreateUser).Users[0])
+
+	tree, err = Parse("set password = 'pw'")
+	assert.Nil(t, err)
+	assert.Nil(t, tree.(*SetPassword).For)
+}
+
+func TestMultiTableDelete(t *testing.T) {
+	tree, err := Parse("delete a from t as a join u on a.id = u.id where u.x = 1")
+	assert.Nil(t, err)
+	del := tree.(*Delete)
+	assert.Nil(t, del.Table)
+	assert.Equal(t, []*TableName{{Name: NewTableIdent("a")}}, del.Targets)
+	assert.Equal(t, "t as a join u on a.id = u.id", String(del.From))
+	assert.False(t, del.Using)
+
+	tree, err = Parse("delete from a using t as a, u where a.id = u.id")
+	assert.Nil(t, err)
+	del = tree.(*Delete)
+	assert.True(t, del.Using)
+	assert.Len(t, del.From, 2)
+}
+
+func TestMultiTableUpdate(t *testing.T) {
+	tree, err := Parse("update t set a = 1")
+	assert.Nil(t, err)
+	assert.Equal(t, TableExprs{&AliasedTableExpr{Expr: &TableName{Name: NewTableIdent("t")}}}, tree.(*Update).Table)
+
+	tree, err = Parse("update t1 join t2 on t1.id = t2.id set t1.a = t2.b")
+	assert.Nil(t, err)
+	join := tree.(*Update).Table[0].(*JoinTableExpr)
+	assert.Equal(t, "t2", String(join.RightExpr))
+}
@@ -17,13 +17,26 @@ type bindLocation struct {
 	offset, length int
 }
 
+// ParsedQuery is a formatted statement that remembers where its
+// bind variables are, so that queries can be generated from it
+// with different values without formatting the statement again.
 type ParsedQuery struct {
 	Query         string
 	bindLocations []bindLocation
 }
 
+// NewParsedQuery formats node into a ParsedQuery.
+func NewParsedQuery(node SQLNode) *ParsedQuery {
+	buf := NewTrackedBuffer(nil)
+	buf.Myprintf("%v", node)
+	return buf.ParsedQuery()
+}
+
 type EncoderFunc func(value interface{}) ([]byte, error)
 
+// GenerateQuery returns the query with its bind variables replaced
+// by the SQL encoding of their values, as done by EncodeValue. The
+// values of list bind variables, such as ::ids, are []interface{}.
 func (pq *ParsedQuery) GenerateQuery(bindVariables map[string]interface{}) ([]byte, error) {
 	if len(pq.bindLocations) == 0 {
 		return []byte(pq.Query), nil
@@ -153,6 +166,24 @@ func (tpl *TupleEqualityList) encodeAsEquality(buf *bytes.Buffer) error {
 	return nil
 }
 
+// GetBindVars returns the names of the bind variables of stmt,
+// value and list ones, without their ':' prefixes, as they are
+// looked up in the bind variables of GenerateQuery. Drivers can use
+// it to check the bind variables supplied for stmt up front.
+func GetBindVars(stmt Statement) map[string]struct{} {
+	bindVars := make(map[string]struct{})
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case ValArg:
+			bindVars[bindVarName(string(node))] = struct{}{}
+		case ListArg:
+			bindVars[bindVarName(string(node))] = struct{}{}
+		}
+		return true, nil
+	}, stmt)
+	return bindVars
+}
+
 func FetchBindVar(name string, bindVariables map[string]interface{}) (val interface{}, isList bool, err error) {
 	name = name[1:]
 	if name[0] == ':' {
@@ -178,3 +209,89 @@ func FetchBindVar(name string, bindVariables map[string]interface{}) (val interf
 	}
 	return supplied, false, nil
 }
+
+// ExpandListArgs formats node for drivers that only support
+// positional "?" placeholders and scalar arguments. Each bind
+// variable becomes a placeholder, and each list bind variable
+// becomes as many placeholders as the list has values. The values
+// are returned in placeholder order, as args.
+//
+// An IN comparison with an empty list is rewritten to 1 = 0, which
+// is false, and a NOT IN comparison to 1 = 1. An empty list in any
+// other position is an error.
+func ExpandListArgs(node SQLNode, bindVariables map[string]interface{}) (query string, args []interface{}, err error) {
+	buf := NewTrackedBuffer(func(buf *TrackedBuffer, node SQLNode) {
+		if err != nil {
+			return
+		}
+		switch node := node.(type) {
+		case ValArg:
+			var val interface{}
+			if val, _, err = FetchBindVar(string(node), bindVariables); err == nil {
+				buf.WriteByte('?')
+				args = append(args, val)
+			}
+			return
+		case ListArg:
+			var vals []interface{}
+			if vals, err = fetchListArg(node, bindVariables); err == nil && len(vals) == 0 {
+				err = fmt.Errorf("empty list supplied for %s", node[2:])
+			}
+			if err == nil {
+				writePlaceholders(buf, len(vals))
+				args = append(args, vals...)
+			}
+			return
+		case *ComparisonExpr:
+			list, ok := node.Right.(ListArg)
+			if !ok || (node.Operator != AST_IN && node.Operator != AST_NOT_IN) {
+				break
+			}
+			var vals []interface{}
+			if vals, err = fetchListArg(list, bindVariables); err != nil {
+				return
+			}
+			if len(vals) != 0 {
+				break
+			}
+			if node.Operator == AST_IN {
+				buf.WriteString("1 = 0")
+			} else {
+				buf.WriteString("1 = 1")
+			}
+			return
+		}
+		node.Format(buf)
+	})
+	buf.Myprintf("%v", node)
+	if err != nil {
+		return "", nil, err
+	}
+	return buf.String(), args, nil
+}
+
+// fetchListArg returns the values of a list bind variable,
+// which unlike FetchBindVar allows an empty list.
+func fetchListArg(arg ListArg, bindVariables map[string]interface{}) ([]interface{}, error) {
+	name := string(arg[2:])
+	supplied, ok := bindVariables[name]
+	if !ok {
+		return nil, fmt.Errorf("missing bind var %s", name)
+	}
+	list, ok := supplied.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected list arg type %T for key %s", supplied, name)
+	}
+	return list, nil
+}
+
+func writePlaceholders(buf *TrackedBuffer, n int) {
+	buf.WriteByte('(')
+	for i := 0; i < n; i++ {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteByte('?')
+	}
+	buf.WriteByte(')')
+}
@@ -5,6 +5,9 @@
 package sqlparser
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/getlantern/sqlparser/dependency/sqltypes"
@@ -194,3 +197,223 @@ func TestParsedQuery(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandListArgs(t *testing.T) {
+	tcases := []struct {
+		desc     string
+		query    string
+		bindVars map[string]interface{}
+		output   string
+		args     []interface{}
+	}{
+		{
+			"no bind vars",
+			"select * from a where id = 2",
+			nil,
+			"select * from a where id = 2",
+			nil,
+		}, {
+			"scalar and list bind vars",
+			"select * from a where id1 = :id1 and id2 in ::vals and id3 not in ::more and id4 = :id4",
+			map[string]interface{}{
+				"id1":  1,
+				"vals": []interface{}{"a", 2},
+				"more": []interface{}{3},
+				"id4":  nil,
+			},
+			"select * from a where id1 = ? and id2 in (?, ?) and id3 not in (?) and id4 = ?",
+			[]interface{}{1, "a", 2, 3, nil},
+		}, {
+			"empty lists",
+			"select * from a where id in ::vals or id not in ::vals and b = :b",
+			map[string]interface{}{
+				"vals": []interface{}{},
+				"b":    "x",
+			},
+			"select * from a where 1 = 0 or 1 = 1 and b = ?",
+			[]interface{}{"x"},
+		}, {
+			"missing bind var",
+			"select * from a where id in ::vals",
+			map[string]interface{}{},
+			"missing bind var vals",
+			nil,
+		}, {
+			"list for scalar",
+			"select * from a where id = :vals",
+			map[string]interface{}{
+				"vals": []interface{}{1},
+			},
+			"unexpected arg type []interface {} for key vals",
+			nil,
+		}, {
+			"scalar for list",
+			"select * from a where id in ::vals",
+			map[string]interface{}{
+				"vals": 1,
+			},
+			"unexpected list arg type int for key vals",
+			nil,
+		},
+	}
+
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.query)
+		if err != nil {
+			t.Errorf("parse failed for %s: %v", tcase.desc, err)
+			continue
+		}
+		got, args, err := ExpandListArgs(tree, tcase.bindVars)
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tcase.output {
+			t.Errorf("for test case: %s, got: '%s', want '%s'", tcase.desc, got, tcase.output)
+		}
+		if !reflect.DeepEqual(args, tcase.args) {
+			t.Errorf("for test case: %s, got args: %v, want %v", tcase.desc, args, tcase.args)
+		}
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	tree, err := Parse("select a from t where b = :b and c in ::cs and d = ? and e = :b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tcases := []struct {
+		placeholders Placeholders
+		want         string
+	}{{
+		placeholders: NamedPlaceholders,
+		want:         "select a from t where b = :b and c in ::cs and d = :v1 and e = :b",
+	}, {
+		placeholders: QuestionPlaceholders,
+		want:         "select a from t where b = ? and c in ? and d = ? and e = ?",
+	}, {
+		placeholders: DollarPlaceholders,
+		want:         "select a from t where b = $1 and c in $2 and d = $3 and e = $4",
+	}}
+	for _, tcase := range tcases {
+		buf := NewTrackedBuffer(nil)
+		buf.SetPlaceholders(tcase.placeholders)
+		buf.Myprintf("%v", tree)
+		if got := buf.String(); got != tcase.want {
+			t.Errorf("got %s, want %s", got, tcase.want)
+		}
+		if want := []string{"b", "cs", "v1", "b"}; !reflect.DeepEqual(buf.BindVars(), want) {
+			t.Errorf("got bind vars %v, want %v", buf.BindVars(), want)
+		}
+		if !buf.HasBindVars() {
+			t.Errorf("got no bind vars for %s", buf.String())
+		}
+	}
+}
+
+func TestNewParsedQuery(t *testing.T) {
+	tree, err := Parse("select * from a where id = :id and b in ::bs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pq := NewParsedQuery(tree)
+	if want := "select * from a where id = :id and b in ::bs"; pq.Query != want {
+		t.Errorf("got %s, want %s", pq.Query, want)
+	}
+	for _, tcase := range []struct {
+		bindVars map[string]interface{}
+		want     string
+	}{{
+		bindVars: map[string]interface{}{"id": 1, "bs": []interface{}{"x", 2}},
+		want:     "select * from a where id = 1 and b in ('x', 2)",
+	}, {
+		bindVars: map[string]interface{}{"id": "a'b", "bs": []interface{}{3}},
+		want:     "select * from a where id = 'a\\'b' and b in (3)",
+	}} {
+		got, err := pq.GenerateQuery(tcase.bindVars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tcase.want {
+			t.Errorf("got %s, want %s", got, tcase.want)
+		}
+	}
+}
+
+// chunkWriter records the chunks written to it, and fails once
+// it has been written to fail times, if fail is set.
+type chunkWriter struct {
+	chunks []string
+	fail   int
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	if w.fail != 0 && len(w.chunks) == w.fail {
+		return 0, errors.New("write failed")
+	}
+	w.chunks = append(w.chunks, string(p))
+	return len(p), nil
+}
+
+func TestFormatTo(t *testing.T) {
+	rows := make([]string, 5000)
+	for i := range rows {
+		rows[i] = "(1, 'abcdefghij', now())"
+	}
+	tree, err := Parse("insert into t(a, b, c) values " + strings.Join(rows, ", "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &chunkWriter{}
+	if err := FormatTo(w, tree); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(w.chunks, ""), String(tree); got != want {
+		t.Errorf("got %d bytes, want %d", len(got), len(want))
+	}
+	if len(w.chunks) < 3 {
+		t.Errorf("got %d chunks, want at least 3", len(w.chunks))
+	}
+	for _, chunk := range w.chunks {
+		if len(chunk) > 2*streamChunk {
+			t.Errorf("got a chunk of %d bytes", len(chunk))
+		}
+	}
+
+	w = &chunkWriter{}
+	buf := NewWriterTrackedBuffer(w, nil)
+	buf.SetPretty(PrettyOptions{})
+	buf.Myprintf("%v", tree)
+	if err := buf.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(w.chunks, ""), FormatPretty(tree, PrettyOptions{}); got != want {
+		t.Errorf("got %d pretty bytes, want %d", len(got), len(want))
+	}
+
+	w = &chunkWriter{fail: 1}
+	if err := FormatTo(w, tree); err == nil || err.Error() != "write failed" {
+		t.Errorf("got error %v, want write failed", err)
+	}
+	if len(w.chunks) != 1 {
+		t.Errorf("got %d chunks after the error, want 1", len(w.chunks))
+	}
+}
+
+func TestGetBindVars(t *testing.T) {
+	tree, err := Parse("select :a, ? from t where b in ::bs and c = :a and d = (select :e from u) limit :n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]struct{}{"a": {}, "v1": {}, "bs": {}, "e": {}, "n": {}}
+	if got := GetBindVars(tree); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetBindVars: %v, want %v", got, want)
+	}
+
+	tree, err = Parse("select a from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := GetBindVars(tree); len(got) != 0 {
+		t.Errorf("GetBindVars: %v, want none", got)
+	}
+}
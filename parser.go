@@ -0,0 +1,257 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Parser is a reusable handle for parsing statements with a fixed
+// set of Options. It keeps counters about the statements it parsed,
+// which long-running services can export as metrics. Its Normalize
+// and Fingerprint methods cache their results by sql, and count the
+// hits and misses of their caches. A Parser is safe for concurrent
+// use.
+//
+// Parsing keeps no global state: each parse has a tokenizer and a
+// parser stack of its own. Parser.Parse, like Parse and
+// ParseWithOptions, takes them from a pool shared by all goroutines
+// and returns them when done, so that parsing many statements
+// allocates little more than the parse trees.
+type Parser struct {
+	opts Options
+
+	statements, errors, bytes uint64
+
+	// The hits and misses of the caches of Normalize and Fingerprint.
+	normalizeHits, normalizeMisses     uint64
+	fingerprintHits, fingerprintMisses uint64
+
+	normalized, fingerprints parserCache
+}
+
+// ParserStats holds the counters of a Parser.
+type ParserStats struct {
+	// Statements is the number of statements parsed successfully.
+	Statements uint64
+	// Errors is the number of parse errors.
+	Errors uint64
+	// Bytes is the total length of the sql parsed, including
+	// the sql that failed to parse.
+	Bytes uint64
+	// NormalizeHits and NormalizeMisses are the number of calls
+	// to Normalize answered from its cache, and the number that
+	// parsed the sql.
+	NormalizeHits, NormalizeMisses uint64
+	// FingerprintHits and FingerprintMisses are the same for
+	// Fingerprint.
+	FingerprintHits, FingerprintMisses uint64
+}
+
+// NewParser creates a Parser that parses with opts.
+func NewParser(opts Options) *Parser {
+	return &Parser{opts: opts}
+}
+
+// Parse parses sql like ParseWithOptions, and updates the counters.
+func (p *Parser) Parse(sql string) (Statement, error) {
+	atomic.AddUint64(&p.bytes, uint64(len(sql)))
+	stmt, err := ParseWithOptions(sql, p.opts)
+	if err != nil {
+		atomic.AddUint64(&p.errors, 1)
+		return nil, err
+	}
+	atomic.AddUint64(&p.statements, 1)
+	return stmt, nil
+}
+
+// normalizeKey is the key of a result of Parser.Normalize.
+type normalizeKey struct {
+	sql, prefix string
+}
+
+// normalizedQuery is a result of Parser.Normalize.
+type normalizedQuery struct {
+	query    string
+	bindVars map[string]interface{}
+}
+
+// Normalize parses sql like Parse, and normalizes it like Normalize,
+// with bind variables named prefix1, prefix2 and so on. It returns
+// the normalized query and the values of its bind variables.
+func (p *Parser) Normalize(sql, prefix string) (string, map[string]interface{}, error) {
+	key := normalizeKey{sql: sql, prefix: prefix}
+	if cached, ok := p.normalized.get(key); ok {
+		atomic.AddUint64(&p.normalizeHits, 1)
+		n := cached.(normalizedQuery)
+		return n.query, copyBindVars(n.bindVars), nil
+	}
+	atomic.AddUint64(&p.normalizeMisses, 1)
+	stmt, err := p.Parse(sql)
+	if err != nil {
+		return "", nil, err
+	}
+	bindVars := make(map[string]interface{})
+	Normalize(stmt, bindVars, prefix)
+	query := String(stmt)
+	p.normalized.put(key, normalizedQuery{query: query, bindVars: copyBindVars(bindVars)})
+	return query, bindVars, nil
+}
+
+// copyBindVars returns a copy of bindVars, so that the bind
+// variables returned by Parser.Normalize can be changed without
+// changing those it cached.
+func copyBindVars(bindVars map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(bindVars))
+	for name, val := range bindVars {
+		copied[name] = val
+	}
+	return copied
+}
+
+// Fingerprint parses sql like Parse, and returns its fingerprint
+// like Fingerprint.
+func (p *Parser) Fingerprint(sql string) (string, error) {
+	if cached, ok := p.fingerprints.get(sql); ok {
+		atomic.AddUint64(&p.fingerprintHits, 1)
+		return cached.(string), nil
+	}
+	atomic.AddUint64(&p.fingerprintMisses, 1)
+	stmt, err := p.Parse(sql)
+	if err != nil {
+		return "", err
+	}
+	fingerprint := fingerprintOf(stmt)
+	p.fingerprints.put(sql, fingerprint)
+	return fingerprint, nil
+}
+
+// maxCachedResults is the number of results a parserCache holds.
+const maxCachedResults = 1024
+
+// parserCache caches the results of a Parser. Once it is full, an
+// arbitrary result is dropped for each one added. Parse errors are
+// not cached.
+type parserCache struct {
+	mu      sync.Mutex
+	results map[interface{}]interface{}
+}
+
+func (c *parserCache) get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.results[key]
+	return result, ok
+}
+
+func (c *parserCache) put(key, result interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.results == nil {
+		c.results = make(map[interface{}]interface{})
+	}
+	if len(c.results) >= maxCachedResults {
+		for old := range c.results {
+			delete(c.results, old)
+			break
+		}
+	}
+	c.results[key] = result
+}
+
+// pooledParser is a tokenizer and a parser stack for parsing
+// one statement at a time.
+type pooledParser struct {
+	reader    strings.Reader
+	tokenizer Tokenizer
+	yacc      yyParserImpl
+	// stack is the parser stack grown by an earlier parse, for
+	// later ones to start with.
+	stack []yySymType
+}
+
+// maxPooledStack is the size of the largest parser stack kept
+// by a pooledParser. Each entry takes over a kilobyte.
+const maxPooledStack = 256
+
+var parserPool = sync.Pool{
+	New: func() interface{} { return new(pooledParser) },
+}
+
+// parsePooled parses sql with opts using a pooledParser. The rows
+// of an INSERT ... VALUES or VALUES statement are passed to onRow,
+// if set, as for ParseWithRowHandler.
+func parsePooled(sql string, opts Options, onRow func(RowTuple) error) (stmt Statement, err error) {
+	pp := parserPool.Get().(*pooledParser)
+	defer func() {
+		// pp is not returned to the pool after a panic, as
+		// it may be left in any state.
+		if r := recover(); r != nil {
+			stmt, err = nil, panicError(r)
+		}
+	}()
+	pp.reader.Reset(sql)
+	pp.tokenizer = Tokenizer{InStream: &pp.reader, opts: opts, src: sql, stack: pp.stack, RowHandler: onRow}
+	switch {
+	case pp.yacc.Parse(&pp.tokenizer) == 0:
+		if err = pp.tokenizer.streamedRowsError(); err == nil {
+			stmt = pp.tokenizer.ParseTree
+		}
+	case pp.tokenizer.rowErr != nil:
+		err = pp.tokenizer.rowErr
+	default:
+		err = pp.tokenizer.parseError()
+	}
+	// Drop the references to the sql and the parse tree,
+	// which the pool must not keep alive.
+	pp.reader.Reset("")
+	pp.stack = pp.tokenizer.stack
+	if len(pp.stack) > maxPooledStack {
+		pp.stack = nil
+	}
+	for i := range pp.stack {
+		pp.stack[i] = yySymType{}
+	}
+	pp.tokenizer = Tokenizer{}
+	pp.yacc = yyParserImpl{}
+	parserPool.Put(pp)
+	return stmt, err
+}
+
+// parseStack returns the stack yyParse starts with: the one the
+// tokenizer yylex holds from an earlier parse, if it is larger than
+// stack, the parser's own. It is called from yyParse, see the
+// Makefile.
+func parseStack(yylex interface{}, stack []yySymType) []yySymType {
+	if tkn := yylex.(*Tokenizer); len(tkn.stack) > len(stack) {
+		return tkn.stack
+	}
+	return stack
+}
+
+// growParseStack returns a parser stack of size n, to replace the
+// full one, and records it in the tokenizer yylex to be reused. It
+// is called from yyParse, see the Makefile.
+func growParseStack(yylex interface{}, n int) []yySymType {
+	tkn := yylex.(*Tokenizer)
+	tkn.stack = make([]yySymType, n)
+	return tkn.stack
+}
+
+// Stats returns a snapshot of the counters of p.
+func (p *Parser) Stats() ParserStats {
+	return ParserStats{
+		Statements: atomic.LoadUint64(&p.statements),
+		Errors:     atomic.LoadUint64(&p.errors),
+		Bytes:      atomic.LoadUint64(&p.bytes),
+
+		NormalizeHits:     atomic.LoadUint64(&p.normalizeHits),
+		NormalizeMisses:   atomic.LoadUint64(&p.normalizeMisses),
+		FingerprintHits:   atomic.LoadUint64(&p.fingerprintHits),
+		FingerprintMisses: atomic.LoadUint64(&p.fingerprintMisses),
+	}
+}
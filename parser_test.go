@@ -0,0 +1,110 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserStats(t *testing.T) {
+	p := NewParser(Options{Dialect: MariaDB})
+	tree, err := p.Parse("select s.nextval from dual")
+	assert.Nil(t, err)
+	assert.Equal(t, "select next value for s from dual", String(tree))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Parse("select 1 from dual")
+			p.Parse("select from")
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, ParserStats{Statements: 11, Errors: 10, Bytes: 26 + 10*(18+11)}, p.Stats())
+}
+
+func TestParserCaches(t *testing.T) {
+	p := NewParser(Options{})
+	for i := 0; i < 3; i++ {
+		query, bindVars, err := p.Normalize("select a from t where b = 'x' and c = 1", "v")
+		assert.Nil(t, err)
+		assert.Equal(t, "select a from t where b = :v1 and c = :v2", query)
+		assert.Equal(t, map[string]interface{}{"v1": "x", "v2": int64(1)}, bindVars)
+		// Changing the bind variables leaves those cached alone.
+		bindVars["v1"] = "y"
+
+		fingerprint, err := p.Fingerprint("select a from t where b in (1, 2)")
+		assert.Nil(t, err)
+		assert.Equal(t, "select a from t where b in (?+)", fingerprint)
+	}
+	_, _, err := p.Normalize("select a from t where b = 'x' and c = 1", "p")
+	assert.Nil(t, err)
+	_, err = p.Fingerprint("select from")
+	assert.NotNil(t, err)
+	_, err = p.Fingerprint("select from")
+	assert.NotNil(t, err)
+	assert.Equal(t, ParserStats{
+		Statements:        3,
+		Errors:            2,
+		Bytes:             2*39 + 33 + 2*11,
+		NormalizeHits:     2,
+		NormalizeMisses:   2,
+		FingerprintHits:   2,
+		FingerprintMisses: 3,
+	}, p.Stats())
+}
+
+func TestParserReuse(t *testing.T) {
+	// Parses that share pooled tokenizers and stacks
+	// do not affect each other.
+	p := NewParser(Options{Dialect: Postgres})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				tree, err := p.Parse("select a from t where b = $1")
+				assert.Nil(t, err)
+				assert.Equal(t, "select a from t where b = $1", String(tree))
+				_, err = p.Parse("select a from")
+				assert.EqualError(t, err, "syntax error at position 15")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+const benchmarkSQL = "select aaaa, bbb, ccc from tttt join ttt1 on tttt.a = ttt1.b where aaaa = bbbb and dddd+1 = eeee order by kkkk limit 3"
+
+func BenchmarkParserParallel(b *testing.B) {
+	p := NewParser(Options{})
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := p.Parse(benchmarkSQL); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkParseUnpooled parses with a new tokenizer and parser
+// stack each time, for comparison with BenchmarkParserParallel.
+func BenchmarkParseUnpooled(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if yyParse(NewStringTokenizer(benchmarkSQL)) != 0 {
+				b.Fatal("parse error")
+			}
+		}
+	})
+}
@@ -0,0 +1,362 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// plan.go lowers SELECT statements into logical query plans.
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PlanNode is an operator of a logical query plan, as built by
+// BuildPlan. Each operator consumes the rows of its inputs.
+type PlanNode interface {
+	// Inputs returns the operators feeding this one.
+	Inputs() []PlanNode
+	// Columns returns the columns referred to by the operator's
+	// own expressions, not counting those of subqueries.
+	Columns() []*ColName
+	// String describes the operator on a single line.
+	String() string
+}
+
+// PlanScan reads the rows of a table.
+type PlanScan struct {
+	Table *TableName
+	As    TableIdent
+}
+
+// PlanDual produces the single row a SELECT without a FROM
+// clause reads, as MySQL's DUAL table.
+type PlanDual struct{}
+
+// PlanDerived reads the rows of a subquery in a FROM clause.
+type PlanDerived struct {
+	Input PlanNode
+	As    TableIdent
+}
+
+// PlanJoin joins the rows of two inputs. Type is one of the
+// JoinTableExpr.Join values. On, or Using for joins with a
+// USING clause, is nil for cross joins.
+type PlanJoin struct {
+	Type        string
+	Left, Right PlanNode
+	On          BoolExpr
+	Using       Columns
+}
+
+// PlanFilter passes on the rows of its input that satisfy Cond.
+// It implements WHERE, HAVING and QUALIFY clauses.
+type PlanFilter struct {
+	Input PlanNode
+	Cond  BoolExpr
+}
+
+// PlanAggregate groups the rows of its input by GroupBy, and
+// computes Aggregates for each group. A statement with
+// aggregates and no GROUP BY forms a single group.
+type PlanAggregate struct {
+	Input      PlanNode
+	GroupBy    SelectExprs
+	Aggregates []*FuncExpr
+}
+
+// PlanProject computes the select expressions.
+type PlanProject struct {
+	Input    PlanNode
+	Exprs    SelectExprs
+	Distinct bool
+}
+
+// PlanSort orders the rows of its input.
+type PlanSort struct {
+	Input   PlanNode
+	OrderBy OrderBy
+}
+
+// PlanLimit passes on at most Limit.Rowcount rows of its input,
+// after skipping Limit.Offset rows.
+type PlanLimit struct {
+	Input PlanNode
+	Limit *Limit
+}
+
+// PlanSetOp combines the rows of two inputs with a UNION,
+// EXCEPT or INTERSECT. Type is one of the Union.Type values.
+type PlanSetOp struct {
+	Type        string
+	Left, Right PlanNode
+}
+
+func (node *PlanScan) Inputs() []PlanNode      { return nil }
+func (node *PlanDual) Inputs() []PlanNode      { return nil }
+func (node *PlanDerived) Inputs() []PlanNode   { return []PlanNode{node.Input} }
+func (node *PlanJoin) Inputs() []PlanNode      { return []PlanNode{node.Left, node.Right} }
+func (node *PlanFilter) Inputs() []PlanNode    { return []PlanNode{node.Input} }
+func (node *PlanAggregate) Inputs() []PlanNode { return []PlanNode{node.Input} }
+func (node *PlanSort) Inputs() []PlanNode      { return []PlanNode{node.Input} }
+func (node *PlanProject) Inputs() []PlanNode   { return []PlanNode{node.Input} }
+func (node *PlanLimit) Inputs() []PlanNode     { return []PlanNode{node.Input} }
+func (node *PlanSetOp) Inputs() []PlanNode     { return []PlanNode{node.Left, node.Right} }
+
+func (node *PlanScan) Columns() []*ColName    { return nil }
+func (node *PlanDual) Columns() []*ColName    { return nil }
+func (node *PlanDerived) Columns() []*ColName { return nil }
+func (node *PlanJoin) Columns() []*ColName {
+	return dedupColumns(append(referencedColumns(node.On), referencedColumns(node.Using)...))
+}
+func (node *PlanFilter) Columns() []*ColName  { return referencedColumns(node.Cond) }
+func (node *PlanProject) Columns() []*ColName { return referencedColumns(node.Exprs) }
+func (node *PlanSort) Columns() []*ColName    { return referencedColumns(node.OrderBy) }
+func (node *PlanLimit) Columns() []*ColName   { return nil }
+func (node *PlanSetOp) Columns() []*ColName   { return nil }
+
+func (node *PlanAggregate) Columns() []*ColName {
+	cols := referencedColumns(node.GroupBy)
+	for _, agg := range node.Aggregates {
+		cols = append(cols, referencedColumns(agg)...)
+	}
+	return dedupColumns(cols)
+}
+
+func (node *PlanScan) String() string {
+	if node.As.IsEmpty() {
+		return "scan " + String(node.Table)
+	}
+	return fmt.Sprintf("scan %s as %s", String(node.Table), String(node.As))
+}
+
+func (node *PlanDual) String() string {
+	return "dual"
+}
+
+func (node *PlanDerived) String() string {
+	return "derived as " + String(node.As)
+}
+
+func (node *PlanJoin) String() string {
+	switch {
+	case node.On != nil:
+		return fmt.Sprintf("%s on %s", node.Type, String(node.On))
+	case node.Using != nil:
+		return fmt.Sprintf("%s using %s", node.Type, String(node.Using))
+	}
+	return node.Type
+}
+
+func (node *PlanFilter) String() string {
+	return "filter " + String(node.Cond)
+}
+
+func (node *PlanAggregate) String() string {
+	var aggs []string
+	for _, agg := range node.Aggregates {
+		aggs = append(aggs, String(agg))
+	}
+	if len(node.GroupBy) == 0 {
+		return "aggregate " + strings.Join(aggs, ", ")
+	}
+	s := "aggregate group by " + String(node.GroupBy)
+	if len(aggs) != 0 {
+		s += ": " + strings.Join(aggs, ", ")
+	}
+	return s
+}
+
+func (node *PlanProject) String() string {
+	if node.Distinct {
+		return "project distinct " + String(node.Exprs)
+	}
+	return "project " + String(node.Exprs)
+}
+
+func (node *PlanSort) String() string {
+	return "sort " + strings.TrimPrefix(String(node.OrderBy), " order by ")
+}
+
+func (node *PlanLimit) String() string {
+	return strings.TrimPrefix(String(node.Limit), " ")
+}
+
+func (node *PlanSetOp) String() string {
+	return node.Type
+}
+
+// BuildPlan lowers stmt into a logical plan. The operators of a
+// SELECT are stacked in the order SQL evaluates its clauses:
+// FROM, WHERE, GROUP BY and aggregation, HAVING, QUALIFY, the
+// select expressions, ORDER BY and LIMIT. A SELECT without a FROM
+// clause reads from a PlanDual. Subqueries in FROM
+// become PlanDerived operators; subqueries elsewhere are left
+// in the expressions that contain them.
+func BuildPlan(stmt SelectStatement) (PlanNode, error) {
+	switch stmt := stmt.(type) {
+	case *Select:
+		return buildSelectPlan(stmt)
+	case *Union:
+		left, err := BuildPlan(stmt.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := BuildPlan(stmt.Right)
+		if err != nil {
+			return nil, err
+		}
+		var plan PlanNode = &PlanSetOp{Type: stmt.Type, Left: left, Right: right}
+		if len(stmt.OrderBy) != 0 {
+			plan = &PlanSort{Input: plan, OrderBy: stmt.OrderBy}
+		}
+		if stmt.Limit != nil {
+			plan = &PlanLimit{Input: plan, Limit: stmt.Limit}
+		}
+		return plan, nil
+	case *ParenSelect:
+		return BuildPlan(stmt.Select)
+	}
+	return nil, fmt.Errorf("unsupported statement: %s", String(stmt))
+}
+
+func buildSelectPlan(sel *Select) (PlanNode, error) {
+	var plan PlanNode
+	for _, expr := range sel.From {
+		input, err := buildTablePlan(expr)
+		if err != nil {
+			return nil, err
+		}
+		if plan == nil {
+			plan = input
+		} else {
+			plan = &PlanJoin{Type: AST_CROSS_JOIN, Left: plan, Right: input}
+		}
+	}
+	if plan == nil {
+		plan = &PlanDual{}
+	}
+	if sel.Where != nil {
+		plan = &PlanFilter{Input: plan, Cond: sel.Where.Expr}
+	}
+	var aggs []*FuncExpr
+	for _, node := range []SQLNode{sel.SelectExprs, sel.Having, sel.OrderBy} {
+		aggs = append(aggs, aggregateFuncs(node)...)
+	}
+	if len(sel.GroupBy) != 0 || len(aggs) != 0 {
+		plan = &PlanAggregate{Input: plan, GroupBy: sel.GroupBy, Aggregates: aggs}
+	}
+	if sel.Having != nil {
+		plan = &PlanFilter{Input: plan, Cond: sel.Having.Expr}
+	}
+	if sel.Qualify != nil {
+		plan = &PlanFilter{Input: plan, Cond: sel.Qualify.Expr}
+	}
+	plan = &PlanProject{Input: plan, Exprs: sel.SelectExprs, Distinct: sel.Distinct != ""}
+	if len(sel.OrderBy) != 0 {
+		plan = &PlanSort{Input: plan, OrderBy: sel.OrderBy}
+	}
+	if sel.Limit != nil {
+		plan = &PlanLimit{Input: plan, Limit: sel.Limit}
+	}
+	return plan, nil
+}
+
+func buildTablePlan(expr TableExpr) (PlanNode, error) {
+	switch expr := expr.(type) {
+	case *AliasedTableExpr:
+		switch table := expr.Expr.(type) {
+		case *TableName:
+			return &PlanScan{Table: table, As: expr.As}, nil
+		case *Subquery:
+			input, err := BuildPlan(table.Select)
+			if err != nil {
+				return nil, err
+			}
+			return &PlanDerived{Input: input, As: expr.As}, nil
+		}
+	case *ParenTableExpr:
+		return buildTablePlan(expr.Expr)
+	case *JoinTableExpr:
+		left, err := buildTablePlan(expr.LeftExpr)
+		if err != nil {
+			return nil, err
+		}
+		right, err := buildTablePlan(expr.RightExpr)
+		if err != nil {
+			return nil, err
+		}
+		return &PlanJoin{Type: expr.Join, Left: left, Right: right, On: expr.On, Using: expr.Using}, nil
+	}
+	return nil, fmt.Errorf("unsupported table expression: %s", String(expr))
+}
+
+// PlanString formats plan as an indented tree, one
+// operator per line, with inputs below their consumers.
+func PlanString(plan PlanNode) string {
+	buf := new(bytes.Buffer)
+	var format func(node PlanNode, indent string)
+	format = func(node PlanNode, indent string) {
+		fmt.Fprintf(buf, "%s%s\n", indent, node)
+		for _, input := range node.Inputs() {
+			format(input, indent+"  ")
+		}
+	}
+	format(plan, "")
+	return buf.String()
+}
+
+// referencedColumns returns the distinct columns in node,
+// without descending into subqueries.
+func referencedColumns(node SQLNode) []*ColName {
+	var cols []*ColName
+	collectNodes(node, func(n SQLNode) bool {
+		col, ok := n.(*ColName)
+		if ok {
+			cols = append(cols, col)
+		}
+		return ok
+	})
+	return dedupColumns(cols)
+}
+
+// aggregateFuncs returns the aggregate function calls in node,
+// without descending into subqueries.
+func aggregateFuncs(node SQLNode) []*FuncExpr {
+	var funcs []*FuncExpr
+	collectNodes(node, func(n SQLNode) bool {
+		f, ok := n.(*FuncExpr)
+		if ok && f.IsAggregate() {
+			funcs = append(funcs, f)
+			return true
+		}
+		return false
+	})
+	return funcs
+}
+
+func dedupColumns(cols []*ColName) []*ColName {
+	seen := make(map[string]bool, len(cols))
+	out := cols[:0]
+	for _, col := range cols {
+		key := strings.ToLower(String(col))
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, col)
+		}
+	}
+	return out
+}
+
+// collectNodes calls match on the nodes reachable from node,
+// descending into those it does not match. It does not descend
+// into subqueries.
+func collectNodes(node SQLNode, match func(SQLNode) bool) {
+	Walk(func(n SQLNode) (bool, error) {
+		if _, ok := n.(*Subquery); ok {
+			return false, nil
+		}
+		return !match(n), nil
+	}, node)
+}
@@ -0,0 +1,130 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildTestPlan(t *testing.T, sql string) PlanNode {
+	tree, err := Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := BuildPlan(tree.(SelectStatement))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plan
+}
+
+func TestBuildPlan(t *testing.T) {
+	tcases := []struct {
+		sql  string
+		want string
+	}{{
+		sql:  "select 1 from dual",
+		want: "project 1\n  scan dual\n",
+	}, {
+		sql:  "select 1",
+		want: "project 1\n  dual\n",
+	}, {
+		sql:  "select 1 where 1 = 1",
+		want: "project 1\n  filter 1 = 1\n    dual\n",
+	}, {
+		sql: "select count(*) having count(*) > 0",
+		want: `project count(*)
+  filter count(*) > 0
+    aggregate count(*), count(*)
+      dual
+`,
+	}, {
+		sql: "select distinct a, count(*) from t as x join u on x.id = u.id, v where b = 1 group by a having sum(c) > 2 order by max(d) desc limit 10",
+		want: `limit 10
+  sort max(d) desc
+    project distinct a, count(*)
+      filter sum(c) > 2
+        aggregate group by a: count(*), sum(c), max(d)
+          filter b = 1
+            cross join
+              join on x.id = u.id
+                scan t as x
+                scan u
+              scan v
+`,
+	}, {
+		sql: "select count(*) from (select a from t where a > 1) as s",
+		want: `project count(*)
+  aggregate count(*)
+    derived as s
+      project a
+        filter a > 1
+          scan t
+`,
+	}, {
+		sql: "select a from t join u join v on u.id = v.id on t.id = u.id left join w using (id)",
+		want: `project a
+  left join using (id)
+    join on t.id = u.id
+      scan t
+      join on u.id = v.id
+        scan u
+        scan v
+    scan w
+`,
+	}, {
+		sql: "select a from t union select b from u",
+		want: `union
+  project a
+    scan t
+  project b
+    scan u
+`,
+	}, {
+		sql: "(select a from t limit 1) union all (select b from u) order by a limit 10",
+		want: `limit 10
+  sort a asc
+    union all
+      limit 1
+        project a
+          scan t
+      project b
+        scan u
+`,
+	}}
+	for _, tcase := range tcases {
+		assert.Equal(t, tcase.want, PlanString(buildTestPlan(t, tcase.sql)), tcase.sql)
+	}
+}
+
+func TestPlanColumns(t *testing.T) {
+	plan := buildTestPlan(t, "select a, b+1 from t where a = c and b in (select x from u) group by a, b order by a")
+	var columns []string
+	for node := plan; node != nil; {
+		var names []string
+		for _, col := range node.Columns() {
+			names = append(names, String(col))
+		}
+		columns = append(columns, node.String()+": "+strings.Join(names, " "))
+		inputs := node.Inputs()
+		if len(inputs) == 0 {
+			break
+		}
+		node = inputs[0]
+	}
+	assert.Equal(t, []string{
+		"sort a asc: a",
+		"project a, b+1: a b",
+		"aggregate group by a, b: a b",
+		"filter a = c and b in (select x from u): a c b",
+		"scan t: ",
+	}, columns)
+
+	_, err := BuildPlan(&Select{From: TableExprs{&PivotTableExpr{}}})
+	assert.NotNil(t, err)
+}
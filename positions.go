@@ -0,0 +1,132 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// positions.go records where in the sql each node was parsed from.
+
+import (
+	"reflect"
+)
+
+// Span is the part of the parsed sql a node was parsed from,
+// as the byte offsets of its first byte and of the byte after
+// its last. sql[span.Start:span.End] is the text of the node.
+type Span struct {
+	Start, End int
+}
+
+// Spans maps the nodes of a parse tree to the parts of the sql
+// they were parsed from.
+//
+// Spans are kept for nodes held by pointer, which includes the
+// statements, expressions and clauses, but not for value nodes
+// such as ColIdent, NumVal or StrVal. The span of a value is
+// usually that of its parent, such as the ColName holding a
+// ColIdent. A few nodes the parser makes up rather than parses,
+// such as the NonStarExprs of the columns of an INSERT, have no
+// span either.
+type Spans struct {
+	spans map[spanKey]Span
+}
+
+// spanKey identifies a pointer node. Nodes are told apart by
+// type too, as a struct and its first field share an address.
+type spanKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// Span returns the span of node, and whether it has one. Nodes
+// that were not parsed, or were copied since, have no span.
+func (s *Spans) Span(node SQLNode) (Span, bool) {
+	val := reflect.ValueOf(node)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return Span{}, false
+	}
+	span, ok := s.spans[spanKey{val.Type(), val.Pointer()}]
+	return span, ok
+}
+
+// ParseWithSpans parses sql like ParseWithOptions, and also
+// returns the spans of the nodes of the parse tree.
+func ParseWithSpans(sql string, opts Options) (Statement, *Spans, error) {
+	tokenizer := NewStringTokenizer(sql)
+	tokenizer.opts = opts
+	tokenizer.spans = make(map[spanKey]Span)
+	if yyParse(tokenizer) != 0 {
+		return nil, nil, tokenizer.parseError()
+	}
+	return tokenizer.ParseTree, &Spans{tokenizer.spans}, nil
+}
+
+// recordSpan is called by the parser after each reduction, with
+// val the value of the reduced symbol and stack[p+1:top+1] the
+// symbols it was reduced from. If spans are being recorded, it
+// sets the span of val, and records it for the nodes val was set
+// to by the reduction.
+//
+// A rule that only wraps a node in tokens, as in WHERE expr or
+// ( select ), leaves the node with its own span. A rule that
+// passes up a node it built from other symbols, as a CREATE
+// TABLE statement does with its table elements, extends the
+// span of the node to the whole rule.
+func recordSpan(yylex interface{}, val *yySymType, stack []yySymType, p, top int) {
+	tkn := yylex.(*Tokenizer)
+	if tkn.spans == nil {
+		return
+	}
+	if top > p {
+		val.pos, val.end = stack[p+1].pos, stack[top].end
+	} else {
+		// An empty rule spans nothing, after the symbol before it.
+		val.pos, val.end = stack[p].end, stack[p].end
+	}
+	val.reduced = true
+	rhs := stack[p+1 : top+1]
+	vals := reflect.ValueOf(val).Elem()
+	for i := 0; i < vals.NumField(); i++ {
+		node, ok := spanNode(vals, i)
+		if !ok {
+			continue
+		}
+		// The parser starts val as a copy of the symbol at p+1,
+		// so the fields that still hold its node were not set.
+		if orig, ok := spanNode(reflect.ValueOf(stack[p+1]), i); ok && orig == node {
+			continue
+		}
+		if wrapsNode(rhs, i, node) {
+			continue
+		}
+		tkn.spans[node] = Span{val.pos, val.end}
+	}
+}
+
+// spanNode returns the key of the pointer node in field i of
+// a yySymType.
+func spanNode(val reflect.Value, i int) (spanKey, bool) {
+	field := val.Field(i)
+	if field.Kind() == reflect.Interface {
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Ptr || field.IsNil() || !field.Type().Implements(typeOfSQLNode) {
+		return spanKey{}, false
+	}
+	return spanKey{field.Type(), field.Pointer()}, true
+}
+
+// wrapsNode reports whether one of the symbols of rhs holds node
+// in field i, and all the others are tokens.
+func wrapsNode(rhs []yySymType, i int, node spanKey) bool {
+	found := false
+	for _, sym := range rhs {
+		if sym.reduced {
+			if key, ok := spanNode(reflect.ValueOf(sym), i); !ok || key != node || found {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
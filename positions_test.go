@@ -0,0 +1,112 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpans(t *testing.T) {
+	sql := "select a, b + 1 as x from t as t1 join u on t1.id = u.id where c = (select d from u) and e in (1, 2) order by a desc limit 1"
+	tree, spans, err := ParseWithSpans(sql, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	Walk(func(node SQLNode) (bool, error) {
+		if span, ok := spans.Span(node); ok {
+			got = append(got, sql[span.Start:span.End])
+		}
+		return true, nil
+	}, tree)
+	assert.Equal(t, []string{
+		sql,
+		"a",
+		"a",
+		"b + 1 as x",
+		"b + 1",
+		"b",
+		"t as t1 join u on t1.id = u.id",
+		"t as t1",
+		"t",
+		"u",
+		"u",
+		"t1.id = u.id",
+		"t1.id",
+		"u.id",
+		"where c = (select d from u) and e in (1, 2)",
+		"c = (select d from u) and e in (1, 2)",
+		"c = (select d from u)",
+		"c",
+		"(select d from u)",
+		"select d from u",
+		"d",
+		"d",
+		"u",
+		"u",
+		"e in (1, 2)",
+		"e",
+		"a desc",
+		"a",
+		"limit 1",
+	}, got)
+}
+
+func TestSpansStatements(t *testing.T) {
+	tcases := []struct {
+		sql  string
+		node func(Statement) SQLNode
+		want string
+	}{{
+		"create table t (a int primary key, b varchar(10), index i (b))",
+		func(stmt Statement) SQLNode { return stmt },
+		"create table t (a int primary key, b varchar(10), index i (b))",
+	}, {
+		"create table t (a int primary key, b varchar(10), index i (b))",
+		func(stmt Statement) SQLNode { return stmt.(*CreateTable).ColumnDefinitions[1] },
+		"b varchar(10)",
+	}, {
+		"create table t (a int primary key, b varchar(10), index i (b))",
+		func(stmt Statement) SQLNode { return stmt.(*CreateTable).Indexes[0] },
+		"index i (b)",
+	}, {
+		"update t set a = 1 where b between 1 and 2",
+		func(stmt Statement) SQLNode { return stmt.(*Update).Exprs[0] },
+		"a = 1",
+	}, {
+		"delete from t where not (b like 'x%')",
+		func(stmt Statement) SQLNode { return stmt.(*Delete).Where.Expr.(*NotExpr).Expr },
+		"(b like 'x%')",
+	}, {
+		"select count(*) from t group by a having count(*) > 1",
+		func(stmt Statement) SQLNode { return stmt.(*Select).Having },
+		"having count(*) > 1",
+	}, {
+		"  select 1 from dual  ",
+		func(stmt Statement) SQLNode { return stmt },
+		"select 1 from dual",
+	}}
+	for _, tcase := range tcases {
+		tree, spans, err := ParseWithSpans(tcase.sql, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		span, ok := spans.Span(tcase.node(tree))
+		if assert.True(t, ok, tcase.want) {
+			assert.Equal(t, tcase.want, tcase.sql[span.Start:span.End])
+		}
+	}
+
+	tree, spans, err := ParseWithSpans("select 1 from dual", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok := spans.Span(tree.(*Select).SelectExprs)
+	assert.False(t, ok)
+	_, ok = spans.Span(&Select{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,982 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// predicate.go compiles boolean expressions into Go functions
+// that can be applied to in-memory rows.
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getlantern/sqlparser/dependency/sqltypes"
+)
+
+// Predicate reports whether a row satisfies a compiled boolean
+// expression. Rows map column names to values.
+type Predicate func(row map[string]interface{}) (bool, error)
+
+// CompilePredicate compiles expr into a Predicate, so that it can
+// be applied to many rows without walking the AST again. Bind
+// variables in expr are bound to bindVars at compile time.
+//
+// Columns are looked up in the row by their qualified name, such
+// as "t.a", and then by their unqualified name. Row values may be
+// nil, bools, integers, floats, strings, byte slices, time.Time or
+// sqltypes.Value. Strings compare byte-wise, as with a binary
+// collation, and are converted when compared with numbers or times.
+//
+// Comparisons follow SQL three-valued logic: a comparison involving
+// NULL is neither true nor false, and a row satisfies the predicate
+// only if the expression is true.
+//
+// Subqueries, function calls and other expressions that need a
+// database to evaluate are rejected with an error.
+func CompilePredicate(expr BoolExpr, bindVars map[string]interface{}) (Predicate, error) {
+	c := &predicateCompiler{bindVars: bindVars}
+	fn, err := c.boolExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	return func(row map[string]interface{}) (bool, error) {
+		t, err := fn(row)
+		return t == sqlTrue, err
+	}, nil
+}
+
+// truth is the result of a boolean expression under
+// three-valued logic.
+type truth int
+
+const (
+	sqlFalse truth = iota
+	sqlTrue
+	sqlUnknown
+)
+
+func truthOf(b bool) truth {
+	if b {
+		return sqlTrue
+	}
+	return sqlFalse
+}
+
+func (t truth) not() truth {
+	switch t {
+	case sqlTrue:
+		return sqlFalse
+	case sqlFalse:
+		return sqlTrue
+	}
+	return sqlUnknown
+}
+
+type boolFunc func(row map[string]interface{}) (truth, error)
+
+type valueFunc func(row map[string]interface{}) (interface{}, error)
+
+type predicateCompiler struct {
+	bindVars map[string]interface{}
+	// funcs enables the functions of sqlFuncs.
+	funcs bool
+	// collated rejects comparisons and matches of strings, whose
+	// results depend on the collation of the database: MySQL's
+	// default collations ignore case and trailing spaces, where
+	// compareValues compares bytes.
+	collated bool
+}
+
+// compare compares two non-nil normalized values, as
+// compareValues does, failing if c is collated and either is a
+// string.
+func (c *predicateCompiler) compare(a, b interface{}) (int, error) {
+	if err := c.checkCollation(a, b); err != nil {
+		return 0, err
+	}
+	return compareValues(a, b)
+}
+
+// checkCollation fails if c is collated and any of vals is a
+// string.
+func (c *predicateCompiler) checkCollation(vals ...interface{}) error {
+	if !c.collated {
+		return nil
+	}
+	for _, v := range vals {
+		if s, ok := v.(string); ok {
+			return fmt.Errorf("comparison depends on the collation: '%s'", s)
+		}
+	}
+	return nil
+}
+
+func (c *predicateCompiler) boolExpr(expr BoolExpr) (boolFunc, error) {
+	switch expr := expr.(type) {
+	case *AndExpr:
+		left, right, err := c.boolExprs(expr.Left, expr.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(row map[string]interface{}) (truth, error) {
+			l, err := left(row)
+			if err != nil || l == sqlFalse {
+				return sqlFalse, err
+			}
+			r, err := right(row)
+			if err != nil || r == sqlFalse {
+				return sqlFalse, err
+			}
+			if l == sqlUnknown || r == sqlUnknown {
+				return sqlUnknown, nil
+			}
+			return sqlTrue, nil
+		}, nil
+	case *OrExpr:
+		left, right, err := c.boolExprs(expr.Left, expr.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(row map[string]interface{}) (truth, error) {
+			l, err := left(row)
+			if err != nil || l == sqlTrue {
+				return l, err
+			}
+			r, err := right(row)
+			if err != nil || r == sqlTrue {
+				return r, err
+			}
+			if l == sqlUnknown || r == sqlUnknown {
+				return sqlUnknown, nil
+			}
+			return sqlFalse, nil
+		}, nil
+	case *NotExpr:
+		inner, err := c.boolExpr(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return func(row map[string]interface{}) (truth, error) {
+			t, err := inner(row)
+			return t.not(), err
+		}, nil
+	case *ParenBoolExpr:
+		return c.boolExpr(expr.Expr)
+	case *ComparisonExpr:
+		return c.comparison(expr)
+	case *RangeCond:
+		return c.rangeCond(expr)
+	case *NullCheck:
+		inner, err := c.valExpr(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		isNull := expr.Operator == AST_IS_NULL
+		return func(row map[string]interface{}) (truth, error) {
+			v, err := inner(row)
+			return truthOf((v == nil) == isNull), err
+		}, nil
+	case *IsExpr:
+		return c.isExpr(expr)
+	}
+	return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+}
+
+// isTruths maps the IsExpr operators to the truth they test
+// for, and whether they are negated.
+var isTruths = map[string]struct {
+	want    truth
+	negated bool
+}{
+	AST_IS_TRUE:        {sqlTrue, false},
+	AST_IS_NOT_TRUE:    {sqlTrue, true},
+	AST_IS_FALSE:       {sqlFalse, false},
+	AST_IS_NOT_FALSE:   {sqlFalse, true},
+	AST_IS_UNKNOWN:     {sqlUnknown, false},
+	AST_IS_NOT_UNKNOWN: {sqlUnknown, true},
+}
+
+func (c *predicateCompiler) isExpr(expr *IsExpr) (boolFunc, error) {
+	test, ok := isTruths[expr.Operator]
+	if !ok {
+		return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+	}
+	if cond, ok := expr.Expr.(BoolExpr); ok {
+		inner, err := c.boolExpr(cond)
+		if err != nil {
+			return nil, err
+		}
+		return func(row map[string]interface{}) (truth, error) {
+			t, err := inner(row)
+			if err != nil {
+				return sqlUnknown, err
+			}
+			return truthOf((t == test.want) != test.negated), nil
+		}, nil
+	}
+	inner, err := c.valExpr(expr.Expr.(ValExpr))
+	if err != nil {
+		return nil, err
+	}
+	return func(row map[string]interface{}) (truth, error) {
+		v, err := inner(row)
+		if err != nil {
+			return sqlUnknown, err
+		}
+		// A value is true if it is not zero, as in MySQL.
+		t := sqlUnknown
+		if v != nil {
+			cmp, err := compareValues(v, int64(0))
+			if err != nil {
+				return sqlUnknown, err
+			}
+			t = truthOf(cmp != 0)
+		}
+		return truthOf((t == test.want) != test.negated), nil
+	}, nil
+}
+
+func (c *predicateCompiler) boolExprs(left, right BoolExpr) (boolFunc, boolFunc, error) {
+	l, err := c.boolExpr(left)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := c.boolExpr(right)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
+
+func (c *predicateCompiler) comparison(expr *ComparisonExpr) (boolFunc, error) {
+	switch expr.Operator {
+	case AST_IN, AST_NOT_IN:
+		return c.in(expr)
+	case AST_LIKE, AST_NOT_LIKE:
+		return c.like(expr)
+	case AST_REGEXP, AST_NOT_REGEXP:
+		return c.regexpMatch(expr)
+	}
+	left, err := c.valExpr(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.valExpr(expr.Right)
+	if err != nil {
+		return nil, err
+	}
+	op := expr.Operator
+	return func(row map[string]interface{}) (truth, error) {
+		l, err := left(row)
+		if err != nil {
+			return sqlFalse, err
+		}
+		r, err := right(row)
+		if err != nil {
+			return sqlFalse, err
+		}
+		if l == nil || r == nil {
+			if op == AST_NSE {
+				return truthOf(l == nil && r == nil), nil
+			}
+			return sqlUnknown, nil
+		}
+		cmp, err := c.compare(l, r)
+		if err != nil {
+			return sqlFalse, err
+		}
+		switch op {
+		case AST_EQ, AST_NSE:
+			return truthOf(cmp == 0), nil
+		case AST_NE:
+			return truthOf(cmp != 0), nil
+		case AST_LT:
+			return truthOf(cmp < 0), nil
+		case AST_LE:
+			return truthOf(cmp <= 0), nil
+		case AST_GT:
+			return truthOf(cmp > 0), nil
+		case AST_GE:
+			return truthOf(cmp >= 0), nil
+		}
+		return sqlFalse, fmt.Errorf("unsupported operator: %s", op)
+	}, nil
+}
+
+func (c *predicateCompiler) in(expr *ComparisonExpr) (boolFunc, error) {
+	left, err := c.valExpr(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+	var list []valueFunc
+	switch right := expr.Right.(type) {
+	case ValTuple:
+		for _, e := range right {
+			fn, err := c.valExpr(e)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, fn)
+		}
+	case ListArg:
+		vals, _, err := FetchBindVar(string(right), c.bindVars)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vals.([]interface{}) {
+			v, err := normalizeValue(v)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, constantValue(v))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported expression: %s", String(expr.Right))
+	}
+	negate := expr.Operator == AST_NOT_IN
+	return func(row map[string]interface{}) (truth, error) {
+		l, err := left(row)
+		if err != nil || l == nil {
+			return sqlUnknown, err
+		}
+		result := sqlFalse
+		for _, fn := range list {
+			r, err := fn(row)
+			if err != nil {
+				return sqlFalse, err
+			}
+			if r == nil {
+				result = sqlUnknown
+				continue
+			}
+			cmp, err := c.compare(l, r)
+			if err != nil {
+				return sqlFalse, err
+			}
+			if cmp == 0 {
+				result = sqlTrue
+				break
+			}
+		}
+		if negate {
+			return result.not(), nil
+		}
+		return result, nil
+	}, nil
+}
+
+func (c *predicateCompiler) like(expr *ComparisonExpr) (boolFunc, error) {
+	escape := byte('\\')
+	if expr.Escape != nil {
+		fn, err := c.valExpr(expr.Escape)
+		if err != nil {
+			return nil, err
+		}
+		v, err := fn(nil)
+		if err != nil || v == nil || len(valueToString(v)) != 1 {
+			return nil, fmt.Errorf("invalid escape: %s", String(expr.Escape))
+		}
+		escape = valueToString(v)[0]
+	}
+	return c.match(expr, expr.Operator == AST_NOT_LIKE, func(pattern string) (*regexp.Regexp, error) {
+		return likeToRegexp(pattern, escape), nil
+	})
+}
+
+// regexpMatch compiles a REGEXP comparison. Patterns use the
+// syntax of the regexp package, which is close to MySQL's for
+// common patterns, and match anywhere in the value.
+func (c *predicateCompiler) regexpMatch(expr *ComparisonExpr) (boolFunc, error) {
+	return c.match(expr, expr.Operator == AST_NOT_REGEXP, regexp.Compile)
+}
+
+// match compiles a comparison of a value with a pattern, which
+// compile converts into a regular expression.
+func (c *predicateCompiler) match(expr *ComparisonExpr, negate bool, compile func(string) (*regexp.Regexp, error)) (boolFunc, error) {
+	left, err := c.valExpr(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.valExpr(expr.Right)
+	if err != nil {
+		return nil, err
+	}
+	// Compile constant patterns once, up front.
+	var re *regexp.Regexp
+	if IsValue(expr.Right) {
+		pattern, err := right(nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkCollation(pattern); err != nil {
+			return nil, err
+		}
+		if pattern != nil {
+			if re, err = compile(valueToString(pattern)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return func(row map[string]interface{}) (truth, error) {
+		l, err := left(row)
+		if err != nil || l == nil {
+			return sqlUnknown, err
+		}
+		if err := c.checkCollation(l); err != nil {
+			return sqlUnknown, err
+		}
+		matcher := re
+		if matcher == nil {
+			r, err := right(row)
+			if err != nil || r == nil {
+				return sqlUnknown, err
+			}
+			if err := c.checkCollation(r); err != nil {
+				return sqlUnknown, err
+			}
+			if matcher, err = compile(valueToString(r)); err != nil {
+				return sqlUnknown, err
+			}
+		}
+		matched := matcher.MatchString(valueToString(l))
+		return truthOf(matched != negate), nil
+	}, nil
+}
+
+// likeToRegexp converts a LIKE pattern into an anchored regular
+// expression. The escape character escapes the character
+// following it.
+func likeToRegexp(pattern string, escape byte) *regexp.Regexp {
+	buf := make([]byte, 0, len(pattern)+8)
+	buf = append(buf, "(?s)^"...)
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; {
+		case ch == escape:
+			if i+1 < len(pattern) {
+				i++
+			}
+			buf = append(buf, regexp.QuoteMeta(pattern[i:i+1])...)
+		case ch == '%':
+			buf = append(buf, ".*"...)
+		case ch == '_':
+			buf = append(buf, '.')
+		default:
+			buf = append(buf, regexp.QuoteMeta(pattern[i:i+1])...)
+		}
+	}
+	buf = append(buf, '$')
+	return regexp.MustCompile(string(buf))
+}
+
+func (c *predicateCompiler) rangeCond(expr *RangeCond) (boolFunc, error) {
+	// a BETWEEN b AND c is a >= b AND a <= c.
+	between := &AndExpr{
+		Left:  &ComparisonExpr{Operator: AST_GE, Left: expr.Left, Right: expr.From},
+		Right: &ComparisonExpr{Operator: AST_LE, Left: expr.Left, Right: expr.To},
+	}
+	if expr.Operator == AST_NOT_BETWEEN {
+		return c.boolExpr(&NotExpr{Expr: between})
+	}
+	return c.boolExpr(between)
+}
+
+func (c *predicateCompiler) valExpr(expr ValExpr) (valueFunc, error) {
+	switch expr := expr.(type) {
+	case StrVal:
+		return constantValue(expr.Val), nil
+	case NumVal, HexVal, BitVal:
+		v, err := literalNumber(expr)
+		if err != nil {
+			return nil, err
+		}
+		return constantValue(v), nil
+	case *NullVal:
+		return constantValue(nil), nil
+	case BoolVal:
+		// TRUE and FALSE are 1 and 0, as in MySQL.
+		v, _ := normalizeValue(bool(expr))
+		return constantValue(v), nil
+	case ValArg:
+		v, _, err := FetchBindVar(string(expr), c.bindVars)
+		if err != nil {
+			return nil, err
+		}
+		v, err = normalizeValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return constantValue(v), nil
+	case *ColName:
+		return columnValue(expr), nil
+	case *ParenExpr:
+		return c.valExpr(expr.Expr)
+	case *UnaryExpr:
+		return c.unary(expr)
+	case *BinaryExpr:
+		return c.binary(expr)
+	case *CaseExpr:
+		return c.caseExpr(expr)
+	case *FuncExpr:
+		if c.funcs {
+			return c.funcExpr(expr)
+		}
+	}
+	return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+}
+
+func constantValue(v interface{}) valueFunc {
+	return func(map[string]interface{}) (interface{}, error) {
+		return v, nil
+	}
+}
+
+func columnValue(col *ColName) valueFunc {
+	name := col.Name.String()
+	qualified := ""
+	if !col.Qualifier.IsEmpty() {
+		qualified = col.Qualifier.String() + "." + name
+	}
+	return func(row map[string]interface{}) (interface{}, error) {
+		v, ok := row[qualified]
+		if !ok {
+			v, ok = row[name]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown column %s", String(col))
+		}
+		return normalizeValue(v)
+	}
+}
+
+func (c *predicateCompiler) unary(expr *UnaryExpr) (valueFunc, error) {
+	operand, ok := expr.Expr.(ValExpr)
+	if !ok {
+		return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+	}
+	inner, err := c.valExpr(operand)
+	if err != nil {
+		return nil, err
+	}
+	op := expr.Operator
+	return func(row map[string]interface{}) (interface{}, error) {
+		v, err := inner(row)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		n, err := toNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case AST_UMINUS:
+			if i, ok := n.(int64); ok {
+				if i == math.MinInt64 {
+					return nil, fmt.Errorf("BIGINT value is out of range in -(%d)", i)
+				}
+				return -i, nil
+			}
+			return -n.(float64), nil
+		case AST_TILDA:
+			return ^toInt64(n), nil
+		}
+		return n, nil
+	}, nil
+}
+
+func (c *predicateCompiler) binary(expr *BinaryExpr) (valueFunc, error) {
+	lexpr, lok := expr.Left.(ValExpr)
+	rexpr, rok := expr.Right.(ValExpr)
+	if !lok || !rok {
+		return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+	}
+	left, err := c.valExpr(lexpr)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.valExpr(rexpr)
+	if err != nil {
+		return nil, err
+	}
+	op := expr.Operator
+	return func(row map[string]interface{}) (interface{}, error) {
+		l, err := left(row)
+		if err != nil || l == nil {
+			return nil, err
+		}
+		r, err := right(row)
+		if err != nil || r == nil {
+			return nil, err
+		}
+		return arithmetic(op, l, r)
+	}, nil
+}
+
+func (c *predicateCompiler) caseExpr(expr *CaseExpr) (valueFunc, error) {
+	if expr.Expr != nil {
+		return nil, fmt.Errorf("unsupported expression: %s", String(expr))
+	}
+	conds := make([]boolFunc, 0, len(expr.Whens))
+	vals := make([]valueFunc, 0, len(expr.Whens))
+	for _, when := range expr.Whens {
+		cond, err := c.boolExpr(when.Cond)
+		if err != nil {
+			return nil, err
+		}
+		val, err := c.valExpr(when.Val)
+		if err != nil {
+			return nil, err
+		}
+		conds, vals = append(conds, cond), append(vals, val)
+	}
+	elseVal := constantValue(nil)
+	if expr.Else != nil {
+		var err error
+		if elseVal, err = c.valExpr(expr.Else); err != nil {
+			return nil, err
+		}
+	}
+	return func(row map[string]interface{}) (interface{}, error) {
+		for i, cond := range conds {
+			t, err := cond(row)
+			if err != nil {
+				return nil, err
+			}
+			if t == sqlTrue {
+				return vals[i](row)
+			}
+		}
+		return elseVal(row)
+	}, nil
+}
+
+// normalizeValue converts a row or bind variable value into one of
+// the types the predicate functions operate on: nil, int64, float64,
+// string or time.Time.
+func normalizeValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, int64, float64, string, time.Time:
+		return v, nil
+	case bool:
+		if v {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint:
+		return normalizeUint(uint64(v)), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return normalizeUint(v), nil
+	case float32:
+		return float64(v), nil
+	case []byte:
+		return string(v), nil
+	case sqltypes.Value:
+		switch {
+		case v.IsNull():
+			return nil, nil
+		case v.IsNumeric(), v.IsFractional():
+			return parseNumber(v.String())
+		}
+		return v.String(), nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
+
+func normalizeUint(v uint64) interface{} {
+	if v > math.MaxInt64 {
+		return float64(v)
+	}
+	return int64(v)
+}
+
+// parseNumber parses s as a decimal number, an int64 if it is an
+// integer that fits one, and a float64 otherwise. Leading zeros
+// do not make it octal, as in MySQL.
+func parseNumber(s string) (interface{}, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+	if !isDecimal(s) {
+		return nil, fmt.Errorf("invalid number %s", s)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %s", s)
+	}
+	return f, nil
+}
+
+// isDecimal reports whether s only has the characters of a decimal
+// number, which excludes the hexadecimal numbers, infinities and
+// NaNs strconv.ParseFloat accepts.
+func isDecimal(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(uint16(s[i])) && strings.IndexByte(".eE+-", s[i]) < 0 {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isHexOrBitNumber reports whether num is a hexadecimal or binary
+// number, such as 0x1F or 0b1010.
+func isHexOrBitNumber(num NumVal) bool {
+	return len(num) > 2 && num[0] == '0' && strings.IndexByte("xXbB", num[1]) >= 0
+}
+
+// literalNumber returns the value of a number literal. The
+// hexadecimal and binary numbers 0x1F and 0b1010 are the same as
+// the literals X'1F' and b'1010', and, as these, are read as
+// unsigned integers.
+func literalNumber(expr ValExpr) (interface{}, error) {
+	if num, ok := expr.(NumVal); ok && isHexOrBitNumber(num) {
+		switch num[1] {
+		case 'x', 'X':
+			expr = HexVal(num[2:])
+		case 'b', 'B':
+			expr = BitVal(num[2:])
+		}
+	}
+	var digits string
+	var base int
+	switch expr := expr.(type) {
+	case NumVal:
+		return parseNumber(string(expr))
+	case HexVal:
+		digits, base = string(expr), 16
+	case BitVal:
+		digits, base = string(expr), 2
+	default:
+		return nil, fmt.Errorf("%s is not a number literal", String(expr))
+	}
+	u, err := strconv.ParseUint(digits, base, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %s", String(expr))
+	}
+	return normalizeUint(u), nil
+}
+
+// toNumber returns v as an int64 or float64, converting strings.
+func toNumber(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case int64, float64:
+		return v, nil
+	case string:
+		return parseNumber(strings.TrimSpace(v))
+	}
+	return nil, fmt.Errorf("%v is not a number", v)
+}
+
+func toInt64(n interface{}) int64 {
+	if i, ok := n.(int64); ok {
+		return i
+	}
+	return int64(n.(float64))
+}
+
+func toFloat64(n interface{}) float64 {
+	if f, ok := n.(float64); ok {
+		return f
+	}
+	return float64(n.(int64))
+}
+
+// timeLayouts are the layouts strings are parsed
+// with when they are compared with times.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	time.RFC3339Nano,
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %s", s)
+}
+
+// compareValues compares two non-nil normalized values, returning
+// -1, 0 or 1. Strings are converted when compared with numbers or
+// times.
+func compareValues(a, b interface{}) (int, error) {
+	switch a := a.(type) {
+	case string:
+		switch b := b.(type) {
+		case string:
+			return strings.Compare(a, b), nil
+		case time.Time:
+			t, err := parseTime(a)
+			if err != nil {
+				return 0, err
+			}
+			return compareTimes(t, b), nil
+		}
+		n, err := toNumber(a)
+		if err != nil {
+			return 0, err
+		}
+		return compareValues(n, b)
+	case time.Time:
+		switch b := b.(type) {
+		case time.Time:
+			return compareTimes(a, b), nil
+		case string:
+			t, err := parseTime(b)
+			if err != nil {
+				return 0, err
+			}
+			return compareTimes(a, t), nil
+		}
+		return 0, fmt.Errorf("cannot compare time with %v", b)
+	}
+	switch b.(type) {
+	case string:
+		n, err := toNumber(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareValues(a, n)
+	case time.Time:
+		return 0, fmt.Errorf("cannot compare %v with time", a)
+	}
+	if ai, ok := a.(int64); ok {
+		if bi, ok := b.(int64); ok {
+			switch {
+			case ai < bi:
+				return -1, nil
+			case ai > bi:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	af, bf := toFloat64(a), toFloat64(b)
+	switch {
+	case af < bf:
+		return -1, nil
+	case af > bf:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// arithmetic applies a BinaryExpr operator to two non-nil values.
+// Like MySQL, it returns NULL on division by zero, and division
+// always produces a float.
+func arithmetic(op byte, l, r interface{}) (interface{}, error) {
+	ln, err := toNumber(l)
+	if err != nil {
+		return nil, err
+	}
+	rn, err := toNumber(r)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case AST_BITAND:
+		return toInt64(ln) & toInt64(rn), nil
+	case AST_BITOR:
+		return toInt64(ln) | toInt64(rn), nil
+	case AST_BITXOR:
+		return toInt64(ln) ^ toInt64(rn), nil
+	case AST_DIV:
+		if toFloat64(rn) == 0 {
+			return nil, nil
+		}
+		return toFloat64(ln) / toFloat64(rn), nil
+	}
+	li, lok := ln.(int64)
+	ri, rok := rn.(int64)
+	if lok && rok {
+		var n int64
+		switch op {
+		case AST_PLUS:
+			if n = li + ri; (li^n)&(ri^n) < 0 {
+				return nil, outOfRange(li, op, ri)
+			}
+			return n, nil
+		case AST_MINUS:
+			if n = li - ri; (li^ri)&(li^n) < 0 {
+				return nil, outOfRange(li, op, ri)
+			}
+			return n, nil
+		case AST_MULT:
+			if n = li * ri; li != 0 && (n/li != ri || li == -1 && ri == math.MinInt64) {
+				return nil, outOfRange(li, op, ri)
+			}
+			return n, nil
+		case AST_MOD:
+			if ri == 0 {
+				return nil, nil
+			}
+			return li % ri, nil
+		}
+	}
+	lf, rf := toFloat64(ln), toFloat64(rn)
+	switch op {
+	case AST_PLUS:
+		return lf + rf, nil
+	case AST_MINUS:
+		return lf - rf, nil
+	case AST_MULT:
+		return lf * rf, nil
+	case AST_MOD:
+		if rf == 0 {
+			return nil, nil
+		}
+		return math.Mod(lf, rf), nil
+	}
+	return nil, fmt.Errorf("unsupported operator: %c", op)
+}
+
+// outOfRange returns the error of an integer operation whose
+// result does not fit an int64, which, as in MySQL, is not wrapped.
+func outOfRange(l int64, op byte, r int64) error {
+	return fmt.Errorf("BIGINT value is out of range in %d %c %d", l, op, r)
+}
+
+// valueToString formats a normalized value for LIKE matching.
+func valueToString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprint(v)
+}
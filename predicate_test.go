@@ -0,0 +1,133 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/getlantern/sqlparser/dependency/sqltypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func compileWhere(t *testing.T, where string, bindVars map[string]interface{}) Predicate {
+	tree, err := Parse("select * from t where " + where)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pred, err := CompilePredicate(tree.(*Select).Where.Expr, bindVars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pred
+}
+
+func TestCompilePredicate(t *testing.T) {
+	row := map[string]interface{}{
+		"a":    5,
+		"b":    "hello",
+		"c":    nil,
+		"d":    2.5,
+		"t.e":  []byte("x"),
+		"f":    true,
+		"g":    sqltypes.MakeNumeric([]byte("7")),
+		"ts":   time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		"name": "50%_off",
+	}
+	bindVars := map[string]interface{}{
+		"x":    uint8(5),
+		"list": []interface{}{"a", "hello"},
+	}
+	tcases := []struct {
+		where string
+		want  bool
+	}{
+		{"a = 5", true},
+		{"a = :x", true},
+		{"a != 5", false},
+		{"a < d*3", true},
+		{"a+1 = 6 and d >= 2.5", true},
+		{"a/2 = 2.5", true},
+		{"a % 0 is null", true},
+		{"-a = -5", true},
+		{"~0 = -1", true},
+		{"a = '5'", true},
+		{"a = 05 and 010 = 10 and 09 = 9", true},
+		{"0x10 = 16 and X'10' = 16 and 0b101 = a and b'101' = a", true},
+		{"b = 'hello'", true},
+		{"b > 'help'", false},
+		{"c = 1", false},
+		{"not c = 1", false},
+		{"c is null", true},
+		{"c is not null", false},
+		{"c <=> null", true},
+		{"a <=> null", false},
+		{"c = 1 or a = 5", true},
+		{"c = 1 and a = 6", false},
+		{"not (c = 1 and a = 6)", true},
+		{"a in (1, 5)", true},
+		{"a not in (1, 2)", true},
+		{"a not in (1, null)", false},
+		{"b in ::list", true},
+		{"b like 'he%'", true},
+		{"b like 'h_llo'", true},
+		{"b like 'HE%'", false},
+		{"b not like '%z%'", true},
+		{"name like '50\\%\\_off'", true},
+		{"name like '50\\%x%'", false},
+		{"name like '5\\_%'", false},
+		{"a between 1 and 5", true},
+		{"a not between 1 and 5", false},
+		{"t.e = 'x' and t.a = 5", true},
+		{"f = 1", true},
+		{"g = 7", true},
+		{"ts > '2020-01-01' and ts < '2020-01-02 03:04:06'", true},
+		{"case when a > 1 then b else c end = 'hello'", true},
+		{"(a = 5)", true},
+		{"name like '50!%!_off' escape '!'", true},
+		{"name like '50|_%' escape '|'", false},
+		{"b regexp '^h.l+o$'", true},
+		{"b rlike 'z'", false},
+		{"b not regexp 'ell'", false},
+		{"a is true", true},
+		{"a - 5 is false", true},
+		{"c is not unknown", false},
+		{"c is not true", true},
+		{"(a = 5) is true", true},
+		{"a = 4 is not false", false},
+		{"(c = 1 or a = 4) is unknown", true},
+		{"not a = 4 is true", true},
+	}
+	for _, tcase := range tcases {
+		got, err := compileWhere(t, tcase.where, bindVars)(row)
+		assert.Nil(t, err, tcase.where)
+		assert.Equal(t, tcase.want, got, tcase.where)
+	}
+}
+
+func TestCompilePredicateErrors(t *testing.T) {
+	for _, where := range []string{
+		"a in (select b from u)",
+		"exists (select b from u)",
+		"lower(a) = 'x'",
+		"a = :missing",
+		"case a when b = 1 then 1 end = 1",
+	} {
+		tree, err := Parse("select * from t where " + where)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = CompilePredicate(tree.(*Select).Where.Expr, nil)
+		assert.NotNil(t, err, where)
+	}
+
+	pred := compileWhere(t, "missing = 1", nil)
+	_, err := pred(map[string]interface{}{"a": 1})
+	assert.Equal(t, "unknown column missing", err.Error())
+
+	pred = compileWhere(t, "a = 1", nil)
+	_, err = pred(map[string]interface{}{"a": "abc"})
+	assert.Equal(t, "invalid number abc", err.Error())
+}
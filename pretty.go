@@ -0,0 +1,224 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// pretty.go formats statements over multiple indented lines.
+
+import (
+	"strings"
+)
+
+// PrettyOptions configures FormatPretty.
+type PrettyOptions struct {
+	// Indent is written once per level of indentation.
+	// It defaults to two spaces.
+	Indent string
+}
+
+// FormatPretty formats node like String, but over multiple lines
+// for logging and generated SQL files. The clauses of a query start
+// lines of their own, with their select expressions, tables, ORDER
+// BY items and the conditions joined by AND of their WHERE clauses
+// listed one per line below them, indented by one level.
+// Subqueries are indented by one level more than the line they
+// start in.
+func FormatPretty(node SQLNode, opts PrettyOptions) string {
+	buf := NewTrackedBuffer(nil)
+	buf.SetPretty(opts)
+	buf.Myprintf("%v", node)
+	return buf.String()
+}
+
+// prettyState is the state of a TrackedBuffer in pretty mode.
+type prettyState struct {
+	indent string
+	depth  int
+}
+
+// SetPretty puts buf in pretty mode, in which queries are
+// formatted as by FormatPretty.
+func (buf *TrackedBuffer) SetPretty(opts PrettyOptions) {
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+	buf.pretty = &prettyState{indent: opts.Indent}
+}
+
+// newline ends the current line, dropping its trailing spaces,
+// and indents the next.
+func (buf *TrackedBuffer) newline() {
+	for buf.Len() > 0 && buf.Bytes()[buf.Len()-1] == ' ' {
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('\n')
+	buf.WriteString(strings.Repeat(buf.pretty.indent, buf.pretty.depth))
+}
+
+// prettyList writes the n nodes returned by item on lines of
+// their own, indented by one level, after the clause keyword.
+func (buf *TrackedBuffer) prettyList(keyword string, n int, item func(i int) SQLNode) {
+	buf.newline()
+	buf.WriteString(keyword)
+	buf.pretty.depth++
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.newline()
+		buf.Myprintf("%v", item(i))
+	}
+	buf.pretty.depth--
+}
+
+// formatPretty formats the nodes laid out differently in pretty
+// mode, and reports whether node was one of them.
+func (buf *TrackedBuffer) formatPretty(node SQLNode) bool {
+	switch node := node.(type) {
+	case *Select:
+		buf.prettySelect(node)
+	case *Union:
+		if node == nil {
+			return true
+		}
+		buf.Myprintf("%v%v", node.With, node.Left)
+		buf.newline()
+		buf.WriteString(node.Type)
+		buf.newline()
+		buf.Myprintf("%v%v%v", node.Right, node.OrderBy, node.Limit)
+		if node.Lock != "" {
+			buf.newline()
+			buf.WriteString(strings.TrimPrefix(node.Lock, " "))
+		}
+	case *ParenSelect:
+		if node == nil {
+			return true
+		}
+		buf.prettyParens(node.Select)
+	case *With:
+		if node == nil {
+			return true
+		}
+		buf.WriteString("with ")
+		if node.Recursive {
+			buf.WriteString("recursive ")
+		}
+		for i, cte := range node.CTEs {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.Myprintf("%v", cte)
+		}
+		buf.newline()
+	case *Subquery:
+		if node == nil {
+			return true
+		}
+		buf.prettyParens(node.Select)
+	case *JoinTableExpr:
+		if node == nil {
+			return true
+		}
+		buf.Myprintf("%v", node.LeftExpr)
+		buf.newline()
+		buf.Myprintf("%s %v", node.Join, node.RightExpr)
+		if node.On != nil {
+			buf.Myprintf(" on %v", node.On)
+		}
+		if node.Using != nil {
+			buf.Myprintf(" using %v", node.Using)
+		}
+	case *Where:
+		if node == nil {
+			return true
+		}
+		buf.newline()
+		buf.WriteString(node.Type)
+		buf.pretty.depth++
+		buf.newline()
+		buf.prettyConds(node.Expr)
+		buf.pretty.depth--
+	case OrderBy:
+		if len(node) == 0 {
+			return true
+		}
+		buf.prettyList("order by", len(node), func(i int) SQLNode { return node[i] })
+	case *Limit:
+		if node == nil {
+			return true
+		}
+		buf.newline()
+		buf.WriteString("limit ")
+		if node.Offset != nil {
+			buf.Myprintf("%v, ", node.Offset)
+		}
+		buf.Myprintf("%v", node.Rowcount)
+	default:
+		return false
+	}
+	return true
+}
+
+// prettyParens writes sel in parentheses, indented by one level
+// on lines of its own.
+func (buf *TrackedBuffer) prettyParens(sel SelectStatement) {
+	buf.WriteByte('(')
+	buf.pretty.depth++
+	buf.newline()
+	buf.Myprintf("%v", sel)
+	buf.pretty.depth--
+	buf.newline()
+	buf.WriteByte(')')
+}
+
+// prettyConds writes the conditions of a chain of ANDs
+// on lines of their own.
+func (buf *TrackedBuffer) prettyConds(expr BoolExpr) {
+	and, ok := expr.(*AndExpr)
+	if !ok {
+		buf.Myprintf("%v", expr)
+		return
+	}
+	buf.prettyConds(and.Left)
+	buf.newline()
+	buf.WriteString("and ")
+	buf.prettyConds(and.Right)
+}
+
+func (buf *TrackedBuffer) prettySelect(node *Select) {
+	if node == nil {
+		return
+	}
+	buf.Myprintf("%vselect %v%v", node.With, node.Comments, node.Hints)
+	node.formatOptions(buf)
+	buf.pretty.depth++
+	for i, expr := range node.SelectExprs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.newline()
+		buf.Myprintf("%v", expr)
+	}
+	buf.pretty.depth--
+	if len(node.From) > 0 {
+		buf.prettyList("from", len(node.From), func(i int) SQLNode { return node.From[i] })
+	}
+	buf.Myprintf("%v%v%v", node.TimeRange, node.Clauses, node.Where)
+	if len(node.GroupBy) > 0 {
+		buf.prettyList("group by", len(node.GroupBy), func(i int) SQLNode { return node.GroupBy[i] })
+	}
+	if node.WithRollup {
+		buf.newline()
+		buf.WriteString("with rollup")
+	}
+	buf.Myprintf("%v%v", node.Having, node.Qualify)
+	if len(node.Windows) > 0 {
+		buf.prettyList("window", len(node.Windows), func(i int) SQLNode { return node.Windows[i] })
+	}
+	buf.Myprintf("%v%v", node.OrderBy, node.Limit)
+	if node.Lock != "" {
+		buf.newline()
+		buf.WriteString(strings.TrimPrefix(node.Lock, " "))
+	}
+}
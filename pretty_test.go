@@ -0,0 +1,73 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPretty(t *testing.T) {
+	tree, err := Parse("select distinct a, count(*) as n from t join u on t.id = u.id " +
+		"where a = 1 and (b = 2 or c = 3) and d in (select e from f where g = 1) " +
+		"group by a having count(*) > 1 order by a desc limit 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `select distinct
+  a,
+  count(*) as n
+from
+  t
+  join u on t.id = u.id
+where
+  a = 1
+  and (b = 2 or c = 3)
+  and d in (
+    select
+      e
+    from
+      f
+    where
+      g = 1
+  )
+group by
+  a
+having
+  count(*) > 1
+order by
+  a desc
+limit 10`, FormatPretty(tree, PrettyOptions{}))
+
+	tree, err = Parse("select a from t union all select b from u where c = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "select\n\ta\nfrom\n\tt\nunion all\nselect\n\tb\nfrom\n\tu\nwhere\n\tc = 1",
+		FormatPretty(tree, PrettyOptions{Indent: "\t"}))
+}
+
+func TestFormatPrettyRoundTrip(t *testing.T) {
+	for _, tcase := range validSQL {
+		tree, err := Parse(tcase.input)
+		if err != nil || tree == nil {
+			continue
+		}
+		if _, err := Parse(String(tree)); err != nil {
+			// Not all statements format back to sql that parses.
+			continue
+		}
+		pretty := FormatPretty(tree, PrettyOptions{})
+		again, err := Parse(pretty)
+		if err != nil {
+			t.Errorf("Parse(FormatPretty(%q)): %v\n%s", tcase.input, err, pretty)
+			continue
+		}
+		if got, want := String(again), String(tree); got != want {
+			t.Errorf("pretty round trip of %q = %q, want %q", tcase.input, got, want)
+		}
+	}
+}
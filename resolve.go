@@ -0,0 +1,511 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// resolve.go binds the columns of statements to the tables
+// they come from.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Schema describes the tables of a database to Resolve and
+// Validate.
+type Schema interface {
+	// Table returns the columns of the table name, in order, and
+	// false if there is no such table. The types of the columns
+	// may be left empty if they are not known.
+	Table(name *TableName) ([]*ColumnDefinition, bool)
+}
+
+// MapSchema is a Schema that maps table names, optionally
+// qualified by their database as in db.t, to the names of their
+// columns. Their types are not known.
+type MapSchema map[string][]string
+
+// Table returns the columns of the table name. A table that is
+// not qualified by its database is looked up in any database.
+func (s MapSchema) Table(name *TableName) ([]*ColumnDefinition, bool) {
+	cols, ok := s.lookup(name)
+	if !ok {
+		return nil, false
+	}
+	defs := make([]*ColumnDefinition, len(cols))
+	for i, col := range cols {
+		defs[i] = &ColumnDefinition{ColName: col}
+	}
+	return defs, true
+}
+
+func (s MapSchema) lookup(name *TableName) ([]string, bool) {
+	// The keys are names as written, such as db.t, not as
+	// formatted, with quotes.
+	want := name.Name.String()
+	if !name.Qualifier.IsEmpty() {
+		want = name.Qualifier.String() + "." + want
+	}
+	for key, cols := range s {
+		if strings.EqualFold(key, want) {
+			return cols, true
+		}
+	}
+	if !name.Qualifier.IsEmpty() {
+		return nil, false
+	}
+	for key, cols := range s {
+		if i := strings.LastIndexByte(key, '.'); i >= 0 && strings.EqualFold(key[i+1:], name.Name.String()) {
+			return cols, true
+		}
+	}
+	return nil, false
+}
+
+// Source is a table that columns are resolved to: a table, a
+// CTE, a derived table or a table function of a FROM clause, or
+// the table of an INSERT, UPDATE or DELETE. Name is the alias of
+// the table, or its name if it has none. Table is nil unless the
+// source is a table. Columns lists the columns of the source, and
+// is nil if they are not known.
+type Source struct {
+	Name    TableIdent
+	Table   *TableName
+	Columns []string
+}
+
+func (src *Source) hasColumn(name ColIdent) bool {
+	for _, col := range src.Columns {
+		if name.EqualString(col) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveProblem is an unknown or ambiguous column, or an unknown
+// table, found by Resolve. Node is the offending ColName, or the
+// TableName of a table missing from the schema.
+type ResolveProblem struct {
+	Message string
+	Node    SQLNode
+}
+
+func (p ResolveProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Message, String(p.Node))
+}
+
+// Resolution is the result of Resolve. Bindings maps each column
+// of the statement to the source it refers to. Columns that cannot
+// be resolved for want of a schema are left out of Bindings, but
+// are not reported as problems.
+type Resolution struct {
+	Bindings map[*ColName]*Source
+	Problems []ResolveProblem
+}
+
+// Resolve binds the columns of stmt to the sources they refer
+// to, following the scoping rules of SQL: a column is looked up
+// among the tables of the FROM clause of the query it appears in,
+// then of the queries enclosing it. schema, which may be nil,
+// gives the columns of tables; those of CTEs and derived tables
+// are taken from their select expressions. A column is ambiguous
+// if more than one table has it, unless it is named by a USING
+// clause, and unknown if no table has it and the columns of all
+// the tables are known. With a schema, tables missing from it are
+// reported as unknown. The select expression aliases are visible
+// in GROUP BY, HAVING, QUALIFY and ORDER BY.
+func Resolve(stmt Statement, schema Schema) *Resolution {
+	r := &resolver{schema: schema, res: &Resolution{Bindings: make(map[*ColName]*Source)}}
+	r.resolveStatement(stmt)
+	return r.res
+}
+
+type resolver struct {
+	schema Schema
+	res    *Resolution
+}
+
+// scope holds the sources a query can refer to, its CTEs, the
+// columns named by the USING clauses of its joins, and, once the
+// select expressions are resolved, their aliases.
+type scope struct {
+	parent  *scope
+	sources []*Source
+	ctes    map[string]*Source
+	using   map[string]bool
+	aliases map[string]bool
+}
+
+// addUsing adds col to the columns named by USING clauses.
+func (s *scope) addUsing(col string) {
+	if s.using == nil {
+		s.using = make(map[string]bool)
+	}
+	s.using[strings.ToLower(col)] = true
+}
+
+func (s *scope) lookupSource(name TableIdent) *Source {
+	for _, src := range s.sources {
+		if src.Name.Equal(name) {
+			return src
+		}
+	}
+	return nil
+}
+
+func (s *scope) lookupCTE(name TableIdent) *Source {
+	for ; s != nil; s = s.parent {
+		if cte := s.ctes[name.Lowered()]; cte != nil {
+			return cte
+		}
+	}
+	return nil
+}
+
+func (r *resolver) problem(message string, node SQLNode) {
+	r.res.Problems = append(r.res.Problems, ResolveProblem{message, node})
+}
+
+func (r *resolver) resolveStatement(stmt Statement) {
+	switch stmt := stmt.(type) {
+	case SelectStatement:
+		r.resolveSelect(stmt, nil)
+	case *Insert:
+		s := &scope{}
+		r.addTable(s, stmt.Table, TableIdent{}, nil)
+		r.resolveExprs(s, stmt.Columns)
+		if rows, ok := stmt.Rows.(SelectStatement); ok {
+			r.resolveSelect(rows, nil)
+		} else {
+			r.resolveExprs(s, stmt.Rows)
+		}
+		r.resolveExprs(r.onDupScope(s, stmt), stmt.OnDup)
+		r.resolveExprs(s, stmt.Returning)
+	case *Update:
+		s := &scope{}
+		joins := r.addTableExprs(s, stmt.Table)
+		r.resolveExprs(s, joins...)
+		r.resolveExprs(s, stmt.Exprs, stmt.Where, stmt.OrderBy, stmt.Limit, stmt.Returning)
+	case *Delete:
+		s := &scope{}
+		if stmt.Table != nil {
+			r.addTable(s, stmt.Table, TableIdent{}, nil)
+		}
+		joins := r.addTableExprs(s, stmt.From)
+		r.resolveExprs(s, joins...)
+		for _, target := range stmt.Targets {
+			if s.lookupSource(target.Name) == nil {
+				r.problem("unknown table", target)
+			}
+		}
+		r.resolveExprs(s, stmt.Where, stmt.OrderBy, stmt.Limit, stmt.Returning)
+	default:
+		// Resolve the queries of other statements, such as
+		// EXPLAIN and CREATE VIEW, on their own.
+		Walk(func(node SQLNode) (bool, error) {
+			if sel, ok := node.(SelectStatement); ok {
+				r.resolveSelect(sel, nil)
+				return false, nil
+			}
+			return true, nil
+		}, stmt)
+	}
+}
+
+// onDupScope returns the scope of the ON DUPLICATE KEY UPDATE
+// clause of stmt, whose table is in s. Its row alias is found in
+// an enclosing scope, so that the columns of the table take
+// precedence over the columns the alias names. Without these, the
+// alias has the columns of the table.
+func (r *resolver) onDupScope(s *scope, stmt *Insert) *scope {
+	if stmt.RowAlias == nil {
+		return s
+	}
+	alias := &Source{Name: stmt.RowAlias.Name}
+	if len(stmt.RowAlias.Columns) == 0 {
+		alias.Table, alias.Columns = stmt.Table, s.sources[0].Columns
+	} else {
+		for _, col := range referencedColumns(stmt.RowAlias.Columns) {
+			alias.Columns = append(alias.Columns, col.Name.String())
+		}
+	}
+	return &scope{parent: &scope{sources: []*Source{alias}}, sources: s.sources}
+}
+
+// resolveSelect resolves the columns of stmt, a query nested in
+// parent, and returns the names of its result columns, or nil if
+// they are not known.
+func (r *resolver) resolveSelect(stmt SelectStatement, parent *scope) []string {
+	switch stmt := stmt.(type) {
+	case *Select:
+		s := &scope{parent: parent}
+		r.addCTEs(s, stmt.With)
+		joins := r.addTableExprs(s, stmt.From)
+		r.resolveExprs(s, joins...)
+		r.resolveExprs(s, stmt.SelectExprs, stmt.Where)
+		s.aliases = make(map[string]bool)
+		for _, expr := range stmt.SelectExprs {
+			if expr, ok := expr.(*NonStarExpr); ok && !expr.As.IsEmpty() {
+				s.aliases[expr.As.Lowered()] = true
+			}
+		}
+		r.resolveExprs(s, stmt.GroupBy, stmt.Having, stmt.Qualify, stmt.OrderBy, stmt.Limit)
+		for _, window := range stmt.Windows {
+			r.resolveExprs(s, window)
+		}
+		return s.resultColumns(stmt.SelectExprs)
+	case *Union:
+		s := &scope{parent: parent}
+		r.addCTEs(s, stmt.With)
+		cols := r.resolveSelect(stmt.Left, s)
+		r.resolveSelect(stmt.Right, s)
+		return cols
+	case *ParenSelect:
+		return r.resolveSelect(stmt.Select, parent)
+	case *ValuesStatement:
+		r.resolveExprs(&scope{parent: parent}, stmt.Rows)
+	}
+	return nil
+}
+
+func (r *resolver) addCTEs(s *scope, with *With) {
+	if with == nil {
+		return
+	}
+	s.ctes = make(map[string]*Source)
+	for _, cte := range with.CTEs {
+		src := &Source{Name: cte.Name, Columns: identNames(cte.Columns)}
+		if with.Recursive {
+			// A recursive CTE refers to itself.
+			s.ctes[cte.Name.Lowered()] = src
+		}
+		cols := r.resolveSelect(cte.Subquery.Select, s)
+		if src.Columns == nil {
+			src.Columns = cols
+		}
+		s.ctes[cte.Name.Lowered()] = src
+	}
+}
+
+// addTableExprs adds the sources of exprs to s, and returns
+// the join conditions, to be resolved once all are added.
+func (r *resolver) addTableExprs(s *scope, exprs TableExprs) []SQLNode {
+	var joins []SQLNode
+	for _, expr := range exprs {
+		joins = r.addTableExpr(s, expr, joins)
+	}
+	return joins
+}
+
+func (r *resolver) addTableExpr(s *scope, expr TableExpr, joins []SQLNode) []SQLNode {
+	switch expr := expr.(type) {
+	case *AliasedTableExpr:
+		r.addAliasedTable(s, expr)
+	case *ParenTableExpr:
+		return r.addTableExpr(s, expr.Expr, joins)
+	case *JoinTableExpr:
+		left := len(s.sources)
+		joins = r.addTableExpr(s, expr.LeftExpr, joins)
+		right := len(s.sources)
+		joins = r.addTableExpr(s, expr.RightExpr, joins)
+		if expr.On != nil {
+			joins = append(joins, expr.On)
+		}
+		for _, col := range referencedColumns(expr.Using) {
+			s.addUsing(col.Name.String())
+		}
+		if expr.Join == AST_NATURAL_JOIN {
+			// A natural join joins the columns both sides have,
+			// as if they were named by USING.
+			for _, lsrc := range s.sources[left:right] {
+				for _, col := range lsrc.Columns {
+					for _, rsrc := range s.sources[right:] {
+						if rsrc.hasColumn(NewColIdent(col)) {
+							s.addUsing(col)
+						}
+					}
+				}
+			}
+		}
+	case *PivotTableExpr:
+		inner := &scope{parent: s.parent}
+		r.resolveExprs(inner, r.addTableExpr(inner, expr.Expr, nil)...)
+		r.resolveExprs(inner, expr.Aggregates, expr.For)
+		s.sources = append(s.sources, &Source{Name: expr.As})
+	case *UnpivotTableExpr:
+		inner := &scope{parent: s.parent}
+		r.resolveExprs(inner, r.addTableExpr(inner, expr.Expr, nil)...)
+		r.resolveExprs(inner, expr.In)
+		s.sources = append(s.sources, &Source{Name: expr.As})
+	}
+	return joins
+}
+
+func (r *resolver) addAliasedTable(s *scope, expr *AliasedTableExpr) {
+	switch table := expr.Expr.(type) {
+	case *TableName:
+		r.addTable(s, table, expr.As, expr.Columns)
+		return
+	case *Subquery:
+		parent := s.parent
+		if expr.Lateral {
+			parent = s
+		}
+		cols := r.resolveSelect(table.Select, parent)
+		s.sources = append(s.sources, &Source{Name: expr.As, Columns: aliasColumns(expr.Columns, cols)})
+	case *JSONTableExpr:
+		r.resolveExprs(s, table.Expr)
+		s.sources = append(s.sources, &Source{Name: expr.As, Columns: aliasColumns(expr.Columns, jsonTableColumns(table.Columns))})
+	case *FuncExpr:
+		r.resolveExprs(s, table.Exprs)
+		s.sources = append(s.sources, &Source{Name: expr.As, Columns: identNames(expr.Columns)})
+	}
+}
+
+// addTable adds the table name, aliased as as, to s. The columns
+// of CTEs and of tables in the schema can be renamed by cols.
+func (r *resolver) addTable(s *scope, name *TableName, as TableIdent, cols []ColIdent) {
+	src := &Source{Name: as, Table: name}
+	if as.IsEmpty() {
+		src.Name = name.Name
+	}
+	if cte := s.lookupCTE(name.Name); cte != nil && name.Qualifier.IsEmpty() {
+		src.Table = nil
+		src.Columns = cte.Columns
+	} else if r.schema != nil {
+		if defs, ok := r.schema.Table(name); ok {
+			src.Columns = make([]string, len(defs))
+			for i, def := range defs {
+				src.Columns[i] = def.ColName
+			}
+		} else {
+			r.problem("unknown table", name)
+		}
+	}
+	src.Columns = aliasColumns(cols, src.Columns)
+	s.sources = append(s.sources, src)
+}
+
+// resolveExprs binds the columns of nodes in s. Subqueries are
+// resolved in scopes of their own, nested in s.
+func (r *resolver) resolveExprs(s *scope, nodes ...SQLNode) {
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Subquery:
+			r.resolveSelect(node.Select, s)
+			return false, nil
+		case *ColName:
+			r.bind(s, node)
+			return false, nil
+		}
+		return true, nil
+	}, nodes...)
+}
+
+func (r *resolver) bind(s *scope, col *ColName) {
+	for ; s != nil; s = s.parent {
+		if !col.Qualifier.IsEmpty() {
+			src := s.lookupSource(col.Qualifier)
+			if src == nil {
+				continue
+			}
+			if src.Columns != nil && !src.hasColumn(col.Name) {
+				r.problem("unknown column", col)
+				return
+			}
+			r.res.Bindings[col] = src
+			return
+		}
+		var found, unknown []*Source
+		for _, src := range s.sources {
+			switch {
+			case src.Columns == nil:
+				unknown = append(unknown, src)
+			case src.hasColumn(col.Name):
+				found = append(found, src)
+			}
+		}
+		switch {
+		case len(found) > 1 && !s.using[col.Name.Lowered()]:
+			r.problem("ambiguous column", col)
+			return
+		case len(found) != 0:
+			r.res.Bindings[col] = found[0]
+			return
+		case len(unknown) == 1:
+			r.res.Bindings[col] = unknown[0]
+			return
+		case len(unknown) > 1, s.aliases[col.Name.Lowered()]:
+			return
+		}
+	}
+	if col.Qualifier.IsEmpty() {
+		r.problem("unknown column", col)
+	} else {
+		r.problem("unknown table", col)
+	}
+}
+
+// resultColumns returns the names of the result columns of a
+// query with the select expressions exprs, or nil if the columns
+// a star expression stands for are not known.
+func (s *scope) resultColumns(exprs SelectExprs) []string {
+	var cols []string
+	for _, expr := range exprs {
+		switch expr := expr.(type) {
+		case *StarExpr:
+			for _, src := range s.sources {
+				if !expr.TableName.IsEmpty() && !strings.EqualFold(src.Name.String(), expr.TableName.String()) {
+					continue
+				}
+				if src.Columns == nil {
+					return nil
+				}
+				cols = append(cols, src.Columns...)
+			}
+		case *NonStarExpr:
+			if !expr.As.IsEmpty() {
+				cols = append(cols, expr.As.String())
+			} else if col, ok := expr.Expr.(*ColName); ok {
+				cols = append(cols, col.Name.String())
+			} else {
+				cols = append(cols, String(expr.Expr))
+			}
+		}
+	}
+	return cols
+}
+
+// aliasColumns returns the column names given by a column alias
+// list, or cols if there is none.
+func aliasColumns(aliases []ColIdent, cols []string) []string {
+	if len(aliases) != 0 {
+		return identNames(aliases)
+	}
+	return cols
+}
+
+func identNames(idents []ColIdent) []string {
+	if len(idents) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(idents))
+	for _, ident := range idents {
+		names = append(names, ident.String())
+	}
+	return names
+}
+
+func jsonTableColumns(cols []*JSONTableColumn) []string {
+	var names []string
+	for _, col := range cols {
+		if col.Kind == AST_NESTED_PATH {
+			names = append(names, jsonTableColumns(col.Columns)...)
+		} else {
+			names = append(names, col.Name.String())
+		}
+	}
+	return names
+}
@@ -0,0 +1,130 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	schema := MapSchema{
+		"t":          {"id", "a", "b"},
+		"u":          {"id", "c"},
+		"db.v":       {"id", "d"},
+		"order":      {"id", "e"},
+		"my db.x":    {"f"},
+		"db.my view": {"g"},
+	}
+	tcases := []struct {
+		sql      string
+		schema   Schema
+		bindings []string
+		problems []string
+	}{{
+		sql:      "select a, c from t join u on t.id = u.id",
+		schema:   schema,
+		bindings: []string{"a: t", "c: u", "t.id: t", "u.id: u"},
+	}, {
+		sql:      "select id from t, u",
+		schema:   schema,
+		problems: []string{"ambiguous column: id"},
+	}, {
+		sql:      "select id from t join u using (id)",
+		schema:   schema,
+		bindings: []string{"id: t"},
+	}, {
+		sql:      "select id, a, c from t natural join u",
+		schema:   schema,
+		bindings: []string{"id: t", "a: t", "c: u"},
+	}, {
+		sql:      "select x.a, e from t as x where x.c = 1",
+		schema:   schema,
+		bindings: []string{"x.a: x"},
+		problems: []string{"unknown column: e", "unknown column: x.c"},
+	}, {
+		sql:      "select y.a from t, w",
+		schema:   schema,
+		problems: []string{"unknown table: w", "unknown table: y.a"},
+	}, {
+		sql:      "select d from v",
+		schema:   schema,
+		bindings: []string{"d: v"},
+	}, {
+		sql:      "select e, f, g from `order`, `my db`.x, db.`my view`",
+		schema:   schema,
+		bindings: []string{"e: order", "f: x", "g: my view"},
+	}, {
+		sql:      "select a from t where id in (select id from u where c = t.b)",
+		schema:   schema,
+		bindings: []string{"a: t", "id: t", "id: u", "c: u", "t.b: t"},
+	}, {
+		sql:      "select s.n, s.c from (select a as n, c from t, u) as s",
+		schema:   schema,
+		bindings: []string{"s.n: s", "s.c: s", "a: t", "c: u"},
+	}, {
+		sql:      "with w (x) as (select a from t) select x from w",
+		schema:   schema,
+		bindings: []string{"a: t", "x: w"},
+	}, {
+		sql:      "select a as k, count(*) from t group by k order by k",
+		schema:   schema,
+		bindings: []string{"a: t"},
+	}, {
+		sql:      "select a from t join lateral (select c from u where u.id = t.id) as l",
+		schema:   schema,
+		bindings: []string{"a: t", "c: u", "u.id: u", "t.id: t"},
+	}, {
+		sql:      "insert into t values (1, 2, 3) as new on duplicate key update a = new.a + b",
+		schema:   schema,
+		bindings: []string{"a: t", "new.a: new", "b: t"},
+	}, {
+		sql:      "insert into t (id, a) values (1, 2) as new (m, n) on duplicate key update a = n, b = new.m",
+		schema:   schema,
+		bindings: []string{"id: t", "a: t", "a: t", "n: new", "b: t", "new.m: new"},
+	}, {
+		sql:      "insert into t values (1, 2, 3) as new on duplicate key update a = new.c",
+		schema:   schema,
+		bindings: []string{"a: t"},
+		problems: []string{"unknown column: new.c"},
+	}, {
+		sql:      "update t join u on t.id = u.id set a = c where b = 1",
+		schema:   schema,
+		bindings: []string{"t.id: t", "u.id: u", "a: t", "c: u", "b: t"},
+	}, {
+		sql:      "select a, b from t, u",
+		bindings: nil,
+	}, {
+		sql:      "select a, t.b from t",
+		bindings: []string{"a: t", "t.b: t"},
+	}, {
+		sql:      "select a from t where z.b = 1",
+		bindings: []string{"a: t"},
+		problems: []string{"unknown table: z.b"},
+	}}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := Resolve(tree, tcase.schema)
+		var bindings []string
+		Walk(func(node SQLNode) (bool, error) {
+			if col, ok := node.(*ColName); ok {
+				if src := res.Bindings[col]; src != nil {
+					bindings = append(bindings, String(col)+": "+src.Name.String())
+				}
+			}
+			return true, nil
+		}, tree)
+		var problems []string
+		for _, p := range res.Problems {
+			problems = append(problems, p.String())
+		}
+		assert.Equal(t, tcase.bindings, bindings, tcase.sql)
+		assert.Equal(t, tcase.problems, problems, tcase.sql)
+	}
+}
@@ -6,6 +6,9 @@ import (
 )
 
 var typeOfBytes = reflect.TypeOf([]byte(nil))
+var typeOfStrVal = reflect.TypeOf(StrVal{})
+var typeOfColIdent = reflect.TypeOf(ColIdent{})
+var typeOfTableIdent = reflect.TypeOf(TableIdent{})
 var typeOfSQLNode = reflect.TypeOf((*SQLNode)(nil)).Elem()
 
 type Rewriter func([]byte) []byte
@@ -30,6 +33,21 @@ func rewrite(nodeVal reflect.Value, rewriter Rewriter) {
 			}
 		}
 	case reflect.Struct:
+		switch nodeTyp {
+		case typeOfStrVal:
+			// String literals are values, not names.
+			return
+		case typeOfColIdent:
+			if id := nodeVal.Interface().(ColIdent); !id.IsEmpty() && nodeVal.CanSet() {
+				nodeVal.Set(reflect.ValueOf(NewColIdent(string(rewriter([]byte(id.String()))))))
+			}
+			return
+		case typeOfTableIdent:
+			if id := nodeVal.Interface().(TableIdent); !id.IsEmpty() && nodeVal.CanSet() {
+				nodeVal.Set(reflect.ValueOf(NewTableIdent(string(rewriter([]byte(id.String()))))))
+			}
+			return
+		}
 		for i := 0; i < nodeVal.NumField(); i++ {
 			f := nodeVal.Field(i)
 			rewrite(f, rewriter)
@@ -0,0 +1,244 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// simplify.go simplifies the conditions of statements.
+
+import (
+	"reflect"
+)
+
+// Simplify simplifies the conditions of stmt, so that statements
+// that differ only in how their conditions are written are more
+// likely to format the same, as for caching or routing them.
+// Parentheses that do not change the meaning of a condition are
+// dropped, repeated terms of AND and OR are merged, as are the
+// terms one of the others implies, as in a and (a or b), and IN
+// with a single value becomes an equality. Constant terms, such as
+// 1 = 1, are dropped from AND and OR where they do not decide the
+// result, and a WHERE or HAVING clause that is always true is
+// removed. Comparisons and matches of strings, such as 'b' > 'B',
+// are not taken for constants, as their results depend on the
+// collation of the database. Terms that may differ each time they are evaluated,
+// such as those calling RAND(), are never merged.
+func Simplify(stmt Statement) {
+	s := &simplifier{folder: &folder{compiler: &predicateCompiler{funcs: true, collated: true}}}
+	s.simplifyValue(reflect.ValueOf(stmt), nil)
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Select:
+			node.Where = s.foldWhere(node.Where)
+			node.Having = s.foldWhere(node.Having)
+		case *Update:
+			node.Where = s.foldWhere(node.Where)
+		case *Delete:
+			node.Where = s.foldWhere(node.Where)
+		}
+		return true, nil
+	}, stmt)
+}
+
+type simplifier struct {
+	*folder
+}
+
+// simplifyValue simplifies the conditions reachable from val, which
+// are held by the node parent, children first.
+func (s *simplifier) simplifyValue(val reflect.Value, parent SQLNode) {
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		for val.CanSet() {
+			paren, ok := val.Elem().Interface().(*ParenBoolExpr)
+			if !ok || paren == nil || !reflect.TypeOf(paren.Expr).Implements(val.Type()) || !redundantParens(parent, paren.Expr) {
+				break
+			}
+			val.Set(reflect.ValueOf(paren.Expr))
+		}
+		if node, ok := val.Elem().Interface().(SQLNode); ok && val.Elem().Kind() != reflect.Ptr {
+			parent = node
+		}
+		s.simplifyValue(val.Elem(), parent)
+		if expr, ok := val.Elem().Interface().(BoolExpr); ok && val.CanSet() {
+			if simplified := s.simplifyExpr(expr); reflect.TypeOf(simplified).AssignableTo(val.Type()) {
+				val.Set(reflect.ValueOf(simplified))
+			}
+		}
+	case reflect.Ptr:
+		if val.IsNil() {
+			return
+		}
+		if node, ok := val.Interface().(SQLNode); ok {
+			parent = node
+		}
+		s.simplifyValue(val.Elem(), parent)
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			s.simplifyValue(val.Index(i), parent)
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			s.simplifyValue(val.Field(i), parent)
+		}
+	}
+}
+
+// simplifyExpr simplifies expr, whose operands are simplified.
+func (s *simplifier) simplifyExpr(expr BoolExpr) BoolExpr {
+	switch expr := expr.(type) {
+	case *ComparisonExpr:
+		tuple, ok := expr.Right.(ValTuple)
+		if !ok || len(tuple) != 1 {
+			break
+		}
+		if _, ok := tuple[0].(*Subquery); ok {
+			// a in ((select ...)) compares a with a single row.
+			break
+		}
+		switch expr.Operator {
+		case AST_IN:
+			return &ComparisonExpr{Operator: AST_EQ, Left: expr.Left, Right: tuple[0]}
+		case AST_NOT_IN:
+			return &ComparisonExpr{Operator: AST_NE, Left: expr.Left, Right: tuple[0]}
+		}
+	case *AndExpr:
+		return s.merge(expr, sqlTrue, sqlFalse)
+	case *OrExpr:
+		return s.merge(expr, sqlFalse, sqlTrue)
+	}
+	return expr
+}
+
+// merge rebuilds expr, an AND or an OR, from its terms, without
+// those that are repeated or implied by another term, and without
+// the constant terms that are neutral. If a term is constant and
+// decides the result, that term is returned alone.
+func (s *simplifier) merge(expr BoolExpr, neutral, decisive truth) BoolExpr {
+	terms := boolTerms(expr, nil)
+	seen := make(map[string]bool)
+	var kept []BoolExpr
+	for _, term := range terms {
+		if t, ok := s.constantTruth(term); ok {
+			if t == decisive {
+				return term
+			}
+			if t == neutral {
+				continue
+			}
+		}
+		key := String(term)
+		if deterministic(term) {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		kept = append(kept, term)
+	}
+	if len(kept) == 0 {
+		// All the terms are neutral, which decides the result.
+		return terms[0]
+	}
+	merged := kept[:0]
+	for _, term := range kept {
+		if !s.absorbed(expr, term, seen) {
+			merged = append(merged, term)
+		}
+	}
+	result := merged[0]
+	for _, term := range merged[1:] {
+		switch expr.(type) {
+		case *AndExpr:
+			result = &AndExpr{Left: result, Right: term}
+		case *OrExpr:
+			result = &OrExpr{Left: result, Right: term}
+		}
+	}
+	return result
+}
+
+// absorbed reports whether term, of the AND or OR expr, is implied
+// by another of its terms, whose formatted texts are in others:
+// whether term is an OR of one of them where expr is an AND, or an
+// AND of one of them where expr is an OR.
+func (s *simplifier) absorbed(expr, term BoolExpr, others map[string]bool) bool {
+	inner, ok := unparen(term).(BoolExpr)
+	if !ok || reflect.TypeOf(inner) == reflect.TypeOf(expr) {
+		return false
+	}
+	switch inner.(type) {
+	case *AndExpr, *OrExpr:
+	default:
+		return false
+	}
+	for _, t := range boolTerms(inner, nil) {
+		if others[String(t)] && deterministic(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// boolTerms appends to terms the operands of expr, an AND or an
+// OR, and of the operands of the same kind it is made of.
+func boolTerms(expr BoolExpr, terms []BoolExpr) []BoolExpr {
+	var left, right BoolExpr
+	switch node := expr.(type) {
+	case *AndExpr:
+		left, right = node.Left, node.Right
+	case *OrExpr:
+		left, right = node.Left, node.Right
+	}
+	for _, operand := range []BoolExpr{left, right} {
+		if inner, ok := unparen(operand).(BoolExpr); ok && reflect.TypeOf(inner) == reflect.TypeOf(expr) {
+			terms = boolTerms(inner, terms)
+		} else {
+			terms = append(terms, operand)
+		}
+	}
+	return terms
+}
+
+// unparen returns expr without the parentheses enclosing it.
+func unparen(expr Expr) Expr {
+	for {
+		inner := parenthesized(expr)
+		if inner == nil {
+			return expr
+		}
+		expr = inner
+	}
+}
+
+// nondeterministicFuncs are the functions whose results may differ
+// each time they are called in a statement.
+var nondeterministicFuncs = map[string]bool{
+	"rand":       true,
+	"uuid":       true,
+	"uuid_short": true,
+	"sysdate":    true,
+	"nextval":    true,
+}
+
+// deterministic reports whether expr gives the same result each
+// time it is evaluated in a statement: whether it neither calls a
+// function such as RAND() nor assigns a variable.
+func deterministic(expr BoolExpr) bool {
+	ok := true
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *FuncExpr:
+			if nondeterministicFuncs[node.Name.Lowered()] {
+				ok = false
+			}
+		case *AssignExpr, *Nextval:
+			ok = false
+		}
+		return ok, nil
+	}, expr)
+	return ok
+}
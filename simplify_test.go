@@ -0,0 +1,83 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplify(t *testing.T) {
+	tcases := []struct {
+		sql  string
+		want string
+	}{{
+		sql:  "select a from t where ((a = 1)) and (((b = 2 or c = 3)))",
+		want: "select a from t where a = 1 and (b = 2 or c = 3)",
+	}, {
+		sql:  "select a from t where not ((a = 1 and b = 2)) or (c = 1 or d = 2)",
+		want: "select a from t where not (a = 1 and b = 2) or c = 1 or d = 2",
+	}, {
+		sql:  "select a from t where a in (1) and b not in ('x') and (c, d) in ((1, 2))",
+		want: "select a from t where a = 1 and b != 'x' and (c, d) = (1, 2)",
+	}, {
+		sql:  "select a from t where a in ((select b from u)) and a in (1, 2)",
+		want: "select a from t where a in ((select b from u)) and a in (1, 2)",
+	}, {
+		sql:  "select a from t where 1 = 1 and a = 1",
+		want: "select a from t where a = 1",
+	}, {
+		sql:  "select a from t where (1 = 1 and 2 > 1)",
+		want: "select a from t",
+	}, {
+		sql:  "select a from t where a = 1 and 1 = 0",
+		want: "select a from t where 1 = 0",
+	}, {
+		sql:  "select a from t where a = 1 or 1 = 1 or b = 2",
+		want: "select a from t",
+	}, {
+		sql:  "select a from t where a = 1 and b = 2 and a = 1",
+		want: "select a from t where a = 1 and b = 2",
+	}, {
+		sql:  "select a from t where a = 1 and b = 1 or (a = 1 and b = 1)",
+		want: "select a from t where a = 1 and b = 1",
+	}, {
+		sql:  "select a from t where a = 1 and (a = 1 or b = 2)",
+		want: "select a from t where a = 1",
+	}, {
+		sql:  "select a from t where a = 1 or (b = 2 and a = 1)",
+		want: "select a from t where a = 1",
+	}, {
+		sql:  "select a from t where a = 1 and (b = 2 or c = 3 and 1 = 1)",
+		want: "select a from t where a = 1 and (b = 2 or c = 3)",
+	}, {
+		sql:  "select a from t where rand() < 0.5 and rand() < 0.5",
+		want: "select a from t where rand() < 0.5 and rand() < 0.5",
+	}, {
+		sql:  "select a from t group by a having (count(*) > 1) and 1 = 1",
+		want: "select a from t group by a having count(*) > 1",
+	}, {
+		sql:  "update t set a = 1 where (b in (2))",
+		want: "update t set a = 1 where b = 2",
+	}, {
+		sql:  "delete from t where 1 = 1",
+		want: "delete from t",
+	}, {
+		sql:  "select * from t where x=1 or 1=0 or 'b' > 'B'",
+		want: "select * from t where x = 1 or 'b' > 'B'",
+	}, {
+		sql:  "select * from t where x = 1 and 'a' = 'a ' and 'a' like 'A'",
+		want: "select * from t where x = 1 and 'a' = 'a ' and 'a' like 'A'",
+	}}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		Simplify(tree)
+		assert.Equal(t, tcase.want, String(tree), tcase.sql)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SplitScript splits a SQL script into its statements, the way
+// the mysql command line client does. Statements end with the
+// current delimiter, which is initially a semicolon and can be
+// changed by a DELIMITER directive on a line of its own, which
+// may follow comments:
+//
+//	DELIMITER $$
+//	CREATE PROCEDURE p() BEGIN SELECT 1; END$$
+//	DELIMITER ;
+//
+// Delimiters inside quotes and comments are ignored. The returned
+// statements do not include the delimiters or the directives, and
+// blank statements are dropped.
+func SplitScript(script string) ([]string, error) {
+	var pieces []string
+	delimiter := ";"
+	start := 0
+	// blank is set while the statement starting at start holds
+	// only white space and comments, so that a directive may
+	// follow.
+	blank := true
+	for i := 0; i < len(script); {
+		if blank && isDelimiterDirective(script[i:]) {
+			end := strings.IndexByte(script[i:], '\n')
+			if end == -1 {
+				end = len(script)
+			} else {
+				end += i
+			}
+			fields := strings.Fields(script[i:end])
+			if len(fields) < 2 {
+				return nil, errors.New("DELIMITER requires an argument")
+			}
+			delimiter = fields[1]
+			i, start = end, end
+			continue
+		}
+		switch ch := script[i]; {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := skipQuoted(script, i)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated quoted string at position %d", i)
+			}
+			i, blank = end, false
+		case ch == '#' || strings.HasPrefix(script[i:], "-- ") || strings.HasPrefix(script[i:], "--\t") || strings.HasPrefix(script[i:], "--\n") || strings.HasPrefix(script[i:], "--\r"):
+			end := strings.IndexByte(script[i:], '\n')
+			if end == -1 {
+				i = len(script)
+			} else {
+				i += end + 1
+			}
+		case strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated comment at position %d", i)
+			}
+			i += end + 4
+		case strings.HasPrefix(script[i:], delimiter):
+			if piece := strings.TrimSpace(script[start:i]); piece != "" {
+				pieces = append(pieces, piece)
+			}
+			i += len(delimiter)
+			start, blank = i, true
+		default:
+			if strings.IndexByte(" \t\r\n", ch) == -1 {
+				blank = false
+			}
+			i++
+		}
+	}
+	if piece := strings.TrimSpace(script[start:]); piece != "" {
+		pieces = append(pieces, piece)
+	}
+	return pieces, nil
+}
+
+// ParseScript splits script with SplitScript and parses each of
+// its statements.
+func ParseScript(script string) ([]Statement, error) {
+	pieces, err := SplitScript(script)
+	if err != nil {
+		return nil, err
+	}
+	stmts := make([]Statement, 0, len(pieces))
+	for i, piece := range pieces {
+		stmt, err := Parse(piece)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %v", i+1, err)
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+// isDelimiterDirective reports whether s starts
+// with a DELIMITER directive.
+func isDelimiterDirective(s string) bool {
+	const directive = "delimiter"
+	if len(s) < len(directive) || !strings.EqualFold(s[:len(directive)], directive) {
+		return false
+	}
+	return len(s) == len(directive) || strings.IndexByte(" \t\r\n", s[len(directive)]) != -1
+}
+
+// skipQuoted returns the position following the quoted string
+// or identifier starting at s[start], or -1 if it is not
+// terminated. Backslash escapes are honored in strings.
+func skipQuoted(s string, start int) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return -1
+}
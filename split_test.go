@@ -0,0 +1,63 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitScript(t *testing.T) {
+	script := `select 1 from dual;
+-- a comment; with a semicolon
+select ';', "a;b", ` + "`c;d`" + `, 'it\'s;' from t; # trailing;
+/* block; comment */ insert into t values (1);;
+
+DELIMITER $$
+create procedure p() begin select 1 from dual; end$$
+begin select 2 from dual; end $$
+delimiter ;
+select 3 from dual`
+	pieces, err := SplitScript(script)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"select 1 from dual",
+		"-- a comment; with a semicolon\nselect ';', \"a;b\", `c;d`, 'it\\'s;' from t",
+		"# trailing;\n/* block; comment */ insert into t values (1)",
+		"create procedure p() begin select 1 from dual; end",
+		"begin select 2 from dual; end",
+		"select 3 from dual",
+	}, pieces)
+
+	pieces, err = SplitScript("-- change the delimiter\n/* for p */ DELIMITER $$\ncreate procedure p() begin select 1 from dual; end$$\n# restore it\ndelimiter ;\nselect 2 from dual;")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"create procedure p() begin select 1 from dual; end",
+		"select 2 from dual",
+	}, pieces)
+
+	pieces, err = SplitScript("select 1 from dual;\r\n--\r\nDELIMITER $$\r\ncreate procedure p() begin select 2 from dual; end$$\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"select 1 from dual",
+		"create procedure p() begin select 2 from dual; end",
+	}, pieces)
+
+	for _, script := range []string{"select 'a", "select /* a", "delimiter\nselect 1"} {
+		_, err := SplitScript(script)
+		assert.NotNil(t, err, script)
+	}
+}
+
+func TestParseScript(t *testing.T) {
+	stmts, err := ParseScript("delimiter //\nbegin set a = 1; set b = 2; end//\nselect 1 from dual//")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(stmts))
+	assert.Equal(t, "begin set a = 1; set b = 2; end", String(stmts[0]))
+
+	_, err = ParseScript("select 1 from dual; select from")
+	assert.Equal(t, "statement 2: syntax error at position 12 near from", err.Error())
+}
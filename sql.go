@@ -1,10 +1,16 @@
+// Code generated by goyacc -o sql.go -v /tmp/y.output sql.y. DO NOT EDIT.
+
 //line sql.y:6
 package sqlparser
 
 import __yyfmt__ "fmt"
 
 //line sql.y:6
-import "bytes"
+
+import (
+	"fmt"
+	"strings"
+)
 
 func SetParseTree(yylex interface{}, stmt Statement) {
 	yylex.(*Tokenizer).ParseTree = stmt
@@ -18,58 +24,209 @@ func ForceEOF(yylex interface{}) {
 	yylex.(*Tokenizer).ForceEOF = true
 }
 
+// NodeArena returns the Arena used by the parse, if any.
+func NodeArena(yylex interface{}) *Arena {
+	return yylex.(*Tokenizer).arena
+}
+
+// NextValFunc returns a NextValExpr for NEXTVAL(seq) if
+// the dialect supports it, or nil otherwise.
+func NextValFunc(yylex interface{}, name ColIdent, exprs SelectExprs) *NextValExpr {
+	if yylex.(*Tokenizer).opts.Dialect != MariaDB || !name.EqualString("nextval") || len(exprs) != 1 {
+		return nil
+	}
+	expr, ok := exprs[0].(*NonStarExpr)
+	if !ok || !expr.As.IsEmpty() {
+		return nil
+	}
+	col, ok := expr.Expr.(*ColName)
+	if !ok {
+		return nil
+	}
+	return &NextValExpr{Sequence: &TableName{Qualifier: col.Qualifier, Name: makeTableIdent(col.Name.val, col.Name.quoted)}}
+}
+
+// ValuesFunc returns a ValuesFuncExpr for VALUES(col), or nil
+// if name and exprs are not such a call.
+func ValuesFunc(name ColIdent, exprs SelectExprs) *ValuesFuncExpr {
+	if !name.EqualString("values") || len(exprs) != 1 {
+		return nil
+	}
+	expr, ok := exprs[0].(*NonStarExpr)
+	if !ok || !expr.As.IsEmpty() {
+		return nil
+	}
+	col, ok := expr.Expr.(*ColName)
+	if !ok {
+		return nil
+	}
+	return &ValuesFuncExpr{Name: col}
+}
+
+// NextValColumn returns a NextValExpr for seq.nextval if
+// the dialect supports it, or col otherwise.
+func NextValColumn(yylex interface{}, col *ColName) ValExpr {
+	if yylex.(*Tokenizer).opts.Dialect != MariaDB || col.Qualifier.IsEmpty() || !col.Name.EqualString("nextval") {
+		return col
+	}
+	return &NextValExpr{Sequence: &TableName{Name: col.Qualifier}}
+}
+
+// NewSequence builds a sequence statement. kind is the word
+// following the action, which must be SEQUENCE.
+func NewSequence(action, kind string, name *TableName, items []string) (*Sequence, error) {
+	if !strings.EqualFold(kind, "sequence") {
+		return nil, fmt.Errorf("syntax error near %s", kind)
+	}
+	options, err := newSequenceOptions(items)
+	if err != nil {
+		return nil, err
+	}
+	return &Sequence{Action: action, Name: name, Options: options}, nil
+}
+
+// AppendRow adds row to rows, unless the tokenizer has a
+// RowHandler, in which case the row is handed to it instead.
+func AppendRow(yylex interface{}, rows Values, row RowTuple) (Values, error) {
+	return appendRow(yylex, true, rows, row)
+}
+
+// appendRow is AppendRow, except that row is only handed to the
+// RowHandler if stream is set, as it is for the rows of the VALUES
+// of the statement itself rather than of a subquery.
+func appendRow(yylex interface{}, stream bool, rows Values, row RowTuple) (Values, error) {
+	tkn := yylex.(*Tokenizer)
+	if tkn.RowHandler == nil || !stream {
+		return append(rows, row), nil
+	}
+	if err := tkn.RowHandler(row); err != nil {
+		tkn.rowErr = err
+		return nil, err
+	}
+	tkn.streamedRows = true
+	return rows, nil
+}
+
 var (
-	SHARE        = []byte("share")
-	MODE         = []byte("mode")
-	IF_BYTES     = []byte("if")
-	VALUES_BYTES = []byte("values")
+	SHARE          = []byte("share")
+	MODE           = []byte("mode")
+	IF_BYTES       = []byte("if")
+	VALUES_BYTES   = []byte("values")
+	DATABASE_BYTES = []byte("database")
+	SCHEMA_BYTES   = []byte("schema")
 )
 
-//line sql.y:31
+//line sql.y:119
 type yySymType struct {
-	yys         int
-	empty       struct{}
-	statement   Statement
-	selStmt     SelectStatement
-	byt         byte
-	bytes       []byte
-	bytes2      [][]byte
-	str         string
-	selectExprs SelectExprs
-	selectExpr  SelectExpr
-	columns     Columns
-	colName     *ColName
-	tableExprs  TableExprs
-	tableExpr   TableExpr
-	smTableExpr SimpleTableExpr
-	tableName   *TableName
-	indexHints  *IndexHints
-	expr        Expr
-	boolExpr    BoolExpr
-	valExpr     ValExpr
-	colTuple    ColTuple
-	valExprs    ValExprs
-	values      Values
-	rowTuple    RowTuple
-	subquery    *Subquery
-	caseExpr    *CaseExpr
-	whens       []*When
-	when        *When
-	orderBy     OrderBy
-	order       *Order
-	timerange   *TimeRange
-	limit       *Limit
-	insRows     InsertRows
-	updateExprs UpdateExprs
-	updateExpr  *UpdateExpr
+	yys               int
+	empty             struct{}
+	statement         Statement
+	selStmt           SelectStatement
+	byt               byte
+	str               string
+	strs              []string
+	quoted            bool
+	strVal            StrVal
+	colIdent          ColIdent
+	colIdents         []ColIdent
+	partitions        Partitions
+	selectOpts        *Select
+	union             *Union
+	statements        Statements
+	elseIfs           []*ElseIf
+	handlerConds      []*HandlerCondition
+	handlerCond       *HandlerCondition
+	tableIdent        TableIdent
+	tableIdents       []TableIdent
+	tableNames        []*TableName
+	renames           []*TableRename
+	privilege         *Privilege
+	privileges        []*Privilege
+	grantObject       *GrantObject
+	account           *Account
+	accounts          []*Account
+	userSpec          *UserSpec
+	userSpecs         []*UserSpec
+	selectExprs       SelectExprs
+	selectExpr        SelectExpr
+	columns           Columns
+	colName           *ColName
+	tableExprs        TableExprs
+	tableExpr         TableExpr
+	smTableExpr       SimpleTableExpr
+	tableName         *TableName
+	indexHints        *IndexHints
+	expr              Expr
+	boolExpr          BoolExpr
+	valExpr           ValExpr
+	colTuple          ColTuple
+	valExprs          ValExprs
+	values            Values
+	rowTuple          RowTuple
+	subquery          *Subquery
+	caseExpr          *CaseExpr
+	whens             []*When
+	when              *When
+	orderBy           OrderBy
+	order             *Order
+	where             *Where
+	with              *With
+	ctes              []*CommonTableExpr
+	cte               *CommonTableExpr
+	windowSpec        *WindowSpec
+	windowFrame       *WindowFrame
+	frameBound        *FrameBound
+	namedWindows      []*NamedWindow
+	namedWindow       *NamedWindow
+	alterSpecs        []*AlterSpec
+	alterSpec         *AlterSpec
+	timerange         *TimeRange
+	clauses           Clauses
+	rowAlias          *RowAlias
+	systemTime        *SystemTime
+	limit             *Limit
+	insRows           InsertRows
+	updateExprs       UpdateExprs
+	updateExpr        *UpdateExpr
+	setExprs          SetExprs
+	setExpr           *SetExpr
+	showFilter        *ShowFilter
+	jsonTableColumns  []*JSONTableColumn
+	jsonTableColumn   *JSONTableColumn
+	jsonTableResponse *JSONTableResponse
+	userVar           *UserVar
+	userVars          []*UserVar
+	loadFields        *LoadFields
+	loadLines         *LoadLines
 
 	/*
 	   for CreateTable
 	*/
-	createTableStmt   CreateTable
-	columnDefinition  *ColumnDefinition
-	columnDefinitions ColumnDefinitions
-	columnAtts        ColumnAtts
+	createTable      *CreateTable
+	columnDefinition *ColumnDefinition
+	columnType       ColumnType
+	convertType      *ConvertType
+	matchExpr        *MatchExpr
+	numVal           NumVal
+	boolean          bool
+	indexDefinition  *IndexDefinition
+	indexColumns     []*IndexColumn
+	indexColumn      *IndexColumn
+	references       *References
+	tableOptions     TableOptions
+	tableOption      *TableOption
+	signalItems      []*SignalItem
+	signalItem       *SignalItem
+
+	/*
+	   pos and end are the offsets of the first byte of a symbol
+	   and of the byte after it. Lex sets them for tokens, and
+	   recordSpan for the other symbols when spans are recorded,
+	   along with reduced, which tells the other symbols apart.
+	*/
+	pos     int
+	end     int
+	reduced bool
 }
 
 const LEX_ERROR = 57346
@@ -94,95 +251,198 @@ const EXISTS = 57364
 const IN = 57365
 const IS = 57366
 const LIKE = 57367
-const BETWEEN = 57368
-const NULL = 57369
-const ASC = 57370
-const DESC = 57371
-const VALUES = 57372
-const INTO = 57373
-const DUPLICATE = 57374
-const KEY = 57375
-const DEFAULT = 57376
-const SET = 57377
-const LOCK = 57378
-const ID = 57379
-const STRING = 57380
-const NUMBER = 57381
-const VALUE_ARG = 57382
-const LIST_ARG = 57383
-const COMMENT = 57384
-const LE = 57385
-const GE = 57386
-const NE = 57387
-const NULL_SAFE_EQUAL = 57388
-const PRIMARY = 57389
-const UNIQUE = 57390
-const UNION = 57391
-const MINUS = 57392
-const EXCEPT = 57393
-const INTERSECT = 57394
-const JOIN = 57395
-const STRAIGHT_JOIN = 57396
-const LEFT = 57397
-const RIGHT = 57398
-const INNER = 57399
-const OUTER = 57400
-const CROSS = 57401
-const NATURAL = 57402
-const USE = 57403
-const FORCE = 57404
-const ON = 57405
-const OR = 57406
-const AND = 57407
-const NOT = 57408
-const UNARY = 57409
-const CASE = 57410
-const WHEN = 57411
-const THEN = 57412
-const ELSE = 57413
-const END = 57414
-const CREATE = 57415
-const ALTER = 57416
-const DROP = 57417
-const RENAME = 57418
-const ANALYZE = 57419
-const TABLE = 57420
-const INDEX = 57421
-const VIEW = 57422
-const TO = 57423
-const IGNORE = 57424
-const IF = 57425
-const USING = 57426
-const SHOW = 57427
-const DESCRIBE = 57428
-const EXPLAIN = 57429
-const BIT = 57430
-const TINYINT = 57431
-const SMALLINT = 57432
-const MEDIUMINT = 57433
-const INT = 57434
-const INTEGER = 57435
-const BIGINT = 57436
-const REAL = 57437
-const DOUBLE = 57438
-const FLOAT = 57439
-const UNSIGNED = 57440
-const ZEROFILL = 57441
-const DECIMAL = 57442
-const NUMERIC = 57443
-const DATE = 57444
-const TIME = 57445
-const TIMESTAMP = 57446
-const DATETIME = 57447
-const YEAR = 57448
-const TEXT = 57449
-const CHAR = 57450
-const VARCHAR = 57451
-const NULLX = 57452
-const AUTO_INCREMENT = 57453
-const BOOL = 57454
-const APPROXNUM = 57455
-const INTNUM = 57456
+const REGEXP = 57368
+const SOUNDS_LIKE = 57369
+const ESCAPE = 57370
+const BETWEEN = 57371
+const NULL = 57372
+const TRUE = 57373
+const FALSE = 57374
+const ASC = 57375
+const DESC = 57376
+const VALUES = 57377
+const INTO = 57378
+const DUPLICATE = 57379
+const KEY = 57380
+const DEFAULT = 57381
+const SET = 57382
+const LOCK = 57383
+const ID = 57384
+const NUMBER = 57385
+const HEX = 57386
+const BIT_LITERAL = 57387
+const VALUE_ARG = 57388
+const LIST_ARG = 57389
+const COMMENT = 57390
+const UNDERSCORE_CHARSET = 57391
+const USER_VAR = 57392
+const STRING = 57393
+const LE = 57394
+const GE = 57395
+const NE = 57396
+const NULL_SAFE_EQUAL = 57397
+const GLOBAL = 57398
+const SESSION = 57399
+const LOCAL = 57400
+const OVER = 57401
+const WINDOW = 57402
+const ROWS = 57403
+const RANGE = 57404
+const ADD = 57405
+const CHANGE = 57406
+const COLUMN = 57407
+const MODIFY = 57408
+const COMMENT_KEYWORD = 57409
+const RECURSIVE = 57410
+const INTERVAL = 57411
+const CAST = 57412
+const CONVERT = 57413
+const MATCH = 57414
+const GROUPING_SETS = 57415
+const NEXT_VALUE_FOR = 57416
+const NEXT = 57417
+const FOR_SYSTEM_TIME = 57418
+const PARTITION = 57419
+const QUALIFY = 57420
+const ARRAY = 57421
+const STRUCT = 57422
+const ILIKE = 57423
+const RETURNING = 57424
+const LATERAL = 57425
+const JSON_TABLE = 57426
+const NOT_REGEXP = 57427
+const WITH_CHECK_OPTION = 57428
+const ANY = 57429
+const CLAUSE_KEYWORD = 57430
+const GRANT = 57431
+const REVOKE = 57432
+const CREATE_USER = 57433
+const ALTER_USER = 57434
+const SET_PASSWORD = 57435
+const SQL_CACHE = 57436
+const SQL_NO_CACHE = 57437
+const MAX_STATEMENT_TIME = 57438
+const DISTINCTROW = 57439
+const HIGH_PRIORITY = 57440
+const SQL_SMALL_RESULT = 57441
+const SQL_BIG_RESULT = 57442
+const SQL_BUFFER_RESULT = 57443
+const SQL_CALC_FOUND_ROWS = 57444
+const LOW_PRIORITY = 57445
+const DELAYED = 57446
+const QUICK = 57447
+const DECLARE = 57448
+const CURSOR = 57449
+const FETCH = 57450
+const BEGIN = 57451
+const ELSEIF = 57452
+const WHILE = 57453
+const LOOP = 57454
+const REPEAT = 57455
+const DO = 57456
+const CONTINUE = 57457
+const EXIT = 57458
+const LEAVE = 57459
+const ITERATE = 57460
+const SQLEXCEPTION = 57461
+const SQLWARNING = 57462
+const SQLSTATE = 57463
+const SIGNAL = 57464
+const RESIGNAL = 57465
+const PRIMARY = 57466
+const CONSTRAINT = 57467
+const DATABASE = 57468
+const SCHEMA = 57469
+const UNIQUE = 57470
+const NO_ALIAS = 57471
+const WITH = 57472
+const UNION = 57473
+const MINUS = 57474
+const EXCEPT = 57475
+const INTERSECT = 57476
+const CONDITIONLESS_JOIN = 57477
+const JOIN = 57478
+const STRAIGHT_JOIN = 57479
+const LEFT = 57480
+const RIGHT = 57481
+const INNER = 57482
+const OUTER = 57483
+const CROSS = 57484
+const NATURAL = 57485
+const USE = 57486
+const FORCE = 57487
+const PIVOT = 57488
+const UNPIVOT = 57489
+const ON = 57490
+const USING = 57491
+const ASSIGN = 57492
+const OR = 57493
+const AND = 57494
+const NOT = 57495
+const UNARY = 57496
+const COLLATE = 57497
+const TYPECAST = 57498
+const JSON_EXTRACT_OP = 57499
+const JSON_UNQUOTE_EXTRACT_OP = 57500
+const CASE = 57501
+const WHEN = 57502
+const THEN = 57503
+const ELSE = 57504
+const END = 57505
+const VALUES_FUNC = 57506
+const CREATE = 57507
+const ALTER = 57508
+const DROP = 57509
+const RENAME = 57510
+const ANALYZE = 57511
+const TABLE = 57512
+const INDEX = 57513
+const VIEW = 57514
+const TO = 57515
+const IGNORE = 57516
+const IF = 57517
+const SHOW = 57518
+const DESCRIBE = 57519
+const EXPLAIN = 57520
+const LOAD = 57521
+const INFILE = 57522
+const LINES = 57523
+const STARTING = 57524
+const TERMINATED = 57525
+const OPTIONALLY = 57526
+const ENCLOSED = 57527
+const ESCAPED = 57528
+const BIT = 57529
+const TINYINT = 57530
+const SMALLINT = 57531
+const MEDIUMINT = 57532
+const INT = 57533
+const INTEGER = 57534
+const BIGINT = 57535
+const REAL = 57536
+const DOUBLE = 57537
+const FLOAT = 57538
+const UNSIGNED = 57539
+const ZEROFILL = 57540
+const DECIMAL = 57541
+const NUMERIC = 57542
+const DATE = 57543
+const TIME = 57544
+const TIMESTAMP = 57545
+const DATETIME = 57546
+const YEAR = 57547
+const TEXT = 57548
+const CHAR = 57549
+const VARCHAR = 57550
+const CHARACTER = 57551
+const CHARSET = 57552
+const FOREIGN = 57553
+const REFERENCES = 57554
+const NULLX = 57555
+const AUTO_INCREMENT = 57556
+const BOOL = 57557
+const APPROXNUM = 57558
+const INTNUM = 57559
 
 var yyToknames = [...]string{
 	"$end",
@@ -210,8 +470,13 @@ var yyToknames = [...]string{
 	"IN",
 	"IS",
 	"LIKE",
+	"REGEXP",
+	"SOUNDS_LIKE",
+	"ESCAPE",
 	"BETWEEN",
 	"NULL",
+	"TRUE",
+	"FALSE",
 	"ASC",
 	"DESC",
 	"VALUES",
@@ -222,27 +487,104 @@ var yyToknames = [...]string{
 	"SET",
 	"LOCK",
 	"ID",
-	"STRING",
 	"NUMBER",
+	"HEX",
+	"BIT_LITERAL",
 	"VALUE_ARG",
 	"LIST_ARG",
 	"COMMENT",
+	"UNDERSCORE_CHARSET",
+	"USER_VAR",
+	"STRING",
 	"LE",
 	"GE",
 	"NE",
 	"NULL_SAFE_EQUAL",
-	"'('",
+	"GLOBAL",
+	"SESSION",
+	"LOCAL",
+	"OVER",
+	"WINDOW",
+	"ROWS",
+	"RANGE",
+	"ADD",
+	"CHANGE",
+	"COLUMN",
+	"MODIFY",
+	"COMMENT_KEYWORD",
+	"RECURSIVE",
+	"INTERVAL",
+	"CAST",
+	"CONVERT",
+	"MATCH",
+	"GROUPING_SETS",
+	"NEXT_VALUE_FOR",
+	"NEXT",
+	"FOR_SYSTEM_TIME",
+	"PARTITION",
+	"QUALIFY",
+	"ARRAY",
+	"STRUCT",
+	"ILIKE",
+	"RETURNING",
+	"LATERAL",
+	"JSON_TABLE",
+	"NOT_REGEXP",
+	"WITH_CHECK_OPTION",
+	"ANY",
+	"CLAUSE_KEYWORD",
+	"GRANT",
+	"REVOKE",
+	"CREATE_USER",
+	"ALTER_USER",
+	"SET_PASSWORD",
+	"SQL_CACHE",
+	"SQL_NO_CACHE",
+	"MAX_STATEMENT_TIME",
+	"DISTINCTROW",
+	"HIGH_PRIORITY",
+	"SQL_SMALL_RESULT",
+	"SQL_BIG_RESULT",
+	"SQL_BUFFER_RESULT",
+	"SQL_CALC_FOUND_ROWS",
+	"LOW_PRIORITY",
+	"DELAYED",
+	"QUICK",
+	"DECLARE",
+	"CURSOR",
+	"FETCH",
+	"BEGIN",
+	"ELSEIF",
+	"WHILE",
+	"LOOP",
+	"REPEAT",
+	"DO",
+	"CONTINUE",
+	"EXIT",
+	"LEAVE",
+	"ITERATE",
+	"SQLEXCEPTION",
+	"SQLWARNING",
+	"SQLSTATE",
+	"SIGNAL",
+	"RESIGNAL",
 	"'='",
 	"'<'",
 	"'>'",
 	"'~'",
 	"PRIMARY",
+	"CONSTRAINT",
+	"DATABASE",
+	"SCHEMA",
 	"UNIQUE",
+	"NO_ALIAS",
+	"WITH",
 	"UNION",
 	"MINUS",
 	"EXCEPT",
 	"INTERSECT",
 	"','",
+	"CONDITIONLESS_JOIN",
 	"JOIN",
 	"STRAIGHT_JOIN",
 	"LEFT",
@@ -253,7 +595,11 @@ var yyToknames = [...]string{
 	"NATURAL",
 	"USE",
 	"FORCE",
+	"PIVOT",
+	"UNPIVOT",
 	"ON",
+	"USING",
+	"ASSIGN",
 	"OR",
 	"AND",
 	"NOT",
@@ -267,11 +613,18 @@ var yyToknames = [...]string{
 	"'%'",
 	"'.'",
 	"UNARY",
+	"COLLATE",
+	"'['",
+	"TYPECAST",
+	"JSON_EXTRACT_OP",
+	"JSON_UNQUOTE_EXTRACT_OP",
 	"CASE",
 	"WHEN",
 	"THEN",
 	"ELSE",
 	"END",
+	"'('",
+	"VALUES_FUNC",
 	"CREATE",
 	"ALTER",
 	"DROP",
@@ -283,10 +636,17 @@ var yyToknames = [...]string{
 	"TO",
 	"IGNORE",
 	"IF",
-	"USING",
 	"SHOW",
 	"DESCRIBE",
 	"EXPLAIN",
+	"LOAD",
+	"INFILE",
+	"LINES",
+	"STARTING",
+	"TERMINATED",
+	"OPTIONALLY",
+	"ENCLOSED",
+	"ESCAPED",
 	"BIT",
 	"TINYINT",
 	"SMALLINT",
@@ -309,13 +669,21 @@ var yyToknames = [...]string{
 	"TEXT",
 	"CHAR",
 	"VARCHAR",
+	"CHARACTER",
+	"CHARSET",
+	"FOREIGN",
+	"REFERENCES",
 	"NULLX",
 	"AUTO_INCREMENT",
 	"BOOL",
 	"APPROXNUM",
 	"INTNUM",
+	"';'",
 	"')'",
+	"':'",
+	"']'",
 }
+
 var yyStatenames = [...]string{}
 
 const yyEofCode = 1
@@ -323,355 +691,1236 @@ const yyErrCode = 2
 const yyInitialStackSize = 16
 
 //line yacctab:1
-var yyExca = [...]int{
+var yyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 83,
-	1, 99,
-	9, 99,
-	14, 99,
-	15, 99,
-	17, 99,
-	18, 99,
-	36, 99,
-	54, 99,
-	55, 99,
-	56, 99,
-	57, 99,
-	58, 99,
-	69, 99,
-	130, 99,
-	-2, 166,
+	-1, 2,
+	1, 2,
+	-2, 426,
+	-1, 33,
+	236, 797,
+	-2, 111,
+	-1, 36,
+	187, 793,
+	188, 324,
+	-2, 296,
+	-1, 45,
+	1, 110,
+	234, 110,
+	-2, 420,
+	-1, 88,
+	167, 798,
+	179, 798,
+	-2, 797,
+	-1, 96,
+	186, 297,
+	-2, 780,
+	-1, 110,
+	186, 297,
+	-2, 778,
+	-1, 170,
+	167, 798,
+	-2, 797,
+	-1, 449,
+	1, 484,
+	9, 484,
+	10, 484,
+	12, 484,
+	13, 484,
+	14, 484,
+	15, 484,
+	17, 484,
+	18, 484,
+	21, 484,
+	41, 484,
+	60, 484,
+	78, 484,
+	82, 484,
+	86, 484,
+	88, 484,
+	135, 484,
+	136, 484,
+	137, 484,
+	138, 484,
+	139, 484,
+	153, 484,
+	234, 484,
+	235, 484,
+	-2, 596,
+	-1, 473,
+	179, 545,
+	-2, 69,
+	-1, 486,
+	167, 798,
+	-2, 797,
+	-1, 551,
+	111, 426,
+	112, 426,
+	113, 426,
+	-2, 422,
+	-1, 667,
+	135, 37,
+	136, 37,
+	137, 37,
+	138, 37,
+	-2, 593,
+	-1, 695,
+	169, 314,
+	225, 314,
+	226, 314,
+	-2, 293,
+	-1, 707,
+	1, 785,
+	234, 785,
+	-2, 315,
+	-1, 709,
+	1, 787,
+	234, 787,
+	-2, 312,
+	-1, 856,
+	167, 798,
+	-2, 797,
+	-1, 866,
+	169, 314,
+	225, 314,
+	226, 314,
+	-2, 791,
+	-1, 1006,
+	139, 66,
+	154, 66,
+	-2, 552,
+	-1, 1079,
+	178, 425,
+	-2, 426,
+	-1, 1141,
+	169, 314,
+	225, 314,
+	226, 314,
+	-2, 298,
+	-1, 1215,
+	1, 291,
+	234, 291,
+	-2, 791,
+	-1, 1216,
+	169, 314,
+	225, 314,
+	226, 314,
+	-2, 299,
+	-1, 1243,
+	111, 426,
+	112, 426,
+	113, 426,
+	-2, 423,
 }
 
-const yyNprod = 258
 const yyPrivate = 57344
 
-var yyTokenNames []string
-var yyStates []string
-
-const yyLast = 660
-
-var yyAct = [...]int{
-
-	95, 296, 159, 434, 92, 360, 93, 51, 63, 81,
-	251, 198, 370, 247, 366, 103, 238, 178, 288, 86,
-	209, 163, 162, 3, 262, 263, 264, 265, 266, 442,
-	267, 268, 136, 135, 52, 53, 442, 82, 445, 66,
-	429, 409, 71, 65, 64, 74, 365, 341, 343, 78,
-	442, 54, 29, 30, 31, 32, 186, 430, 399, 87,
-	130, 77, 69, 257, 406, 400, 230, 299, 124, 294,
-	130, 120, 44, 388, 45, 387, 386, 342, 70, 121,
-	128, 73, 123, 405, 407, 132, 130, 230, 47, 48,
-	49, 351, 228, 164, 50, 345, 46, 165, 239, 239,
-	286, 444, 273, 398, 148, 149, 150, 119, 443, 158,
-	161, 134, 172, 66, 169, 117, 66, 65, 182, 181,
-	65, 176, 441, 42, 113, 135, 72, 218, 229, 429,
-	136, 135, 350, 87, 204, 182, 196, 383, 347, 300,
-	208, 293, 283, 216, 217, 202, 220, 221, 222, 223,
-	224, 225, 226, 227, 211, 206, 207, 401, 281, 231,
-	180, 289, 115, 39, 253, 41, 192, 205, 203, 232,
-	87, 87, 219, 289, 127, 66, 66, 234, 236, 65,
-	245, 335, 385, 243, 333, 190, 336, 254, 193, 334,
-	136, 135, 384, 339, 246, 255, 242, 338, 337, 249,
-	115, 179, 130, 430, 393, 353, 394, 14, 15, 16,
-	17, 355, 272, 232, 116, 174, 202, 276, 277, 143,
-	144, 145, 146, 147, 148, 149, 150, 175, 420, 211,
-	260, 129, 274, 280, 275, 76, 110, 18, 87, 419,
-	189, 191, 188, 418, 166, 282, 60, 115, 291, 146,
-	147, 148, 149, 150, 285, 29, 30, 31, 32, 287,
-	295, 348, 292, 143, 144, 145, 146, 147, 148, 149,
-	150, 330, 201, 332, 376, 202, 329, 202, 259, 371,
-	130, 349, 200, 367, 183, 79, 170, 168, 167, 352,
-	20, 21, 23, 22, 24, 66, 439, 357, 412, 356,
-	358, 361, 25, 26, 27, 212, 111, 425, 426, 114,
-	362, 210, 315, 316, 317, 318, 319, 320, 321, 322,
-	323, 324, 368, 369, 325, 326, 310, 311, 312, 313,
-	314, 309, 307, 308, 411, 379, 372, 373, 374, 377,
-	375, 378, 396, 397, 410, 416, 331, 271, 133, 72,
-	235, 389, 98, 14, 67, 252, 390, 102, 346, 344,
-	108, 328, 392, 270, 72, 447, 327, 85, 99, 100,
-	101, 195, 262, 263, 264, 265, 266, 90, 267, 268,
-	194, 106, 177, 448, 428, 201, 125, 143, 144, 145,
-	146, 147, 148, 149, 150, 200, 422, 361, 122, 118,
-	423, 61, 89, 417, 80, 75, 104, 105, 83, 112,
-	427, 391, 354, 109, 14, 14, 59, 424, 87, 435,
-	435, 435, 66, 436, 437, 433, 65, 431, 107, 279,
-	438, 451, 98, 440, 432, 184, 126, 102, 57, 241,
-	108, 55, 213, 449, 214, 215, 33, 67, 99, 100,
-	101, 98, 452, 453, 297, 415, 102, 90, 298, 108,
-	233, 106, 35, 36, 37, 38, 85, 99, 100, 101,
-	248, 414, 381, 179, 382, 62, 90, 450, 421, 14,
-	106, 34, 89, 404, 403, 363, 104, 105, 160, 304,
-	306, 305, 402, 109, 408, 364, 302, 303, 19, 250,
-	301, 89, 185, 40, 256, 104, 105, 83, 107, 187,
-	43, 68, 109, 98, 14, 244, 173, 446, 102, 395,
-	359, 108, 413, 380, 284, 171, 237, 107, 67, 99,
-	100, 101, 97, 94, 96, 290, 102, 91, 90, 108,
-	240, 137, 106, 88, 258, 340, 67, 99, 100, 101,
-	199, 261, 197, 84, 269, 131, 166, 56, 28, 58,
-	106, 13, 12, 89, 11, 10, 9, 104, 105, 160,
-	102, 8, 7, 108, 109, 6, 5, 4, 2, 1,
-	67, 99, 100, 101, 0, 104, 105, 160, 0, 107,
-	166, 0, 109, 0, 106, 0, 0, 0, 0, 0,
-	0, 0, 138, 142, 140, 141, 278, 107, 143, 144,
-	145, 146, 147, 148, 149, 150, 0, 0, 0, 104,
-	105, 160, 154, 155, 156, 157, 109, 151, 152, 153,
-	143, 144, 145, 146, 147, 148, 149, 150, 0, 0,
-	0, 107, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 139, 143, 144, 145, 146, 147, 148, 149, 150,
+const yyLast = 3939
+
+var yyAct = [...]int16{
+	151, 1393, 980, 46, 1476, 942, 1471, 890, 644, 1388,
+	597, 997, 1372, 1347, 143, 1311, 608, 582, 436, 5,
+	1371, 458, 1253, 1308, 450, 124, 870, 524, 1197, 1236,
+	1186, 841, 1235, 1107, 90, 234, 240, 1102, 1024, 714,
+	527, 866, 669, 981, 123, 129, 894, 852, 80, 547,
+	179, 180, 183, 183, 583, 418, 314, 1047, 851, 899,
+	896, 746, 670, 1472, 898, 131, 1483, 777, 1058, 289,
+	710, 500, 686, 961, 850, 313, 662, 892, 213, 315,
+	137, 86, 767, 947, 216, 219, 541, 877, 256, 260,
+	119, 165, 230, 232, 542, 736, 343, 824, 239, 3,
+	685, 448, 811, 417, 428, 409, 615, 554, 564, 290,
+	741, 501, 491, 452, 624, 266, 623, 267, 188, 534,
+	207, 144, 209, 464, 774, 85, 1405, 101, 348, 1405,
+	1290, 341, 46, 132, 121, 835, 836, 837, 838, 839,
+	302, 840, 832, 651, 1459, 833, 834, 66, 67, 68,
+	69, 280, 66, 67, 68, 69, 239, 1458, 708, 1432,
+	148, 133, 375, 376, 377, 378, 379, 380, 381, 382,
+	348, 1346, 383, 374, 371, 372, 373, 651, 1426, 309,
+	1405, 261, 707, 310, 310, 709, 310, 774, 76, 66,
+	67, 68, 69, 388, 279, 121, 1295, 282, 1290, 1290,
+	1290, 1290, 310, 288, 774, 1290, 711, 713, 1217, 712,
+	716, 1167, 1290, 271, 310, 1092, 275, 276, 774, 310,
+	1523, 775, 1521, 75, 1140, 1506, 1501, 651, 1091, 1001,
+	284, 285, 286, 287, 310, 1085, 918, 121, 310, 1431,
+	772, 651, 549, 402, 403, 4, 464, 667, 1380, 349,
+	350, 883, 463, 320, 923, 319, 920, 920, 476, 477,
+	347, 346, 523, 466, 310, 651, 651, 490, 425, 651,
+	735, 525, 526, 1430, 1425, 462, 1404, 1496, 774, 1403,
+	1402, 487, 1401, 1397, 464, 505, 65, 464, 1440, 426,
+	401, 64, 475, 856, 1342, 1341, 1334, 1332, 1324, 464,
+	1318, 1292, 347, 346, 869, 416, 429, 868, 1289, 678,
+	1269, 521, 1168, 73, 1256, 1205, 645, 459, 72, 451,
+	1141, 236, 210, 1130, 498, 706, 703, 705, 711, 713,
+	1031, 712, 716, 486, 969, 508, 1512, 946, 509, 1234,
+	543, 545, 935, 548, 512, 513, 473, 515, 1492, 1493,
+	922, 1465, 921, 919, 208, 529, 104, 530, 531, 1149,
+	799, 781, 779, 190, 1148, 776, 496, 497, 121, 911,
+	499, 466, 467, 715, 773, 259, 468, 506, 507, 121,
+	679, 559, 121, 665, 596, 482, 484, 490, 121, 121,
+	514, 121, 1053, 494, 495, 465, 76, 794, 516, 613,
+	882, 598, 76, 46, 46, 239, 504, 511, 503, 602,
+	550, 551, 604, 607, 631, 274, 326, 327, 328, 329,
+	330, 331, 332, 333, 334, 335, 601, 103, 336, 337,
+	321, 322, 323, 324, 325, 318, 316, 317, 869, 630,
+	88, 868, 1264, 1012, 202, 199, 204, 195, 1038, 1039,
+	883, 655, 643, 1027, 536, 537, 538, 539, 192, 460,
+	552, 553, 909, 1263, 79, 350, 716, 883, 1157, 1057,
+	869, 869, 1511, 868, 868, 1262, 488, 489, 581, 680,
+	200, 191, 451, 865, 626, 451, 451, 864, 202, 199,
+	204, 195, 273, 115, 881, 715, 696, 1212, 716, 716,
+	283, 869, 192, 628, 868, 73, 876, 628, 278, 272,
+	72, 1467, 1469, 1468, 1470, 422, 488, 489, 908, 907,
+	111, 105, 258, 883, 200, 191, 1490, 739, 197, 716,
+	1488, 629, 632, 1198, 1200, 432, 431, 528, 732, 889,
+	430, 752, 664, 184, 1462, 883, 1438, 543, 348, 895,
+	881, 46, 46, 414, 900, 900, 883, 879, 901, 901,
+	674, 326, 327, 328, 329, 330, 331, 332, 567, 699,
+	1026, 1026, 197, 102, 1199, 104, 126, 77, 729, 730,
+	1222, 846, 944, 886, 89, 239, 695, 87, 1231, 1223,
+	692, 1013, 1451, 847, 724, 725, 727, 677, 641, 882,
+	690, 683, 761, 682, 384, 262, 627, 271, 1077, 294,
+	701, 955, 293, 879, 1366, 1365, 882, 1227, 413, 731,
+	194, 193, 196, 295, 780, 292, 198, 205, 625, 715,
+	389, 203, 113, 755, 1359, 900, 897, 116, 117, 901,
+	1327, 862, 115, 631, 743, 1323, 672, 676, 1322, 1321,
+	814, 1314, 808, 902, 902, 25, 880, 820, 613, 883,
+	528, 715, 715, 758, 194, 193, 196, 201, 807, 1240,
+	198, 205, 882, 299, 532, 203, 118, 762, 1230, 1239,
+	347, 346, 1232, 789, 490, 27, 886, 1053, 771, 110,
+	1109, 1233, 715, 239, 882, 818, 1218, 451, 487, 631,
+	565, 1201, 960, 1224, 1194, 882, 822, 888, 1221, 1019,
+	385, 201, 880, 944, 78, 1159, 828, 628, 628, 1158,
+	1128, 786, 883, 905, 873, 404, 895, 792, 25, 407,
+	788, 1081, 429, 791, 902, 867, 844, 801, 915, 917,
+	795, 796, 673, 451, 674, 805, 46, 996, 987, 986,
+	121, 813, 848, 817, 543, 543, 642, 548, 27, 532,
+	121, 298, 827, 700, 698, 674, 535, 533, 878, 853,
+	887, 397, 396, 394, 875, 490, 393, 99, 100, 943,
+	1225, 858, 390, 386, 59, 954, 116, 117, 255, 941,
+	46, 548, 861, 326, 327, 328, 329, 330, 331, 332,
+	903, 904, 25, 855, 968, 238, 931, 616, 882, 933,
+	737, 1211, 900, 897, 262, 973, 901, 296, 1287, 297,
+	913, 1010, 798, 914, 25, 118, 616, 566, 787, 1108,
+	490, 797, 27, 657, 107, 108, 398, 306, 254, 262,
+	959, 930, 924, 719, 982, 262, 936, 945, 929, 1273,
+	962, 963, 126, 262, 27, 421, 962, 59, 950, 950,
+	979, 170, 949, 949, 1420, 466, 1004, 953, 348, 718,
+	722, 882, 1029, 387, 966, 263, 934, 1519, 1033, 1034,
+	672, 676, 871, 1022, 579, 556, 557, 1041, 1042, 492,
+	965, 348, 1030, 983, 984, 1417, 558, 1006, 1055, 1059,
+	978, 1009, 58, 1254, 664, 1065, 1025, 412, 844, 1021,
+	998, 902, 1023, 348, 412, 1067, 1133, 1069, 1120, 1028,
+	493, 415, 674, 674, 1007, 1297, 752, 728, 411, 1119,
+	1014, 59, 993, 675, 1288, 999, 1136, 674, 1002, 451,
+	1020, 853, 348, 992, 1103, 1083, 985, 990, 1032, 1008,
+	1051, 721, 991, 59, 1043, 1037, 121, 1056, 1054, 1112,
+	1052, 720, 25, 29, 30, 31, 1062, 964, 1423, 883,
+	829, 1049, 1187, 895, 821, 627, 845, 1040, 988, 651,
+	1357, 1064, 464, 989, 1072, 1358, 1097, 1075, 490, 1086,
+	723, 1087, 27, 1089, 96, 652, 893, 631, 58, 1110,
+	1079, 346, 1118, 1070, 1071, 1117, 1121, 348, 1088, 1090,
+	126, 751, 580, 753, 1084, 556, 557, 237, 1111, 348,
+	264, 1103, 1127, 347, 346, 1195, 558, 759, 760, 830,
+	912, 1296, 884, 566, 380, 381, 382, 1147, 857, 383,
+	374, 371, 372, 373, 1132, 347, 346, 1078, 1116, 1150,
+	823, 1134, 681, 1059, 383, 374, 371, 372, 373, 900,
+	897, 1122, 1059, 901, 1059, 1169, 1143, 1142, 1135, 830,
+	361, 830, 1415, 1413, 347, 346, 1165, 674, 451, 1009,
+	46, 640, 99, 100, 97, 633, 999, 747, 748, 750,
+	1112, 59, 1129, 621, 957, 548, 548, 617, 1414, 138,
+	674, 853, 1137, 878, 887, 1051, 806, 1151, 98, 490,
+	490, 121, 1189, 490, 1173, 1156, 1155, 675, 882, 1188,
+	1144, 1153, 1162, 598, 982, 651, 749, 982, 213, 631,
+	1160, 1164, 1161, 1166, 802, 502, 58, 843, 675, 347,
+	346, 235, 555, 1163, 1174, 1175, 651, 1190, 1112, 1219,
+	1220, 347, 346, 1176, 1206, 485, 1191, 1098, 902, 1237,
+	1237, 1419, 1097, 69, 1146, 1416, 891, 1154, 1208, 1113,
+	653, 345, 1210, 639, 1444, 212, 1209, 237, 242, 433,
+	434, 867, 1445, 1216, 622, 1412, 307, 1214, 66, 67,
+	68, 69, 1238, 8, 1095, 490, 490, 490, 790, 1094,
+	7, 344, 631, 435, 114, 1259, 308, 1247, 353, 598,
+	1260, 1261, 1258, 66, 67, 68, 69, 1183, 1213, 1237,
+	1257, 1241, 186, 126, 126, 674, 470, 1265, 1193, 1242,
+	1271, 6, 253, 249, 1237, 1243, 352, 247, 126, 1177,
+	1237, 1237, 245, 246, 46, 1312, 1104, 1272, 976, 391,
+	392, 1278, 803, 395, 1277, 1443, 1025, 176, 177, 178,
+	1274, 357, 358, 359, 360, 1305, 1285, 952, 842, 1172,
+	248, 995, 1293, 1294, 1317, 400, 1110, 1309, 1291, 1255,
+	1316, 211, 215, 1027, 1355, 1303, 568, 1328, 569, 570,
+	1315, 1237, 572, 252, 228, 675, 675, 1331, 881, 778,
+	69, 26, 182, 182, 121, 472, 1329, 1301, 1302, 187,
+	675, 127, 128, 457, 305, 490, 1336, 291, 1526, 1340,
+	1337, 304, 631, 631, 631, 1362, 1525, 453, 1524, 598,
+	1520, 1360, 1364, 354, 355, 356, 1349, 1351, 242, 1276,
+	1352, 1044, 1045, 242, 571, 166, 1363, 1367, 1368, 1369,
+	1046, 419, 303, 1370, 250, 242, 1389, 1374, 635, 636,
+	420, 1377, 1382, 1353, 206, 1306, 218, 218, 1378, 378,
+	379, 380, 381, 382, 218, 218, 383, 374, 371, 372,
+	373, 166, 1391, 1309, 1399, 1400, 1398, 268, 269, 270,
+	1386, 1407, 1348, 1518, 181, 166, 481, 490, 609, 1428,
+	689, 928, 1421, 693, 451, 1349, 1351, 126, 1422, 1352,
+	927, 982, 688, 1442, 1429, 239, 916, 1473, 1433, 1356,
+	1044, 1045, 1516, 1447, 1389, 1515, 217, 1486, 1457, 1046,
+	1456, 1454, 1353, 1455, 1453, 333, 334, 335, 951, 1460,
+	336, 337, 321, 322, 323, 324, 325, 185, 948, 1475,
+	675, 352, 1237, 560, 1474, 1479, 1446, 451, 451, 1282,
+	1207, 561, 1179, 1178, 573, 574, 575, 576, 577, 578,
+	1482, 1484, 1418, 675, 587, 588, 589, 590, 591, 592,
+	593, 594, 595, 1487, 1480, 1076, 1073, 599, 637, 242,
+	453, 490, 220, 453, 453, 689, 611, 612, 687, 231,
+	233, 1510, 406, 967, 1491, 598, 126, 688, 906, 1507,
+	490, 405, 1522, 854, 544, 375, 376, 377, 378, 379,
+	380, 381, 382, 804, 982, 383, 374, 371, 372, 373,
+	742, 620, 586, 646, 585, 510, 424, 455, 556, 557,
+	456, 770, 556, 557, 1000, 605, 1478, 139, 1477, 558,
+	999, 999, 1436, 558, 849, 162, 163, 164, 1074, 1066,
+	172, 663, 910, 819, 666, 262, 1180, 170, 158, 159,
+	160, 161, 1435, 1527, 149, 166, 157, 610, 835, 836,
+	837, 838, 839, 744, 840, 832, 740, 262, 833, 834,
+	656, 694, 170, 1373, 153, 154, 155, 140, 675, 145,
+	1498, 1481, 126, 1463, 146, 147, 25, 29, 30, 31,
+	1461, 126, 375, 376, 377, 378, 379, 380, 381, 382,
+	733, 1452, 383, 374, 371, 372, 373, 835, 836, 837,
+	838, 839, 647, 840, 832, 62, 27, 833, 834, 1114,
+	1115, 34, 1267, 33, 130, 1503, 1448, 1437, 1434, 262,
+	434, 1411, 169, 1390, 1505, 173, 174, 1504, 1384, 1383,
+	1381, 1345, 1344, 1343, 1335, 242, 1298, 648, 1270, 763,
+	764, 765, 766, 435, 348, 1249, 1048, 126, 1202, 1050,
+	1139, 859, 1017, 135, 1015, 972, 940, 167, 168, 449,
+	53, 54, 55, 56, 57, 926, 812, 410, 634, 175,
+	517, 479, 478, 77, 136, 453, 338, 43, 277, 44,
+	45, 257, 122, 84, 1509, 1068, 171, 1499, 49, 50,
+	139, 738, 793, 51, 52, 691, 1500, 520, 162, 163,
+	164, 186, 300, 172, 92, 59, 95, 1361, 1284, 1283,
+	170, 158, 159, 160, 161, 1063, 1060, 149, 166, 157,
+	1268, 453, 375, 376, 377, 378, 379, 380, 381, 382,
+	603, 1036, 383, 374, 371, 372, 373, 153, 154, 155,
+	140, 1035, 145, 70, 106, 1313, 109, 146, 147, 340,
+	58, 1138, 36, 37, 39, 38, 40, 754, 668, 139,
+	860, 546, 47, 41, 61, 60, 32, 162, 163, 164,
+	660, 659, 172, 81, 82, 83, 339, 1279, 91, 170,
+	158, 159, 160, 161, 1338, 1339, 149, 166, 157, 825,
+	826, 1320, 1124, 1319, 649, 169, 1099, 293, 173, 174,
+	638, 1100, 1126, 423, 1123, 4, 153, 154, 155, 140,
+	292, 145, 1125, 1424, 1187, 1101, 146, 147, 872, 294,
+	225, 226, 293, 223, 224, 1196, 135, 221, 222, 540,
+	167, 168, 449, 295, 518, 292, 433, 1517, 938, 939,
+	1514, 1513, 175, 1497, 1495, 1251, 1494, 136, 1330, 1252,
+	1248, 461, 362, 370, 364, 365, 367, 956, 369, 171,
+	237, 1182, 1103, 816, 169, 1450, 1449, 173, 174, 800,
+	658, 1396, 1281, 2, 71, 1061, 1229, 63, 1228, 970,
+	971, 357, 358, 359, 360, 717, 977, 1376, 1215, 1379,
+	1152, 1226, 863, 663, 25, 135, 1275, 35, 408, 167,
+	168, 449, 1018, 1003, 1286, 734, 522, 311, 312, 1096,
+	368, 175, 189, 281, 366, 726, 136, 453, 1005, 162,
+	163, 164, 94, 93, 241, 469, 885, 702, 171, 480,
+	483, 170, 158, 159, 160, 161, 265, 1508, 149, 166,
+	157, 1489, 1464, 1439, 1466, 1410, 1441, 471, 244, 1145,
+	1375, 874, 1011, 354, 355, 356, 251, 661, 153, 154,
+	155, 1304, 1250, 145, 785, 162, 163, 164, 146, 147,
+	172, 399, 606, 614, 156, 150, 152, 170, 158, 159,
+	160, 161, 74, 142, 149, 166, 157, 363, 375, 376,
+	377, 378, 379, 380, 381, 382, 134, 994, 383, 374,
+	371, 372, 373, 975, 153, 154, 155, 1080, 974, 145,
+	815, 1406, 697, 671, 146, 147, 169, 825, 826, 173,
+	174, 831, 650, 59, 1502, 1485, 654, 1093, 1392, 1307,
+	1181, 227, 1387, 320, 1354, 1409, 1350, 1300, 1299, 1171,
+	1082, 704, 214, 427, 1408, 28, 1105, 1244, 229, 454,
+	519, 167, 168, 141, 125, 48, 453, 745, 757, 932,
+	1016, 684, 169, 175, 42, 173, 174, 120, 243, 112,
+	301, 24, 23, 22, 21, 20, 19, 18, 17, 320,
+	171, 319, 16, 15, 14, 13, 12, 11, 10, 9,
+	1, 162, 163, 164, 0, 0, 172, 167, 168, 141,
+	0, 0, 0, 170, 158, 159, 160, 161, 0, 175,
+	149, 166, 157, 0, 78, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 1427, 0, 171, 0, 1245, 0,
+	153, 154, 155, 0, 0, 145, 0, 0, 0, 0,
+	146, 147, 0, 375, 376, 377, 378, 379, 380, 381,
+	382, 1170, 0, 383, 374, 371, 372, 373, 0, 0,
+	0, 0, 0, 0, 320, 0, 319, 0, 0, 0,
+	0, 0, 600, 0, 1184, 0, 1185, 0, 310, 0,
+	0, 0, 810, 1192, 0, 0, 0, 0, 169, 0,
+	0, 173, 174, 0, 1203, 1204, 326, 327, 328, 329,
+	330, 331, 332, 333, 334, 335, 1106, 0, 336, 337,
+	321, 322, 323, 324, 325, 318, 316, 317, 0, 0,
+	320, 0, 584, 167, 168, 141, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 175, 0, 0, 0, 1246,
+	78, 0, 326, 327, 328, 329, 330, 331, 332, 333,
+	334, 335, 171, 0, 336, 337, 321, 322, 323, 324,
+	325, 318, 316, 317, 375, 376, 377, 378, 379, 380,
+	381, 382, 1266, 0, 383, 374, 371, 372, 373, 809,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 1280, 0, 0, 474, 0, 0, 375,
+	376, 377, 378, 379, 380, 381, 382, 0, 0, 383,
+	374, 371, 372, 373, 0, 0, 0, 0, 242, 0,
+	0, 0, 453, 0, 0, 0, 0, 326, 327, 328,
+	329, 330, 331, 332, 333, 334, 335, 1325, 1326, 336,
+	337, 321, 322, 323, 324, 325, 318, 316, 317, 0,
+	0, 1333, 0, 0, 375, 376, 377, 378, 379, 380,
+	381, 382, 0, 0, 383, 374, 371, 372, 373, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 453, 326, 327, 328, 329, 330, 331, 332,
+	333, 334, 335, 0, 0, 336, 337, 321, 322, 323,
+	324, 325, 318, 316, 317, 937, 0, 375, 376, 377,
+	378, 379, 380, 381, 382, 0, 0, 383, 374, 371,
+	372, 373, 0, 1385, 0, 0, 0, 0, 453, 1394,
+	437, 0, 139, 0, 0, 453, 453, 0, 0, 0,
+	162, 163, 164, 0, 0, 172, 0, 0, 0, 0,
+	0, 0, 170, 158, 159, 160, 161, 0, 0, 149,
+	166, 157, 0, 0, 0, 242, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 153,
+	154, 155, 140, 0, 145, 0, 0, 0, 0, 146,
+	147, 25, 29, 30, 31, 0, 1394, 0, 0, 0,
+	0, 0, 0, 0, 444, 445, 447, 438, 439, 441,
+	442, 443, 446, 0, 0, 0, 0, 0, 0, 0,
+	62, 27, 0, 783, 0, 0, 34, 0, 33, 25,
+	29, 30, 31, 0, 0, 0, 0, 169, 784, 0,
+	173, 174, 0, 375, 376, 377, 378, 379, 380, 381,
+	382, 0, 440, 383, 374, 371, 372, 373, 62, 27,
+	0, 0, 0, 0, 34, 0, 33, 0, 135, 0,
+	0, 0, 167, 168, 449, 53, 54, 55, 56, 57,
+	0, 0, 0, 0, 175, 0, 0, 0, 0, 136,
+	0, 0, 43, 0, 44, 45, 0, 0, 0, 0,
+	0, 171, 0, 49, 50, 0, 0, 0, 51, 52,
+	0, 0, 0, 53, 54, 55, 56, 57, 0, 0,
+	59, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	43, 0, 44, 45, 0, 0, 0, 0, 0, 0,
+	0, 49, 50, 0, 0, 0, 51, 52, 0, 0,
+	0, 0, 0, 25, 29, 30, 31, 0, 59, 0,
+	0, 0, 0, 925, 958, 58, 0, 36, 37, 39,
+	38, 40, 0, 0, 0, 0, 0, 47, 41, 61,
+	60, 32, 62, 27, 0, 0, 0, 0, 34, 0,
+	33, 25, 29, 30, 31, 0, 0, 619, 0, 0,
+	0, 0, 0, 58, 0, 36, 37, 39, 38, 40,
+	0, 0, 0, 0, 0, 47, 41, 61, 60, 32,
+	62, 27, 0, 0, 0, 0, 34, 0, 33, 0,
+	0, 0, 0, 0, 0, 0, 0, 53, 54, 55,
+	56, 57, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 43, 0, 44, 45, 0, 0,
+	0, 0, 0, 0, 0, 49, 50, 0, 0, 0,
+	51, 52, 0, 0, 0, 53, 54, 55, 56, 57,
+	0, 0, 59, 0, 0, 0, 0, 0, 25, 29,
+	30, 31, 43, 0, 44, 45, 0, 0, 0, 0,
+	0, 0, 0, 49, 50, 0, 0, 0, 51, 52,
+	0, 0, 0, 0, 768, 0, 0, 62, 27, 0,
+	59, 0, 0, 34, 1131, 33, 756, 58, 0, 36,
+	37, 39, 38, 40, 0, 0, 0, 0, 0, 47,
+	41, 61, 60, 32, 375, 376, 377, 378, 379, 380,
+	381, 382, 0, 0, 383, 374, 371, 372, 373, 0,
+	0, 0, 0, 0, 0, 58, 0, 36, 37, 39,
+	38, 40, 53, 54, 55, 56, 57, 47, 41, 61,
+	60, 32, 0, 0, 0, 25, 29, 30, 31, 43,
+	782, 44, 45, 0, 0, 0, 0, 0, 0, 0,
+	49, 50, 0, 0, 0, 51, 52, 25, 29, 30,
+	31, 0, 0, 0, 62, 27, 0, 59, 0, 0,
+	34, 0, 33, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 62, 27, 0, 0,
+	0, 0, 34, 0, 33, 375, 376, 377, 378, 379,
+	380, 381, 382, 0, 0, 383, 374, 371, 372, 373,
+	0, 618, 58, 0, 36, 37, 39, 38, 40, 53,
+	54, 55, 56, 57, 47, 41, 61, 60, 32, 0,
+	0, 0, 0, 0, 0, 0, 43, 0, 44, 45,
+	0, 53, 54, 55, 56, 57, 0, 49, 50, 0,
+	0, 0, 51, 52, 0, 0, 0, 0, 43, 0,
+	44, 45, 0, 0, 59, 0, 0, 0, 0, 49,
+	50, 0, 0, 0, 51, 52, 0, 0, 375, 376,
+	377, 378, 379, 380, 381, 382, 59, 0, 383, 374,
+	371, 372, 373, 375, 376, 377, 378, 379, 380, 381,
+	382, 0, 0, 383, 374, 371, 372, 373, 342, 58,
+	0, 36, 37, 39, 38, 40, 0, 0, 0, 0,
+	0, 47, 41, 61, 60, 32, 0, 0, 0, 0,
+	0, 58, 25, 36, 37, 39, 38, 40, 0, 0,
+	0, 0, 0, 47, 41, 61, 60, 32, 0, 139,
+	0, 0, 0, 0, 0, 0, 0, 162, 163, 164,
+	0, 0, 241, 0, 0, 0, 0, 0, 0, 170,
+	158, 159, 160, 161, 0, 0, 149, 166, 157, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 139, 153, 154, 155, 140,
+	0, 145, 0, 162, 163, 164, 146, 147, 172, 0,
+	0, 0, 0, 0, 0, 170, 158, 159, 160, 161,
+	0, 0, 149, 166, 157, 769, 0, 375, 376, 377,
+	378, 379, 380, 381, 382, 0, 0, 383, 374, 371,
+	372, 373, 153, 154, 155, 140, 1310, 145, 0, 0,
+	0, 0, 146, 147, 169, 0, 0, 173, 174, 0,
+	0, 59, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 135, 0, 0, 0, 167,
+	168, 141, 0, 0, 0, 0, 0, 0, 0, 0,
+	169, 175, 0, 173, 174, 0, 351, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 171, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 135, 0, 139, 0, 167, 168, 141, 0, 0,
+	0, 162, 163, 164, 0, 0, 172, 175, 0, 0,
+	0, 0, 136, 170, 158, 159, 160, 161, 0, 0,
+	149, 166, 157, 0, 171, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 139,
+	153, 154, 155, 140, 0, 145, 0, 162, 163, 164,
+	146, 147, 172, 0, 0, 0, 0, 0, 0, 170,
+	158, 159, 160, 161, 0, 0, 149, 166, 157, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 153, 154, 155, 140,
+	0, 145, 0, 0, 0, 0, 146, 147, 169, 0,
+	0, 173, 174, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 25, 135,
+	0, 0, 0, 167, 168, 449, 0, 0, 0, 0,
+	0, 0, 0, 0, 169, 175, 0, 173, 174, 0,
+	136, 0, 0, 162, 163, 164, 0, 0, 241, 0,
+	0, 0, 171, 0, 0, 170, 158, 159, 160, 161,
+	0, 0, 149, 166, 157, 135, 0, 0, 0, 167,
+	168, 141, 0, 0, 0, 0, 0, 0, 562, 0,
+	0, 175, 153, 154, 155, 0, 136, 145, 0, 162,
+	163, 164, 146, 147, 172, 0, 0, 0, 171, 0,
+	0, 170, 158, 159, 160, 161, 0, 0, 149, 166,
+	157, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 153, 154,
+	155, 0, 0, 145, 0, 0, 0, 0, 146, 147,
+	169, 0, 0, 173, 174, 0, 563, 59, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 167, 168, 141, 0, 0,
+	0, 0, 0, 0, 0, 0, 169, 175, 0, 173,
+	174, 0, 243, 0, 0, 162, 163, 164, 0, 0,
+	172, 0, 0, 0, 171, 0, 0, 170, 158, 159,
+	160, 161, 0, 0, 149, 166, 157, 0, 0, 0,
+	0, 167, 168, 141, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 175, 153, 154, 155, 140, 78, 145,
+	0, 162, 163, 164, 146, 147, 172, 0, 0, 0,
+	171, 0, 0, 170, 158, 159, 160, 161, 0, 0,
+	149, 166, 157, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	153, 154, 155, 0, 0, 145, 0, 0, 0, 0,
+	146, 147, 169, 0, 0, 173, 174, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 167, 168, 141,
+	0, 0, 0, 0, 0, 0, 0, 0, 169, 175,
+	0, 173, 174, 0, 78, 0, 0, 162, 163, 164,
+	0, 0, 172, 0, 0, 0, 171, 0, 0, 170,
+	158, 159, 160, 161, 0, 0, 149, 166, 157, 0,
+	0, 0, 0, 167, 168, 141, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 175, 153, 154, 155, 0,
+	1395, 145, 0, 0, 0, 0, 146, 147, 0, 0,
+	0, 0, 171, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 169, 0, 0, 173, 174, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 167,
+	168, 141, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 175, 0, 0, 0, 0, 78, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 171,
 }
-var yyPact = [...]int{
 
-	202, -1000, -1000, 201, -1000, -1000, -1000, -1000, -1000, -1000,
+var yyPact = [...]int16{
+	-1000, -1000, 1601, -1000, -1000, 1053, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	70, -23, 3, -5, 1, -1000, -1000, -1000, 474, 422,
-	-1000, -1000, -1000, 418, -1000, 385, 364, 466, 317, -36,
-	-16, 312, -1000, -12, 312, -1000, 368, -37, 312, -37,
-	367, -1000, -1000, -1000, -1000, -1000, 429, -1000, 194, 364,
-	374, 43, 364, 142, -1000, 166, -1000, 34, 362, 35,
-	312, -1000, -1000, 361, -1000, -28, 349, 414, 105, 312,
-	-1000, 222, -1000, -1000, 327, 30, 60, 579, -1000, 491,
-	410, -1000, -1000, -1000, 543, 241, 240, -1000, 239, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 543,
-	-1000, 180, 317, 345, 461, 317, 543, 312, 237, 413,
-	-43, -1000, 151, -1000, 343, -1000, -1000, 334, -1000, 235,
-	429, -1000, -1000, 312, 89, 491, 491, 543, 264, 419,
-	543, 543, 100, 543, 543, 543, 543, 543, 543, 543,
-	543, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 579,
-	-1000, -38, -2, 29, 579, -1000, 509, 330, 429, -1000,
-	474, 15, 557, 409, 317, 317, 189, -1000, 455, 491,
-	-1000, 557, -1000, 318, -1000, 95, 312, -1000, -33, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 220, 313, 326,
-	348, 21, -1000, -1000, -1000, -1000, -1000, 54, 557, -1000,
-	509, -1000, -1000, 264, 543, 543, 557, 535, -1000, 402,
-	173, 173, 173, 26, 26, -1000, -1000, -1000, -1000, -1000,
-	543, -1000, 557, -1000, 28, 429, 12, 14, -1000, 491,
-	92, 197, 201, 104, 11, -1000, 455, 437, 442, 60,
-	9, -1000, 209, 329, -1000, -1000, 324, -1000, 461, 235,
-	308, 235, -1000, -1000, 125, 122, 139, 138, 134, -20,
-	-1000, 322, -35, 321, 8, -1000, 557, 190, 543, -1000,
-	557, -1000, 2, -1000, 4, -1000, 543, 120, -1000, 380,
-	153, -1000, -1000, -1000, 317, 437, -1000, 543, 543, 318,
-	-1000, -1000, -67, -1000, -1000, 236, -1000, 236, 236, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, 1053, 535, 723, -1000,
+	-1000, -1000, 1671, 398, -1000, -1000, 952, 385, 335, 647,
+	334, 451, 1670, 1196, 1635, -1000, -103, 3337, 1146, 1560,
+	1560, 1181, 1182, 483, 483, 163, 131, 1157, 723, 1214,
+	-1000, -1000, -1000, 11, 723, 723, 1838, -1000, 1834, 1831,
+	1219, -1000, 723, 723, 1002, -1000, -1000, 626, 3443, -1000,
+	1053, 1139, 1130, 1130, 1190, 671, 609, 1669, 333, 1607,
+	866, 1331, 323, 305, 227, 163, 163, -1000, 1666, -1000,
+	-1000, 322, 1607, 1607, -1000, 1607, 314, 131, 131, 131,
+	131, 1607, 600, 631, -1000, -1000, -1000, -1000, 1692, -1000,
+	957, 670, 1062, 1099, 2069, 1664, -1000, -1000, -1000, 1770,
+	1560, 2910, 1092, 995, -1000, 3337, 3107, 1209, 1859, 531,
+	604, -1000, -1000, -1000, 718, 1607, 460, 603, -1000, 3747,
+	3747, 597, 594, 3747, 593, 592, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 669, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 3747, 3337, -1000, -1000, -1000,
+	-1000, 1691, 1460, -1000, -1000, 1691, 1655, 775, -1000, 439,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 232, 232, 232, 227, 227, -1000, -1000, 459,
-	313, 463, 68, -1000, 133, -1000, 123, -1000, -1000, -1000,
-	-1000, -18, -19, -21, -1000, -1000, -1000, -1000, 543, 557,
-	-1000, -1000, 557, 543, 378, 197, -1000, -1000, 146, 148,
-	-1000, 314, -1000, 31, -73, -1000, -1000, 305, -1000, -1000,
-	-1000, 295, -1000, -1000, -1000, -1000, 259, -1000, -1000, -1000,
-	457, 439, 307, 491, -1000, -1000, 196, 192, 181, 557,
-	557, 471, -1000, 543, 543, -1000, -1000, -1000, 390, -1000,
-	269, -1000, -1000, -1000, -1000, 377, -1000, 351, -1000, -1000,
-	-90, 145, -1, 455, 491, 429, -1000, 60, 312, 312,
-	312, 317, 557, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	257, 437, 60, 144, -8, -1000, -22, -29, 142, -92,
-	347, -1000, 312, -1000, -1000, -1000, -1000, 470, 408, -1000,
-	-1000, 312, 312, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, 768, 1309, 697, 1309,
+	1811, 1485, 1309, 54, 1607, -1000, 1025, -1000, 1162, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 2440, 1494, 1265,
+	1025, -1000, -1000, -1000, 1849, 535, -1000, 1865, 3747, 17,
+	160, 1661, 2914, 3443, 166, -1000, -1000, -1000, 166, -1000,
+	1121, 1247, -1000, -1000, 1607, 2091, -1000, 1560, 1660, 1659,
+	-1000, -1000, -1000, 1345, 1295, 1016, 291, -1000, -1000, -1000,
+	-1000, 765, 163, 163, 1607, 1607, 1607, -1000, 1607, -1000,
+	-1000, 996, 219, 131, 1560, 1607, 1607, 1607, -1000, -1000,
+	1607, -1000, 1484, 3337, -1000, -1000, 1607, 1607, 1607, 1607,
+	-1000, -1000, 1053, -1000, -1000, -1000, 1607, 1658, 1846, 1688,
+	1560, 49, 46, 481, 481, -1000, 481, 481, -1000, 580,
+	588, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 587, 587, 587, 587, 587, 1841, 1464,
+	1560, 1755, 1560, 8, -1000, -1000, 3337, 3337, 984, 1650,
+	146, 3107, 1859, 3747, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 3489, 521, 1263, 3747, 3747, 3747, 3747, 3747, 3747,
+	854, 2210, 1483, 1481, 3747, 3747, 3747, 3747, 3747, 3747,
+	3747, 3747, 3747, 1560, -1000, 723, 1550, 3747, -1000, 1965,
+	3291, 885, 885, 1525, 1767, 1356, 3747, 3747, 1560, 632,
+	2914, 983, 2813, 2716, -1000, -1000, 1480, -1000, 954, -1000,
+	1060, 442, 483, 1560, -1000, 442, 946, -1000, 1656, 1308,
+	1438, 1808, 946, -1000, -1000, 1049, -1000, 942, -1000, 577,
+	1849, 1632, -1000, 3747, 1625, 1801, 986, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 1046, -1000, -1000,
+	1569, 666, 867, 1859, 1891, 1766, 1765, -1000, -1000, -1000,
+	-1000, 3595, 148, -1000, 3747, -1000, 12, 1752, 563, 166,
+	-1000, 113, -1000, -1000, -1000, 145, -1000, -1000, 1560, -1000,
+	-1000, -1000, -1000, 913, -1000, 1331, 1456, 765, 1685, 1361,
+	-1000, 3747, -1000, -1000, 1607, 1560, 585, -1000, 584, 143,
+	-1000, 827, 1607, 1607, 1607, 774, -1000, -1000, -1000, 1840,
+	-1000, 867, -1000, -1000, -1000, -1000, -1000, -1000, 723, -1000,
+	3747, -1000, 56, -1000, 641, 1681, 1560, -1000, 1543, -1000,
+	-1000, -1000, 1479, 1479, -1000, 1540, -1000, -1000, -1000, -1000,
+	968, 874, -1000, -1000, -1000, 1751, 1464, -1000, -1000, -1000,
+	2678, 2932, 1650, 844, -1000, 1507, -1000, -1000, -1000, -1000,
+	2914, 2914, 531, 531, -1000, 3443, -1000, -1000, 521, 3747,
+	3747, 3747, 3747, 2816, 2914, 2914, 2914, 2914, 3048, -1000,
+	1511, -1000, -1000, -1000, 580, -1000, -1000, 3, 1207, 1207,
+	1207, 870, 870, 885, 885, 885, -1000, 139, -1000, 2914,
+	-1000, -16, 130, 1240, 127, 3291, -1000, 126, -1000, -1000,
+	-1000, 2899, 2414, -1000, 651, -1000, 3337, -1000, 1086, 3337,
+	-1000, 1655, 3747, 208, -1000, 811, 811, 664, 655, -1000,
+	125, -1000, 1890, 1309, 1118, -1000, -1000, -1000, -1000, 1472,
+	1607, 531, 1560, 1632, -1000, -1000, 2170, 1654, 1654, 1560,
+	1883, 3291, 563, 1520, -1000, -1000, 1560, 810, 1607, -1000,
+	-1000, 911, -1000, 2014, 1786, -1000, 2914, -1000, 1607, 930,
+	1437, 1191, 531, 797, 414, -1000, 573, 1545, 1462, -1000,
+	-1000, 1295, -1000, 251, 899, 641, -1000, 1639, -1000, -1000,
+	3747, 1361, -1000, -1000, 2914, 462, 728, 1827, 1560, -1000,
+	-1000, 827, -1000, 429, 893, 518, -1000, -1000, -1000, 1042,
+	-1000, 931, 1233, 1233, -1000, 1042, 1457, 293, -1000, -1000,
+	-1000, -1000, -1000, 1519, 180, -1000, 891, -1000, 1607, -1000,
+	-1000, 1607, 1053, 2914, -1000, -1000, -1000, 1365, 1560, -1000,
+	1, 118, -1000, 117, 115, 2554, -1000, -1000, -1000, 1653,
+	1359, -1000, -1000, 1464, 1464, 874, 1560, 699, -1000, -1000,
+	-1000, 107, -1000, 2816, 2914, 2914, 2278, -1000, 3747, 3747,
+	-1000, -1000, -1000, 1644, 1550, -1000, -1000, -1000, 534, 1240,
+	102, -1000, 1225, 1225, 1560, 433, -1000, 3747, 918, 2516,
+	1560, 524, -1000, 2914, 1309, -1000, -1000, 687, 803, -1000,
+	1309, -1000, 1452, 1560, -1000, -1000, -1000, 99, -1000, 3747,
+	3747, -1000, 1643, -1000, 1560, 1160, 3747, -1000, 890, -1000,
+	-1000, -1000, -1000, 3595, -1000, -1000, -1000, -1000, 1191, 1550,
+	563, 563, 805, 570, 569, -1000, -1000, 837, 806, 802,
+	791, 1195, 568, 1523, -6, 797, 1607, 1698, 3747, 1607,
+	940, -1000, -1000, 654, 401, -1000, 1361, 1642, -1000, 1640,
+	2914, -1000, 684, 723, 1607, -1000, 432, -1000, 1042, -1000,
+	766, 1560, 723, 95, -1000, -1000, -1000, 1560, 1560, 1733,
+	1723, -1000, -1000, -1000, 265, 1607, 1560, 1560, -1000, -1000,
+	1299, -1000, 1634, 1637, -1000, 213, -1000, 427, 1560, -1000,
+	1708, 412, 1707, 1637, 1560, 1516, -1000, 1042, 1675, 1042,
+	-1000, 1607, 1607, -1000, 1815, -1000, -1000, -1000, -1000, -1000,
+	1435, -1000, -1000, 1515, -1000, 968, -1000, -1000, 1434, -1000,
+	874, -1000, 430, 3337, -1000, -1000, -1000, 3747, 2914, 2914,
+	552, -1000, -1000, -1000, 1560, -1000, 1240, 0, 481, -1000,
+	481, 590, 358, -7, -20, -1000, 2914, 3747, 1088, -1000,
+	1081, 1023, -1000, -1000, -1000, -1000, 847, -1000, 1810, 1824,
+	2914, 2914, -1000, -1000, 1880, 1158, 3747, 2225, -1000, 650,
+	1009, -1000, 1045, 1437, 1486, 563, 3291, 1550, -1000, 788,
+	-1000, 777, -1000, -1000, 1523, 1813, 1560, -1000, 541, -1000,
+	1607, -1000, -1000, -1000, 88, 2715, 1880, 762, 563, 1607,
+	772, 1745, -1000, -1000, -1000, 1639, -1000, 1638, 85, 1607,
+	-1000, -1000, 1053, -1000, -1000, -1000, 399, -1000, 1607, -1000,
+	1078, -1000, -1000, -1000, -1000, -1000, 1560, -1000, 485, 621,
+	-1000, 175, 170, -1000, -1000, -1000, -1000, -1000, 1560, 1634,
+	2154, -1000, 1560, 3337, -1000, 426, -1000, 508, 540, -1000,
+	536, 1560, -1000, 1560, 1634, 1637, -1000, 1299, 1042, 1299,
+	-1000, -1000, -1000, -1000, -24, -1000, -1000, 121, 889, 2932,
+	2914, 3747, 1192, -1000, -1000, -1000, 46, -1000, -1000, -1000,
+	-1000, -1000, -1000, 2914, 1560, 1560, -1000, 1309, 1150, 1412,
+	1411, 531, 1878, 3337, 3747, 2914, 3747, 1823, 819, 1550,
+	1053, 1875, 1550, 3747, 3337, 525, -1000, 1007, 1837, -1000,
+	-1000, 384, 522, 1636, 3747, 3747, -1000, 80, 1560, -1000,
+	-1000, 1409, 1875, 563, 932, -1000, -1000, 644, 311, -1000,
+	507, 399, -27, -1000, 517, -1000, -1000, -1000, 1560, 1560,
+	-1000, -1000, 550, 512, 104, -1000, -1000, 508, 1560, 1560,
+	500, 490, -1000, 1634, -1000, 1299, -1000, -1000, -1000, -1000,
+	2135, 1875, 1864, -1000, -1000, -1000, -1000, 1633, -1000, -1000,
+	-1000, 1861, 1863, 867, 2914, 2914, 750, 1607, 79, 951,
+	1849, -1000, 2914, 867, 1550, 1550, 1550, -1000, 288, 276,
+	255, 1560, 3747, 1453, 1593, -1000, 75, 1626, 1849, 932,
+	-1000, 685, 1607, -1000, -1000, 1262, 431, -1000, 1560, -1000,
+	-1000, 1777, -1000, 3747, 1895, -1000, -1000, 1408, -1000, -1000,
+	1701, -1000, 1700, 1560, 776, 73, -1000, 481, 66, 1560,
+	1560, -1000, -1000, 2932, -39, 883, 1624, 1246, 3747, -1000,
+	1187, 3337, 3153, 1163, 1738, 472, 723, 750, 1163, 65,
+	1800, 1798, 470, 469, 466, 63, 2914, 3747, 3747, -1000,
+	461, 1163, -1000, -1000, 1191, -1000, 1862, 723, 62, -1000,
+	2914, 3747, -1000, -1000, -1000, 61, -1000, -1000, 1622, 728,
+	1560, 1781, 728, 60, 59, -1000, 1621, 1620, 1619, -64,
+	1363, -1000, -1000, 843, 1224, 3337, 867, 846, -1000, -1000,
+	455, -1000, 3291, 1699, 1550, 1823, 1163, -1000, -1000, 436,
+	435, 1560, 1560, 1560, 384, 2914, 2914, 1551, -1000, 46,
+	-1000, 1053, -1000, 2914, 728, -1000, -1000, -1000, -1000, -1000,
+	-1000, 728, 20, 1618, -1000, -1000, -1000, -1000, 1294, 1617,
+	1616, -1000, -1000, 3747, 1875, 1560, 867, 1611, 3153, 3641,
+	840, 1894, 48, 750, -1000, 3291, 3291, 47, 45, 44,
+	-1000, 41, -1000, 2023, 1609, -1000, 1031, -1000, -1000, 742,
+	1607, 1027, 707, -1000, -1000, 1356, 1849, 829, -1000, 1822,
+	-1000, -1000, 39, -1000, 2914, 1919, 1550, -1000, 1163, 38,
+	4, -1000, -1000, -1000, -76, 1551, 1606, 1530, 1605, 495,
+	91, -1000, 1560, 1131, 1405, 1042, 1604, 1888, 413, 1579,
+	1294, -1000, 1632, 1560, 403, -1000, 3641, -1000, 820, -1000,
+	-78, -91, -1000, -1000, -1000, 1388, 1568, 365, 1561, 161,
+	-1000, 312, -1000, 1378, -1000, -1000, -1000, 1378, 1560, 1506,
+	1506, 1560, 1559, -1000, -1000, -1000, -1000, -1000, 1523, 1523,
+	-1000, 1376, 1551, 351, 347, 1461, 150, 1860, 1858, 76,
+	1857, -1000, -1000, -1000, -1000, -1000, -1000, 1558, 1687, -1000,
+	-9, -1000, -1000, -1000, -1000, 1615, -1000, -10, 1551, 1674,
+	1550, 275, 1855, 1854, 1374, 1371, 1851, 1342, -1000, -1000,
+	-1000, -1000, 724, -1000, -1000, 1279, -1000, -13, -1000, 1550,
+	-15, -1000, -1000, 1277, 1275, -1000, -1000, 1267, -1000, 1531,
+	-1000, -1000, 820, -1000, -1000, -1000, -1000, -1000,
 }
-var yyPgo = [...]int{
-
-	0, 579, 578, 22, 577, 576, 575, 572, 571, 566,
-	565, 564, 562, 561, 446, 559, 558, 557, 9, 37,
-	555, 554, 553, 552, 11, 551, 550, 246, 545, 3,
-	17, 544, 19, 543, 541, 540, 537, 2, 20, 21,
-	535, 6, 534, 15, 533, 4, 532, 526, 16, 525,
-	524, 523, 522, 13, 520, 5, 519, 1, 517, 516,
-	515, 18, 8, 44, 235, 511, 510, 509, 504, 503,
-	502, 0, 7, 500, 10, 499, 498, 14, 497, 496,
-	495, 494, 492, 491, 490, 12, 489, 485, 484, 483,
-	481,
+
+var yyPgo = [...]int16{
+	0, 2120, 96, 19, 1301, 1231, 1200, 1193, 2119, 2118,
+	2117, 2116, 2115, 2114, 2113, 2112, 2108, 2107, 2106, 2105,
+	2104, 2103, 2102, 2101, 2100, 2099, 1204, 2097, 69, 109,
+	2094, 2091, 72, 2090, 65, 2089, 2088, 2087, 61, 2085,
+	49, 2084, 2080, 2079, 1773, 2078, 111, 291, 286, 16,
+	107, 2077, 80, 2075, 2073, 104, 2072, 2071, 70, 57,
+	87, 67, 5, 2070, 2069, 2068, 2067, 13, 2066, 2064,
+	2062, 9, 2061, 2060, 1426, 18, 2059, 101, 23, 2058,
+	1, 2056, 11, 66, 20, 12, 2055, 2054, 24, 42,
+	2052, 62, 2051, 2043, 47, 58, 74, 28, 25, 2042,
+	37, 2040, 2038, 2033, 2027, 31, 113, 2026, 1070, 33,
+	2013, 1099, 108, 36, 2012, 160, 223, 2006, 464, 2005,
+	14, 2004, 2003, 106, 2001, 1994, 82, 15, 1992, 1991,
+	35, 321, 1987, 76, 97, 21, 317, 8, 316, 102,
+	1986, 1982, 1981, 1980, 1979, 1978, 1270, 1977, 1976, 1975,
+	1974, 1973, 1972, 1971, 1967, 10, 22, 30, 2, 43,
+	1966, 117, 115, 112, 100, 121, 1960, 1959, 86, 94,
+	1957, 1956, 1736, 127, 1955, 122, 120, 1953, 1952, 1734,
+	0, 91, 1945, 1943, 118, 1309, 1942, 363, 116, 114,
+	1939, 55, 73, 103, 305, 71, 17, 54, 1938, 1937,
+	79, 119, 40, 83, 1936, 1935, 1934, 110, 27, 95,
+	77, 1932, 46, 60, 59, 32, 29, 1394, 543, 1928,
+	105, 68, 26, 1927, 1926, 1922, 1921, 56, 75, 1920,
+	1919, 4, 63, 1918, 41, 39, 1917, 6, 7, 1915,
+	38, 1903, 1908, 1906, 64, 1905, 1904,
 }
-var yyR1 = [...]int{
-
-	0, 1, 2, 2, 2, 2, 2, 2, 2, 2,
-	2, 2, 2, 3, 3, 4, 4, 5, 6, 7,
-	81, 81, 73, 73, 73, 86, 86, 86, 86, 86,
-	78, 78, 78, 79, 79, 83, 83, 83, 83, 83,
-	83, 83, 84, 84, 84, 84, 84, 84, 84, 85,
-	85, 77, 77, 80, 80, 87, 87, 87, 87, 87,
-	87, 87, 82, 82, 88, 88, 89, 89, 74, 75,
-	75, 76, 8, 8, 8, 9, 9, 9, 10, 11,
-	11, 11, 12, 13, 13, 13, 90, 14, 15, 15,
-	16, 16, 16, 16, 16, 17, 17, 18, 18, 19,
-	19, 19, 22, 22, 20, 20, 20, 23, 23, 24,
-	24, 24, 24, 21, 21, 21, 25, 25, 25, 25,
-	25, 25, 25, 25, 25, 26, 26, 26, 27, 27,
-	28, 28, 28, 28, 29, 29, 30, 30, 32, 32,
-	32, 32, 32, 33, 33, 33, 33, 33, 33, 33,
-	33, 33, 33, 34, 34, 34, 34, 34, 34, 34,
-	38, 38, 38, 43, 39, 39, 37, 37, 37, 37,
-	37, 37, 37, 37, 37, 37, 37, 37, 37, 37,
-	37, 37, 37, 37, 42, 42, 44, 44, 44, 46,
-	49, 49, 47, 47, 48, 50, 50, 45, 45, 36,
-	36, 36, 36, 51, 51, 52, 52, 53, 53, 54,
-	54, 55, 56, 56, 56, 31, 31, 31, 57, 57,
-	57, 58, 58, 58, 59, 59, 60, 60, 61, 61,
-	35, 35, 40, 40, 41, 41, 62, 62, 63, 64,
-	64, 65, 65, 66, 66, 67, 67, 67, 67, 67,
-	68, 68, 69, 69, 70, 70, 71, 72,
+
+var yyR1 = [...]uint8{
+	0, 1, 1, 241, 241, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 4, 74, 74,
+	74, 74, 53, 56, 56, 54, 54, 55, 55, 5,
+	5, 5, 6, 7, 7, 7, 145, 145, 145, 145,
+	146, 146, 174, 174, 96, 96, 95, 95, 95, 9,
+	9, 9, 8, 140, 140, 140, 147, 147, 141, 141,
+	141, 149, 149, 148, 148, 148, 148, 148, 151, 151,
+	150, 150, 150, 152, 152, 152, 153, 153, 154, 154,
+	127, 127, 10, 10, 31, 31, 32, 32, 33, 33,
+	22, 22, 22, 22, 22, 22, 22, 23, 23, 23,
+	23, 23, 23, 185, 185, 184, 184, 186, 186, 187,
+	187, 187, 187, 187, 187, 187, 187, 187, 187, 187,
+	187, 187, 187, 187, 188, 188, 188, 189, 189, 189,
+	189, 189, 190, 190, 192, 192, 191, 191, 191, 191,
+	191, 194, 194, 193, 193, 193, 193, 193, 205, 205,
+	197, 197, 197, 196, 196, 203, 203, 203, 203, 203,
+	203, 203, 228, 228, 228, 228, 228, 198, 198, 198,
+	198, 198, 207, 207, 208, 208, 208, 209, 209, 209,
+	199, 199, 227, 227, 227, 227, 227, 227, 227, 200,
+	200, 200, 200, 200, 201, 201, 201, 202, 202, 204,
+	204, 229, 229, 229, 229, 229, 229, 229, 229, 226,
+	226, 242, 242, 243, 243, 210, 211, 211, 211, 211,
+	212, 212, 212, 212, 212, 212, 212, 212, 214, 206,
+	206, 206, 213, 213, 213, 230, 230, 230, 231, 231,
+	231, 231, 244, 244, 245, 245, 221, 221, 215, 215,
+	216, 216, 216, 222, 222, 236, 236, 236, 236, 236,
+	236, 236, 236, 236, 237, 237, 223, 223, 223, 223,
+	223, 224, 224, 225, 225, 225, 179, 179, 233, 233,
+	234, 234, 234, 235, 235, 235, 235, 235, 235, 232,
+	232, 232, 238, 238, 239, 239, 11, 11, 11, 11,
+	11, 11, 142, 143, 178, 178, 99, 99, 144, 144,
+	12, 12, 12, 12, 12, 12, 57, 57, 58, 58,
+	58, 58, 58, 58, 58, 58, 58, 58, 58, 58,
+	60, 60, 59, 59, 59, 13, 183, 183, 14, 15,
+	15, 15, 15, 15, 16, 17, 17, 17, 17, 17,
+	17, 17, 17, 17, 25, 25, 26, 26, 26, 26,
+	29, 29, 28, 28, 28, 30, 30, 30, 27, 27,
+	24, 24, 24, 24, 18, 18, 18, 18, 18, 168,
+	168, 169, 169, 19, 19, 19, 167, 167, 166, 166,
+	20, 20, 20, 20, 20, 20, 20, 20, 20, 20,
+	34, 34, 36, 36, 35, 35, 39, 39, 40, 40,
+	42, 42, 41, 41, 37, 37, 38, 38, 38, 38,
+	38, 38, 38, 21, 21, 21, 217, 217, 217, 218,
+	218, 219, 219, 220, 43, 43, 246, 44, 45, 45,
+	47, 47, 47, 47, 47, 47, 47, 48, 48, 48,
+	72, 72, 72, 72, 72, 72, 72, 72, 72, 72,
+	72, 72, 75, 75, 77, 77, 77, 88, 88, 81,
+	81, 81, 90, 90, 89, 89, 91, 91, 91, 91,
+	91, 91, 91, 91, 91, 91, 91, 105, 105, 104,
+	104, 104, 104, 104, 82, 82, 83, 83, 92, 92,
+	92, 92, 92, 92, 92, 92, 93, 93, 93, 93,
+	93, 93, 84, 84, 85, 85, 85, 85, 85, 86,
+	86, 87, 87, 87, 94, 94, 97, 97, 97, 97,
+	98, 98, 100, 100, 106, 106, 106, 106, 106, 106,
+	106, 107, 107, 107, 107, 107, 107, 107, 107, 107,
+	107, 107, 107, 107, 107, 107, 107, 107, 107, 107,
+	107, 107, 107, 108, 108, 108, 108, 108, 108, 108,
+	112, 112, 112, 118, 113, 113, 111, 111, 111, 111,
+	111, 111, 111, 111, 111, 111, 111, 111, 111, 111,
+	111, 111, 111, 111, 111, 111, 111, 111, 111, 111,
+	111, 111, 111, 111, 111, 111, 111, 111, 111, 111,
+	111, 61, 61, 61, 62, 63, 63, 64, 64, 65,
+	65, 65, 66, 66, 67, 67, 68, 68, 68, 69,
+	69, 70, 70, 71, 117, 117, 117, 117, 49, 49,
+	119, 119, 119, 121, 124, 124, 122, 122, 123, 125,
+	125, 120, 120, 52, 51, 51, 51, 51, 51, 126,
+	126, 50, 50, 50, 110, 110, 110, 110, 110, 110,
+	110, 110, 73, 73, 73, 76, 76, 78, 78, 79,
+	79, 80, 80, 128, 128, 129, 129, 130, 130, 131,
+	132, 132, 133, 133, 134, 134, 134, 101, 101, 101,
+	102, 102, 103, 103, 135, 135, 136, 136, 136, 137,
+	137, 138, 138, 138, 139, 139, 139, 155, 155, 157,
+	157, 157, 156, 156, 109, 114, 114, 115, 115, 116,
+	116, 158, 158, 159, 160, 160, 161, 161, 161, 161,
+	161, 164, 164, 164, 165, 162, 162, 162, 162, 163,
+	163, 46, 46, 46, 46, 46, 46, 46, 175, 175,
+	176, 176, 173, 173, 170, 170, 170, 170, 171, 171,
+	171, 240, 240, 177, 177, 172, 172, 180, 181, 182,
+	182, 195,
 }
-var yyR2 = [...]int{
-
-	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 13, 3, 7, 7, 8, 7, 3,
-	0, 1, 3, 1, 1, 1, 1, 1, 1, 1,
-	2, 2, 1, 2, 1, 1, 1, 1, 1, 1,
-	1, 1, 2, 2, 2, 2, 2, 2, 2, 0,
-	5, 0, 3, 0, 1, 0, 3, 2, 3, 3,
-	2, 2, 1, 1, 2, 1, 1, 2, 3, 1,
-	3, 7, 1, 8, 4, 6, 7, 4, 5, 4,
-	5, 5, 3, 2, 2, 2, 0, 2, 0, 2,
-	1, 2, 1, 1, 1, 0, 1, 1, 3, 1,
-	2, 3, 1, 1, 0, 1, 2, 1, 3, 3,
-	3, 3, 5, 0, 1, 2, 1, 1, 2, 3,
-	2, 3, 2, 2, 2, 1, 3, 1, 1, 3,
-	0, 5, 5, 5, 1, 3, 0, 2, 1, 3,
-	3, 2, 3, 3, 3, 4, 3, 4, 5, 6,
+
+var yyR2 = [...]int8{
+	0, 3, 1, 0, 2, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 15, 6, 3, 3, 3,
+	3, 3, 3, 3, 3, 4, 2, 3, 1, 4,
+	3, 2, 3, 0, 1, 1, 3, 3, 6, 11,
+	14, 12, 11, 11, 9, 10, 0, 1, 1, 1,
+	0, 1, 0, 1, 1, 3, 1, 3, 5, 4,
+	4, 5, 17, 0, 1, 1, 0, 1, 0, 1,
+	1, 0, 2, 0, 4, 4, 5, 4, 0, 2,
+	0, 4, 4, 0, 3, 3, 0, 3, 0, 2,
+	0, 2, 3, 5, 1, 3, 3, 2, 1, 2,
+	1, 1, 3, 4, 4, 5, 4, 7, 6, 3,
+	3, 3, 5, 1, 3, 1, 4, 1, 2, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 2, 2, 1, 3, 1,
+	3, 3, 0, 3, 1, 3, 1, 2, 2, 1,
+	2, 1, 3, 1, 4, 4, 6, 6, 0, 1,
+	3, 3, 2, 1, 1, 3, 1, 2, 1, 2,
+	2, 2, 1, 1, 1, 1, 1, 2, 2, 1,
+	4, 4, 1, 3, 0, 3, 2, 0, 2, 2,
+	2, 1, 1, 1, 1, 1, 1, 1, 1, 2,
+	2, 2, 2, 2, 0, 3, 5, 0, 3, 0,
+	1, 0, 3, 2, 3, 4, 2, 2, 3, 1,
+	1, 2, 1, 1, 2, 3, 1, 1, 3, 3,
+	1, 2, 3, 6, 7, 1, 2, 3, 5, 0,
+	1, 2, 6, 7, 7, 5, 4, 4, 1, 2,
+	2, 2, 1, 1, 0, 1, 0, 1, 1, 3,
+	2, 3, 3, 0, 2, 0, 3, 2, 4, 3,
+	3, 3, 4, 4, 1, 1, 10, 12, 7, 7,
+	9, 0, 2, 0, 1, 2, 0, 1, 0, 1,
+	1, 2, 3, 3, 3, 2, 4, 5, 4, 1,
+	1, 1, 0, 1, 0, 1, 1, 12, 8, 5,
+	6, 5, 0, 0, 0, 2, 0, 3, 0, 1,
+	6, 7, 5, 7, 4, 4, 1, 3, 3, 4,
+	2, 3, 3, 3, 4, 4, 5, 5, 5, 1,
+	0, 1, 0, 1, 2, 3, 3, 5, 3, 5,
+	6, 5, 4, 4, 3, 3, 5, 7, 4, 4,
+	4, 4, 2, 3, 1, 2, 1, 1, 1, 2,
+	1, 1, 0, 2, 2, 1, 1, 1, 0, 3,
+	1, 1, 1, 1, 5, 2, 4, 5, 6, 1,
+	3, 1, 1, 4, 4, 3, 1, 1, 1, 3,
+	4, 6, 8, 8, 6, 8, 2, 2, 4, 6,
+	0, 3, 0, 5, 0, 2, 0, 2, 0, 1,
+	0, 2, 1, 1, 1, 3, 1, 1, 2, 2,
+	3, 1, 1, 3, 2, 3, 2, 3, 1, 0,
+	2, 1, 3, 3, 2, 2, 0, 2, 0, 2,
+	1, 2, 2, 1, 1, 2, 2, 1, 2, 2,
+	0, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+	2, 4, 1, 3, 1, 2, 3, 1, 1, 0,
+	1, 2, 0, 2, 1, 3, 5, 8, 3, 6,
+	3, 3, 5, 7, 4, 12, 12, 0, 4, 0,
+	4, 5, 5, 2, 0, 1, 1, 2, 1, 1,
+	2, 3, 2, 3, 2, 2, 1, 3, 1, 3,
+	4, 10, 1, 3, 3, 5, 5, 6, 7, 0,
+	4, 1, 1, 2, 1, 3, 0, 5, 5, 5,
+	1, 3, 0, 2, 1, 3, 3, 2, 3, 3,
+	4, 1, 3, 3, 4, 4, 3, 4, 4, 5,
+	3, 4, 3, 3, 3, 4, 5, 6, 3, 4,
 	3, 4, 2, 1, 1, 1, 1, 1, 1, 1,
 	3, 1, 1, 3, 1, 3, 1, 1, 1, 1,
+	3, 2, 3, 4, 4, 3, 3, 3, 4, 1,
 	3, 3, 3, 3, 3, 3, 3, 3, 2, 3,
-	4, 5, 4, 1, 1, 1, 1, 1, 1, 5,
-	0, 1, 1, 2, 4, 0, 2, 1, 3, 1,
-	1, 1, 1, 0, 3, 0, 2, 0, 3, 1,
-	3, 2, 0, 1, 1, 0, 2, 4, 0, 2,
-	4, 0, 2, 4, 0, 3, 1, 3, 0, 5,
-	2, 1, 1, 3, 3, 1, 1, 3, 3, 0,
-	2, 0, 3, 0, 1, 1, 1, 1, 1, 1,
-	0, 1, 0, 1, 0, 2, 1, 0,
+	2, 4, 5, 6, 3, 4, 3, 6, 6, 6,
+	1, 0, 2, 2, 6, 0, 1, 0, 3, 0,
+	2, 5, 1, 1, 2, 2, 1, 1, 3, 0,
+	2, 1, 3, 3, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 5, 0, 1, 1, 2, 4, 0,
+	2, 1, 3, 9, 0, 4, 7, 3, 3, 0,
+	2, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 0, 3, 5, 1, 3, 1, 4, 1,
+	3, 1, 2, 0, 2, 0, 2, 0, 1, 3,
+	1, 3, 2, 2, 0, 1, 1, 0, 2, 4,
+	0, 1, 2, 3, 0, 1, 2, 4, 4, 0,
+	1, 3, 3, 4, 0, 1, 2, 1, 3, 0,
+	2, 5, 0, 5, 1, 1, 3, 3, 1, 1,
+	4, 1, 3, 3, 1, 3, 4, 3, 4, 4,
+	3, 1, 1, 1, 1, 0, 1, 1, 1, 1,
+	1, 0, 2, 2, 2, 2, 2, 3, 0, 2,
+	0, 3, 0, 1, 1, 1, 1, 1, 0, 1,
+	1, 0, 1, 0, 1, 1, 1, 1, 1, 1,
+	3, 0,
 }
-var yyChk = [...]int{
-
-	-1000, -1, -2, -3, -4, -5, -6, -7, -8, -9,
-	-10, -11, -12, -13, 5, 6, 7, 8, 35, -76,
-	88, 89, 91, 90, 92, 100, 101, 102, -16, 54,
-	55, 56, 57, -14, -90, -14, -14, -14, -14, 93,
-	-69, 95, 53, -66, 95, 97, 93, 93, 94, 95,
-	93, -72, -72, -72, -3, 19, -17, 20, -15, 31,
-	-27, 37, 9, -62, -63, -45, -71, 37, -65, 98,
-	94, -71, 37, 93, -71, 37, -64, 98, -71, -64,
-	37, -18, -19, 78, -22, 37, -32, -37, -33, 72,
-	47, -36, -45, -41, -44, -71, -42, -46, 22, 38,
-	39, 40, 27, -43, 76, 77, 51, 98, 30, 83,
-	42, -27, 35, 81, -27, 58, 48, 81, 37, 72,
-	-71, -72, 37, -72, 96, 37, 22, 69, -71, 9,
-	58, -20, -71, 21, 81, 71, 70, -34, 23, 72,
-	25, 26, 24, 73, 74, 75, 76, 77, 78, 79,
-	80, 48, 49, 50, 43, 44, 45, 46, -32, -37,
-	78, -32, -3, -39, -37, -37, 47, 47, 47, -43,
-	47, -49, -37, -59, 35, 47, -62, 37, -30, 12,
-	-63, -37, -71, 47, 22, -70, 99, -67, 91, 89,
-	34, 90, 15, 37, 37, 37, -72, -23, -24, -26,
-	47, 37, -43, -19, -71, 78, -32, -32, -37, -38,
-	47, -43, 41, 23, 25, 26, -37, -37, 27, 72,
-	-37, -37, -37, -37, -37, -37, -37, -37, 130, 130,
-	58, 130, -37, 130, -18, 20, -18, -47, -48, 84,
-	-35, 30, -3, -62, -60, -45, -30, -53, 15, -32,
-	-75, -74, 37, 69, -71, -72, -68, 96, -31, 58,
-	10, -25, 59, 60, 61, 62, 63, 65, 66, -21,
-	37, 21, -24, 81, -39, -38, -37, -37, 71, 27,
-	-37, 130, -18, 130, -50, -48, 86, -32, -61, 69,
-	-40, -41, -61, 130, 58, -53, -57, 17, 16, 58,
-	130, -73, -79, -78, -86, -83, -84, 123, 124, 122,
-	117, 118, 119, 120, 121, 103, 104, 105, 106, 107,
-	108, 109, 110, 111, 112, 115, 116, 37, 37, -30,
-	-24, 38, -24, 59, 64, 59, 64, 59, 59, 59,
-	-28, 67, 97, 68, 37, 130, 37, 130, 71, -37,
-	130, 87, -37, 85, 32, 58, -45, -57, -37, -54,
-	-55, -37, -74, -87, -80, 113, -77, 47, -77, -77,
-	-85, 47, -85, -85, -85, -77, 47, -85, -77, -72,
-	-51, 13, 11, 69, 59, 59, 94, 94, 94, -37,
-	-37, 33, -41, 58, 58, -56, 28, 29, 72, 27,
-	34, 126, -82, -88, -89, 52, 33, 53, -81, 114,
-	39, 39, 39, -52, 14, 16, 38, -32, 47, 47,
-	47, 7, -37, -55, 27, 38, 39, 33, 33, 130,
-	58, -53, -32, -18, -29, -71, -29, -29, -62, 39,
-	-57, 130, 58, 130, 130, 130, -58, 18, 36, -71,
-	7, 23, -71, -71,
+
+var yyChk = [...]int16{
+	-1000, -1, -241, -2, 234, -3, -5, -6, -7, -8,
+	-9, -10, -11, -12, -13, -14, -15, -16, -17, -18,
+	-19, -20, -21, -22, -23, 5, -4, 35, -53, 6,
+	7, 8, 195, 42, 40, -223, 181, 182, 184, 183,
+	185, 192, -30, 106, 108, 109, -180, 191, -39, 117,
+	118, 122, 123, 89, 90, 91, 92, 93, 179, 134,
+	194, 193, 34, -241, -47, -48, 135, 136, 137, 138,
+	-44, -246, -47, -48, -114, -116, -115, 42, 179, -118,
+	-3, -44, -44, -44, 42, -181, -94, 189, 42, 186,
+	-180, -44, -179, -177, -178, -172, 42, 132, 156, 130,
+	131, -173, 188, 42, 190, 186, -179, 187, 188, -172,
+	42, 186, -25, 181, -26, 42, 186, 187, 225, -94,
+	-27, -181, 42, -180, -98, -41, 42, 115, 116, -180,
+	9, -34, 236, -106, -107, 158, 179, -52, -111, 22,
+	72, 164, -110, -120, -165, 74, 79, 80, -115, 49,
+	-119, -180, -117, 69, 70, 71, -121, 51, 43, 44,
+	45, 46, 30, 31, 32, -181, 50, 162, 163, 127,
+	42, 191, 35, 130, 131, 174, 111, 112, 113, -180,
+	-180, -217, 121, -180, -218, -217, 40, -185, -184, -186,
+	-187, 42, 19, 182, 181, 8, 183, 89, 187, 6,
+	41, 228, 5, 192, 7, 188, -185, -176, 191, -175,
+	191, 124, 18, -3, -56, 68, -3, -74, -4, -3,
+	-74, 19, 20, 19, 20, 19, 20, -72, 75, -45,
+	-3, -74, -3, -74, -130, 139, -131, 15, 179, -3,
+	-113, 35, -111, 179, -145, 103, 104, 98, -146, 103,
+	-146, -140, 103, 42, 167, 179, -180, 42, 189, 42,
+	-180, -181, 42, 9, 154, -160, -162, -161, 56, 57,
+	58, -165, 186, 187, 188, -176, -176, 42, 186, -181,
+	-94, -183, -181, 186, -175, -175, -175, -175, -181, -28,
+	-29, -26, 25, 12, 9, 23, 186, 188, 130, 42,
+	40, -24, -3, -5, -6, -7, 167, 124, 107, -197,
+	139, -199, -198, -228, -227, -200, 223, 224, 222, 42,
+	40, 217, 218, 219, 220, 221, 203, 204, 205, 206,
+	207, 208, 209, 210, 211, 212, 215, 216, 42, 36,
+	9, -180, 178, -2, 109, 176, 157, 156, 24, -106,
+	-106, 179, -111, -108, 124, 125, 126, 52, 53, 54,
+	55, -108, 23, 158, 25, 26, 85, 27, 81, 29,
+	24, 171, 172, 173, 170, 159, 160, 161, 162, 163,
+	164, 165, 166, 169, -118, 179, 179, 155, -94, 170,
+	179, -111, -111, 179, 179, -111, 179, 179, 167, -124,
+	-111, -106, -34, -34, -218, 51, 42, -218, -219, -220,
+	42, 153, 139, 179, -187, 153, -194, -193, -191, 42,
+	51, 158, -194, 22, 51, -191, 235, -54, -55, -181,
+	-131, -136, -138, 17, 18, 41, -75, 20, 97, 98,
+	142, 99, 100, 101, 94, 95, 102, 96, -77, 164,
+	-88, -181, -106, -111, -43, 43, 46, 48, -135, -136,
+	-116, 16, -113, 235, 139, 235, -3, -173, -173, -174,
+	105, -147, 58, -181, 235, -113, -180, -180, 42, 42,
+	-167, 51, -165, -166, -165, 139, 42, -120, 225, 226,
+	-180, -163, 124, 155, -176, -176, -181, -181, -94, -181,
+	-195, -46, 139, 189, -175, -180, -181, -181, -94, -94,
+	51, -106, -94, -94, -181, -94, -181, 42, 18, -42,
+	39, -180, -204, 213, -208, 225, 226, -202, 179, -202,
+	-202, -202, 179, 179, -201, 179, -201, -201, -201, -201,
+	18, -168, -169, -180, 50, -180, 36, -40, -180, 234,
+	-34, -34, -106, -106, -50, 158, 31, 32, 42, 235,
+	-111, -111, 19, 87, -112, 179, -118, 47, 23, 25,
+	26, 81, 29, -111, -111, -111, -111, -111, -111, 30,
+	158, -50, -196, -197, 42, 51, 51, -111, -111, -111,
+	-111, -111, -111, -111, -111, -111, -180, -155, -120, -111,
+	237, -113, -75, 235, -75, 20, 235, -75, -49, 42,
+	221, -111, -111, -180, -122, -123, 175, 114, 178, 11,
+	51, 139, 124, -188, -189, 186, 42, 164, -181, -184,
+	-98, -180, -188, 139, 42, 50, 51, 50, 22, 124,
+	139, 21, 179, -135, -137, -138, -111, 7, 42, 23,
+	-90, 139, 9, 124, -81, -180, 21, 167, 9, 35,
+	35, -132, -133, -111, -52, 235, -111, 235, 36, -89,
+	-91, -93, 83, 179, -181, -118, 84, -173, 196, 235,
+	-180, 139, -161, -162, -31, -164, -32, 42, 51, 39,
+	-163, 40, -164, 42, -111, -181, -180, -99, 179, -195,
+	179, -46, -170, 183, -57, 184, 182, 39, 15, 42,
+	-58, 63, 66, 64, -235, 230, 67, -239, 42, 16,
+	134, 124, 43, 163, -181, -181, -182, -181, 153, -195,
+	-28, -29, -3, -111, -205, 214, -209, 169, 40, -180,
+	43, -207, 51, -207, 43, -37, -38, 119, 120, 158,
+	121, 43, -180, 139, 36, -168, 178, -36, -50, -118,
+	-118, -113, -112, -111, -111, -111, -111, -126, 28, 157,
+	30, -50, 237, 235, 139, 237, 235, -61, 59, 235,
+	-75, 235, 21, 139, 154, -125, -123, 177, -106, -34,
+	112, -106, -220, -111, 189, -189, -189, 167, 167, 235,
+	9, -193, 16, 134, 51, -55, -118, -98, -137, 139,
+	42, -139, 42, -139, -180, -101, 10, -77, -89, 43,
+	-180, 164, -94, 139, -134, 33, 34, -134, -94, 40,
+	139, -92, 148, 151, 152, 141, 142, 143, 144, 145,
+	147, -105, 77, -118, -91, 179, 167, 179, 179, 9,
+	-96, -95, -94, -181, 51, -165, 42, 139, -209, 42,
+	-111, -164, 179, -225, 25, 21, -234, -235, 42, 39,
+	-222, 154, 21, -98, -142, -195, 77, -60, -244, 128,
+	227, 65, 187, 38, 139, -171, 65, -244, 189, 21,
+	-238, 124, -210, 65, -212, 42, -213, 129, -244, -214,
+	128, 132, 227, -60, -60, -238, 51, 226, 225, 169,
+	43, 189, 139, -181, -181, -180, 51, -180, 235, 235,
+	139, 235, 235, 139, -2, 139, 42, 51, 42, -169,
+	-168, -40, -35, 110, 177, 235, -126, 157, -111, -111,
+	42, -120, -62, -180, 179, -61, 235, -203, 223, -200,
+	-228, 213, 42, -203, -180, 178, -111, 176, 178, -40,
+	178, -192, -191, 164, 164, -181, -192, 51, -180, 235,
+	-111, -111, 42, -180, -102, -103, 88, -111, -133, -105,
+	-158, -159, -120, -91, -91, 141, 179, 179, 141, 146,
+	141, 146, 141, 141, -104, 76, 179, -82, -83, -181,
+	21, 235, -181, 235, -75, -111, -94, -96, 9, 139,
+	167, -141, 42, 190, -32, 42, -33, 42, -211, 25,
+	-210, -212, -3, -94, -240, -235, 139, 21, 153, -180,
+	-3, 235, -195, -180, -180, 38, 38, -58, 183, 184,
+	-181, -180, -180, -232, 42, 43, 51, -59, 42, -210,
+	42, -197, -244, 179, -213, -180, -214, 42, -221, -180,
+	38, -245, -244, 38, -210, -180, 43, -238, 40, -238,
+	-181, -181, -28, 51, 43, -38, 51, 178, -106, -34,
+	-111, 179, -63, -180, -61, 235, -202, -202, -227, -202,
+	-227, 235, 235, -111, 111, 113, -190, 139, 134, 16,
+	21, 21, -100, 12, 88, -111, 11, -109, 179, 40,
+	-3, -100, 139, 124, 153, 154, -91, -75, -120, 141,
+	141, -82, -83, 21, 9, 29, 19, -98, 179, -181,
+	235, 139, -100, 154, -89, -95, 164, -181, 36, 42,
+	139, 235, -94, -235, -181, -144, 86, -180, 189, 189,
+	-180, -59, -229, -221, -106, -213, -214, 42, 179, 179,
+	-221, -221, -59, -210, -232, -238, -232, 235, 191, 176,
+	-111, -64, 77, -208, -40, -40, -191, 89, 51, 51,
+	-118, -73, 13, -106, -111, -111, -157, 21, -155, -158,
+	-130, -159, -111, -106, 179, 18, 18, -97, 149, 190,
+	150, 179, 42, -111, -111, 235, -98, 51, -130, -89,
+	-100, 167, 186, -210, -212, -233, -234, 235, 179, -180,
+	-180, 158, 30, 39, 153, 230, -226, 67, -242, -243,
+	128, 38, 132, 179, 235, -215, -216, -180, -215, 179,
+	179, -59, -232, -34, -51, 23, 134, -130, 16, 42,
+	-128, 14, 16, -156, 153, -181, 235, -157, -135, -155,
+	-120, -120, 187, 187, 187, -98, -111, 189, 157, 235,
+	42, -135, -100, 164, -94, -224, 77, -240, -215, 30,
+	-111, 7, 51, 38, 38, -215, -206, 42, 158, 235,
+	139, -202, 235, -215, -215, 235, 148, 42, 42, -65,
+	-66, 61, 62, -113, -129, 78, -106, -76, -78, -88,
+	73, -127, 82, 37, 179, -109, -156, -127, 235, 23,
+	23, 179, 179, 179, 235, -111, -111, 179, -127, -105,
+	16, -3, 235, -111, 235, 42, -222, -216, 33, 34,
+	-222, 235, 235, 42, 42, 42, 235, -67, 29, 42,
+	-68, 43, 46, 69, -69, 60, -106, 134, 139, 179,
+	-75, 38, -155, -157, -127, 179, 179, -98, -98, -98,
+	-97, -84, -85, 42, -208, -143, -236, -222, -222, -230,
+	228, 42, -67, 42, 42, -111, -130, -70, -71, -180,
+	42, -78, -79, -80, -111, 179, 7, 235, -156, -75,
+	-75, 235, 235, 235, 235, 139, 18, -196, 51, 42,
+	-149, 42, 154, 42, 67, 41, 134, 153, -181, 134,
+	157, -49, -135, 139, 21, 235, 139, 235, -158, -127,
+	235, 235, 235, -85, 42, 42, 22, 42, 51, -151,
+	197, -148, -180, 124, 43, 51, 51, -238, 42, 8,
+	7, 179, 42, -67, -137, -71, -62, -80, 235, 235,
+	51, 42, 179, 42, -152, 190, -150, 199, 201, 200,
+	202, -237, -232, 39, -237, -180, -231, 42, 40, -231,
+	-215, 42, -82, -83, -82, -86, 51, -84, 179, -153,
+	179, 43, 198, 199, 16, 16, 201, 16, 42, 30,
+	39, 235, -87, 30, 42, 39, 235, -84, -154, 40,
+	-155, 197, 61, 16, 16, 51, 51, 16, 51, 153,
+	51, 235, -158, 235, 51, 51, 51, 42,
 }
-var yyDef = [...]int{
-
-	0, -2, 1, 2, 3, 4, 5, 6, 7, 8,
-	9, 10, 11, 12, 86, 86, 86, 86, 86, 72,
-	252, 243, 0, 0, 0, 257, 257, 257, 0, 90,
-	92, 93, 94, 95, 88, 0, 0, 0, 0, 241,
-	0, 0, 253, 0, 0, 244, 0, 239, 0, 239,
-	0, 83, 84, 85, 14, 91, 0, 96, 87, 0,
-	0, 128, 0, 19, 236, 0, 197, 256, 0, 0,
-	0, 257, 256, 0, 257, 0, 0, 0, 0, 0,
-	82, 0, 97, -2, 104, 256, 102, 103, 138, 0,
-	0, 167, 168, 169, 0, 197, 0, 183, 0, 199,
-	200, 201, 202, 235, 186, 187, 188, 184, 185, 190,
-	89, 224, 0, 0, 136, 0, 0, 0, 0, 0,
-	254, 74, 0, 77, 0, 79, 240, 0, 257, 0,
-	0, 100, 105, 0, 0, 0, 0, 0, 0, 0,
+
+var yyDef = [...]int16{
+	3, -2, -2, 3, 4, 5, 6, 7, 8, 9,
+	10, 11, 12, 13, 14, 15, 16, 17, 18, 19,
+	20, 21, 22, 23, 24, 456, 0, 0, 0, 456,
+	456, 456, 0, -2, 456, 316, -2, 782, 0, 296,
+	0, 0, 388, 0, 0, -2, 0, 0, 0, 0,
+	0, 0, 449, 0, 0, 780, 778, 0, 0, 43,
+	385, 386, 387, 1, 0, 0, 460, 463, 464, 467,
+	470, 458, 0, 0, 707, 745, 749, 0, 0, 748,
+	36, 56, 60, 60, 73, 544, 0, 0, -2, 0,
+	395, 765, 0, 0, 0, 780, -2, 794, 0, 795,
+	796, 0, 0, 0, 783, 0, 0, 778, 778, 778,
+	-2, 0, 382, 0, 374, 376, 377, 378, 0, 372,
+	0, 544, 798, 550, 0, 0, 797, 432, 433, 0,
+	0, 426, 427, 0, 554, 0, 0, 561, 0, 0,
+	0, 596, 597, 598, 599, 0, 0, 0, 609, 0,
+	0, 671, 0, 0, 0, 0, 630, 684, 685, 686,
+	687, 688, 689, 690, 691, 0, 764, 660, 661, 662,
+	-2, 654, 655, 656, 657, 664, 0, 420, 420, 416,
+	417, 449, 0, 448, 444, 449, 0, 0, 123, 125,
+	127, 129, 130, 131, 132, 133, 134, 135, 136, 137,
+	138, 139, 140, 141, 142, 143, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 44, 27, 31, 38, 28,
+	32, 461, 462, 465, 466, 468, 469, 0, 0, 457,
+	29, 33, 30, 34, 724, 0, 708, 0, 0, 0,
+	0, 655, 594, 0, 782, 57, 58, 59, 782, 61,
+	62, 76, 74, 75, 0, 0, 112, 797, 0, 797,
+	405, 358, 798, 0, 0, 102, 0, 754, 766, 767,
+	768, 0, 780, 780, 0, 0, 0, 325, 0, 801,
+	771, 355, 0, 778, 0, 0, 0, 0, 364, 365,
+	0, 375, 0, 0, 380, 381, 0, 0, 0, 0,
+	379, 373, 390, 391, 392, 393, 0, 0, 0, 430,
+	0, 219, 194, 217, 217, 201, 217, 217, 189, 0,
+	0, 182, 183, 184, 185, 186, 202, 203, 204, 205,
+	206, 207, 208, 214, 214, 214, 214, 214, 0, 0,
+	0, 0, 428, 0, 420, 420, 0, 0, 0, 557,
+	0, 0, 594, 0, 583, 584, 585, 586, 587, 588,
+	589, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 153, 154, 155, 156, 157, 158, 159, 141, 0,
-	166, 0, 0, 0, 164, 178, 0, 0, 0, 152,
-	0, 0, 191, 0, 0, 0, 136, 129, 207, 0,
-	237, 238, 198, 0, 242, 0, 0, 257, 250, 245,
-	246, 247, 248, 249, 78, 80, 81, 215, 107, 113,
-	0, 125, 127, 98, 106, 101, 139, 140, 143, 144,
-	0, 161, 162, 0, 0, 0, 146, 0, 150, 0,
-	170, 171, 172, 173, 174, 175, 176, 177, 142, 163,
-	0, 234, 164, 179, 0, 0, 0, 195, 192, 0,
-	228, 0, 231, 228, 0, 226, 207, 218, 0, 137,
-	0, 69, 0, 0, 255, 75, 0, 251, 136, 0,
-	0, 0, 116, 117, 0, 0, 0, 0, 0, 130,
-	114, 0, 0, 0, 0, 145, 147, 0, 0, 151,
-	165, 180, 0, 182, 0, 193, 0, 0, 15, 0,
-	230, 232, 16, 225, 0, 218, 18, 0, 0, 0,
-	71, 55, 53, 23, 24, 51, 34, 51, 51, 32,
-	25, 26, 27, 28, 29, 35, 36, 37, 38, 39,
-	40, 41, 49, 49, 49, 49, 49, 257, 76, 203,
-	108, 216, 111, 118, 0, 120, 0, 122, 123, 124,
-	109, 0, 0, 0, 115, 110, 126, 160, 0, 148,
-	181, 189, 196, 0, 0, 0, 227, 17, 219, 208,
-	209, 212, 70, 68, 20, 54, 33, 0, 30, 31,
-	42, 0, 43, 44, 45, 46, 0, 47, 48, 73,
-	205, 0, 0, 0, 119, 121, 0, 0, 0, 149,
-	194, 0, 233, 0, 0, 211, 213, 214, 0, 57,
-	0, 60, 61, 62, 63, 0, 65, 66, 22, 21,
-	0, 0, 0, 207, 0, 0, 217, 112, 0, 0,
-	0, 0, 220, 210, 56, 58, 59, 64, 67, 52,
-	0, 218, 206, 204, 0, 134, 0, 0, 229, 0,
-	221, 131, 0, 132, 133, 50, 13, 0, 0, 135,
-	222, 0, 0, 223,
+	0, 0, 0, 0, 582, 0, 0, 0, 601, 0,
+	0, 618, 620, 0, 0, 0, 0, 0, 0, 0,
+	665, 0, 426, 426, 443, 446, 0, 445, 450, 451,
+	0, 0, 0, 0, 128, 0, 119, 161, 163, 156,
+	159, 0, 120, 779, 121, 0, 37, 42, 45, 0,
+	724, 729, 41, 0, 0, 0, 492, 471, 472, 473,
+	474, 475, 476, 477, 478, 479, 480, 0, 482, -2,
+	489, 0, 487, 488, 0, 0, 0, 459, 35, 725,
+	746, 0, 0, 593, 0, 747, 0, 0, 0, 782,
+	63, 0, 77, -2, 70, 0, 113, 114, 797, 116,
+	403, 406, 407, 404, 408, 765, -2, 0, 0, 0,
+	671, 0, 769, 770, 0, 0, 326, 801, 771, 314,
+	334, 335, 0, 0, 0, 0, 801, 362, 363, 382,
+	383, 384, 368, 369, 370, 371, 545, 389, 0, 418,
+	0, 551, 168, 220, 197, 0, 0, 172, 0, 200,
+	187, 188, 0, 0, 209, 0, 210, 211, 212, 213,
+	0, 396, 399, 401, 402, 0, 0, 410, 429, 421,
+	426, -2, 555, 556, 559, 0, 681, 682, 683, 558,
+	562, 563, 0, 0, 566, 0, 591, 592, 0, 0,
+	0, 0, 0, 679, 570, 572, 573, 574, 0, 578,
+	0, 580, 605, 173, 174, 606, 607, 0, 610, 611,
+	612, 613, 614, 615, 616, 617, 619, 0, 737, 600,
+	602, 0, 0, 631, 0, 0, 624, 0, 626, 658,
+	659, 0, 0, 672, 669, 666, 0, 420, 0, 0,
+	447, 0, 0, 0, 144, 0, 798, 147, 149, 124,
+	0, 550, 0, 0, 0, 157, 158, 160, 781, 0,
+	0, 0, 0, 729, 40, 730, 726, 734, 734, 0,
+	717, 0, 0, 0, 485, 490, 0, 0, 0, 454,
+	455, 709, 710, 714, 714, 750, 595, -2, 0, 0,
+	494, 507, 0, 0, 526, 528, 0, 0, 0, 71,
+	115, 0, 755, 0, 103, 197, 104, 761, 762, 763,
+	0, 0, 760, 761, 757, -2, 273, 0, 0, 319,
+	322, 321, 801, 350, 332, 788, 784, -2, 786, -2,
+	336, 0, 350, 350, 349, 312, 0, 0, 772, 773,
+	774, 775, 776, 0, 0, 356, 359, 799, 0, 361,
+	366, 0, 394, 431, 170, 169, 171, 0, 0, 196,
+	0, 0, 192, 0, 0, 426, 434, 436, 437, 0,
+	0, 441, 442, 0, 0, 397, 428, 424, 560, 564,
+	565, 0, 567, 679, 571, 575, 0, 568, 0, 0,
+	579, 581, 608, 0, 0, 603, 604, 621, 0, 631,
+	0, 625, 0, 0, 0, 0, 667, 0, 0, 426,
+	428, 0, 452, 453, 0, 145, 146, 0, 0, 126,
+	0, 162, 0, 0, 122, 46, 47, 0, 39, 0,
+	0, 731, 735, 732, 0, 720, 0, 483, 493, 481,
+	491, 486, 26, 0, 712, 715, 716, 713, 507, 0,
+	0, 0, 0, 0, 0, 518, 519, 0, 0, 0,
+	0, 509, 0, 514, 0, 0, 0, 0, 0, 0,
+	0, 64, 66, 544, 78, 409, -2, 0, 758, 107,
+	756, 759, 0, 0, 0, 294, -2, 300, 312, 315,
+	0, 0, 0, 0, 320, 330, 801, 0, 0, 0,
+	0, 351, 262, 263, 314, 0, 0, 0, 789, 790,
+	0, 313, 352, 0, 340, 0, 240, 0, 266, 245,
+	0, 264, 0, 0, 0, 0, 305, 312, 0, 312,
+	777, 0, 0, 360, 382, 198, 199, 195, 218, 190,
+	0, 191, 215, 0, 419, 0, 438, 439, 0, 400,
+	398, 411, 0, 0, 420, 590, 569, 0, 680, 576,
+	0, 738, 632, 633, 635, 622, 631, 0, 217, 176,
+	217, 178, 217, 0, 0, 663, 670, 0, 0, 414,
+	0, 152, 154, 148, 150, 151, 118, 164, 165, 0,
+	727, 728, 736, 733, 552, 721, 0, 718, 711, 0,
+	552, 751, 0, 495, 501, 0, 0, 0, 520, 0,
+	522, 0, 524, 525, 514, 0, 0, 498, 515, 516,
+	0, 500, 527, 529, 0, 0, -2, 0, 0, 0,
+	0, 0, 79, 80, 105, 0, 106, 108, 0, 0,
+	236, 237, 288, 289, 295, 301, 314, 792, 0, 274,
+	328, 327, 331, 341, 342, 343, 0, 337, 350, 0,
+	333, 0, 0, 303, 309, 310, 311, 338, 353, 352,
+	0, 221, 266, 0, 241, 0, 246, 797, 0, 267,
+	0, 266, 265, 266, 352, 0, 304, 0, 312, 0,
+	357, 800, 367, 193, 0, 435, 440, 0, 0, -2,
+	577, 0, 637, 636, 623, 627, 194, 177, 179, 180,
+	181, 628, 629, 668, 428, 428, 117, 0, 0, 0,
+	0, 0, 692, 0, 0, 722, 0, 739, 0, 0,
+	744, 707, 0, 0, 0, 0, 504, 0, 0, 521,
+	523, 546, 515, 0, 0, 0, 513, 0, 0, 517,
+	530, 0, 707, 0, 552, 65, 67, 545, 0, 109,
+	0, -2, 0, 302, 0, 318, 329, 344, 0, 0,
+	354, 339, 235, 0, 0, 242, 247, 0, 0, 0,
+	0, 0, 345, 352, 306, 0, 308, 216, 412, 420,
+	674, 707, 0, 175, 413, 415, 155, 0, 166, 167,
+	48, 703, 0, 553, 723, 719, 742, 0, 0, 739,
+	724, 752, 753, 502, 0, 0, 0, 496, 0, 0,
+	0, 0, 0, 0, 0, 508, 0, 0, 724, 552,
+	54, 0, 0, 238, 239, -2, -2, 290, 0, 347,
+	348, 0, 223, 0, 0, 226, 227, 0, 229, 230,
+	0, 232, 233, 0, 249, 0, 268, 217, 0, 0,
+	0, 346, 307, -2, 0, 0, 0, 639, 0, 153,
+	705, 0, 0, 100, 0, 740, 0, 742, 100, 0,
+	0, 0, 0, 0, 0, 0, 510, 0, 0, 499,
+	0, 100, 55, 68, 507, 286, 0, 0, 0, 222,
+	224, 0, 228, 231, 234, 0, 248, 250, 0, 273,
+	0, 270, 273, 0, 0, 673, 0, 0, 0, 0,
+	0, 642, 643, 638, 649, 0, 704, 693, 695, 697,
+	0, 49, 0, 0, 0, 739, 100, 52, 503, 0,
+	0, 0, 0, 0, 546, 511, 512, 0, 53, 194,
+	323, 292, 275, 225, 273, 251, 243, 269, 271, 272,
+	252, 273, 0, 0, 677, 678, 634, 640, 0, 0,
+	0, 646, 647, 0, 707, 0, 706, 0, 0, 0,
+	101, 0, 0, 742, 51, 0, 0, 0, 0, 0,
+	497, 0, 532, 0, 81, 287, 317, 244, 253, 254,
+	0, 675, 0, 644, 645, 0, 724, 650, 651, 0,
+	694, 696, 0, 699, 701, 0, 0, 741, 100, 0,
+	0, 547, 548, 549, 0, 0, 0, 0, 0, 174,
+	88, 83, 0, 277, 0, 312, 0, 0, 0, 0,
+	0, 648, 729, 0, 0, 698, 0, 702, 743, 50,
+	0, 0, 531, 533, 534, 0, 0, 0, 0, 93,
+	90, 82, 276, 0, 279, 280, 281, 0, 0, 0,
+	0, 0, 0, 641, 25, 652, 653, 700, 514, 514,
+	539, 0, 0, 0, 96, 0, 89, 0, 0, 0,
+	0, 278, 284, 285, 282, 283, 256, 258, 0, 257,
+	0, 676, 505, 515, 506, 535, 536, 0, 0, 98,
+	0, 0, 0, 0, 0, 0, 0, 0, 259, 260,
+	261, 255, 0, 541, 542, 0, 537, 0, 72, 0,
+	0, 94, 95, 0, 0, 84, 85, 0, 87, 0,
+	543, 538, 99, 97, 91, 92, 86, 540,
 }
-var yyTok1 = [...]int{
 
+var yyTok1 = [...]uint8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 80, 73, 3,
-	47, 130, 78, 76, 58, 77, 81, 79, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	49, 48, 50, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 166, 159, 3,
+	179, 235, 164, 162, 139, 163, 167, 165, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 236, 234,
+	125, 124, 126, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 75, 3, 3, 3, 3, 3,
+	3, 170, 3, 237, 161, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 74, 3, 51,
+	3, 3, 3, 3, 160, 3, 127,
 }
-var yyTok2 = [...]int{
 
+var yyTok2 = [...]uint8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
 	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
-	42, 43, 44, 45, 46, 52, 53, 54, 55, 56,
-	57, 59, 60, 61, 62, 63, 64, 65, 66, 67,
-	68, 69, 70, 71, 72, 82, 83, 84, 85, 86,
-	87, 88, 89, 90, 91, 92, 93, 94, 95, 96,
-	97, 98, 99, 100, 101, 102, 103, 104, 105, 106,
-	107, 108, 109, 110, 111, 112, 113, 114, 115, 116,
-	117, 118, 119, 120, 121, 122, 123, 124, 125, 126,
-	127, 128, 129,
+	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
+	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
+	82, 83, 84, 85, 86, 87, 88, 89, 90, 91,
+	92, 93, 94, 95, 96, 97, 98, 99, 100, 101,
+	102, 103, 104, 105, 106, 107, 108, 109, 110, 111,
+	112, 113, 114, 115, 116, 117, 118, 119, 120, 121,
+	122, 123, 128, 129, 130, 131, 132, 133, 134, 135,
+	136, 137, 138, 140, 141, 142, 143, 144, 145, 146,
+	147, 148, 149, 150, 151, 152, 153, 154, 155, 156,
+	157, 158, 168, 169, 171, 172, 173, 174, 175, 176,
+	177, 178, 180, 181, 182, 183, 184, 185, 186, 187,
+	188, 189, 190, 191, 192, 193, 194, 195, 196, 197,
+	198, 199, 200, 201, 202, 203, 204, 205, 206, 207,
+	208, 209, 210, 211, 212, 213, 214, 215, 216, 217,
+	218, 219, 220, 221, 222, 223, 224, 225, 226, 227,
+	228, 229, 230, 231, 232, 233,
 }
-var yyTok3 = [...]int{
+
+var yyTok3 = [...]int8{
 	0,
 }
 
@@ -753,9 +2002,9 @@ func yyErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := yyPact[state]
+	base := int(yyPact[state])
 	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
-		if n := base + tok; n >= 0 && n < yyLast && yyChk[yyAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -765,13 +2014,13 @@ func yyErrorMessage(state, lookAhead int) string {
 
 	if yyDef[state] == -2 {
 		i := 0
-		for yyExca[i] != -1 || yyExca[i+1] != state {
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; yyExca[i] >= 0; i += 2 {
-			tok := yyExca[i]
+			tok := int(yyExca[i])
 			if tok < TOKSTART || yyExca[i+1] == 0 {
 				continue
 			}
@@ -802,30 +2051,30 @@ func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = yyTok1[0]
+		token = int(yyTok1[0])
 		goto out
 	}
 	if char < len(yyTok1) {
-		token = yyTok1[char]
+		token = int(yyTok1[char])
 		goto out
 	}
 	if char >= yyPrivate {
 		if char < yyPrivate+len(yyTok2) {
-			token = yyTok2[char-yyPrivate]
+			token = int(yyTok2[char-yyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(yyTok3); i += 2 {
-		token = yyTok3[i+0]
+		token = int(yyTok3[i+0])
 		if token == char {
-			token = yyTok3[i+1]
+			token = int(yyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = yyTok2[1] /* unknown char */
+		token = int(yyTok2[1]) /* unknown char */
 	}
 	if yyDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
@@ -842,7 +2091,7 @@ func (yyrcvr *yyParserImpl) Parse(yylex yyLexer) int {
 	var yyVAL yySymType
 	var yyDollar []yySymType
 	_ = yyDollar // silence set and not used
-	yyS := yyrcvr.stack[:]
+	yyS := parseStack(yylex, yyrcvr.stack[:])
 
 	Nerrs := 0   /* number of errors */
 	Errflag := 0 /* error recovery flag */
@@ -872,7 +2121,7 @@ yystack:
 
 	yyp++
 	if yyp >= len(yyS) {
-		nyys := make([]yySymType, len(yyS)*2)
+		nyys := growParseStack(yylex, len(yyS)*2)
 		copy(nyys, yyS)
 		yyS = nyys
 	}
@@ -880,7 +2129,7 @@ yystack:
 	yyS[yyp].yys = yystate
 
 yynewstate:
-	yyn = yyPact[yystate]
+	yyn = int(yyPact[yystate])
 	if yyn <= yyFlag {
 		goto yydefault /* simple state */
 	}
@@ -891,8 +2140,8 @@ yynewstate:
 	if yyn < 0 || yyn >= yyLast {
 		goto yydefault
 	}
-	yyn = yyAct[yyn]
-	if yyChk[yyn] == yytoken { /* valid shift */
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
 		yyrcvr.char = -1
 		yytoken = -1
 		yyVAL = yyrcvr.lval
@@ -905,7 +2154,7 @@ yynewstate:
 
 yydefault:
 	/* default state action */
-	yyn = yyDef[yystate]
+	yyn = int(yyDef[yystate])
 	if yyn == -2 {
 		if yyrcvr.char < 0 {
 			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
@@ -914,18 +2163,18 @@ yydefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if yyExca[xi+0] == -1 && yyExca[xi+1] == yystate {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			yyn = yyExca[xi+0]
+			yyn = int(yyExca[xi+0])
 			if yyn < 0 || yyn == yytoken {
 				break
 			}
 		}
-		yyn = yyExca[xi+1]
+		yyn = int(yyExca[xi+1])
 		if yyn < 0 {
 			goto ret0
 		}
@@ -934,6 +2183,7 @@ yydefault:
 		/* error ... attempt to resume parsing */
 		switch Errflag {
 		case 0: /* brand new error */
+			recordErrorState(yylex, yystate)
 			yylex.Error(yyErrorMessage(yystate, yytoken))
 			Nerrs++
 			if yyDebug >= 1 {
@@ -947,10 +2197,10 @@ yydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for yyp >= 0 {
-				yyn = yyPact[yyS[yyp].yys] + yyErrCode
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
 				if yyn >= 0 && yyn < yyLast {
-					yystate = yyAct[yyn] /* simulate a shift of "error" */
-					if yyChk[yystate] == yyErrCode {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
 						goto yystack
 					}
 				}
@@ -986,1511 +2236,5208 @@ yydefault:
 	yypt := yyp
 	_ = yypt // guard against "declared and not used"
 
-	yyp -= yyR2[yyn]
+	yyp -= int(yyR2[yyn])
 	// yyp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if yyp+1 >= len(yyS) {
-		nyys := make([]yySymType, len(yyS)*2)
+		nyys := growParseStack(yylex, len(yyS)*2)
 		copy(nyys, yyS)
 		yyS = nyys
 	}
 	yyVAL = yyS[yyp+1]
 
 	/* consult goto table to find next state */
-	yyn = yyR1[yyn]
-	yyg := yyPgo[yyn]
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
 	yyj := yyg + yyS[yyp].yys + 1
 
 	if yyj >= yyLast {
-		yystate = yyAct[yyg]
+		yystate = int(yyAct[yyg])
 	} else {
-		yystate = yyAct[yyj]
-		if yyChk[yystate] != -yyn {
-			yystate = yyAct[yyg]
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
 		}
 	}
 	// dummy call; replaced with literal code
 	switch yynt {
 
 	case 1:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:183
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:468
 		{
-			SetParseTree(yylex, yyDollar[1].statement)
+			SetParseTree(yylex, yyDollar[2].statement)
 		}
 	case 2:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:189
-		{
-			yyVAL.statement = yyDollar[1].selStmt
-		}
-	case 13:
-		yyDollar = yyS[yypt-13 : yypt+1]
-		//line sql.y:205
-		{
-			yyVAL.selStmt = &Select{Comments: Comments(yyDollar[2].bytes2), Distinct: yyDollar[3].str, SelectExprs: yyDollar[4].selectExprs, From: yyDollar[6].tableExprs, TimeRange: yyDollar[7].timerange, Where: NewWhere(AST_WHERE, yyDollar[8].boolExpr), GroupBy: yyDollar[9].selectExprs, Having: NewWhere(AST_HAVING, yyDollar[10].boolExpr), OrderBy: yyDollar[11].orderBy, Limit: yyDollar[12].limit, Lock: yyDollar[13].str}
-		}
-	case 14:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:209
-		{
-			yyVAL.selStmt = &Union{Type: yyDollar[2].str, Left: yyDollar[1].selStmt, Right: yyDollar[3].selStmt}
-		}
-	case 15:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:215
-		{
-			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Columns: yyDollar[5].columns, Rows: yyDollar[6].insRows, OnDup: OnDup(yyDollar[7].updateExprs)}
-		}
-	case 16:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:219
-		{
-			cols := make(Columns, 0, len(yyDollar[6].updateExprs))
-			vals := make(ValTuple, 0, len(yyDollar[6].updateExprs))
-			for _, col := range yyDollar[6].updateExprs {
-				cols = append(cols, &NonStarExpr{Expr: col.Name})
-				vals = append(vals, col.Expr)
-			}
-			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Columns: cols, Rows: Values{vals}, OnDup: OnDup(yyDollar[7].updateExprs)}
-		}
-	case 17:
-		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:231
-		{
-			yyVAL.statement = &Update{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[3].tableName, Exprs: yyDollar[5].updateExprs, Where: NewWhere(AST_WHERE, yyDollar[6].boolExpr), OrderBy: yyDollar[7].orderBy, Limit: yyDollar[8].limit}
-		}
-	case 18:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:237
-		{
-			yyVAL.statement = &Delete{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Where: NewWhere(AST_WHERE, yyDollar[5].boolExpr), OrderBy: yyDollar[6].orderBy, Limit: yyDollar[7].limit}
-		}
-	case 19:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:243
+//line sql.y:472
 		{
-			yyVAL.statement = &Set{Comments: Comments(yyDollar[2].bytes2), Exprs: yyDollar[3].updateExprs}
+			SetParseTree(yylex, nil)
 		}
-	case 20:
+	case 3:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:248
+//line sql.y:477
 		{
-			yyVAL.str = ""
 		}
-	case 21:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:252
+	case 4:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:479
 		{
-			yyVAL.str = AST_ZEROFILL
 		}
-	case 22:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:257
+	case 5:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:483
 		{
-			yyVAL.str = yyDollar[1].str
-			if yyDollar[2].str != "" {
-				yyVAL.str += " " + yyDollar[2].str
-			}
-			if yyDollar[3].str != "" {
-				yyVAL.str += " " + yyDollar[3].str
-			}
+			yyVAL.statement = yyDollar[1].selStmt
 		}
 	case 25:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:271
-		{
-			yyVAL.str = AST_DATE
+		yyDollar = yyS[yypt-15 : yypt+1]
+//line sql.y:508
+		{
+			sel := yyDollar[3].selectOpts
+			sel.Comments, sel.Hints = splitHints(yyDollar[2].strs)
+			sel.SelectExprs, sel.From, sel.TimeRange, sel.Clauses = yyDollar[4].selectExprs, yyDollar[5].tableExprs, yyDollar[6].timerange, yyDollar[7].clauses
+			sel.Where, sel.Having, sel.Qualify, sel.Windows = yyDollar[8].where, yyDollar[10].where, yyDollar[11].where, yyDollar[12].namedWindows
+			sel.OrderBy, sel.Limit, sel.Lock = yyDollar[13].orderBy, yyDollar[14].limit, yyDollar[15].str
+			if yyDollar[9].selectOpts != nil {
+				sel.GroupBy, sel.WithRollup = yyDollar[9].selectOpts.GroupBy, yyDollar[9].selectOpts.WithRollup
+			}
+			yyVAL.selStmt = sel
 		}
 	case 26:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:275
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:520
 		{
-			yyVAL.str = AST_TIME
+			sel := &Select{SelectExprs: SelectExprs{&Nextval{Expr: yyDollar[4].valExpr}}, From: TableExprs{&AliasedTableExpr{Expr: yyDollar[6].tableName}}}
+			sel.Comments, sel.Hints = splitHints(yyDollar[2].strs)
+			yyVAL.selStmt = sel
 		}
 	case 27:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:279
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:526
 		{
-			yyVAL.str = AST_TIMESTAMP
+			yyVAL.selStmt = newUnion(yyDollar[2].str, yyDollar[1].selStmt, yyDollar[3].selStmt)
 		}
 	case 28:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:283
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:530
 		{
-			yyVAL.str = AST_DATETIME
+			yyVAL.selStmt = newUnion(yyDollar[2].str, yyDollar[1].selStmt, yyDollar[3].selStmt)
 		}
 	case 29:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:287
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:534
 		{
-			yyVAL.str = AST_YEAR
+			yyVAL.selStmt = newUnion(yyDollar[2].str, yyDollar[1].selStmt, yyDollar[3].selStmt)
 		}
 	case 30:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:293
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:538
 		{
-			if yyDollar[2].str == "" {
-				yyVAL.str = AST_CHAR
-			} else {
-				yyVAL.str = AST_CHAR + yyDollar[2].str
-			}
+			yyVAL.selStmt = newUnion(yyDollar[2].str, yyDollar[1].selStmt, yyDollar[3].selStmt)
 		}
 	case 31:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:301
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:542
 		{
-			if yyDollar[2].str == "" {
-				yyVAL.str = AST_VARCHAR
-			} else {
-				yyVAL.str = AST_VARCHAR + yyDollar[2].str
-			}
+			yyDollar[3].union.Type, yyDollar[3].union.Left = yyDollar[2].str, yyDollar[1].selStmt
+			yyVAL.selStmt = yyDollar[3].union
 		}
 	case 32:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:309
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:547
 		{
-			yyVAL.str = AST_TEXT
+			yyDollar[3].union.Type, yyDollar[3].union.Left = yyDollar[2].str, yyDollar[1].selStmt
+			yyVAL.selStmt = yyDollar[3].union
 		}
 	case 33:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:315
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:552
 		{
-			yyVAL.str = yyDollar[1].str + yyDollar[2].str
+			yyDollar[3].union.Type, yyDollar[3].union.Left = yyDollar[2].str, yyDollar[1].selStmt
+			yyVAL.selStmt = yyDollar[3].union
 		}
 	case 34:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:319
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:557
 		{
-			yyVAL.str = yyDollar[1].str
+			yyDollar[3].union.Type, yyDollar[3].union.Left = yyDollar[2].str, yyDollar[1].selStmt
+			yyVAL.selStmt = yyDollar[3].union
 		}
 	case 35:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:325
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:562
 		{
-			yyVAL.str = AST_BIT
+			yyVAL.selStmt = &ValuesStatement{Rows: yyDollar[2].values, OrderBy: yyDollar[3].orderBy, Limit: yyDollar[4].limit}
 		}
 	case 36:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:329
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:566
 		{
-			yyVAL.str = AST_TINYINT
+			switch stmt := yyDollar[2].selStmt.(type) {
+			case *Select:
+				stmt.With = yyDollar[1].with
+			case *Union:
+				stmt.With = yyDollar[1].with
+			}
+			yyVAL.selStmt = yyDollar[2].selStmt
 		}
 	case 37:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:333
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:578
 		{
-			yyVAL.str = AST_SMALLINT
+			yyVAL.selStmt = &ParenSelect{Select: yyDollar[2].selStmt}
 		}
 	case 38:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:337
+//line sql.y:590
 		{
-			yyVAL.str = AST_MEDIUMINT
+			yyVAL.union = &Union{Right: yyDollar[1].selStmt}
 		}
 	case 39:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:341
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:594
 		{
-			yyVAL.str = AST_INT
+			yyVAL.union = &Union{Right: yyDollar[1].selStmt, OrderBy: yyDollar[2].orderBy, Limit: yyDollar[3].limit, Lock: yyDollar[4].str}
 		}
 	case 40:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:345
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:598
 		{
-			yyVAL.str = AST_INTEGER
+			yyVAL.union = &Union{Right: yyDollar[1].selStmt, Limit: yyDollar[2].limit, Lock: yyDollar[3].str}
 		}
 	case 41:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:349
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:602
 		{
-			yyVAL.str = AST_BIGINT
+			yyVAL.union = &Union{Right: yyDollar[1].selStmt, Lock: yyDollar[2].str}
 		}
 	case 42:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:355
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:608
 		{
-			yyVAL.str = AST_REAL + yyDollar[2].str
+			yyVAL.with = &With{Recursive: yyDollar[2].boolean, CTEs: yyDollar[3].ctes}
 		}
 	case 43:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:359
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:613
 		{
-			yyVAL.str = AST_DOUBLE + yyDollar[2].str
+			yyVAL.boolean = false
 		}
 	case 44:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:363
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:617
 		{
-			yyVAL.str = AST_FLOAT + yyDollar[2].str
+			yyVAL.boolean = true
 		}
 	case 45:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:367
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:623
 		{
-			yyVAL.str = AST_DECIMAL + yyDollar[2].str
+			yyVAL.ctes = []*CommonTableExpr{yyDollar[1].cte}
 		}
 	case 46:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:371
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:627
 		{
-			yyVAL.str = AST_DECIMAL + yyDollar[2].str
+			yyVAL.ctes = append(yyDollar[1].ctes, yyDollar[3].cte)
 		}
 	case 47:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:375
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:633
 		{
-			yyVAL.str = AST_NUMERIC + yyDollar[2].str
+			yyVAL.cte = &CommonTableExpr{Name: yyDollar[1].tableIdent, Subquery: yyDollar[3].subquery}
 		}
 	case 48:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:379
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:637
 		{
-			yyVAL.str = AST_NUMERIC + yyDollar[2].str
+			yyVAL.cte = &CommonTableExpr{Name: yyDollar[1].tableIdent, Columns: yyDollar[3].colIdents, Subquery: yyDollar[6].subquery}
 		}
 	case 49:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:384
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line sql.y:643
 		{
-			yyVAL.str = ""
+			comments, hints := splitHints(yyDollar[2].strs)
+			yyVAL.statement = &Insert{Comments: comments, Hints: hints, Priority: yyDollar[3].str, Ignore: yyDollar[4].boolean, Table: yyDollar[6].tableName, Partitions: yyDollar[7].partitions, Rows: yyDollar[8].insRows, RowAlias: yyDollar[9].rowAlias, OnDup: OnDup(yyDollar[10].updateExprs), Returning: yyDollar[11].selectExprs}
 		}
 	case 50:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:388
+		yyDollar = yyS[yypt-14 : yypt+1]
+//line sql.y:648
 		{
-			yyVAL.str = "(" + string(yyDollar[2].bytes) + ", " + string(yyDollar[4].bytes) + ")"
+			comments, hints := splitHints(yyDollar[2].strs)
+			yyVAL.statement = &Insert{Comments: comments, Hints: hints, Priority: yyDollar[3].str, Ignore: yyDollar[4].boolean, Table: yyDollar[6].tableName, Partitions: yyDollar[7].partitions, Columns: yyDollar[9].columns, Rows: yyDollar[11].insRows, RowAlias: yyDollar[12].rowAlias, OnDup: OnDup(yyDollar[13].updateExprs), Returning: yyDollar[14].selectExprs}
 		}
 	case 51:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:393
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:653
 		{
-			yyVAL.str = ""
+			cols := make(Columns, 0, len(yyDollar[9].updateExprs))
+			vals := make(ValTuple, 0, len(yyDollar[9].updateExprs))
+			for _, col := range yyDollar[9].updateExprs {
+				cols = append(cols, NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: col.Name}))
+				vals = append(vals, col.Expr)
+			}
+			comments, hints := splitHints(yyDollar[2].strs)
+			yyVAL.statement = &Insert{Comments: comments, Hints: hints, Priority: yyDollar[3].str, Ignore: yyDollar[4].boolean, Table: yyDollar[6].tableName, Partitions: yyDollar[7].partitions, Columns: cols, Rows: Values{vals}, RowAlias: yyDollar[10].rowAlias, OnDup: OnDup(yyDollar[11].updateExprs), Returning: yyDollar[12].selectExprs}
 		}
 	case 52:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:397
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line sql.y:666
 		{
-			yyVAL.str = "(" + string(yyDollar[2].bytes) + ")"
+			comments, hints := splitHints(yyDollar[2].strs)
+			yyVAL.statement = &Update{Comments: comments, Hints: hints, Priority: yyDollar[3].str, Ignore: yyDollar[4].boolean, Table: yyDollar[5].tableExprs, Exprs: yyDollar[7].updateExprs, Where: yyDollar[8].where, OrderBy: yyDollar[9].orderBy, Limit: yyDollar[10].limit, Returning: yyDollar[11].selectExprs}
 		}
 	case 53:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:402
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line sql.y:673
 		{
-			yyVAL.str = ""
+			comments, hints := splitHints(yyDollar[2].strs)
+			yyVAL.statement = &Delete{Comments: comments, Hints: hints, Priority: yyDollar[3].str, Quick: yyDollar[4].boolean, Ignore: yyDollar[5].boolean, Table: yyDollar[7].tableName, Where: yyDollar[8].where, OrderBy: yyDollar[9].orderBy, Limit: yyDollar[10].limit, Returning: yyDollar[11].selectExprs}
 		}
 	case 54:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:406
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line sql.y:678
 		{
-			yyVAL.str = AST_UNSIGNED
+			comments, hints := splitHints(yyDollar[2].strs)
+			yyVAL.statement = &Delete{Comments: comments, Hints: hints, Priority: yyDollar[3].str, Quick: yyDollar[4].boolean, Ignore: yyDollar[5].boolean, Targets: yyDollar[6].tableNames, From: yyDollar[8].tableExprs, Where: yyDollar[9].where}
 		}
 	case 55:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:411
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line sql.y:683
 		{
-			yyVAL.columnAtts = ColumnAtts{}
+			comments, hints := splitHints(yyDollar[2].strs)
+			yyVAL.statement = &Delete{Comments: comments, Hints: hints, Priority: yyDollar[3].str, Quick: yyDollar[4].boolean, Ignore: yyDollar[5].boolean, Targets: yyDollar[7].tableNames, From: yyDollar[9].tableExprs, Using: true, Where: yyDollar[10].where}
 		}
 	case 56:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:415
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:689
 		{
-			yyVAL.columnAtts = append(yyVAL.columnAtts, AST_NOT_NULL)
+			yyVAL.str = ""
+		}
+	case 57:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:693
+		{
+			yyVAL.str = AST_LOW_PRIORITY
 		}
 	case 58:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:421
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:697
 		{
-			node := StrVal(yyDollar[3].bytes)
-			yyVAL.columnAtts = append(yyVAL.columnAtts, "default "+String(node))
+			yyVAL.str = AST_DELAYED
 		}
 	case 59:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:426
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:701
 		{
-			node := NumVal(yyDollar[3].bytes)
-			yyVAL.columnAtts = append(yyVAL.columnAtts, "default "+String(node))
+			yyVAL.str = AST_HIGH_PRIORITY
 		}
 	case 60:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:431
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:706
 		{
-			yyVAL.columnAtts = append(yyVAL.columnAtts, AST_AUTO_INCREMENT)
+			yyVAL.str = ""
 		}
 	case 61:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:435
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:710
 		{
-			yyVAL.columnAtts = append(yyVAL.columnAtts, yyDollar[2].str)
+			yyVAL.str = AST_LOW_PRIORITY
 		}
 	case 62:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:441
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:715
 		{
-			yyVAL.str = AST_PRIMARY_KEY
+			yyVAL.boolean = false
 		}
 	case 63:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:445
+//line sql.y:719
 		{
-			yyVAL.str = AST_UNIQUE_KEY
+			yyVAL.boolean = true
 		}
-	case 68:
+	case 64:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:725
+		{
+			yyVAL.tableNames = []*TableName{yyDollar[1].tableName}
+		}
+	case 65:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:729
+		{
+			yyVAL.tableNames = append(yyDollar[1].tableNames, yyDollar[3].tableName)
+		}
+	case 67:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:459
+//line sql.y:740
 		{
-			yyVAL.columnDefinition = &ColumnDefinition{ColName: string(yyDollar[1].bytes), ColType: yyDollar[2].str, ColumnAtts: yyDollar[3].columnAtts}
+			yyVAL.tableName = NodeArena(yylex).tableName(TableName{Name: yyDollar[1].tableIdent})
+		}
+	case 68:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:744
+		{
+			yyVAL.tableName = NodeArena(yylex).tableName(TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent})
 		}
 	case 69:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:465
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:754
 		{
-			yyVAL.columnDefinitions = ColumnDefinitions{yyDollar[1].columnDefinition}
+			if !strings.EqualFold(yyDollar[1].str, "call") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Call{Name: NodeArena(yylex).tableName(TableName{Qualifier: yyDollar[2].tableIdent, Name: yyDollar[4].tableIdent})}
 		}
 	case 70:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:469
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:762
 		{
-			yyVAL.columnDefinitions = append(yyVAL.columnDefinitions, yyDollar[3].columnDefinition)
+			if !strings.EqualFold(yyDollar[1].str, "call") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Call{Name: yyDollar[2].tableName}
 		}
 	case 71:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:475
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:770
 		{
-			yyVAL.statement = &CreateTable{Name: yyDollar[4].bytes, ColumnDefinitions: yyDollar[6].columnDefinitions}
+			if !strings.EqualFold(yyDollar[1].str, "call") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Call{Name: yyDollar[2].tableName, Args: yyDollar[4].valExprs}
 		}
 	case 72:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:481
+		yyDollar = yyS[yypt-17 : yypt+1]
+//line sql.y:780
 		{
-			yyVAL.statement = yyDollar[1].statement
+			if !strings.EqualFold(yyDollar[2].str, "data") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.statement = &LoadData{
+				Priority: yyDollar[3].str, Local: yyDollar[4].boolean, File: yyDollar[6].strVal, Duplicate: yyDollar[7].str,
+				Table: yyDollar[10].tableName, Partitions: yyDollar[11].partitions, Charset: yyDollar[12].str, Fields: yyDollar[13].loadFields, Lines: yyDollar[14].loadLines,
+				IgnoreLines: yyDollar[15].numVal, Columns: yyDollar[16].columns, Set: yyDollar[17].updateExprs,
+			}
 		}
 	case 73:
-		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:485
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:793
 		{
-			// Change this to an alter statement
-			yyVAL.statement = &DDL{Action: AST_ALTER, Table: yyDollar[7].bytes, NewName: yyDollar[7].bytes}
+			yyVAL.str = ""
 		}
 	case 74:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:490
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:797
 		{
-			yyVAL.statement = &DDL{Action: AST_CREATE, NewName: yyDollar[3].bytes}
+			yyVAL.str = AST_LOW_PRIORITY
 		}
 	case 75:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:496
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:801
 		{
-			yyVAL.statement = &DDL{Action: AST_ALTER, Table: yyDollar[4].bytes, NewName: yyDollar[4].bytes}
+			if !strings.EqualFold(yyDollar[1].str, AST_CONCURRENT) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = AST_CONCURRENT
 		}
 	case 76:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:500
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:810
 		{
-			// Change this to a rename statement
-			yyVAL.statement = &DDL{Action: AST_RENAME, Table: yyDollar[4].bytes, NewName: yyDollar[7].bytes}
+			yyVAL.boolean = false
 		}
 	case 77:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:505
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:814
 		{
-			yyVAL.statement = &DDL{Action: AST_ALTER, Table: yyDollar[3].bytes, NewName: yyDollar[3].bytes}
+			yyVAL.boolean = true
 		}
 	case 78:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:511
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:819
 		{
-			yyVAL.statement = &DDL{Action: AST_RENAME, Table: yyDollar[3].bytes, NewName: yyDollar[5].bytes}
+			yyVAL.str = ""
 		}
 	case 79:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:517
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:823
 		{
-			yyVAL.statement = &DDL{Action: AST_DROP, Table: yyDollar[4].bytes}
+			if !strings.EqualFold(yyDollar[1].str, AST_REPLACE) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = AST_REPLACE
 		}
 	case 80:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:521
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:831
 		{
-			// Change this to an alter statement
-			yyVAL.statement = &DDL{Action: AST_ALTER, Table: yyDollar[5].bytes, NewName: yyDollar[5].bytes}
+			yyVAL.str = AST_IGNORE
 		}
 	case 81:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:526
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:836
 		{
-			yyVAL.statement = &DDL{Action: AST_DROP, Table: yyDollar[4].bytes}
+			yyVAL.loadFields = nil
 		}
 	case 82:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:532
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:840
 		{
-			yyVAL.statement = &DDL{Action: AST_ALTER, Table: yyDollar[3].bytes, NewName: yyDollar[3].bytes}
+			switch strings.ToLower(yyDollar[1].str) {
+			case "fields", "columns":
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			if *yyDollar[2].loadFields == (LoadFields{}) {
+				yylex.Error("syntax error: FIELDS without options")
+				return 1
+			}
+			yyVAL.loadFields = yyDollar[2].loadFields
 		}
 	case 83:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:538
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:855
 		{
-			yyVAL.statement = &Other{}
+			yyVAL.loadFields = &LoadFields{}
 		}
 	case 84:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:542
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:859
 		{
-			yyVAL.statement = &Other{}
+			s := yyDollar[4].strVal
+			yyDollar[1].loadFields.TerminatedBy = &s
 		}
 	case 85:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:546
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:864
 		{
-			yyVAL.statement = &Other{}
+			s := yyDollar[4].strVal
+			yyDollar[1].loadFields.EnclosedBy = &s
 		}
 	case 86:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:551
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:869
 		{
-			SetAllowComments(yylex, true)
+			s := yyDollar[5].strVal
+			yyDollar[1].loadFields.EnclosedBy, yyDollar[1].loadFields.OptionallyEnclosed = &s, true
 		}
 	case 87:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:555
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:874
 		{
-			yyVAL.bytes2 = yyDollar[2].bytes2
-			SetAllowComments(yylex, false)
+			s := yyDollar[4].strVal
+			yyDollar[1].loadFields.EscapedBy = &s
 		}
 	case 88:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:561
+//line sql.y:880
 		{
-			yyVAL.bytes2 = nil
+			yyVAL.loadLines = nil
 		}
 	case 89:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:565
+//line sql.y:884
 		{
-			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
+			if *yyDollar[2].loadLines == (LoadLines{}) {
+				yylex.Error("syntax error: LINES without options")
+				return 1
+			}
+			yyVAL.loadLines = yyDollar[2].loadLines
 		}
 	case 90:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:571
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:893
 		{
-			yyVAL.str = AST_UNION
+			yyVAL.loadLines = &LoadLines{}
 		}
 	case 91:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:575
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:897
 		{
-			yyVAL.str = AST_UNION_ALL
+			s := yyDollar[4].strVal
+			yyDollar[1].loadLines.StartingBy = &s
 		}
 	case 92:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:579
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:902
 		{
-			yyVAL.str = AST_SET_MINUS
+			s := yyDollar[4].strVal
+			yyDollar[1].loadLines.TerminatedBy = &s
 		}
 	case 93:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:583
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:908
 		{
-			yyVAL.str = AST_EXCEPT
+			yyVAL.numVal = ""
 		}
 	case 94:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:587
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:912
 		{
-			yyVAL.str = AST_INTERSECT
+			yyVAL.numVal = NumVal(yyDollar[2].str)
 		}
 	case 95:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:592
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:916
 		{
-			yyVAL.str = ""
+			yyVAL.numVal = NumVal(yyDollar[2].str)
 		}
 	case 96:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:596
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:921
 		{
-			yyVAL.str = AST_DISTINCT
+			yyVAL.columns = nil
 		}
 	case 97:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:602
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:925
 		{
-			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
+			yyVAL.columns = yyDollar[2].columns
 		}
 	case 98:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:606
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:930
 		{
-			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
+			yyVAL.updateExprs = nil
 		}
 	case 99:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:612
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:934
 		{
-			yyVAL.selectExpr = &StarExpr{}
+			yyVAL.updateExprs = yyDollar[2].updateExprs
 		}
 	case 100:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:616
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:939
 		{
-			yyVAL.selectExpr = &NonStarExpr{Expr: yyDollar[1].expr, As: yyDollar[2].bytes}
+			yyVAL.selectExprs = nil
 		}
 	case 101:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:620
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:943
 		{
-			yyVAL.selectExpr = &StarExpr{TableName: yyDollar[1].bytes}
+			yyVAL.selectExprs = yyDollar[2].selectExprs
 		}
 	case 102:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:626
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:949
 		{
-			yyVAL.expr = yyDollar[1].boolExpr
+			yyVAL.statement = &Set{Comments: Comments(yyDollar[2].strs), Exprs: yyDollar[3].setExprs}
 		}
 	case 103:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:630
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:953
 		{
-			yyVAL.expr = yyDollar[1].valExpr
+			if !strings.EqualFold(yyDollar[4].str, "transaction") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[4].str))
+				return 1
+			}
+			stmt := &SetTransaction{Comments: Comments(yyDollar[2].strs), Scope: yyDollar[3].str}
+			for _, c := range yyDollar[5].strs {
+				if c == AST_READ_ONLY || c == AST_READ_WRITE {
+					stmt.AccessMode = c
+				} else {
+					stmt.IsolationLevel = c
+				}
+			}
+			yyVAL.statement = stmt
 		}
 	case 104:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:635
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:971
 		{
-			yyVAL.bytes = nil
+			yyVAL.strs = []string{yyDollar[1].str}
 		}
 	case 105:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:639
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:975
 		{
-			yyVAL.bytes = yyDollar[1].bytes
+			yyVAL.strs = append(yyDollar[1].strs, yyDollar[3].str)
 		}
 	case 106:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:643
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:981
 		{
-			yyVAL.bytes = yyDollar[2].bytes
+			if !strings.EqualFold(yyDollar[1].str, "isolation") || !strings.EqualFold(yyDollar[2].str, "level") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = yyDollar[3].str
 		}
 	case 107:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:649
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:989
 		{
-			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
+			switch mode := strings.ToLower(yyDollar[1].str + " " + yyDollar[2].str); mode {
+			case AST_READ_ONLY, AST_READ_WRITE:
+				yyVAL.str = mode
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
 		}
 	case 108:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:653
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1001
 		{
-			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
+			if !strings.EqualFold(yyDollar[1].str, AST_SERIALIZABLE) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = AST_SERIALIZABLE
 		}
 	case 109:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:659
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1009
 		{
-			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].smTableExpr, As: yyDollar[2].bytes, Hints: yyDollar[3].indexHints}
+			switch level := strings.ToLower(yyDollar[1].str + " " + yyDollar[2].str); level {
+			case AST_READ_UNCOMMITTED, AST_READ_COMMITTED, AST_REPEATABLE_READ:
+				yyVAL.str = level
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
 		}
 	case 110:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:663
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1021
 		{
-			yyVAL.tableExpr = &ParenTableExpr{Expr: yyDollar[2].tableExpr}
+			yyVAL.statement = &Begin{}
 		}
 	case 111:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:667
-		{
-			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1025
+		{
+			switch strings.ToLower(yyDollar[1].str) {
+			case "commit":
+				yyVAL.statement = &Commit{}
+			case "rollback":
+				yyVAL.statement = &Rollback{}
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
 		}
 	case 112:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:671
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1037
 		{
-			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].boolExpr}
+			if !strings.EqualFold(yyDollar[1].str, "rollback") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Rollback{Savepoint: yyDollar[3].colIdent}
 		}
 	case 113:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:676
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1045
 		{
-			yyVAL.bytes = nil
+			if !strings.EqualFold(yyDollar[1].str, "rollback") || !strings.EqualFold(yyDollar[3].str, "savepoint") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Rollback{Savepoint: yyDollar[4].colIdent}
 		}
 	case 114:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:680
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1053
 		{
-			yyVAL.bytes = yyDollar[1].bytes
+			if !strings.EqualFold(yyDollar[1].str, "rollback") || !strings.EqualFold(yyDollar[2].str, "work") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Rollback{Savepoint: yyDollar[4].colIdent}
 		}
 	case 115:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:684
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1061
 		{
-			yyVAL.bytes = yyDollar[2].bytes
+			if !strings.EqualFold(yyDollar[1].str, "rollback") || !strings.EqualFold(yyDollar[2].str, "work") || !strings.EqualFold(yyDollar[4].str, "savepoint") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Rollback{Savepoint: yyDollar[5].colIdent}
 		}
 	case 116:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:690
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1069
 		{
-			yyVAL.str = AST_JOIN
+			mode := strings.ToLower(yyDollar[3].str + " " + yyDollar[4].str)
+			if !strings.EqualFold(yyDollar[1].str, "start") || !strings.EqualFold(yyDollar[2].str, "transaction") || mode != AST_READ_ONLY && mode != AST_READ_WRITE {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Begin{AccessMode: mode}
 		}
 	case 117:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:694
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1080
 		{
-			yyVAL.str = AST_STRAIGHT_JOIN
+			yyVAL.statement = &Grant{Privileges: yyDollar[2].privileges, On: yyDollar[4].grantObject, To: yyDollar[6].accounts, WithGrantOption: yyDollar[7].boolean}
 		}
 	case 118:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:698
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1084
 		{
-			yyVAL.str = AST_LEFT_JOIN
+			yyVAL.statement = &Revoke{Privileges: yyDollar[2].privileges, On: yyDollar[4].grantObject, From: yyDollar[6].accounts}
 		}
 	case 119:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:702
+//line sql.y:1088
 		{
-			yyVAL.str = AST_LEFT_JOIN
+			yyVAL.statement = &CreateUser{IfNotExists: yyDollar[2].boolean, Users: yyDollar[3].userSpecs}
 		}
 	case 120:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:706
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1092
 		{
-			yyVAL.str = AST_RIGHT_JOIN
+			yyVAL.statement = &AlterUser{IfExists: yyDollar[2].boolean, Users: yyDollar[3].userSpecs}
 		}
 	case 121:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:710
+//line sql.y:1096
 		{
-			yyVAL.str = AST_RIGHT_JOIN
+			yyVAL.statement = &SetPassword{Password: yyDollar[3].strVal}
 		}
 	case 122:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:714
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1100
 		{
-			yyVAL.str = AST_JOIN
+			yyVAL.statement = &SetPassword{For: yyDollar[3].account, Password: yyDollar[5].strVal}
 		}
 	case 123:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:718
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1106
 		{
-			yyVAL.str = AST_CROSS_JOIN
+			yyVAL.privileges = []*Privilege{yyDollar[1].privilege}
 		}
 	case 124:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:722
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1110
 		{
-			yyVAL.str = AST_NATURAL_JOIN
+			yyVAL.privileges = append(yyDollar[1].privileges, yyDollar[3].privilege)
 		}
 	case 125:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:728
+//line sql.y:1116
 		{
-			yyVAL.smTableExpr = &TableName{Name: yyDollar[1].bytes}
+			yyVAL.privilege = &Privilege{Type: yyDollar[1].str}
 		}
 	case 126:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:732
-		{
-			yyVAL.smTableExpr = &TableName{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
-		}
-	case 127:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:736
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1120
 		{
-			yyVAL.smTableExpr = yyDollar[1].subquery
+			yyVAL.privilege = &Privilege{Type: yyDollar[1].str, Columns: yyDollar[3].colIdents}
 		}
 	case 128:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:742
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1127
 		{
-			yyVAL.tableName = &TableName{Name: yyDollar[1].bytes}
+			yyVAL.str = yyDollar[1].str + " " + yyDollar[2].str
 		}
 	case 129:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:746
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1137
 		{
-			yyVAL.tableName = &TableName{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
+			yyVAL.str = strings.ToLower(yyDollar[1].str)
 		}
 	case 130:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:751
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1141
 		{
-			yyVAL.indexHints = nil
+			yyVAL.str = "all"
 		}
 	case 131:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:755
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1145
 		{
-			yyVAL.indexHints = &IndexHints{Type: AST_USE, Indexes: yyDollar[4].bytes2}
+			yyVAL.str = "alter"
 		}
 	case 132:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:759
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1149
 		{
-			yyVAL.indexHints = &IndexHints{Type: AST_IGNORE, Indexes: yyDollar[4].bytes2}
+			yyVAL.str = "create"
 		}
 	case 133:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:763
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1153
 		{
-			yyVAL.indexHints = &IndexHints{Type: AST_FORCE, Indexes: yyDollar[4].bytes2}
+			yyVAL.str = "delete"
 		}
 	case 134:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:769
+//line sql.y:1157
 		{
-			yyVAL.bytes2 = [][]byte{yyDollar[1].bytes}
+			yyVAL.str = "drop"
 		}
 	case 135:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:773
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1161
 		{
-			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[3].bytes)
+			yyVAL.str = "grant"
 		}
 	case 136:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:778
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1165
 		{
-			yyVAL.boolExpr = nil
+			yyVAL.str = "index"
 		}
 	case 137:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:782
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1169
+		{
+			yyVAL.str = "insert"
+		}
+	case 138:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1173
 		{
-			yyVAL.boolExpr = yyDollar[2].boolExpr
+			yyVAL.str = "lock"
 		}
 	case 139:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:789
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1177
 		{
-			yyVAL.boolExpr = &AndExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
+			yyVAL.str = "references"
 		}
 	case 140:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:793
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1181
 		{
-			yyVAL.boolExpr = &OrExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
+			yyVAL.str = "select"
 		}
 	case 141:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:797
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1185
 		{
-			yyVAL.boolExpr = &NotExpr{Expr: yyDollar[2].boolExpr}
+			yyVAL.str = "show"
 		}
 	case 142:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:801
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1189
 		{
-			yyVAL.boolExpr = &ParenBoolExpr{Expr: yyDollar[2].boolExpr}
+			yyVAL.str = "update"
 		}
 	case 143:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:807
-		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: yyDollar[2].str, Right: yyDollar[3].valExpr}
-		}
-	case 144:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:811
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1193
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_IN, Right: yyDollar[3].colTuple}
+			yyVAL.str = "view"
 		}
 	case 145:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:815
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1200
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_IN, Right: yyDollar[4].colTuple}
+			yyDollar[2].grantObject.Type = AST_TABLE
+			yyVAL.grantObject = yyDollar[2].grantObject
 		}
 	case 146:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:819
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1205
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_LIKE, Right: yyDollar[3].valExpr}
+			kind := strings.ToLower(yyDollar[1].str)
+			if kind != AST_FUNCTION && kind != AST_PROCEDURE {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyDollar[2].grantObject.Type = kind
+			yyVAL.grantObject = yyDollar[2].grantObject
 		}
 	case 147:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:823
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1217
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_LIKE, Right: yyDollar[4].valExpr}
+			yyVAL.grantObject = &GrantObject{Name: NewTableIdent("*")}
 		}
 	case 148:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:827
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1221
 		{
-			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_BETWEEN, From: yyDollar[3].valExpr, To: yyDollar[5].valExpr}
+			yyVAL.grantObject = &GrantObject{Database: NewTableIdent("*"), Name: NewTableIdent("*")}
 		}
 	case 149:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:831
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1225
 		{
-			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_NOT_BETWEEN, From: yyDollar[4].valExpr, To: yyDollar[6].valExpr}
+			yyVAL.grantObject = &GrantObject{Name: yyDollar[1].tableIdent}
 		}
 	case 150:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:835
+//line sql.y:1229
 		{
-			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NULL, Expr: yyDollar[1].valExpr}
+			yyVAL.grantObject = &GrantObject{Database: yyDollar[1].tableIdent, Name: NewTableIdent("*")}
 		}
 	case 151:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:839
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1233
 		{
-			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NOT_NULL, Expr: yyDollar[1].valExpr}
+			yyVAL.grantObject = &GrantObject{Database: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent}
 		}
 	case 152:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:843
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1238
 		{
-			yyVAL.boolExpr = &ExistsExpr{Subquery: yyDollar[2].subquery}
+			yyVAL.boolean = false
 		}
 	case 153:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:849
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1242
 		{
-			yyVAL.str = AST_EQ
+			if !strings.EqualFold(yyDollar[3].str, "option") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+			yyVAL.boolean = true
 		}
 	case 154:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:853
+//line sql.y:1252
 		{
-			yyVAL.str = AST_LT
+			yyVAL.accounts = []*Account{yyDollar[1].account}
 		}
 	case 155:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:857
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1256
 		{
-			yyVAL.str = AST_GT
+			yyVAL.accounts = append(yyDollar[1].accounts, yyDollar[3].account)
 		}
 	case 156:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:861
+//line sql.y:1267
 		{
-			yyVAL.str = AST_LE
+			if yyDollar[1].quoted {
+				yyVAL.account = &Account{User: yyDollar[1].str}
+			} else {
+				yyVAL.account = newAccount(yyDollar[1].str)
+			}
 		}
 	case 157:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:865
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1275
 		{
-			yyVAL.str = AST_GE
+			yyVAL.account = &Account{User: yyDollar[1].str, Host: yyDollar[2].str}
 		}
 	case 158:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:869
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1279
 		{
-			yyVAL.str = AST_NE
+			if !strings.HasSuffix(yyDollar[1].str, "@") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.account = &Account{User: strings.TrimSuffix(yyDollar[1].str, "@"), Host: yyDollar[2].strVal.Val}
 		}
 	case 159:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:873
+//line sql.y:1287
 		{
-			yyVAL.str = AST_NSE
+			yyVAL.account = &Account{User: yyDollar[1].strVal.Val}
 		}
 	case 160:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:879
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1291
 		{
-			yyVAL.colTuple = ValTuple(yyDollar[2].valExprs)
+			yyVAL.account = &Account{User: yyDollar[1].strVal.Val, Host: yyDollar[2].str}
 		}
 	case 161:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:883
+//line sql.y:1297
 		{
-			yyVAL.colTuple = yyDollar[1].subquery
+			yyVAL.userSpecs = []*UserSpec{yyDollar[1].userSpec}
 		}
 	case 162:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:887
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1301
 		{
-			yyVAL.colTuple = ListArg(yyDollar[1].bytes)
+			yyVAL.userSpecs = append(yyDollar[1].userSpecs, yyDollar[3].userSpec)
 		}
 	case 163:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:893
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1307
 		{
-			yyVAL.subquery = &Subquery{yyDollar[2].selStmt}
+			yyVAL.userSpec = &UserSpec{Account: yyDollar[1].account}
 		}
 	case 164:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:899
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1311
 		{
-			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
+			if !strings.EqualFold(yyDollar[2].str, "identified") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			password := yyDollar[4].strVal
+			yyVAL.userSpec = &UserSpec{Account: yyDollar[1].account, Password: &password}
 		}
 	case 165:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:903
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1320
 		{
-			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
+			if !strings.EqualFold(yyDollar[2].str, "identified") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.userSpec = &UserSpec{Account: yyDollar[1].account, Plugin: yyDollar[4].colIdent.Lowered()}
 		}
 	case 166:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:909
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1328
 		{
-			yyVAL.valExpr = &StarExpr{}
+			if !strings.EqualFold(yyDollar[2].str, "identified") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			password := yyDollar[6].strVal
+			yyVAL.userSpec = &UserSpec{Account: yyDollar[1].account, Plugin: yyDollar[4].colIdent.Lowered(), Password: &password}
 		}
 	case 167:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:913
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1337
 		{
-			yyVAL.valExpr = yyDollar[1].valExpr
+			if !strings.EqualFold(yyDollar[2].str, "identified") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			authString := yyDollar[6].strVal
+			yyVAL.userSpec = &UserSpec{Account: yyDollar[1].account, Plugin: yyDollar[4].colIdent.Lowered(), AuthString: &authString}
 		}
 	case 168:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:917
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1347
 		{
-			yyVAL.valExpr = yyDollar[1].colName
+			yyVAL.boolean = false
 		}
 	case 169:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:921
+//line sql.y:1351
 		{
-			yyVAL.valExpr = yyDollar[1].rowTuple
+			yyVAL.boolean = true
 		}
 	case 170:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:925
+//line sql.y:1357
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITAND, Right: yyDollar[3].valExpr}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Unsigned, yyVAL.columnType.Zerofill = yyDollar[2].boolean, yyDollar[3].boolean
 		}
 	case 171:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:929
+//line sql.y:1362
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITOR, Right: yyDollar[3].valExpr}
+			yyVAL.columnType = yyDollar[1].columnType
+			yyVAL.columnType.Charset, yyVAL.columnType.Collate = yyDollar[2].str, yyDollar[3].str
 		}
 	case 172:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:933
-		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITXOR, Right: yyDollar[3].valExpr}
-		}
-	case 173:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:937
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1367
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_PLUS, Right: yyDollar[3].valExpr}
+			yyVAL.columnType = ColumnType{Type: yyDollar[1].str, Length: yyDollar[2].numVal}
 		}
 	case 174:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:941
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1374
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MINUS, Right: yyDollar[3].valExpr}
+			yyVAL.columnType = ColumnType{Type: strings.ToLower(yyDollar[1].str)}
 		}
 	case 175:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:945
+//line sql.y:1380
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MULT, Right: yyDollar[3].valExpr}
+			yyVAL.convertType = &ConvertType{Type: AST_CHAR, Length: yyDollar[2].numVal, Charset: yyDollar[3].str}
 		}
 	case 176:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:949
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1384
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_DIV, Right: yyDollar[3].valExpr}
+			yyVAL.convertType = &ConvertType{Type: yyDollar[1].columnType.Type, Length: yyDollar[1].columnType.Length, Scale: yyDollar[1].columnType.Scale}
 		}
 	case 177:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:953
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1388
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MOD, Right: yyDollar[3].valExpr}
+			yyVAL.convertType = &ConvertType{Type: yyDollar[1].str, Length: yyDollar[2].numVal}
 		}
 	case 178:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:957
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1392
 		{
-			if num, ok := yyDollar[2].valExpr.(NumVal); ok {
-				switch yyDollar[1].byt {
-				case '-':
-					yyVAL.valExpr = append(NumVal("-"), num...)
-				case '+':
-					yyVAL.valExpr = num
-				default:
-					yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
-				}
-			} else {
-				yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
-			}
+			yyVAL.convertType = &ConvertType{Type: AST_UNSIGNED}
 		}
 	case 179:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:972
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1396
 		{
-			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].bytes}
+			if yyDollar[2].str != AST_INT && yyDollar[2].str != AST_INTEGER {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.convertType = &ConvertType{Type: AST_UNSIGNED}
 		}
 	case 180:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:976
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1404
 		{
-			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].selectExprs}
+			yyVAL.convertType = &ConvertType{Type: strings.ToLower(yyDollar[1].str), Length: yyDollar[2].numVal}
 		}
 	case 181:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:980
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1408
 		{
-			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].bytes, Distinct: true, Exprs: yyDollar[4].selectExprs}
+			if !strings.EqualFold(yyDollar[1].str, AST_SIGNED) || yyDollar[2].str != AST_INT && yyDollar[2].str != AST_INTEGER {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.convertType = &ConvertType{Type: AST_SIGNED}
 		}
 	case 182:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:984
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1418
 		{
-			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].selectExprs}
+			yyVAL.str = AST_DATE
 		}
 	case 183:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:988
+//line sql.y:1422
 		{
-			yyVAL.valExpr = yyDollar[1].caseExpr
+			yyVAL.str = AST_TIME
 		}
 	case 184:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:994
+//line sql.y:1426
 		{
-			yyVAL.bytes = IF_BYTES
+			yyVAL.str = AST_TIMESTAMP
 		}
 	case 185:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:998
+//line sql.y:1430
 		{
-			yyVAL.bytes = VALUES_BYTES
+			yyVAL.str = AST_DATETIME
 		}
 	case 186:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1004
+//line sql.y:1434
 		{
-			yyVAL.byt = AST_UPLUS
+			yyVAL.str = AST_YEAR
 		}
 	case 187:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1008
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1440
 		{
-			yyVAL.byt = AST_UMINUS
+			yyVAL.columnType = ColumnType{Type: AST_CHAR, Length: yyDollar[2].numVal}
 		}
 	case 188:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1012
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1444
 		{
-			yyVAL.byt = AST_TILDA
+			yyVAL.columnType = ColumnType{Type: AST_VARCHAR, Length: yyDollar[2].numVal}
 		}
 	case 189:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:1018
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1448
 		{
-			yyVAL.caseExpr = &CaseExpr{Expr: yyDollar[2].valExpr, Whens: yyDollar[3].whens, Else: yyDollar[4].valExpr}
+			yyVAL.columnType = ColumnType{Type: AST_TEXT}
 		}
 	case 190:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1023
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1452
 		{
-			yyVAL.valExpr = nil
+			if !strings.EqualFold(yyDollar[1].str, AST_ENUM) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.columnType = ColumnType{Type: AST_ENUM, EnumValues: yyDollar[3].strs}
 		}
 	case 191:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1027
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1460
 		{
-			yyVAL.valExpr = yyDollar[1].valExpr
+			yyVAL.columnType = ColumnType{Type: AST_SET, EnumValues: yyDollar[3].strs}
 		}
 	case 192:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1033
+//line sql.y:1466
 		{
-			yyVAL.whens = []*When{yyDollar[1].when}
+			yyVAL.strs = []string{yyDollar[1].strVal.Val}
 		}
 	case 193:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1037
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1470
 		{
-			yyVAL.whens = append(yyDollar[1].whens, yyDollar[2].when)
+			yyVAL.strs = append(yyDollar[1].strs, yyDollar[3].strVal.Val)
 		}
 	case 194:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:1043
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1475
 		{
-			yyVAL.when = &When{Cond: yyDollar[2].boolExpr, Val: yyDollar[4].valExpr}
+			yyVAL.str = ""
 		}
 	case 195:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1048
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1479
 		{
-			yyVAL.valExpr = nil
+			yyVAL.str = yyDollar[3].colIdent.String()
 		}
 	case 196:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1052
+//line sql.y:1483
 		{
-			yyVAL.valExpr = yyDollar[2].valExpr
+			yyVAL.str = yyDollar[2].colIdent.String()
 		}
 	case 197:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1058
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1490
 		{
-			yyVAL.colName = &ColName{Name: yyDollar[1].bytes}
+			yyVAL.str = ""
 		}
 	case 198:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1062
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1494
 		{
-			yyVAL.colName = &ColName{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
+			yyVAL.str = yyDollar[2].colIdent.String()
 		}
 	case 199:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1068
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1498
 		{
-			yyVAL.valExpr = StrVal(yyDollar[1].bytes)
+			yyVAL.str = yyDollar[2].strVal.Val
 		}
 	case 200:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1072
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1504
 		{
-			yyVAL.valExpr = NumVal(yyDollar[1].bytes)
+			yyVAL.columnType = ColumnType{Type: yyDollar[1].str, Length: yyDollar[2].numVal}
 		}
 	case 201:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1076
+//line sql.y:1508
 		{
-			yyVAL.valExpr = ValArg(yyDollar[1].bytes)
+			yyVAL.columnType = yyDollar[1].columnType
 		}
 	case 202:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1080
+//line sql.y:1514
 		{
-			yyVAL.valExpr = &NullVal{}
+			yyVAL.str = AST_BIT
 		}
 	case 203:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1085
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1518
 		{
-			yyVAL.selectExprs = nil
+			yyVAL.str = AST_TINYINT
 		}
 	case 204:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1089
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1522
 		{
-			yyVAL.selectExprs = yyDollar[3].selectExprs
+			yyVAL.str = AST_SMALLINT
 		}
 	case 205:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1094
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1526
 		{
-			yyVAL.boolExpr = nil
+			yyVAL.str = AST_MEDIUMINT
 		}
 	case 206:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1098
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1530
 		{
-			yyVAL.boolExpr = yyDollar[2].boolExpr
+			yyVAL.str = AST_INT
 		}
 	case 207:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1103
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1534
 		{
-			yyVAL.orderBy = nil
+			yyVAL.str = AST_INTEGER
 		}
 	case 208:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1107
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1538
 		{
-			yyVAL.orderBy = yyDollar[3].orderBy
+			yyVAL.str = AST_BIGINT
 		}
 	case 209:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1113
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1544
 		{
-			yyVAL.orderBy = OrderBy{yyDollar[1].order}
+			yyVAL.columnType = yyDollar[2].columnType
+			yyVAL.columnType.Type = AST_REAL
 		}
 	case 210:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1117
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1549
 		{
-			yyVAL.orderBy = append(yyDollar[1].orderBy, yyDollar[3].order)
+			yyVAL.columnType = yyDollar[2].columnType
+			yyVAL.columnType.Type = AST_DOUBLE
 		}
 	case 211:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1123
+//line sql.y:1554
 		{
-			yyVAL.order = &Order{Expr: yyDollar[1].valExpr, Direction: yyDollar[2].str}
+			yyVAL.columnType = yyDollar[2].columnType
+			yyVAL.columnType.Type = AST_FLOAT
 		}
 	case 212:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1128
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1559
 		{
-			yyVAL.str = AST_ASC
+			yyVAL.columnType = yyDollar[2].columnType
+			yyVAL.columnType.Type = AST_DECIMAL
 		}
 	case 213:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1132
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1564
 		{
-			yyVAL.str = AST_ASC
+			yyVAL.columnType = yyDollar[2].columnType
+			yyVAL.columnType.Type = AST_NUMERIC
 		}
 	case 214:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1136
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1570
 		{
-			yyVAL.str = AST_DESC
+			yyVAL.columnType = ColumnType{}
 		}
 	case 215:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1141
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1574
 		{
-			yyVAL.timerange = nil
+			yyVAL.columnType = ColumnType{Length: NumVal(yyDollar[2].str)}
 		}
 	case 216:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1145
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1578
 		{
-			yyVAL.timerange = &TimeRange{From: string(yyDollar[2].bytes)}
+			yyVAL.columnType = ColumnType{Length: NumVal(yyDollar[2].str), Scale: NumVal(yyDollar[4].str)}
 		}
 	case 217:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:1149
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1583
 		{
-			yyVAL.timerange = &TimeRange{From: string(yyDollar[2].bytes), To: string(yyDollar[4].bytes)}
+			yyVAL.numVal = ""
 		}
 	case 218:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1154
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1587
 		{
-			yyVAL.limit = nil
+			yyVAL.numVal = NumVal(yyDollar[2].str)
 		}
 	case 219:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1158
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1592
 		{
-			yyVAL.limit = &Limit{Rowcount: yyDollar[2].valExpr}
+			yyVAL.boolean = false
 		}
 	case 220:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:1162
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1596
 		{
-			yyVAL.limit = &Limit{Offset: yyDollar[2].valExpr, Rowcount: yyDollar[4].valExpr}
+			yyVAL.boolean = true
 		}
 	case 221:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1167
+//line sql.y:1601
 		{
-			yyVAL.str = ""
+			yyVAL.columnDefinition = &ColumnDefinition{ColumnAtts: ColumnAtts{}}
 		}
 	case 222:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1171
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1605
 		{
-			yyVAL.str = AST_FOR_UPDATE
+			yyDollar[1].columnDefinition.Nullable = NullNotAllowed
+			yyVAL.columnDefinition = yyDollar[1].columnDefinition
 		}
 	case 223:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:1175
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1610
 		{
-			if !bytes.Equal(yyDollar[3].bytes, SHARE) {
-				yylex.Error("expecting share")
-				return 1
-			}
-			if !bytes.Equal(yyDollar[4].bytes, MODE) {
-				yylex.Error("expecting mode")
-				return 1
-			}
-			yyVAL.str = AST_SHARE_MODE
+			yyDollar[1].columnDefinition.Nullable = NullAllowed
+			yyVAL.columnDefinition = yyDollar[1].columnDefinition
 		}
 	case 224:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1188
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1615
 		{
-			yyVAL.columns = nil
+			yyDollar[1].columnDefinition.HasDefault, yyDollar[1].columnDefinition.DefaultValue = true, timestampFunc(yyDollar[3].valExpr)
+			yyVAL.columnDefinition = yyDollar[1].columnDefinition
 		}
 	case 225:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1192
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1620
 		{
-			yyVAL.columns = yyDollar[2].columns
+			yyDollar[1].columnDefinition.OnUpdate = timestampFunc(yyDollar[4].valExpr)
+			yyVAL.columnDefinition = yyDollar[1].columnDefinition
 		}
 	case 226:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1198
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1625
 		{
-			yyVAL.columns = Columns{&NonStarExpr{Expr: yyDollar[1].colName}}
+			yyDollar[1].columnDefinition.ColumnAtts = append(yyDollar[1].columnDefinition.ColumnAtts, AST_AUTO_INCREMENT)
+			yyVAL.columnDefinition = yyDollar[1].columnDefinition
 		}
 	case 227:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1202
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1630
 		{
-			yyVAL.columns = append(yyVAL.columns, &NonStarExpr{Expr: yyDollar[3].colName})
+			yyDollar[1].columnDefinition.ColumnAtts = append(yyDollar[1].columnDefinition.ColumnAtts, yyDollar[2].str)
+			yyVAL.columnDefinition = yyDollar[1].columnDefinition
 		}
 	case 228:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1207
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1635
 		{
-			yyVAL.updateExprs = nil
+			yyDollar[1].columnDefinition.Comment = yyDollar[3].strVal.Val
+			yyVAL.columnDefinition = yyDollar[1].columnDefinition
 		}
 	case 229:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:1211
-		{
-			yyVAL.updateExprs = yyDollar[5].updateExprs
-		}
-	case 230:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1217
-		{
-			yyVAL.insRows = yyDollar[2].values
-		}
-	case 231:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1221
+//line sql.y:1642
 		{
-			yyVAL.insRows = yyDollar[1].selStmt
+			yyVAL.str = AST_PRIMARY_KEY
 		}
-	case 232:
+	case 230:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1227
+//line sql.y:1646
 		{
-			yyVAL.values = Values{yyDollar[1].rowTuple}
-		}
-	case 233:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1231
-		{
-			yyVAL.values = append(yyDollar[1].values, yyDollar[3].rowTuple)
-		}
-	case 234:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1237
-		{
-			yyVAL.rowTuple = ValTuple(yyDollar[2].valExprs)
+			yyVAL.str = AST_UNIQUE_KEY
 		}
 	case 235:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1241
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1660
 		{
-			yyVAL.rowTuple = yyDollar[1].subquery
+			yyDollar[3].columnDefinition.ColName, yyDollar[3].columnDefinition.ColType = yyDollar[1].str, yyDollar[2].columnType
+			yyVAL.columnDefinition = yyDollar[3].columnDefinition
 		}
 	case 236:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1247
+//line sql.y:1667
 		{
-			yyVAL.updateExprs = UpdateExprs{yyDollar[1].updateExpr}
+			yyVAL.createTable = &CreateTable{ColumnDefinitions: ColumnDefinitions{yyDollar[1].columnDefinition}}
 		}
 	case 237:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1251
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1671
 		{
-			yyVAL.updateExprs = append(yyDollar[1].updateExprs, yyDollar[3].updateExpr)
+			yyVAL.createTable = &CreateTable{Indexes: []*IndexDefinition{yyDollar[1].indexDefinition}}
 		}
 	case 238:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1257
+//line sql.y:1675
 		{
-			yyVAL.updateExpr = &UpdateExpr{Name: yyDollar[1].colName, Expr: yyDollar[3].valExpr}
+			yyDollar[1].createTable.ColumnDefinitions = append(yyDollar[1].createTable.ColumnDefinitions, yyDollar[3].columnDefinition)
+			yyVAL.createTable = yyDollar[1].createTable
 		}
 	case 239:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1262
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1680
 		{
-			yyVAL.empty = struct{}{}
+			yyDollar[1].createTable.Indexes = append(yyDollar[1].createTable.Indexes, yyDollar[3].indexDefinition)
+			yyVAL.createTable = yyDollar[1].createTable
 		}
 	case 240:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1264
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1687
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
 		}
 	case 241:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1267
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1691
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.indexDefinition = yyDollar[2].indexDefinition
 		}
 	case 242:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1269
+//line sql.y:1695
 		{
-			yyVAL.empty = struct{}{}
+			yyDollar[3].indexDefinition.Constraint = yyDollar[2].colIdent
+			yyVAL.indexDefinition = yyDollar[3].indexDefinition
 		}
 	case 243:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1272
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1700
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.indexDefinition = &IndexDefinition{Type: AST_INDEX, Name: yyDollar[2].colIdent, Columns: yyDollar[4].indexColumns, Using: yyDollar[6].str}
 		}
 	case 244:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1274
-		{
-			yyVAL.empty = struct{}{}
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1704
+		{
+			var typ string
+			switch strings.ToLower(yyDollar[1].str) {
+			case "fulltext":
+				typ = AST_FULLTEXT_KEY
+			case "spatial":
+				typ = AST_SPATIAL_KEY
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.indexDefinition = &IndexDefinition{Type: typ, Name: yyDollar[3].colIdent, Columns: yyDollar[5].indexColumns, Using: yyDollar[7].str}
 		}
 	case 245:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1278
+//line sql.y:1718
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
 		}
 	case 246:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1280
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1722
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.indexDefinition = yyDollar[2].indexDefinition
 		}
 	case 247:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1282
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1726
 		{
-			yyVAL.empty = struct{}{}
+			yyDollar[3].indexDefinition.Constraint = yyDollar[2].colIdent
+			yyVAL.indexDefinition = yyDollar[3].indexDefinition
 		}
 	case 248:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1284
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1733
 		{
-			yyVAL.empty = struct{}{}
+			if !strings.EqualFold(yyDollar[1].str, AST_CHECK) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.indexDefinition = &IndexDefinition{Type: AST_CHECK, Check: yyDollar[3].boolExpr, NotEnforced: !yyDollar[5].boolean}
 		}
 	case 249:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1286
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1742
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.boolean = true
 		}
 	case 250:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1289
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1746
 		{
-			yyVAL.empty = struct{}{}
+			if !strings.EqualFold(yyDollar[1].str, "enforced") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.boolean = true
 		}
 	case 251:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1291
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1754
 		{
-			yyVAL.empty = struct{}{}
+			if !strings.EqualFold(yyDollar[2].str, "enforced") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.boolean = false
 		}
 	case 252:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1294
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:1764
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.indexDefinition = &IndexDefinition{Type: AST_PRIMARY_KEY, Columns: yyDollar[4].indexColumns, Using: yyDollar[6].str}
 		}
 	case 253:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1296
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1768
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.indexDefinition = &IndexDefinition{Type: AST_UNIQUE_KEY, Name: yyDollar[3].colIdent, Columns: yyDollar[5].indexColumns, Using: yyDollar[7].str}
 		}
 	case 254:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1299
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1772
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.indexDefinition = &IndexDefinition{Type: AST_FOREIGN_KEY, Name: yyDollar[3].colIdent, Columns: yyDollar[5].indexColumns, References: yyDollar[7].references}
 		}
 	case 255:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1301
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:1778
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.references = &References{Table: yyDollar[2].tableIdent, Columns: yyDollar[4].indexColumns}
 		}
 	case 256:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1305
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1782
 		{
-			yyVAL.bytes = bytes.ToLower(yyDollar[1].bytes)
+			yyDollar[1].references.OnDelete = yyDollar[4].str
+			yyVAL.references = yyDollar[1].references
 		}
 	case 257:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1787
+		{
+			yyDollar[1].references.OnUpdate = yyDollar[4].str
+			yyVAL.references = yyDollar[1].references
+		}
+	case 258:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1794
+		{
+			switch strings.ToLower(yyDollar[1].str) {
+			case AST_CASCADE:
+				yyVAL.str = AST_CASCADE
+			case AST_RESTRICT:
+				yyVAL.str = AST_RESTRICT
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+		}
+	case 259:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1806
+		{
+			if !strings.EqualFold(yyDollar[1].str, "no") || !strings.EqualFold(yyDollar[2].str, "action") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = AST_NO_ACTION
+		}
+	case 260:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1814
+		{
+			yyVAL.str = AST_SET_NULL
+		}
+	case 261:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1818
+		{
+			yyVAL.str = AST_SET_DEFAULT
+		}
+	case 264:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1827
+		{
+		}
+	case 266:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1831
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 267:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1835
+		{
+			yyVAL.colIdent = yyDollar[1].colIdent
+		}
+	case 268:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1841
+		{
+			yyVAL.indexColumns = []*IndexColumn{yyDollar[1].indexColumn}
+		}
+	case 269:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1845
+		{
+			yyVAL.indexColumns = append(yyDollar[1].indexColumns, yyDollar[3].indexColumn)
+		}
+	case 270:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1851
+		{
+			yyVAL.indexColumn = &IndexColumn{Column: yyDollar[1].colIdent, Length: yyDollar[2].numVal}
+		}
+	case 271:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1855
+		{
+			yyVAL.indexColumn = &IndexColumn{Column: yyDollar[1].colIdent, Length: yyDollar[2].numVal, Direction: AST_ASC}
+		}
+	case 272:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1859
+		{
+			yyVAL.indexColumn = &IndexColumn{Column: yyDollar[1].colIdent, Length: yyDollar[2].numVal, Direction: AST_DESC}
+		}
+	case 273:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1864
+		{
+			yyVAL.str = ""
+		}
+	case 274:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1868
+		{
+			yyVAL.str = yyDollar[2].colIdent.Lowered()
+		}
+	case 275:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1879
+		{
+			yyVAL.indexDefinition = &IndexDefinition{}
+		}
+	case 276:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1883
+		{
+			yyDollar[1].indexDefinition.Using = yyDollar[3].colIdent.Lowered()
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+		}
+	case 277:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1888
+		{
+			name := strings.ToLower(yyDollar[2].str)
+			if name != AST_VISIBLE && name != AST_INVISIBLE {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyDollar[1].indexDefinition.Options = append(yyDollar[1].indexDefinition.Options, &IndexOption{Name: name})
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+		}
+	case 278:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1898
+		{
+			yyDollar[1].indexDefinition.Options = append(yyDollar[1].indexDefinition.Options, &IndexOption{Name: strings.ToLower(yyDollar[2].str), Value: yyDollar[4].str})
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+		}
+	case 279:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1903
+		{
+			yyDollar[1].indexDefinition.Options = append(yyDollar[1].indexDefinition.Options, &IndexOption{Name: strings.ToLower(yyDollar[2].str), Value: yyDollar[3].str})
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+		}
+	case 280:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1908
+		{
+			name := strings.ToLower(yyDollar[2].str)
+			if name != AST_COMMENT && name != "engine_attribute" && name != "secondary_engine_attribute" {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyDollar[1].indexDefinition.Options = append(yyDollar[1].indexDefinition.Options, &IndexOption{Name: name, Value: yyDollar[3].strVal.Val})
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+		}
+	case 281:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:1918
+		{
+			yyDollar[1].indexDefinition.Options = append(yyDollar[1].indexDefinition.Options, &IndexOption{Name: AST_COMMENT, Value: yyDollar[3].strVal.Val})
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+		}
+	case 282:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1923
+		{
+			yyDollar[1].indexDefinition.Options = append(yyDollar[1].indexDefinition.Options, &IndexOption{Name: AST_LOCK, Value: yyDollar[4].str})
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+		}
+	case 283:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:1928
+		{
+			if !strings.EqualFold(yyDollar[3].str, "parser") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+			yyDollar[1].indexDefinition.Options = append(yyDollar[1].indexDefinition.Options, &IndexOption{Name: AST_WITH_PARSER, Value: yyDollar[4].colIdent.String()})
+			yyVAL.indexDefinition = yyDollar[1].indexDefinition
+		}
+	case 284:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1939
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 285:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1943
+		{
+			yyVAL.str = AST_DEFAULT
+		}
+	case 286:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line sql.y:1949
+		{
+			yyDollar[7].createTable.Temporary, yyDollar[7].createTable.IfNotExists, yyDollar[7].createTable.Name, yyDollar[7].createTable.Options, yyDollar[7].createTable.Select = yyDollar[2].boolean, yyDollar[4].boolean, yyDollar[5].tableIdent, yyDollar[9].tableOptions, yyDollar[10].selStmt
+			yyVAL.statement = yyDollar[7].createTable
+		}
+	case 287:
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:1954
+		{
+			yyDollar[7].createTable.Temporary, yyDollar[7].createTable.IfNotExists, yyDollar[7].createTable.Name, yyDollar[7].createTable.Options, yyDollar[7].createTable.Partitions = yyDollar[2].boolean, yyDollar[4].boolean, yyDollar[5].tableIdent, yyDollar[9].tableOptions, yyDollar[12].str
+			yyVAL.statement = yyDollar[7].createTable
+		}
+	case 288:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1959
+		{
+			yyVAL.statement = &CreateTable{Temporary: yyDollar[2].boolean, IfNotExists: yyDollar[4].boolean, Name: yyDollar[5].tableIdent, Options: yyDollar[6].tableOptions, Select: yyDollar[7].selStmt}
+		}
+	case 289:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:1963
+		{
+			yyVAL.statement = &CreateTable{Temporary: yyDollar[2].boolean, IfNotExists: yyDollar[4].boolean, Name: yyDollar[5].tableIdent, LikeTable: yyDollar[7].tableName}
+		}
+	case 290:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line sql.y:1967
+		{
+			yyVAL.statement = &CreateTable{Temporary: yyDollar[2].boolean, IfNotExists: yyDollar[4].boolean, Name: yyDollar[5].tableIdent, LikeTable: yyDollar[8].tableName}
+		}
+	case 291:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1972
+		{
+			yyVAL.selStmt = nil
+		}
+	case 292:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1976
+		{
+			yyVAL.selStmt = yyDollar[2].selStmt
+		}
+	case 293:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:1988
+		{
+			yyVAL.tableOptions = nil
+		}
+	case 294:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:1992
+		{
+			yyVAL.tableOptions = nil
+		}
+	case 295:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:1996
+		{
+			yyVAL.tableOptions = yyDollar[1].tableOptions
+		}
+	case 296:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2001
+		{
+			yyVAL.boolean = false
+		}
+	case 297:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2005
+		{
+			if !strings.EqualFold(yyDollar[1].str, "temporary") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.boolean = true
+		}
+	case 298:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2014
+		{
+			yyVAL.tableOptions = nil
+		}
+	case 299:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2018
+		{
+			yyVAL.tableOptions = yyDollar[1].tableOptions
+		}
+	case 300:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2024
+		{
+			yyVAL.tableOptions = TableOptions{yyDollar[1].tableOption}
+		}
+	case 301:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2028
+		{
+			yyVAL.tableOptions = append(yyDollar[1].tableOptions, yyDollar[2].tableOption)
+		}
+	case 302:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2032
+		{
+			yyVAL.tableOptions = append(yyDollar[1].tableOptions, yyDollar[3].tableOption)
+		}
+	case 303:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2038
+		{
+			name := strings.ToLower(yyDollar[1].str)
+			if !tableOptionNames[name] {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.tableOption = &TableOption{Name: name, Value: yyDollar[3].str}
+		}
+	case 304:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2047
+		{
+			yyVAL.tableOption = &TableOption{Name: AST_AUTO_INCREMENT, Value: yyDollar[3].str}
+		}
+	case 305:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2051
+		{
+			yyVAL.tableOption = &TableOption{Name: AST_COMMENT, Value: yyDollar[2].strVal.Val}
+		}
+	case 306:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2055
+		{
+			yyVAL.tableOption = &TableOption{Name: AST_CHARACTER_SET, Value: yyDollar[4].str}
+		}
+	case 307:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2059
+		{
+			yyVAL.tableOption = &TableOption{Name: AST_CHARACTER_SET, Value: yyDollar[5].str}
+		}
+	case 308:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2063
+		{
+			yyVAL.tableOption = &TableOption{Name: AST_COLLATE, Value: yyDollar[4].str}
+		}
+	case 309:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2069
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 310:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2073
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 311:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2077
+		{
+			yyVAL.str = yyDollar[1].strVal.Val
+		}
+	case 312:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2082
+		{
+		}
+	case 313:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2084
+		{
+		}
+	case 314:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2087
+		{
+		}
+	case 315:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2089
+		{
+		}
+	case 316:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2093
+		{
+			yyVAL.statement = yyDollar[1].statement
+		}
+	case 317:
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:2097
+		{
+			index := yyDollar[12].indexDefinition
+			index.Type, index.Name, index.Columns = AST_INDEX, yyDollar[5].colIdent, yyDollar[10].indexColumns
+			if index.Using == "" {
+				index.Using = yyDollar[6].str
+			}
+			if yyDollar[2].boolean {
+				index.Type = AST_UNIQUE_KEY
+			}
+			yyVAL.statement = &DDL{Action: AST_CREATE, Kind: AST_INDEX, IfNotExists: yyDollar[4].boolean, Table: yyDollar[8].tableIdent, IndexName: yyDollar[5].colIdent, Index: index}
+		}
+	case 318:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:2109
+		{
+			yyVAL.statement = &DDL{Action: AST_CREATE, Kind: AST_VIEW, OrReplace: yyDollar[2].boolean, Table: yyDollar[4].tableIdent, Columns: yyDollar[5].colIdents, Select: yyDollar[7].selStmt, CheckOption: yyDollar[8].str}
+		}
+	case 319:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2113
+		{
+			yyVAL.statement = &DDL{Action: AST_CREATE, Kind: AST_DATABASE, IfNotExists: yyDollar[3].boolean, Table: yyDollar[4].tableIdent}
+		}
+	case 320:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2117
+		{
+			kind := strings.ToLower(yyDollar[2].str)
+			if kind != AST_PROCEDURE && kind != AST_FUNCTION {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.statement = &CreateRoutine{Kind: kind, IfNotExists: yyDollar[3].boolean, Name: yyDollar[4].tableName, Body: "(" + yyDollar[6].str}
+		}
+	case 321:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2126
+		{
+			seq, err := NewSequence(AST_CREATE, yyDollar[2].str, yyDollar[4].tableName, yyDollar[5].strs)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			seq.IfNotExists = yyDollar[3].boolean
+			yyVAL.statement = seq
+		}
+	case 322:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2142
+		{
+			yyVAL.str = yylex.(*Tokenizer).scanRest()
+		}
+	case 323:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2152
+		{
+			yyVAL.str = strings.TrimLeft(yylex.(*Tokenizer).scanRest(), " \n\r\t")
+		}
+	case 324:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2157
+		{
+			yyVAL.boolean = false
+		}
+	case 325:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2161
+		{
+			if !strings.EqualFold(yyDollar[2].str, AST_REPLACE) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.boolean = true
+		}
+	case 326:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2170
+		{
+			yyVAL.colIdents = nil
+		}
+	case 327:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2174
+		{
+			yyVAL.colIdents = yyDollar[2].colIdents
+		}
+	case 328:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2179
+		{
+			yyVAL.str = ""
+		}
+	case 329:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2183
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 330:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2189
+		{
+			yyVAL.statement = &DDL{Action: AST_ALTER, Kind: AST_TABLE, Table: yyDollar[4].tableIdent}
+		}
+	case 331:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:2193
+		{
+			yyVAL.statement = &DDL{Action: AST_ALTER, Kind: AST_TABLE, Table: yyDollar[4].tableIdent}
+		}
+	case 332:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2197
+		{
+			yyVAL.statement = &AlterTable{Ignore: yyDollar[2].boolean, Table: yyDollar[4].tableIdent, Specs: yyDollar[5].alterSpecs}
+		}
+	case 333:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:2201
+		{
+			// Change this to a rename statement
+			yyVAL.statement = &DDL{Action: AST_RENAME, Kind: AST_TABLE, Table: yyDollar[4].tableIdent, NewName: yyDollar[7].tableIdent}
+		}
+	case 334:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2206
+		{
+			yyVAL.statement = &DDL{Action: AST_ALTER, Kind: AST_VIEW, Table: yyDollar[3].tableIdent}
+		}
+	case 335:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2210
+		{
+			seq, err := NewSequence(AST_ALTER, yyDollar[2].str, yyDollar[3].tableName, yyDollar[4].strs)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			yyVAL.statement = seq
+		}
+	case 336:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2221
+		{
+			yyVAL.alterSpecs = []*AlterSpec{yyDollar[1].alterSpec}
+		}
+	case 337:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2225
+		{
+			yyVAL.alterSpecs = append(yyDollar[1].alterSpecs, yyDollar[3].alterSpec)
+		}
+	case 338:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2235
+		{
+			yyVAL.alterSpec = yyDollar[3].alterSpec
+			yyVAL.alterSpec.Action, yyVAL.alterSpec.Column = AST_ADD_COLUMN, yyDollar[2].columnDefinition
+		}
+	case 339:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2240
+		{
+			yyVAL.alterSpec = yyDollar[4].alterSpec
+			yyVAL.alterSpec.Action, yyVAL.alterSpec.Column = AST_ADD_COLUMN, yyDollar[3].columnDefinition
+		}
+	case 340:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2245
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AST_ADD_INDEX, Index: yyDollar[2].indexDefinition}
+		}
+	case 341:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2249
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AST_DROP_COLUMN, Name: yyDollar[3].colIdent}
+		}
+	case 342:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2253
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AST_DROP_INDEX, Name: yyDollar[3].colIdent}
+		}
+	case 343:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2257
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AST_DROP_PRIMARY_KEY}
+		}
+	case 344:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2261
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AST_DROP_FOREIGN_KEY, Name: yyDollar[4].colIdent}
+		}
+	case 345:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2265
+		{
+			yyVAL.alterSpec = yyDollar[4].alterSpec
+			yyVAL.alterSpec.Action, yyVAL.alterSpec.Column = AST_MODIFY_COLUMN, yyDollar[3].columnDefinition
+		}
+	case 346:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2270
+		{
+			yyVAL.alterSpec = yyDollar[5].alterSpec
+			yyVAL.alterSpec.Action, yyVAL.alterSpec.Name, yyVAL.alterSpec.Column = AST_CHANGE_COLUMN, yyDollar[3].colIdent, yyDollar[4].columnDefinition
+		}
+	case 347:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2275
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AST_RENAME_COLUMN, Name: yyDollar[3].colIdent, NewName: yyDollar[5].colIdent}
+		}
+	case 348:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2279
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AST_RENAME_INDEX, Name: yyDollar[3].colIdent, NewName: yyDollar[5].colIdent}
+		}
+	case 349:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2283
+		{
+			yyVAL.alterSpec = &AlterSpec{Action: AST_TABLE_OPTION, Option: yyDollar[1].tableOption}
+		}
+	case 350:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2288
+		{
+		}
+	case 351:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2290
+		{
+		}
+	case 352:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2293
+		{
+			yyVAL.alterSpec = &AlterSpec{}
+		}
+	case 353:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2297
+		{
+			if !strings.EqualFold(yyDollar[1].str, "first") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.alterSpec = &AlterSpec{First: true}
+		}
+	case 354:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2305
+		{
+			if !strings.EqualFold(yyDollar[1].str, "after") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.alterSpec = &AlterSpec{After: yyDollar[2].colIdent}
+		}
+	case 355:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2315
+		{
+			yyVAL.statement = &DDL{Action: AST_RENAME, Kind: AST_TABLE, Table: yyDollar[3].renames[0].From, NewName: yyDollar[3].renames[0].To, Renames: yyDollar[3].renames}
+		}
+	case 356:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2321
+		{
+			yyVAL.renames = []*TableRename{{From: yyDollar[1].tableIdent, To: yyDollar[3].tableIdent}}
+		}
+	case 357:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2325
+		{
+			yyVAL.renames = append(yyDollar[1].renames, &TableRename{From: yyDollar[3].tableIdent, To: yyDollar[5].tableIdent})
+		}
+	case 358:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2331
+		{
+			if !strings.EqualFold(yyDollar[1].str, AST_TRUNCATE) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &DDL{Action: AST_TRUNCATE, Kind: AST_TABLE, Table: yyDollar[3].tableIdent}
+		}
+	case 359:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2341
+		{
+			yyVAL.statement = &DDL{Action: AST_DROP, Kind: AST_TABLE, Temporary: yyDollar[2].boolean, IfExists: yyDollar[4].boolean, Table: yyDollar[5].tableIdents[0], Tables: yyDollar[5].tableIdents}
+		}
+	case 360:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2345
+		{
+			yyVAL.statement = &DDL{Action: AST_DROP, Kind: AST_INDEX, IfExists: yyDollar[3].boolean, Table: yyDollar[6].tableIdent, IndexName: yyDollar[4].colIdent}
+		}
+	case 361:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2349
+		{
+			yyVAL.statement = &DDL{Action: AST_DROP, Kind: AST_VIEW, IfExists: yyDollar[3].boolean, Table: yyDollar[4].tableIdent}
+		}
+	case 362:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2353
+		{
+			yyVAL.statement = &DDL{Action: AST_DROP, Kind: AST_DATABASE, IfExists: yyDollar[3].boolean, Table: yyDollar[4].tableIdent}
+		}
+	case 363:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2357
+		{
+			if strings.EqualFold(yyDollar[2].str, "prepare") && !yyDollar[3].boolean && yyDollar[4].tableName.Qualifier.IsEmpty() {
+				// DROP PREPARE is a synonym for DEALLOCATE PREPARE.
+				yyVAL.statement = &Deallocate{Name: makeColIdent(yyDollar[4].tableName.Name.val, yyDollar[4].tableName.Name.quoted)}
+			} else {
+				seq, err := NewSequence(AST_DROP, yyDollar[2].str, yyDollar[4].tableName, nil)
+				if err != nil {
+					yylex.Error(err.Error())
+					return 1
+				}
+				seq.IfExists = yyDollar[3].boolean
+				yyVAL.statement = seq
+			}
+		}
+	case 364:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2374
+		{
+			yyVAL.statement = &DDL{Action: AST_ALTER, Kind: AST_TABLE, Table: yyDollar[3].tableIdent}
+		}
+	case 365:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2380
+		{
+			show, err := newShow(yyDollar[2].strs, nil, TableIdent{})
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			show.Filter = yyDollar[3].showFilter
+			yyVAL.statement = show
+		}
+	case 366:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2390
+		{
+			show, err := newShow(yyDollar[2].strs, yyDollar[4].tableName, TableIdent{})
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			show.Filter = yyDollar[5].showFilter
+			yyVAL.statement = show
+		}
+	case 367:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:2400
+		{
+			show, err := newShow(yyDollar[2].strs, yyDollar[4].tableName, yyDollar[6].tableIdent)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			show.Filter = yyDollar[7].showFilter
+			yyVAL.statement = show
+		}
+	case 368:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2410
+		{
+			yyVAL.statement = &Show{Type: AST_SHOW_CREATE_TABLE, Table: yyDollar[4].tableName}
+		}
+	case 369:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2414
+		{
+			yyVAL.statement = &Show{Type: AST_CREATE + " " + AST_VIEW, Table: yyDollar[4].tableName}
+		}
+	case 370:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2418
+		{
+			yyVAL.statement = &Show{Type: AST_CREATE + " " + AST_DATABASE, Database: yyDollar[4].tableIdent}
+		}
+	case 371:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2422
+		{
+			switch kind := strings.ToLower(yyDollar[3].str); kind {
+			case "procedure", "function", "trigger", "event":
+				yyVAL.statement = &Show{Type: AST_CREATE + " " + kind, Table: yyDollar[4].tableName}
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+		}
+	case 372:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2432
+		{
+			yyVAL.statement = &Describe{Table: yyDollar[2].tableName}
+		}
+	case 373:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2436
+		{
+			yyVAL.statement = &Explain{Statement: yyDollar[3].statement, OutputFormat: yyDollar[2].str}
+		}
+	case 374:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2442
+		{
+			yyVAL.strs = []string{yyDollar[1].str}
+		}
+	case 375:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2446
+		{
+			yyVAL.strs = append(yyDollar[1].strs, yyDollar[2].str)
+		}
+	case 376:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2452
+		{
+			if yyDollar[1].quoted {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = strings.ToLower(yyDollar[1].str)
+		}
+	case 377:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2460
+		{
+			yyVAL.str = AST_TABLE
+		}
+	case 378:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2464
+		{
+			yyVAL.str = AST_SHOW_INDEX
+		}
+	case 379:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2468
+		{
+			yyVAL.str = AST_CHARACTER_SET
+		}
+	case 382:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2477
+		{
+			yyVAL.showFilter = nil
+		}
+	case 383:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2481
+		{
+			yyVAL.showFilter = &ShowFilter{Like: yyDollar[2].strVal.Val}
+		}
+	case 384:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2485
+		{
+			yyVAL.showFilter = &ShowFilter{Filter: yyDollar[2].boolExpr}
+		}
+	case 388:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2495
+		{
+			yyVAL.str = ""
+		}
+	case 389:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2499
+		{
+			if !strings.EqualFold(yyDollar[1].str, "format") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = strings.ToLower(yyDollar[3].str)
+		}
+	case 390:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2509
+		{
+			yyVAL.statement = yyDollar[1].selStmt
+		}
+	case 394:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2518
+		{
+			yyVAL.statement = &DeclareCursor{Name: yyDollar[2].colIdent, Select: yyDollar[5].selStmt}
+		}
+	case 395:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2522
+		{
+			// COMMIT WORK, ROLLBACK WORK, START TRANSACTION,
+			// SAVEPOINT, EXECUTE, CALL and TRUNCATE take this form
+			// too, as none of their words are reserved.
+			switch word := strings.ToLower(yyDollar[1].str); {
+			case word == AST_OPEN:
+				yyVAL.statement = &OpenCursor{Name: yyDollar[2].colIdent}
+			case word == AST_CLOSE:
+				yyVAL.statement = &CloseCursor{Name: yyDollar[2].colIdent}
+			case word == "commit" && yyDollar[2].colIdent.EqualString("work"):
+				yyVAL.statement = &Commit{}
+			case word == "rollback" && yyDollar[2].colIdent.EqualString("work"):
+				yyVAL.statement = &Rollback{}
+			case word == "start" && yyDollar[2].colIdent.EqualString("transaction"):
+				yyVAL.statement = &Begin{}
+			case word == "savepoint":
+				yyVAL.statement = &Savepoint{Name: yyDollar[2].colIdent}
+			case word == "execute":
+				yyVAL.statement = &Execute{Name: yyDollar[2].colIdent}
+			case word == AST_TRUNCATE:
+				yyVAL.statement = &DDL{Action: AST_TRUNCATE, Kind: AST_TABLE, Table: makeTableIdent(yyDollar[2].colIdent.val, yyDollar[2].colIdent.quoted)}
+			case word == "call":
+				yyVAL.statement = &Call{Name: NodeArena(yylex).tableName(TableName{Name: makeTableIdent(yyDollar[2].colIdent.val, yyDollar[2].colIdent.quoted)})}
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+		}
+	case 396:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2551
+		{
+			yyVAL.statement = &FetchCursor{Name: yyDollar[2].colIdent, Into: yyDollar[4].colIdents}
+		}
+	case 397:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2555
+		{
+			yyVAL.statement = &FetchCursor{Name: yyDollar[3].colIdent, Into: yyDollar[5].colIdents}
+		}
+	case 398:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2559
+		{
+			if !yyDollar[2].colIdent.EqualString("next") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].colIdent.String()))
+				return 1
+			}
+			yyVAL.statement = &FetchCursor{Name: yyDollar[4].colIdent, Into: yyDollar[6].colIdents}
+		}
+	case 399:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2569
+		{
+			yyVAL.colIdents = []ColIdent{yyDollar[1].colIdent}
+		}
+	case 400:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2573
+		{
+			yyVAL.colIdents = append(yyDollar[1].colIdents, yyDollar[3].colIdent)
+		}
+	case 402:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2580
+		{
+			yyVAL.colIdent = NewColIdent("@" + yyDollar[1].str)
+		}
+	case 403:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2586
+		{
+			if !strings.EqualFold(yyDollar[1].str, "prepare") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Prepare{Name: yyDollar[2].colIdent, Stmt: yyDollar[4].valExpr}
+		}
+	case 404:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2594
+		{
+			if !strings.EqualFold(yyDollar[1].str, "execute") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.statement = &Execute{Name: yyDollar[2].colIdent, Using: yyDollar[4].userVars}
+		}
+	case 405:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2602
+		{
+			// RELEASE SAVEPOINT takes this form too.
+			switch {
+			case strings.EqualFold(yyDollar[1].str, "deallocate") && strings.EqualFold(yyDollar[2].str, "prepare"):
+				yyVAL.statement = &Deallocate{Name: yyDollar[3].colIdent}
+			case strings.EqualFold(yyDollar[1].str, "release") && strings.EqualFold(yyDollar[2].str, "savepoint"):
+				yyVAL.statement = &ReleaseSavepoint{Name: yyDollar[3].colIdent}
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+		}
+	case 406:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2617
+		{
+			yyVAL.valExpr = yyDollar[1].strVal
+		}
+	case 407:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2621
+		{
+			yyVAL.valExpr = yyDollar[1].userVar
+		}
+	case 408:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2627
+		{
+			yyVAL.userVars = []*UserVar{yyDollar[1].userVar}
+		}
+	case 409:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2631
+		{
+			yyVAL.userVars = append(yyDollar[1].userVars, yyDollar[3].userVar)
+		}
+	case 410:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2637
+		{
+			yyVAL.statement = &Block{Statements: yyDollar[2].statements, EndLabel: yyDollar[4].colIdent}
+		}
+	case 411:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2641
+		{
+			yyVAL.statement = &Block{Label: yyDollar[1].colIdent, Statements: yyDollar[4].statements, EndLabel: yyDollar[6].colIdent}
+		}
+	case 412:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:2645
+		{
+			yyVAL.statement = &IfStatement{Cond: yyDollar[2].boolExpr, Then: yyDollar[4].statements, ElseIfs: yyDollar[5].elseIfs, Else: yyDollar[6].statements}
+		}
+	case 413:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:2649
+		{
+			yyVAL.statement = &While{Label: yyDollar[1].colIdent, Cond: yyDollar[3].boolExpr, Body: yyDollar[5].statements, EndLabel: yyDollar[8].colIdent}
+		}
+	case 414:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2653
+		{
+			yyVAL.statement = &Loop{Label: yyDollar[1].colIdent, Body: yyDollar[3].statements, EndLabel: yyDollar[6].colIdent}
+		}
+	case 415:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:2657
+		{
+			yyVAL.statement = &Repeat{Label: yyDollar[1].colIdent, Body: yyDollar[3].statements, Until: yyDollar[5].boolExpr, EndLabel: yyDollar[8].colIdent}
+		}
+	case 416:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2661
+		{
+			yyVAL.statement = &Leave{Label: yyDollar[2].colIdent}
+		}
+	case 417:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2665
+		{
+			yyVAL.statement = &Iterate{Label: yyDollar[2].colIdent}
+		}
+	case 418:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2669
+		{
+			yyVAL.statement = &DeclareVars{Names: yyDollar[2].colIdents, Type: yyDollar[3].columnType, Default: yyDollar[4].valExpr}
+		}
+	case 419:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2673
+		{
+			if !strings.EqualFold(yyDollar[3].str, "handler") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+			yyVAL.statement = &DeclareHandler{Action: yyDollar[2].str, Conditions: yyDollar[5].handlerConds, Body: yyDollar[6].statement}
+		}
+	case 420:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2682
+		{
+			yyVAL.statements = nil
+		}
+	case 421:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2686
+		{
+			yyVAL.statements = append(yyDollar[1].statements, yyDollar[2].statement)
+		}
+	case 422:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2691
+		{
+			yyVAL.elseIfs = nil
+		}
+	case 423:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2695
+		{
+			yyVAL.elseIfs = append(yyDollar[1].elseIfs, &ElseIf{Cond: yyDollar[3].boolExpr, Then: yyDollar[5].statements})
+		}
+	case 424:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2700
+		{
+			yyVAL.statements = nil
+		}
+	case 425:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2704
+		{
+			yyVAL.statements = yyDollar[2].statements
+			if yyVAL.statements == nil {
+				yyVAL.statements = Statements{}
+			}
+		}
+	case 426:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2712
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 427:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2716
+		{
+			yyVAL.colIdent = yyDollar[1].colIdent
+		}
+	case 428:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2721
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 429:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2725
+		{
+			yyVAL.colIdent = yyDollar[1].colIdent
+		}
+	case 430:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2730
+		{
+			yyVAL.valExpr = nil
+		}
+	case 431:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2734
+		{
+			yyVAL.valExpr = yyDollar[2].valExpr
+		}
+	case 432:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2740
+		{
+			yyVAL.str = AST_CONTINUE
+		}
+	case 433:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2744
+		{
+			yyVAL.str = AST_EXIT
+		}
+	case 434:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2750
+		{
+			yyVAL.handlerConds = []*HandlerCondition{yyDollar[1].handlerCond}
+		}
+	case 435:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2754
+		{
+			yyVAL.handlerConds = append(yyDollar[1].handlerConds, yyDollar[3].handlerCond)
+		}
+	case 436:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2760
+		{
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_SQLEXCEPTION}
+		}
+	case 437:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2764
+		{
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_SQLWARNING}
+		}
+	case 438:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2768
+		{
+			if !strings.EqualFold(yyDollar[2].str, "found") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_NOT_FOUND}
+		}
+	case 439:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2776
+		{
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_SQLSTATE, Value: yyDollar[2].strVal.Val}
+		}
+	case 440:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2780
+		{
+			if !strings.EqualFold(yyDollar[2].str, "value") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_SQLSTATE, Value: yyDollar[3].strVal.Val}
+		}
+	case 441:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2788
+		{
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_ERROR_CODE, Value: yyDollar[1].str}
+		}
+	case 442:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2792
+		{
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_CONDITION_NAME, Value: yyDollar[1].colIdent.String()}
+		}
+	case 443:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2798
+		{
+			yyVAL.statement = &Signal{Action: AST_SIGNAL, Condition: yyDollar[2].handlerCond, Items: yyDollar[3].signalItems}
+		}
+	case 444:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2802
+		{
+			yyVAL.statement = &Signal{Action: AST_RESIGNAL, Items: yyDollar[2].signalItems}
+		}
+	case 445:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2806
+		{
+			yyVAL.statement = &Signal{Action: AST_RESIGNAL, Condition: yyDollar[2].handlerCond, Items: yyDollar[3].signalItems}
+		}
+	case 446:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2812
+		{
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_SQLSTATE, Value: yyDollar[2].strVal.Val}
+		}
+	case 447:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2816
+		{
+			if !strings.EqualFold(yyDollar[2].str, "value") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_SQLSTATE, Value: yyDollar[3].strVal.Val}
+		}
+	case 448:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2824
+		{
+			yyVAL.handlerCond = &HandlerCondition{Type: AST_CONDITION_NAME, Value: yyDollar[1].colIdent.String()}
+		}
+	case 449:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2829
+		{
+			yyVAL.signalItems = nil
+		}
+	case 450:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2833
+		{
+			yyVAL.signalItems = yyDollar[2].signalItems
+		}
+	case 451:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2839
+		{
+			yyVAL.signalItems = []*SignalItem{yyDollar[1].signalItem}
+		}
+	case 452:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2843
+		{
+			yyVAL.signalItems = append(yyDollar[1].signalItems, yyDollar[3].signalItem)
+		}
+	case 453:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2849
+		{
+			name := strings.ToLower(yyDollar[1].str)
+			if !signalItemNames[name] {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.signalItem = &SignalItem{Name: name, Value: yyDollar[3].valExpr}
+		}
+	case 454:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2861
+		{
+			yyVAL.valExpr = NumVal(yyDollar[1].str)
+		}
+	case 455:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2865
+		{
+			yyVAL.valExpr = ValArg(yyDollar[1].str)
+		}
+	case 456:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2870
+		{
+			SetAllowComments(yylex, true)
+		}
+	case 457:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2874
+		{
+			yyVAL.strs = yyDollar[2].strs
+			SetAllowComments(yylex, false)
+		}
+	case 458:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2880
+		{
+			yyVAL.strs = nil
+		}
+	case 459:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2884
+		{
+			yyVAL.strs = append(yyDollar[1].strs, yyDollar[2].str)
+		}
+	case 460:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2890
+		{
+			yyVAL.str = AST_UNION
+		}
+	case 461:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2894
+		{
+			yyVAL.str = AST_UNION_ALL
+		}
+	case 462:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2898
+		{
+			yyVAL.str = AST_UNION_DISTINCT
+		}
+	case 463:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2902
+		{
+			yyVAL.str = AST_SET_MINUS
+		}
+	case 464:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2906
+		{
+			yyVAL.str = AST_EXCEPT
+		}
+	case 465:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2910
+		{
+			yyVAL.str = AST_EXCEPT_ALL
+		}
+	case 466:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2914
+		{
+			yyVAL.str = AST_EXCEPT_DISTINCT
+		}
+	case 467:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2920
+		{
+			yyVAL.str = AST_INTERSECT
+		}
+	case 468:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2924
+		{
+			yyVAL.str = AST_INTERSECT_ALL
+		}
+	case 469:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2928
+		{
+			yyVAL.str = AST_INTERSECT_DISTINCT
+		}
+	case 470:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2933
+		{
+			yyVAL.selectOpts = &Select{}
+		}
+	case 471:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2937
+		{
+			yyDollar[1].selectOpts.Distinct = AST_DISTINCT
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 472:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2942
+		{
+			yyDollar[1].selectOpts.Distinct = AST_DISTINCTROW
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 473:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2947
+		{
+			yyDollar[1].selectOpts.HighPriority = true
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 474:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2952
+		{
+			yyDollar[1].selectOpts.StraightJoin = true
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 475:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2957
+		{
+			if yyDollar[1].selectOpts.ResultSize != "" {
+				yylex.Error("conflicting result size options")
+				return 1
+			}
+			yyDollar[1].selectOpts.ResultSize = AST_SQL_SMALL_RESULT
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 476:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2966
+		{
+			if yyDollar[1].selectOpts.ResultSize != "" {
+				yylex.Error("conflicting result size options")
+				return 1
+			}
+			yyDollar[1].selectOpts.ResultSize = AST_SQL_BIG_RESULT
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 477:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2975
+		{
+			yyDollar[1].selectOpts.BufferResult = true
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 478:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2980
+		{
+			if yyDollar[1].selectOpts.Cache != "" {
+				yylex.Error("conflicting cache options")
+				return 1
+			}
+			yyDollar[1].selectOpts.Cache = AST_SQL_CACHE
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 479:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2989
+		{
+			if yyDollar[1].selectOpts.Cache != "" {
+				yylex.Error("conflicting cache options")
+				return 1
+			}
+			yyDollar[1].selectOpts.Cache = AST_SQL_NO_CACHE
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 480:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2998
+		{
+			yyDollar[1].selectOpts.CalcFoundRows = true
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 481:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3003
+		{
+			yyDollar[1].selectOpts.MaxStatementTime = NumVal(yyDollar[4].str)
+			yyVAL.selectOpts = yyDollar[1].selectOpts
+		}
+	case 482:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3010
+		{
+			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
+		}
+	case 483:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3014
+		{
+			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
+		}
+	case 484:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3020
+		{
+			yyVAL.selectExpr = &StarExpr{}
+		}
+	case 485:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3024
+		{
+			yyVAL.selectExpr = NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: yyDollar[1].expr, As: yyDollar[2].colIdent})
+		}
+	case 486:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3028
+		{
+			yyVAL.selectExpr = &StarExpr{TableName: yyDollar[1].tableIdent}
+		}
+	case 487:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3034
+		{
+			yyVAL.expr = yyDollar[1].boolExpr
+		}
+	case 488:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3038
+		{
+			yyVAL.expr = yyDollar[1].valExpr
+		}
+	case 489:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3044
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 490:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3048
+		{
+			yyVAL.colIdent = yyDollar[1].colIdent
+		}
+	case 491:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3052
+		{
+			yyVAL.colIdent = yyDollar[2].colIdent
+		}
+	case 492:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3057
+		{
+			yyVAL.tableExprs = nil
+		}
+	case 493:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3061
+		{
+			yyVAL.tableExprs = yyDollar[2].tableExprs
+		}
+	case 494:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3067
+		{
+			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
+		}
+	case 495:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3071
+		{
+			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
+		}
+	case 496:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3077
+		{
+			yyVAL.tableExpr = NodeArena(yylex).aliasedTableExpr(AliasedTableExpr{Expr: yyDollar[1].smTableExpr, Partitions: yyDollar[2].partitions, SystemTime: yyDollar[3].systemTime, As: yyDollar[4].tableIdent, Hints: yyDollar[5].indexHints})
+		}
+	case 497:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:3081
+		{
+			yyVAL.tableExpr = NodeArena(yylex).aliasedTableExpr(AliasedTableExpr{Expr: yyDollar[1].smTableExpr, Partitions: yyDollar[2].partitions, SystemTime: yyDollar[3].systemTime, As: yyDollar[4].tableIdent, Columns: yyDollar[6].colIdents, Hints: yyDollar[8].indexHints})
+		}
+	case 498:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3085
+		{
+			yyVAL.tableExpr = NodeArena(yylex).aliasedTableExpr(AliasedTableExpr{Expr: yyDollar[2].subquery, As: yyDollar[3].tableIdent, Lateral: true})
+		}
+	case 499:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3089
+		{
+			yyVAL.tableExpr = NodeArena(yylex).aliasedTableExpr(AliasedTableExpr{Expr: yyDollar[2].subquery, As: yyDollar[3].tableIdent, Columns: yyDollar[5].colIdents, Lateral: true})
+		}
+	case 500:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3093
+		{
+			yyVAL.tableExpr = &ParenTableExpr{Expr: yyDollar[2].tableExpr}
+		}
+	case 501:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3103
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
+		}
+	case 502:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3107
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].boolExpr}
+		}
+	case 503:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:3111
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, Using: yyDollar[6].columns}
+		}
+	case 504:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3115
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: AST_NATURAL_JOIN, RightExpr: yyDollar[4].tableExpr}
+		}
+	case 505:
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:3119
+		{
+			yyVAL.tableExpr = &PivotTableExpr{Expr: yyDollar[1].tableExpr, Aggregates: yyDollar[4].selectExprs, For: yyDollar[6].colName, In: yyDollar[9].selectExprs, As: yyDollar[12].tableIdent}
+		}
+	case 506:
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line sql.y:3123
+		{
+			yyVAL.tableExpr = &UnpivotTableExpr{Expr: yyDollar[1].tableExpr, Value: yyDollar[4].colName, For: yyDollar[6].colName, In: yyDollar[9].selectExprs, As: yyDollar[12].tableIdent}
+		}
+	case 507:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3128
+		{
+			yyVAL.partitions = nil
+		}
+	case 508:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3132
+		{
+			yyVAL.partitions = Partitions(yyDollar[3].colIdents)
+		}
+	case 509:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3137
+		{
+			yyVAL.systemTime = nil
+		}
+	case 510:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3141
+		{
+			if !strings.EqualFold(yyDollar[3].str, "of") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+			yyVAL.systemTime = &SystemTime{Type: AST_SYSTEM_TIME_AS_OF, From: yyDollar[4].valExpr}
+		}
+	case 511:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3149
+		{
+			yyVAL.systemTime = &SystemTime{Type: AST_SYSTEM_TIME_FROM_TO, From: yyDollar[3].valExpr, To: yyDollar[5].valExpr}
+		}
+	case 512:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3153
+		{
+			yyVAL.systemTime = &SystemTime{Type: AST_SYSTEM_TIME_BETWEEN, From: yyDollar[3].valExpr, To: yyDollar[5].valExpr}
+		}
+	case 513:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3157
+		{
+			yyVAL.systemTime = &SystemTime{Type: AST_SYSTEM_TIME_ALL}
+		}
+	case 514:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3163
+		{
+			yyVAL.tableIdent = TableIdent{}
+		}
+	case 516:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3170
+		{
+			yyVAL.tableIdent = yyDollar[1].tableIdent
+		}
+	case 517:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3174
+		{
+			yyVAL.tableIdent = yyDollar[2].tableIdent
+		}
+	case 518:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3180
+		{
+			yyVAL.str = AST_JOIN
+		}
+	case 519:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3184
+		{
+			yyVAL.str = AST_STRAIGHT_JOIN
+		}
+	case 520:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3188
+		{
+			yyVAL.str = AST_LEFT_JOIN
+		}
+	case 521:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3192
+		{
+			yyVAL.str = AST_LEFT_JOIN
+		}
+	case 522:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3196
+		{
+			yyVAL.str = AST_RIGHT_JOIN
+		}
+	case 523:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3200
+		{
+			yyVAL.str = AST_RIGHT_JOIN
+		}
+	case 524:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3204
+		{
+			yyVAL.str = AST_JOIN
+		}
+	case 525:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3208
+		{
+			yyVAL.str = AST_CROSS_JOIN
+		}
+	case 526:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3214
+		{
+			yyVAL.smTableExpr = NodeArena(yylex).tableName(TableName{Name: yyDollar[1].tableIdent})
+		}
+	case 527:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3218
+		{
+			yyVAL.smTableExpr = NodeArena(yylex).tableName(TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent})
+		}
+	case 528:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3222
+		{
+			yyVAL.smTableExpr = yyDollar[1].subquery
+		}
+	case 529:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3226
+		{
+			yyVAL.smTableExpr = &FuncExpr{Name: NewColIdent(yyDollar[1].tableIdent.String())}
+		}
+	case 530:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3230
+		{
+			yyVAL.smTableExpr = &FuncExpr{Name: NewColIdent(yyDollar[1].tableIdent.String()), Exprs: yyDollar[3].selectExprs}
+		}
+	case 531:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line sql.y:3234
+		{
+			if !strings.EqualFold(yyDollar[6].str, "columns") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[6].str))
+				return 1
+			}
+			yyVAL.smTableExpr = &JSONTableExpr{Expr: yyDollar[3].valExpr, Path: yyDollar[5].strVal, Columns: yyDollar[8].jsonTableColumns}
+		}
+	case 532:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3244
+		{
+			yyVAL.jsonTableColumns = []*JSONTableColumn{yyDollar[1].jsonTableColumn}
+		}
+	case 533:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3248
+		{
+			yyVAL.jsonTableColumns = append(yyDollar[1].jsonTableColumns, yyDollar[3].jsonTableColumn)
+		}
+	case 534:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3258
+		{
+			if !strings.EqualFold(yyDollar[3].str, "ordinality") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+			yyVAL.jsonTableColumn = &JSONTableColumn{Kind: AST_FOR_ORDINALITY, Name: makeColIdent(yyDollar[1].str, yyDollar[1].quoted)}
+		}
+	case 535:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3266
+		{
+			if !strings.EqualFold(yyDollar[3].str, AST_PATH) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+			yyVAL.jsonTableColumn = yyDollar[5].jsonTableColumn
+			yyVAL.jsonTableColumn.Kind, yyVAL.jsonTableColumn.Name, yyVAL.jsonTableColumn.Type, yyVAL.jsonTableColumn.Path = AST_PATH, makeColIdent(yyDollar[1].str, yyDollar[1].quoted), yyDollar[2].columnType, yyDollar[4].strVal
+		}
+	case 536:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3275
+		{
+			if !strings.EqualFold(yyDollar[4].str, AST_PATH) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[4].str))
+				return 1
+			}
+			yyVAL.jsonTableColumn = &JSONTableColumn{Kind: AST_EXISTS_PATH, Name: makeColIdent(yyDollar[1].str, yyDollar[1].quoted), Type: yyDollar[2].columnType, Path: yyDollar[5].strVal}
+		}
+	case 537:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3283
+		{
+			if !strings.EqualFold(yyDollar[1].str, "nested") || !strings.EqualFold(yyDollar[3].str, "columns") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.jsonTableColumn = &JSONTableColumn{Kind: AST_NESTED_PATH, Path: yyDollar[2].strVal, Columns: yyDollar[5].jsonTableColumns}
+		}
+	case 538:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:3291
+		{
+			if !strings.EqualFold(yyDollar[1].str+" "+yyDollar[2].str, AST_NESTED_PATH) || !strings.EqualFold(yyDollar[4].str, "columns") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.jsonTableColumn = &JSONTableColumn{Kind: AST_NESTED_PATH, Path: yyDollar[3].strVal, Columns: yyDollar[6].jsonTableColumns}
+		}
+	case 539:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3300
+		{
+			yyVAL.jsonTableColumn = &JSONTableColumn{}
+		}
+	case 540:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3304
+		{
+			switch {
+			case strings.EqualFold(yyDollar[4].str, "empty") && yyDollar[1].jsonTableColumn.OnEmpty == nil && yyDollar[1].jsonTableColumn.OnError == nil:
+				yyDollar[1].jsonTableColumn.OnEmpty = yyDollar[2].jsonTableResponse
+			case strings.EqualFold(yyDollar[4].str, AST_ERROR) && yyDollar[1].jsonTableColumn.OnError == nil:
+				yyDollar[1].jsonTableColumn.OnError = yyDollar[2].jsonTableResponse
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[4].str))
+				return 1
+			}
+		}
+	case 541:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3318
+		{
+			yyVAL.jsonTableResponse = &JSONTableResponse{Type: AST_NULL}
+		}
+	case 542:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3322
+		{
+			if !strings.EqualFold(yyDollar[1].str, AST_ERROR) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.jsonTableResponse = &JSONTableResponse{Type: AST_ERROR}
+		}
+	case 543:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3330
+		{
+			yyVAL.jsonTableResponse = &JSONTableResponse{Type: AST_DEFAULT, Default: yyDollar[2].strVal}
+		}
+	case 544:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3336
+		{
+			yyVAL.tableName = NodeArena(yylex).tableName(TableName{Name: yyDollar[1].tableIdent})
+		}
+	case 545:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3340
+		{
+			yyVAL.tableName = NodeArena(yylex).tableName(TableName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].tableIdent})
+		}
+	case 546:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3345
+		{
+			yyVAL.indexHints = nil
+		}
+	case 547:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3349
+		{
+			yyVAL.indexHints = &IndexHints{Type: AST_USE, Indexes: yyDollar[4].colIdents}
+		}
+	case 548:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3353
+		{
+			yyVAL.indexHints = &IndexHints{Type: AST_IGNORE, Indexes: yyDollar[4].colIdents}
+		}
+	case 549:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3357
+		{
+			yyVAL.indexHints = &IndexHints{Type: AST_FORCE, Indexes: yyDollar[4].colIdents}
+		}
+	case 550:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3363
+		{
+			yyVAL.colIdents = []ColIdent{yyDollar[1].colIdent}
+		}
+	case 551:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3367
+		{
+			yyVAL.colIdents = append(yyDollar[1].colIdents, yyDollar[3].colIdent)
+		}
+	case 552:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3372
+		{
+			yyVAL.where = nil
+		}
+	case 553:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3376
+		{
+			yyVAL.where = NewWhere(AST_WHERE, yyDollar[2].boolExpr)
+		}
+	case 555:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3383
+		{
+			yyVAL.boolExpr = NodeArena(yylex).andExpr(AndExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr})
+		}
+	case 556:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3387
+		{
+			yyVAL.boolExpr = NodeArena(yylex).orExpr(OrExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr})
+		}
+	case 557:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3391
+		{
+			yyVAL.boolExpr = &NotExpr{Expr: yyDollar[2].boolExpr}
+		}
+	case 558:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3395
+		{
+			yyVAL.boolExpr = &ParenBoolExpr{Expr: yyDollar[2].boolExpr}
+		}
+	case 559:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3399
+		{
+			yyVAL.boolExpr = &IsExpr{Operator: "is " + yyDollar[3].str, Expr: yyDollar[1].boolExpr}
+		}
+	case 560:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3403
+		{
+			yyVAL.boolExpr = &IsExpr{Operator: "is not " + yyDollar[4].str, Expr: yyDollar[1].boolExpr}
+		}
+	case 561:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3409
+		{
+			yyVAL.boolExpr = yyDollar[1].matchExpr
+		}
+	case 562:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3413
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].matchExpr, Operator: yyDollar[2].str, Right: yyDollar[3].valExpr})
+		}
+	case 563:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3417
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: yyDollar[2].str, Right: yyDollar[3].valExpr})
+		}
+	case 564:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3421
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: yyDollar[2].str, Quantifier: AST_ALL, Right: yyDollar[4].subquery})
+		}
+	case 565:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3425
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: yyDollar[2].str, Quantifier: yyDollar[3].str, Right: yyDollar[4].subquery})
+		}
+	case 566:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3429
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_IN, Right: yyDollar[3].colTuple})
+		}
+	case 567:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3433
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_IN, Right: yyDollar[4].colTuple})
+		}
+	case 568:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3437
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_LIKE, Right: yyDollar[3].valExpr, Escape: yyDollar[4].valExpr})
+		}
+	case 569:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3441
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_LIKE, Right: yyDollar[4].valExpr, Escape: yyDollar[5].valExpr})
+		}
+	case 570:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3445
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_REGEXP, Right: yyDollar[3].valExpr})
+		}
+	case 571:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3449
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_REGEXP, Right: yyDollar[4].valExpr})
+		}
+	case 572:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3453
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_REGEXP, Right: yyDollar[3].valExpr})
+		}
+	case 573:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3457
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_SOUNDS_LIKE, Right: yyDollar[3].valExpr})
+		}
+	case 574:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3461
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_ILIKE, Right: yyDollar[3].valExpr})
+		}
+	case 575:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3465
+		{
+			yyVAL.boolExpr = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_ILIKE, Right: yyDollar[4].valExpr})
+		}
+	case 576:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3469
+		{
+			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_BETWEEN, From: yyDollar[3].valExpr, To: yyDollar[5].valExpr}
+		}
+	case 577:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3473
+		{
+			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_NOT_BETWEEN, From: yyDollar[4].valExpr, To: yyDollar[6].valExpr}
+		}
+	case 578:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3477
+		{
+			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NULL, Expr: yyDollar[1].valExpr}
+		}
+	case 579:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3481
+		{
+			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NOT_NULL, Expr: yyDollar[1].valExpr}
+		}
+	case 580:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3485
+		{
+			yyVAL.boolExpr = &IsExpr{Operator: "is " + yyDollar[3].str, Expr: yyDollar[1].valExpr}
+		}
+	case 581:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3489
+		{
+			yyVAL.boolExpr = &IsExpr{Operator: "is not " + yyDollar[4].str, Expr: yyDollar[1].valExpr}
+		}
+	case 582:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3493
+		{
+			yyVAL.boolExpr = &ExistsExpr{Subquery: yyDollar[2].subquery}
+		}
+	case 583:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3499
+		{
+			yyVAL.str = AST_EQ
+		}
+	case 584:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3503
+		{
+			yyVAL.str = AST_LT
+		}
+	case 585:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3507
+		{
+			yyVAL.str = AST_GT
+		}
+	case 586:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3511
+		{
+			yyVAL.str = AST_LE
+		}
+	case 587:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3515
+		{
+			yyVAL.str = AST_GE
+		}
+	case 588:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3519
+		{
+			yyVAL.str = AST_NE
+		}
+	case 589:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3523
+		{
+			yyVAL.str = AST_NSE
+		}
+	case 590:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3529
+		{
+			yyVAL.colTuple = ValTuple(yyDollar[2].valExprs)
+		}
+	case 591:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3533
+		{
+			yyVAL.colTuple = yyDollar[1].subquery
+		}
+	case 592:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3537
+		{
+			yyVAL.colTuple = ListArg(yyDollar[1].str)
+		}
+	case 593:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3543
+		{
+			yyVAL.subquery = &Subquery{yyDollar[2].selStmt}
+		}
+	case 594:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3549
+		{
+			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
+		}
+	case 595:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3553
+		{
+			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
+		}
+	case 596:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3559
+		{
+			yyVAL.valExpr = &StarExpr{}
+		}
+	case 597:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3563
+		{
+			yyVAL.valExpr = yyDollar[1].valExpr
+		}
+	case 598:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3567
+		{
+			yyVAL.valExpr = NextValColumn(yylex, yyDollar[1].colName)
+		}
+	case 599:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3571
+		{
+			yyVAL.valExpr = yyDollar[1].userVar
+		}
+	case 600:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3575
+		{
+			yyVAL.valExpr = &AssignExpr{Var: yyDollar[1].userVar, Expr: yyDollar[3].valExpr}
+		}
+	case 601:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3579
+		{
+			yyVAL.valExpr = &NextValExpr{Sequence: yyDollar[2].tableName}
+		}
+	case 602:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3583
+		{
+			yyVAL.valExpr = &ArrayExpr{}
+		}
+	case 603:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3587
+		{
+			yyVAL.valExpr = &ArrayExpr{Elems: yyDollar[3].valExprs}
+		}
+	case 604:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3591
+		{
+			yyVAL.valExpr = &StructExpr{Fields: yyDollar[3].selectExprs}
+		}
+	case 605:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3595
+		{
+			yyVAL.valExpr = &CastExpr{Expr: yyDollar[1].valExpr, Type: yyDollar[3].columnType}
+		}
+	case 606:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3599
+		{
+			yyVAL.valExpr = &JSONExtractExpr{Expr: yyDollar[1].valExpr, Path: yyDollar[3].strVal}
+		}
+	case 607:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3603
+		{
+			yyVAL.valExpr = &JSONExtractExpr{Expr: yyDollar[1].valExpr, Path: yyDollar[3].strVal, Unquote: true}
+		}
+	case 608:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3607
+		{
+			if !yylex.(*Tokenizer).opts.Dialect.hasArrays() {
+				yylex.Error("subscripts are not supported in this dialect")
+				return 1
+			}
+			yyVAL.valExpr = &SubscriptExpr{Expr: yyDollar[1].valExpr, Index: yyDollar[3].valExpr}
+		}
+	case 609:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3615
+		{
+			if tuple, ok := yyDollar[1].rowTuple.(ValTuple); ok && len(tuple) == 1 {
+				yyVAL.valExpr = &ParenExpr{Expr: tuple[0]}
+			} else {
+				yyVAL.valExpr = yyDollar[1].rowTuple
+			}
+		}
+	case 610:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3623
+		{
+			yyVAL.valExpr = NodeArena(yylex).binaryExpr(BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITAND, Right: yyDollar[3].valExpr})
+		}
+	case 611:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3627
+		{
+			yyVAL.valExpr = NodeArena(yylex).binaryExpr(BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITOR, Right: yyDollar[3].valExpr})
+		}
+	case 612:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3631
+		{
+			yyVAL.valExpr = NodeArena(yylex).binaryExpr(BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITXOR, Right: yyDollar[3].valExpr})
+		}
+	case 613:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3635
+		{
+			yyVAL.valExpr = NodeArena(yylex).binaryExpr(BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_PLUS, Right: yyDollar[3].valExpr})
+		}
+	case 614:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3639
+		{
+			yyVAL.valExpr = NodeArena(yylex).binaryExpr(BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MINUS, Right: yyDollar[3].valExpr})
+		}
+	case 615:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3643
+		{
+			yyVAL.valExpr = NodeArena(yylex).binaryExpr(BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MULT, Right: yyDollar[3].valExpr})
+		}
+	case 616:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3647
+		{
+			yyVAL.valExpr = NodeArena(yylex).binaryExpr(BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_DIV, Right: yyDollar[3].valExpr})
+		}
+	case 617:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3651
+		{
+			yyVAL.valExpr = NodeArena(yylex).binaryExpr(BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MOD, Right: yyDollar[3].valExpr})
+		}
+	case 618:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3655
+		{
+			yyVAL.valExpr = &IntroducerExpr{CharacterSet: strings.ToLower(yyDollar[1].str[1:]), Expr: yyDollar[2].valExpr}
+		}
+	case 619:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3659
+		{
+			yyVAL.valExpr = &CollateExpr{Expr: yyDollar[1].valExpr, Collation: yyDollar[3].colIdent.String()}
+		}
+	case 620:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3663
+		{
+			if num, ok := yyDollar[2].valExpr.(NumVal); ok {
+				switch yyDollar[1].byt {
+				case '-':
+					if strings.HasPrefix(string(num), "-") {
+						yyVAL.valExpr = num[1:]
+					} else {
+						yyVAL.valExpr = "-" + num
+					}
+				case '+':
+					yyVAL.valExpr = num
+				default:
+					yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
+				}
+			} else {
+				yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
+			}
+		}
+	case 621:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3682
+		{
+			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].colIdent, Over: yyDollar[4].windowSpec}
+		}
+	case 622:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3686
+		{
+			if seq := NextValFunc(yylex, yyDollar[1].colIdent, yyDollar[3].selectExprs); seq != nil && yyDollar[5].windowSpec == nil {
+				yyVAL.valExpr = seq
+			} else {
+				yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].colIdent, Exprs: yyDollar[3].selectExprs, Over: yyDollar[5].windowSpec}
+			}
+		}
+	case 623:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3694
+		{
+			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].colIdent, Distinct: true, Exprs: yyDollar[4].selectExprs, Over: yyDollar[6].windowSpec}
+		}
+	case 624:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3698
+		{
+			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].colIdent}
+		}
+	case 625:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3702
+		{
+			if values := ValuesFunc(yyDollar[1].colIdent, yyDollar[3].selectExprs); values != nil {
+				yyVAL.valExpr = values
+				break
+			}
+			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].colIdent, Exprs: yyDollar[3].selectExprs}
+		}
+	case 626:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3710
+		{
+			yyVAL.valExpr = &IntervalExpr{Expr: yyDollar[2].valExpr, Unit: yyDollar[3].str}
+		}
+	case 627:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3714
+		{
+			yyVAL.valExpr = &ConvertExpr{Name: AST_CAST, Expr: yyDollar[3].valExpr, Type: yyDollar[5].convertType}
+		}
+	case 628:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3718
+		{
+			yyVAL.valExpr = &ConvertExpr{Name: AST_CONVERT, Expr: yyDollar[3].valExpr, Type: yyDollar[5].convertType}
+		}
+	case 629:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3722
+		{
+			yyVAL.valExpr = &ConvertUsingExpr{Expr: yyDollar[3].valExpr, Charset: yyDollar[5].colIdent.String()}
+		}
+	case 630:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3726
+		{
+			yyVAL.valExpr = yyDollar[1].caseExpr
+		}
+	case 631:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3731
+		{
+			yyVAL.windowSpec = nil
+		}
+	case 632:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3735
+		{
+			yyVAL.windowSpec = yyDollar[2].windowSpec
+		}
+	case 633:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3739
+		{
+			yyVAL.windowSpec = &WindowSpec{Name: yyDollar[2].colIdent}
+		}
+	case 634:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:3745
+		{
+			yyVAL.windowSpec = &WindowSpec{Name: yyDollar[2].colIdent, PartitionBy: yyDollar[3].valExprs, OrderBy: yyDollar[4].orderBy, Frame: yyDollar[5].windowFrame}
+		}
+	case 635:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3750
+		{
+			yyVAL.colIdent = ColIdent{}
+		}
+	case 636:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3754
+		{
+			yyVAL.colIdent = yyDollar[1].colIdent
+		}
+	case 637:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3759
+		{
+			yyVAL.valExprs = nil
+		}
+	case 638:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3763
+		{
+			yyVAL.valExprs = yyDollar[3].valExprs
+		}
+	case 639:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3768
+		{
+			yyVAL.windowFrame = nil
+		}
+	case 640:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3772
+		{
+			yyVAL.windowFrame = &WindowFrame{Unit: yyDollar[1].str, Start: yyDollar[2].frameBound}
+		}
+	case 641:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3776
+		{
+			yyVAL.windowFrame = &WindowFrame{Unit: yyDollar[1].str, Start: yyDollar[3].frameBound, End: yyDollar[5].frameBound}
+		}
+	case 642:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3782
+		{
+			yyVAL.str = AST_ROWS
+		}
+	case 643:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3786
+		{
+			yyVAL.str = AST_RANGE
+		}
+	case 644:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3792
+		{
+			typ := strings.ToLower(yyDollar[1].str + " " + yyDollar[2].str)
+			switch typ {
+			case AST_UNBOUNDED_PRECEDING, AST_CURRENT_ROW, AST_UNBOUNDED_FOLLOWING:
+			default:
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.frameBound = &FrameBound{Type: typ}
+		}
+	case 645:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3803
+		{
+			typ := strings.ToLower(yyDollar[2].str)
+			if typ != AST_PRECEDING && typ != AST_FOLLOWING {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.frameBound = &FrameBound{Type: typ, Expr: yyDollar[1].valExpr}
+		}
+	case 646:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3814
+		{
+			yyVAL.valExpr = NumVal(yyDollar[1].str)
+		}
+	case 647:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3818
+		{
+			yyVAL.valExpr = ValArg(yyDollar[1].str)
+		}
+	case 648:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3822
+		{
+			yyVAL.valExpr = &IntervalExpr{Expr: yyDollar[2].valExpr, Unit: yyDollar[3].str}
+		}
+	case 649:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3827
+		{
+			yyVAL.namedWindows = nil
+		}
+	case 650:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3831
+		{
+			yyVAL.namedWindows = yyDollar[2].namedWindows
+		}
+	case 651:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3837
+		{
+			yyVAL.namedWindows = []*NamedWindow{yyDollar[1].namedWindow}
+		}
+	case 652:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3841
+		{
+			yyVAL.namedWindows = append(yyDollar[1].namedWindows, yyDollar[3].namedWindow)
+		}
+	case 653:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3847
+		{
+			yyVAL.namedWindow = &NamedWindow{Name: yyDollar[1].colIdent, Spec: yyDollar[3].windowSpec}
+		}
+	case 654:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3853
+		{
+			yyVAL.colIdent = NewColIdent(string(IF_BYTES))
+		}
+	case 655:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3861
+		{
+			yyVAL.colIdent = NewColIdent(string(VALUES_BYTES))
+		}
+	case 656:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3865
+		{
+			yyVAL.colIdent = NewColIdent(string(DATABASE_BYTES))
+		}
+	case 657:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3869
+		{
+			yyVAL.colIdent = NewColIdent(string(SCHEMA_BYTES))
+		}
+	case 658:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3875
+		{
+			unit := strings.ToLower(yyDollar[1].str)
+			if !intervalUnits[unit] {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = unit
+		}
+	case 659:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3884
+		{
+			yyVAL.str = AST_YEAR_UNIT
+		}
+	case 660:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3890
+		{
+			yyVAL.byt = AST_UPLUS
+		}
+	case 661:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3894
+		{
+			yyVAL.byt = AST_UMINUS
+		}
+	case 662:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3898
+		{
+			yyVAL.byt = AST_TILDA
+		}
+	case 663:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:3904
+		{
+			yyVAL.caseExpr = &CaseExpr{Expr: yyDollar[2].valExpr, Whens: yyDollar[3].whens, Else: yyDollar[4].valExpr}
+		}
+	case 664:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3909
+		{
+			yyVAL.valExpr = nil
+		}
+	case 665:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3913
+		{
+			yyVAL.valExpr = yyDollar[1].valExpr
+		}
+	case 666:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3919
+		{
+			yyVAL.whens = []*When{yyDollar[1].when}
+		}
+	case 667:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3923
+		{
+			yyVAL.whens = append(yyDollar[1].whens, yyDollar[2].when)
+		}
+	case 668:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3929
+		{
+			yyVAL.when = &When{Cond: yyDollar[2].boolExpr, Val: yyDollar[4].valExpr}
+		}
+	case 669:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3934
+		{
+			yyVAL.valExpr = nil
+		}
+	case 670:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:3938
+		{
+			yyVAL.valExpr = yyDollar[2].valExpr
+		}
+	case 671:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:3944
+		{
+			yyVAL.colName = NodeArena(yylex).colName(ColName{Name: yyDollar[1].colIdent})
+		}
+	case 672:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3948
+		{
+			yyVAL.colName = NodeArena(yylex).colName(ColName{Qualifier: yyDollar[1].tableIdent, Name: yyDollar[3].colIdent})
+		}
+	case 673:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line sql.y:3954
+		{
+			if !strings.EqualFold(yyDollar[5].str, "against") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[5].str))
+				return 1
+			}
+			yyVAL.matchExpr = &MatchExpr{Columns: yyDollar[3].columns, Expr: yyDollar[7].valExpr, Option: yyDollar[8].str}
+		}
+	case 674:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:3963
+		{
+			yyVAL.str = ""
+		}
+	case 675:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:3967
+		{
+			if !strings.EqualFold(yyDollar[3].str+" "+yyDollar[4].str, "language mode") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+			yyVAL.str = AST_NATURAL_LANGUAGE_MODE
+		}
+	case 676:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:3975
+		{
+			if !strings.EqualFold(yyDollar[3].str+" "+yyDollar[4].str, "language mode") || !strings.EqualFold(yyDollar[6].str+" "+yyDollar[7].str, "query expansion") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[3].str))
+				return 1
+			}
+			yyVAL.str = AST_NATURAL_LANGUAGE_MODE_WITH_QUERY_EXPANSION
+		}
+	case 677:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3983
+		{
+			if !strings.EqualFold(yyDollar[2].str+" "+yyDollar[3].str, "boolean mode") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.str = AST_BOOLEAN_MODE
+		}
+	case 678:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:3991
+		{
+			if !strings.EqualFold(yyDollar[2].str+" "+yyDollar[3].str, "query expansion") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.str = AST_QUERY_EXPANSION
+		}
+	case 679:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4000
+		{
+			yyVAL.valExpr = nil
+		}
+	case 680:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4004
+		{
+			yyVAL.valExpr = yyDollar[2].valExpr
+		}
+	case 681:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4010
+		{
+			yyVAL.str = AST_TRUE
+		}
+	case 682:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4014
+		{
+			yyVAL.str = AST_FALSE
+		}
+	case 683:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4018
+		{
+			if !strings.EqualFold(yyDollar[1].str, AST_UNKNOWN) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = AST_UNKNOWN
+		}
+	case 684:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4028
+		{
+			yyVAL.valExpr = yyDollar[1].strVal
+		}
+	case 685:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4032
+		{
+			yyVAL.valExpr = NumVal(yyDollar[1].str)
+		}
+	case 686:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4036
+		{
+			yyVAL.valExpr = HexVal(yyDollar[1].str)
+		}
+	case 687:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4040
+		{
+			yyVAL.valExpr = BitVal(yyDollar[1].str)
+		}
+	case 688:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4044
+		{
+			yyVAL.valExpr = ValArg(yyDollar[1].str)
+		}
+	case 689:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4048
+		{
+			yyVAL.valExpr = &NullVal{}
+		}
+	case 690:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4052
+		{
+			yyVAL.valExpr = BoolVal(true)
+		}
+	case 691:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4056
+		{
+			yyVAL.valExpr = BoolVal(false)
+		}
+	case 692:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4062
+		{
+			yyVAL.selectOpts = nil
+		}
+	case 693:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4066
+		{
+			yyVAL.selectOpts = &Select{GroupBy: yyDollar[3].selectExprs}
+		}
+	case 694:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:4070
+		{
+			if !strings.EqualFold(yyDollar[5].str, "rollup") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[5].str))
+				return 1
+			}
+			yyVAL.selectOpts = &Select{GroupBy: yyDollar[3].selectExprs, WithRollup: true}
+		}
+	case 695:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4080
+		{
+			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
+		}
+	case 696:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4084
+		{
+			yyVAL.selectExprs = append(yyDollar[1].selectExprs, yyDollar[3].selectExpr)
+		}
+	case 697:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4090
+		{
+			yyVAL.selectExpr = NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: groupingFunc(yyDollar[1].expr)})
+		}
+	case 698:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4094
+		{
+			yyVAL.selectExpr = NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: &GroupingExpr{Type: AST_GROUPING_SETS, Exprs: yyDollar[3].valExprs}})
+		}
+	case 699:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4100
+		{
+			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
+		}
+	case 700:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4104
+		{
+			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
+		}
+	case 702:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4111
+		{
+			yyVAL.valExpr = ValTuple{}
+		}
+	case 703:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4117
+		{
+			yyVAL.where = nil
+		}
+	case 704:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4121
+		{
+			yyVAL.where = NewWhere(AST_HAVING, yyDollar[2].boolExpr)
+		}
+	case 705:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4126
+		{
+			yyVAL.where = nil
+		}
+	case 706:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4130
+		{
+			yyVAL.where = NewWhere(AST_QUALIFY, yyDollar[2].boolExpr)
+		}
+	case 707:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4135
+		{
+			yyVAL.orderBy = nil
+		}
+	case 709:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4142
+		{
+			yyVAL.orderBy = yyDollar[3].orderBy
+		}
+	case 710:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4148
+		{
+			yyVAL.orderBy = OrderBy{yyDollar[1].order}
+		}
+	case 711:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4152
+		{
+			yyVAL.orderBy = append(yyDollar[1].orderBy, yyDollar[3].order)
+		}
+	case 712:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4158
+		{
+			yyVAL.order = &Order{Expr: yyDollar[1].valExpr, Direction: yyDollar[2].str}
+		}
+	case 713:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4162
+		{
+			yyVAL.order = &Order{Expr: yyDollar[1].matchExpr, Direction: yyDollar[2].str}
+		}
+	case 714:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4167
+		{
+			yyVAL.str = AST_ASC
+		}
+	case 715:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4171
+		{
+			yyVAL.str = AST_ASC
+		}
+	case 716:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4175
+		{
+			yyVAL.str = AST_DESC
+		}
+	case 717:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4180
+		{
+			yyVAL.timerange = nil
+		}
+	case 718:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4184
+		{
+			yyVAL.timerange = &TimeRange{From: yyDollar[2].valExpr}
+		}
+	case 719:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4188
+		{
+			yyVAL.timerange = &TimeRange{From: yyDollar[2].valExpr, To: yyDollar[4].valExpr}
+		}
+	case 720:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4193
+		{
+			yyVAL.clauses = nil
+		}
+	case 721:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4197
+		{
+			if err := yylex.(*Tokenizer).opts.checkClauses(yyDollar[1].clauses); err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			yyVAL.clauses = yyDollar[1].clauses
+		}
+	case 722:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4207
+		{
+			clauses, err := yylex.(*Tokenizer).opts.addClausePart(nil, yyDollar[1].str, yyDollar[2].valExpr)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			yyVAL.clauses = clauses
+		}
+	case 723:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4216
+		{
+			clauses, err := yylex.(*Tokenizer).opts.addClausePart(yyDollar[1].clauses, yyDollar[2].str, yyDollar[3].valExpr)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			yyVAL.clauses = clauses
+		}
+	case 724:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4226
+		{
+			yyVAL.limit = nil
+		}
+	case 726:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4233
+		{
+			yyVAL.limit = &Limit{Rowcount: yyDollar[2].valExpr}
+		}
+	case 727:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4237
+		{
+			yyVAL.limit = &Limit{Offset: yyDollar[2].valExpr, Rowcount: yyDollar[4].valExpr}
+		}
+	case 728:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4241
+		{
+			if !strings.EqualFold(yyDollar[3].str, AST_OFFSET) {
+				yylex.Error("expecting offset")
+				return 1
+			}
+			yyVAL.limit = &Limit{Offset: yyDollar[4].valExpr, Rowcount: yyDollar[2].valExpr}
+		}
+	case 729:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4250
+		{
+			yyVAL.str = ""
+		}
+	case 731:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4257
+		{
+			yyVAL.str = AST_FOR_UPDATE + yyDollar[3].str
+		}
+	case 732:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4261
+		{
+			if !strings.EqualFold(yyDollar[2].str, string(SHARE)) {
+				yylex.Error("expecting share")
+				return 1
+			}
+			if yyDollar[3].str == "" {
+				yyVAL.str = AST_SHARE_MODE
+			} else {
+				yyVAL.str = AST_FOR_SHARE + yyDollar[3].str
+			}
+		}
+	case 733:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4273
+		{
+			if !yyDollar[3].colIdent.EqualString(string(SHARE)) {
+				yylex.Error("expecting share")
+				return 1
+			}
+			if !yyDollar[4].colIdent.EqualString(string(MODE)) {
+				yylex.Error("expecting mode")
+				return 1
+			}
+			yyVAL.str = AST_SHARE_MODE
+		}
+	case 734:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4286
+		{
+			yyVAL.str = ""
+		}
+	case 735:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4290
+		{
+			if !strings.EqualFold(yyDollar[1].str, "nowait") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = AST_NOWAIT
+		}
+	case 736:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4298
+		{
+			if !strings.EqualFold(yyDollar[1].str, "skip") || !strings.EqualFold(yyDollar[2].str, "locked") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.str = AST_SKIP_LOCKED
+		}
+	case 737:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4308
+		{
+			yyVAL.columns = Columns{NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: yyDollar[1].colName})}
+		}
+	case 738:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4312
+		{
+			yyVAL.columns = append(yyVAL.columns, NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: yyDollar[3].colName}))
+		}
+	case 739:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4317
+		{
+			yyVAL.rowAlias = nil
+		}
+	case 740:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4321
+		{
+			yyVAL.rowAlias = &RowAlias{Name: yyDollar[2].tableIdent}
+		}
+	case 741:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:4325
+		{
+			yyVAL.rowAlias = &RowAlias{Name: yyDollar[2].tableIdent, Columns: yyDollar[4].columns}
+		}
+	case 742:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4330
+		{
+			yyVAL.updateExprs = nil
+		}
+	case 743:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:4334
+		{
+			yyVAL.updateExprs = yyDollar[5].updateExprs
+		}
+	case 744:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4344
+		{
+			yyVAL.insRows = insertRows(yyDollar[1].selStmt)
+		}
+	case 745:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4354
+		{
+			rows, err := appendRow(yylex, yyDollar[0].boolean, nil, yyDollar[1].rowTuple)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			yyVAL.values = rows
+		}
+	case 746:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4363
+		{
+			rows, err := appendRow(yylex, yyDollar[0].boolean, yyDollar[1].values, yyDollar[3].rowTuple)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			yyVAL.values = rows
+		}
+	case 747:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4374
+		{
+			yyVAL.rowTuple = ValTuple(yyDollar[2].valExprs)
+		}
+	case 748:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4378
+		{
+			yyVAL.rowTuple = yyDollar[1].subquery
+		}
+	case 749:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4384
+		{
+			yyVAL.rowTuple = yyDollar[1].rowTuple
+		}
+	case 750:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4388
+		{
+			if !strings.EqualFold(yyDollar[1].str, "row") {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[1].str))
+				return 1
+			}
+			yyVAL.rowTuple = ValTuple(yyDollar[3].valExprs)
+		}
+	case 751:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4398
+		{
+			yyVAL.updateExprs = UpdateExprs{yyDollar[1].updateExpr}
+		}
+	case 752:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4402
+		{
+			yyVAL.updateExprs = append(yyDollar[1].updateExprs, yyDollar[3].updateExpr)
+		}
+	case 753:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4408
+		{
+			yyVAL.updateExpr = &UpdateExpr{Name: yyDollar[1].colName, Expr: yyDollar[3].valExpr}
+		}
+	case 754:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4414
+		{
+			yyVAL.setExprs = SetExprs{yyDollar[1].setExpr}
+		}
+	case 755:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4418
+		{
+			yyVAL.setExprs = append(yyDollar[1].setExprs, yyDollar[3].setExpr)
+		}
+	case 756:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4424
+		{
+			setExpr, err := newSetExpr(yyDollar[1].str, yyDollar[2].colName, yyDollar[3].str, yyDollar[4].valExpr)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+			yyVAL.setExpr = setExpr
+		}
+	case 757:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4433
+		{
+			yyVAL.setExpr = &SetExpr{Kind: AST_USER_VAR, Name: yyDollar[1].userVar.Name, Operator: yyDollar[2].str, Expr: yyDollar[3].valExpr}
+		}
+	case 758:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4437
+		{
+			if !strings.EqualFold(yyDollar[2].str, AST_NAMES) {
+				yylex.Error(fmt.Sprintf("syntax error near %s", yyDollar[2].str))
+				return 1
+			}
+			if yyDollar[1].str != "" {
+				yylex.Error(fmt.Sprintf("scope cannot be applied to %s", yyDollar[2].str))
+				return 1
+			}
+			yyVAL.setExpr = &SetExpr{Kind: AST_NAMES, Charset: yyDollar[3].str, Collation: yyDollar[4].str}
+		}
+	case 759:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:4449
+		{
+			if yyDollar[1].str != "" {
+				yylex.Error("scope cannot be applied to character set")
+				return 1
+			}
+			yyVAL.setExpr = &SetExpr{Kind: AST_CHARACTER_SET, Charset: yyDollar[4].str}
+		}
+	case 760:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4457
+		{
+			if yyDollar[1].str != "" {
+				yylex.Error("scope cannot be applied to character set")
+				return 1
+			}
+			yyVAL.setExpr = &SetExpr{Kind: AST_CHARACTER_SET, Charset: yyDollar[3].str}
+		}
+	case 761:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4467
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 762:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4471
+		{
+			yyVAL.str = yyDollar[1].strVal.Val
+		}
+	case 763:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4475
+		{
+			yyVAL.str = AST_DEFAULT
+		}
+	case 764:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4481
+		{
+			yyVAL.userVar = &UserVar{Name: makeColIdent(yyDollar[1].str, yyDollar[1].quoted)}
+		}
+	case 765:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4486
+		{
+			yyVAL.str = ""
+		}
+	case 766:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4490
+		{
+			yyVAL.str = AST_GLOBAL
+		}
+	case 767:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4494
+		{
+			yyVAL.str = AST_SESSION
+		}
+	case 768:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4498
+		{
+			yyVAL.str = AST_LOCAL
+		}
+	case 769:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4504
+		{
+			yyVAL.str = AST_EQ
+		}
+	case 770:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4508
+		{
+			yyVAL.str = AST_ASSIGN
+		}
+	case 771:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4513
+		{
+			yyVAL.strs = nil
+		}
+	case 772:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4517
+		{
+			yyVAL.strs = append(yyDollar[1].strs, strings.ToLower(yyDollar[2].str))
+		}
+	case 773:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4521
+		{
+			yyVAL.strs = append(yyDollar[1].strs, "by")
+		}
+	case 774:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4525
+		{
+			yyVAL.strs = append(yyDollar[1].strs, "with")
+		}
+	case 775:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4529
+		{
+			yyVAL.strs = append(yyDollar[1].strs, "=")
+		}
+	case 776:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4533
+		{
+			yyVAL.strs = append(yyDollar[1].strs, yyDollar[2].str)
+		}
+	case 777:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4537
+		{
+			yyVAL.strs = append(yyDollar[1].strs, "-"+yyDollar[3].str)
+		}
+	case 778:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4542
+		{
+			yyVAL.boolean = false
+		}
+	case 779:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:4544
+		{
+			yyVAL.boolean = true
+		}
+	case 780:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4547
+		{
+			yyVAL.boolean = false
+		}
+	case 781:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4549
+		{
+			yyVAL.boolean = true
+		}
+	case 782:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4552
+		{
+			yyVAL.boolean = false
+		}
+	case 783:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4554
+		{
+			yyVAL.boolean = true
+		}
+	case 784:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4558
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 785:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4560
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 786:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4562
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 787:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4564
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 788:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4567
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 789:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4569
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 790:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4571
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 791:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4574
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 792:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4576
+		{
+			yyVAL.empty = struct{}{}
+		}
+	case 793:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:4579
+		{
+			yyVAL.boolean = false
+		}
+	case 794:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4581
+		{
+			yyVAL.boolean = true
+		}
+	case 797:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4589
+		{
+			yyVAL.colIdent = makeColIdent(yyDollar[1].str, yyDollar[1].quoted)
+		}
+	case 798:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4595
+		{
+			yyVAL.tableIdent = makeTableIdent(yyDollar[1].str, yyDollar[1].quoted)
+		}
+	case 799:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:4601
+		{
+			yyVAL.tableIdents = []TableIdent{yyDollar[1].tableIdent}
+		}
+	case 800:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:4605
+		{
+			yyVAL.tableIdents = append(yyDollar[1].tableIdents, yyDollar[3].tableIdent)
+		}
+	case 801:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1310
+//line sql.y:4610
 		{
 			ForceEOF(yylex)
 		}
 	}
+	recordSpan(yylex, &yyVAL, yyS, yyp, yypt)
 	goto yystack /* stack new state and value */
 }
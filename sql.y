@@ -5,7 +5,10 @@
 %{
 package sqlparser
 
-import "bytes"
+import (
+  "fmt"
+  "strings"
+)
 
 func SetParseTree(yylex interface{}, stmt Statement) {
   yylex.(*Tokenizer).ParseTree = stmt
@@ -19,11 +22,96 @@ func ForceEOF(yylex interface{}) {
   yylex.(*Tokenizer).ForceEOF = true
 }
 
+// NodeArena returns the Arena used by the parse, if any.
+func NodeArena(yylex interface{}) *Arena {
+  return yylex.(*Tokenizer).arena
+}
+
+// NextValFunc returns a NextValExpr for NEXTVAL(seq) if
+// the dialect supports it, or nil otherwise.
+func NextValFunc(yylex interface{}, name ColIdent, exprs SelectExprs) *NextValExpr {
+  if yylex.(*Tokenizer).opts.Dialect != MariaDB || !name.EqualString("nextval") || len(exprs) != 1 {
+    return nil
+  }
+  expr, ok := exprs[0].(*NonStarExpr)
+  if !ok || !expr.As.IsEmpty() {
+    return nil
+  }
+  col, ok := expr.Expr.(*ColName)
+  if !ok {
+    return nil
+  }
+  return &NextValExpr{Sequence: &TableName{Qualifier: col.Qualifier, Name: makeTableIdent(col.Name.val, col.Name.quoted)}}
+}
+
+// ValuesFunc returns a ValuesFuncExpr for VALUES(col), or nil
+// if name and exprs are not such a call.
+func ValuesFunc(name ColIdent, exprs SelectExprs) *ValuesFuncExpr {
+  if !name.EqualString("values") || len(exprs) != 1 {
+    return nil
+  }
+  expr, ok := exprs[0].(*NonStarExpr)
+  if !ok || !expr.As.IsEmpty() {
+    return nil
+  }
+  col, ok := expr.Expr.(*ColName)
+  if !ok {
+    return nil
+  }
+  return &ValuesFuncExpr{Name: col}
+}
+
+// NextValColumn returns a NextValExpr for seq.nextval if
+// the dialect supports it, or col otherwise.
+func NextValColumn(yylex interface{}, col *ColName) ValExpr {
+  if yylex.(*Tokenizer).opts.Dialect != MariaDB || col.Qualifier.IsEmpty() || !col.Name.EqualString("nextval") {
+    return col
+  }
+  return &NextValExpr{Sequence: &TableName{Name: col.Qualifier}}
+}
+
+// NewSequence builds a sequence statement. kind is the word
+// following the action, which must be SEQUENCE.
+func NewSequence(action, kind string, name *TableName, items []string) (*Sequence, error) {
+  if !strings.EqualFold(kind, "sequence") {
+    return nil, fmt.Errorf("syntax error near %s", kind)
+  }
+  options, err := newSequenceOptions(items)
+  if err != nil {
+    return nil, err
+  }
+  return &Sequence{Action: action, Name: name, Options: options}, nil
+}
+
+// AppendRow adds row to rows, unless the tokenizer has a
+// RowHandler, in which case the row is handed to it instead.
+func AppendRow(yylex interface{}, rows Values, row RowTuple) (Values, error) {
+  return appendRow(yylex, true, rows, row)
+}
+
+// appendRow is AppendRow, except that row is only handed to the
+// RowHandler if stream is set, as it is for the rows of the VALUES
+// of the statement itself rather than of a subquery.
+func appendRow(yylex interface{}, stream bool, rows Values, row RowTuple) (Values, error) {
+  tkn := yylex.(*Tokenizer)
+  if tkn.RowHandler == nil || !stream {
+    return append(rows, row), nil
+  }
+  if err := tkn.RowHandler(row); err != nil {
+    tkn.rowErr = err
+    return nil, err
+  }
+  tkn.streamedRows = true
+  return rows, nil
+}
+
 var (
   SHARE =        []byte("share")
   MODE  =        []byte("mode")
   IF_BYTES =     []byte("if")
   VALUES_BYTES = []byte("values")
+  DATABASE_BYTES = []byte("database")
+  SCHEMA_BYTES =   []byte("schema")
 )
 
 %}
@@ -33,9 +121,30 @@ var (
   statement   Statement
   selStmt     SelectStatement
   byt         byte
-  bytes       []byte
-  bytes2      [][]byte
   str         string
+  strs        []string
+  quoted      bool
+  strVal      StrVal
+  colIdent    ColIdent
+  colIdents   []ColIdent
+  partitions  Partitions
+  selectOpts  *Select
+  union       *Union
+  statements  Statements
+  elseIfs     []*ElseIf
+  handlerConds []*HandlerCondition
+  handlerCond *HandlerCondition
+  tableIdent  TableIdent
+  tableIdents []TableIdent
+  tableNames  []*TableName
+  renames     []*TableRename
+  privilege   *Privilege
+  privileges  []*Privilege
+  grantObject *GrantObject
+  account     *Account
+  accounts    []*Account
+  userSpec    *UserSpec
+  userSpecs   []*UserSpec
   selectExprs SelectExprs
   selectExpr  SelectExpr
   columns     Columns
@@ -58,73 +167,195 @@ var (
   when        *When
   orderBy     OrderBy
   order       *Order
+  where       *Where
+  with        *With
+  ctes        []*CommonTableExpr
+  cte         *CommonTableExpr
+  windowSpec  *WindowSpec
+  windowFrame *WindowFrame
+  frameBound  *FrameBound
+  namedWindows []*NamedWindow
+  namedWindow *NamedWindow
+  alterSpecs  []*AlterSpec
+  alterSpec   *AlterSpec
   timerange   *TimeRange
+  clauses     Clauses
+  rowAlias    *RowAlias
+  systemTime  *SystemTime
   limit       *Limit
   insRows     InsertRows
   updateExprs UpdateExprs
   updateExpr  *UpdateExpr
+  setExprs    SetExprs
+  setExpr     *SetExpr
+  showFilter  *ShowFilter
+  jsonTableColumns []*JSONTableColumn
+  jsonTableColumn *JSONTableColumn
+  jsonTableResponse *JSONTableResponse
+  userVar     *UserVar
+  userVars    []*UserVar
+  loadFields  *LoadFields
+  loadLines   *LoadLines
 
 /*
 for CreateTable
 */
-  createTableStmt CreateTable
+  createTable *CreateTable
   columnDefinition *ColumnDefinition
-  columnDefinitions ColumnDefinitions
-  columnAtts ColumnAtts
+  columnType  ColumnType
+  convertType *ConvertType
+  matchExpr   *MatchExpr
+  numVal      NumVal
+  boolean     bool
+  indexDefinition *IndexDefinition
+  indexColumns []*IndexColumn
+  indexColumn *IndexColumn
+  references  *References
+  tableOptions TableOptions
+  tableOption *TableOption
+  signalItems []*SignalItem
+  signalItem  *SignalItem
+
+/*
+pos and end are the offsets of the first byte of a symbol
+and of the byte after it. Lex sets them for tokens, and
+recordSpan for the other symbols when spans are recorded,
+along with reduced, which tells the other symbols apart.
+*/
+  pos         int
+  end         int
+  reduced     bool
 }
 
 %token LEX_ERROR
 %token <empty> SELECT INSERT UPDATE DELETE FROM ASOF UNTIL WHERE GROUP HAVING ORDER BY LIMIT FOR
-%token <empty> ALL DISTINCT AS EXISTS IN IS LIKE BETWEEN NULL ASC DESC VALUES INTO DUPLICATE KEY DEFAULT SET LOCK
-%token <bytes> ID STRING NUMBER VALUE_ARG LIST_ARG COMMENT
+%token <empty> ALL DISTINCT AS EXISTS IN IS LIKE REGEXP SOUNDS_LIKE ESCAPE BETWEEN NULL TRUE FALSE ASC DESC VALUES INTO DUPLICATE KEY DEFAULT SET LOCK
+%token <str> ID NUMBER HEX BIT_LITERAL VALUE_ARG LIST_ARG COMMENT UNDERSCORE_CHARSET USER_VAR
+%token <strVal> STRING
 %token <empty> LE GE NE NULL_SAFE_EQUAL
-%token <empty> '(' '=' '<' '>' '~'
+%token <empty> GLOBAL SESSION LOCAL
+%token <empty> OVER WINDOW ROWS RANGE
+%token <empty> ADD CHANGE COLUMN MODIFY COMMENT_KEYWORD
+%token <empty> RECURSIVE INTERVAL CAST CONVERT MATCH GROUPING_SETS NEXT_VALUE_FOR NEXT FOR_SYSTEM_TIME PARTITION QUALIFY ARRAY STRUCT
+%token <empty> ILIKE RETURNING LATERAL JSON_TABLE NOT_REGEXP
+%token <str> WITH_CHECK_OPTION
+// ANY is ANY or SOME, whose lower-cased word it holds, when it
+// is followed by a subquery.
+%token <str> ANY
+%token <str> CLAUSE_KEYWORD
+%token <empty> GRANT REVOKE CREATE_USER ALTER_USER SET_PASSWORD
+%token <empty> SQL_CACHE SQL_NO_CACHE MAX_STATEMENT_TIME
+%token <empty> DISTINCTROW HIGH_PRIORITY SQL_SMALL_RESULT SQL_BIG_RESULT SQL_BUFFER_RESULT SQL_CALC_FOUND_ROWS
+%token <empty> LOW_PRIORITY DELAYED QUICK
+%token <empty> DECLARE CURSOR FETCH
+%token <empty> BEGIN ELSEIF WHILE LOOP REPEAT DO CONTINUE EXIT LEAVE ITERATE
+%token <empty> SQLEXCEPTION SQLWARNING SQLSTATE SIGNAL RESIGNAL
+%token <empty> '=' '<' '>' '~'
 
-%token <empty> PRIMARY
+%token <empty> PRIMARY CONSTRAINT DATABASE SCHEMA
 %token <empty> UNIQUE
-%left <empty> UNION MINUS EXCEPT INTERSECT
+// An alias after the rows of an INSERT ... SELECT belongs to the
+// SELECT, not to the rows: the empty aliases have NO_ALIAS, so
+// that AS is shifted.
+%nonassoc <empty> NO_ALIAS
+%nonassoc AS
+%nonassoc <empty> WITH
+%left <empty> UNION MINUS EXCEPT
+%left <empty> INTERSECT
 %left <empty> ','
-%left <empty> JOIN STRAIGHT_JOIN LEFT RIGHT INNER OUTER CROSS NATURAL USE FORCE
-%left <empty> ON
+%left <empty> CONDITIONLESS_JOIN
+%left <empty> JOIN STRAIGHT_JOIN LEFT RIGHT INNER OUTER CROSS NATURAL USE FORCE PIVOT UNPIVOT
+%left <empty> ON USING
+%right <empty> ASSIGN
 %left <empty> OR
 %left <empty> AND
 %right <empty> NOT
+%left IS
 %left <empty> '&' '|' '^'
 %left <empty> '+' '-'
 %left <empty> '*' '/' '%'
 %nonassoc <empty> '.'
 %left <empty> UNARY
+%left <empty> COLLATE
+%left <empty> '['
+%left <empty> TYPECAST
+%left <empty> JSON_EXTRACT_OP JSON_UNQUOTE_EXTRACT_OP
 %right <empty> CASE WHEN THEN ELSE
 %left <empty> END
+%nonassoc <empty> '('
+%nonassoc <empty> VALUES_FUNC
 
 // DDL Tokens
 %token <empty> CREATE ALTER DROP RENAME ANALYZE
-%token <empty> TABLE INDEX VIEW TO IGNORE IF USING
+%token <empty> TABLE INDEX VIEW TO IGNORE IF
 %token <empty> SHOW DESCRIBE EXPLAIN
+%token <empty> LOAD INFILE LINES STARTING TERMINATED OPTIONALLY ENCLOSED ESCAPED
 
 %start any_command
 
 %type <statement> command
-%type <selStmt> select_statement
-%type <statement> insert_statement update_statement delete_statement set_statement
-%type <statement> create_statement alter_statement rename_statement drop_statement
-%type <statement> analyze_statement other_statement
-%type <bytes2> comment_opt comment_list
-%type <str> union_op
-%type <str> distinct_opt
-%type <selectExprs> select_expression_list
-%type <selectExpr> select_expression
-%type <bytes> as_lower_opt as_opt
+%type <selStmt> select_statement paren_select
+%type <statement> insert_statement update_statement delete_statement load_statement call_statement set_statement
+%type <statement> create_statement alter_statement rename_statement truncate_statement drop_statement
+%type <statement> analyze_statement other_statement cursor_statement prepare_statement compound_statement signal_statement
+%type <statement> transaction_statement privilege_statement explainable_statement
+%type <strs> show_word_list
+%type <str> show_word explain_format_opt
+%type <showFilter> show_filter_opt
+%type <empty> from_or_in explain_verb
+%type <strs> transaction_characteristic_list
+%type <str> transaction_characteristic isolation_level
+%type <statements> statement_list else_statements_opt
+%type <elseIfs> elseif_list
+%type <handlerConds> handler_condition_list
+%type <handlerCond> handler_condition
+%type <colIdent> begin_label_opt end_label_opt
+%type <str> handler_action
+%type <valExpr> default_value_opt next_count
+%type <strs> comment_opt comment_list sequence_items
+%type <str> union_op intersect_op interval_unit is_value match_option
+%type <matchExpr> match_expression
+%type <with> with_clause
+%type <ctes> cte_list
+%type <cte> cte
+%type <boolean> recursive_opt
+%type <alterSpecs> alter_spec_list
+%type <alterSpec> alter_spec column_position_opt
+%type <empty> column_opt
+%type <windowSpec> over_opt window_spec
+%type <colIdent> window_name_opt
+%type <valExprs> partition_by_opt
+%type <windowFrame> frame_opt
+%type <str> frame_unit
+%type <frameBound> frame_bound
+%type <valExpr> frame_offset
+%type <namedWindows> window_opt named_window_list
+%type <namedWindow> named_window
+%type <selectOpts> select_options group_by_opt
+%type <union> paren_operand
+%type <selectExprs> select_expression_list group_by_list
+%type <selectExpr> select_expression group_by_element
+%type <valExprs> grouping_set_list
+%type <valExpr> grouping_set
+%type <colIdent> as_lower_opt
+%type <tableIdent> as_opt table_alias
+%type <jsonTableColumns> json_table_column_list
+%type <jsonTableColumn> json_table_column json_table_response_list
+%type <jsonTableResponse> json_table_response
 %type <expr> expression
-%type <tableExprs> table_expression_list
+%type <tableExprs> table_expression_list from_opt
 %type <tableExpr> table_expression
 %type <str> join_type
 %type <smTableExpr> simple_table_expression
-%type <tableName> dml_table_expression
+%type <tableName> dml_table_expression delete_target
+%type <tableNames> delete_target_list
 %type <indexHints> index_hint_list
-%type <bytes2> index_list
-%type <boolExpr> where_expression_opt
+%type <colIdents> sql_id_list view_columns_opt
+%type <where> where_expression_opt
 %type <timerange> timerange_opt
+%type <clauses> clauses_opt clause_list
+%type <systemTime> system_time_opt
+%type <partitions> partition_opt
 %type <boolExpr> boolean_expression condition
 %type <str> compare
 %type <insRows> row_list
@@ -132,28 +363,59 @@ for CreateTable
 %type <colTuple> col_tuple
 %type <valExprs> value_expression_list
 %type <values> tuple_list
-%type <rowTuple> row_tuple
-%type <bytes> keyword_as_func
+%type <rowTuple> row_tuple values_row
+%type <colIdent> keyword_as_func
 %type <subquery> subquery
 %type <byt> unary_operator
 %type <colName> column_name
 %type <caseExpr> case_expression
 %type <whens> when_expression_list
 %type <when> when_expression
-%type <valExpr> value_expression_opt else_expression_opt
-%type <selectExprs> group_by_opt
-%type <boolExpr> having_opt
-%type <orderBy> order_by_opt order_list
+%type <valExpr> value_expression_opt else_expression_opt like_escape_opt
+%type <selectExprs> returning_opt
+%type <where> having_opt qualify_opt
+%type <orderBy> order_by_opt order_by order_list
 %type <order> order
 %type <str> asc_desc_opt
-%type <limit> limit_opt
-%type <str> lock_opt
-%type <columns> column_list_opt column_list
+%type <limit> limit_opt limit
+%type <str> lock_opt lock lock_wait_opt
+%type <str> load_priority_opt load_duplicate_opt routine_body partition_rest check_option_opt
+%type <str> insert_priority_opt low_priority_opt
+%type <boolean> local_opt
+%type <loadFields> load_field_list load_fields_opt
+%type <loadLines> load_line_list load_lines_opt
+%type <numVal> load_ignore_opt
+%type <columns> load_columns_opt
+%type <updateExprs> load_set_opt
+%type <columns> column_list
 %type <updateExprs> on_dup_opt
+%type <rowAlias> row_alias_opt
 %type <updateExprs> update_list
 %type <updateExpr> update_expression
-%type <empty> exists_opt not_exists_opt ignore_opt non_rename_operation to_opt constraint_opt using_opt
-%type <bytes> sql_id
+%type <setExprs> set_list
+%type <setExpr> set_expression
+%type <str> set_scope_opt assign_op charset_value
+%type <userVar> user_var
+%type <userVars> user_var_list
+%type <valExpr> prepare_source
+%type <colIdents> fetch_var_list
+%type <colIdent> fetch_var
+%type <empty> non_rename_operation to_opt database_or_schema
+%type <boolean> ignore_opt quick_opt
+%type <boolean> exists_opt not_exists_opt unique_opt or_replace_opt temporary_opt
+%type <colIdent> sql_id
+%type <tableIdent> table_id
+%type <tableIdents> table_id_list
+%type <renames> table_rename_list
+%type <privilege> privilege
+%type <privileges> privilege_list
+%type <str> privilege_words privilege_word
+%type <grantObject> grant_object grant_name
+%type <boolean> grant_option_opt
+%type <account> account
+%type <accounts> account_list
+%type <userSpec> user_spec
+%type <userSpecs> user_spec_list
 %type <empty> force_eof
 
 /*
@@ -163,27 +425,59 @@ Below are modification to extract primary key
 keywords
 */
 %token <empty> BIT TINYINT SMALLINT MEDIUMINT INT INTEGER BIGINT REAL DOUBLE FLOAT UNSIGNED ZEROFILL DECIMAL NUMERIC DATE TIME TIMESTAMP DATETIME YEAR
-%token <empty> TEXT CHAR VARCHAR
+%token <empty> TEXT CHAR VARCHAR CHARACTER CHARSET
+%token <empty> FOREIGN REFERENCES
 
 %token <empty> NULLX AUTO_INCREMENT BOOL APPROXNUM INTNUM
 
-%type <str> data_type
+%type <columnType> cast_type data_type char_type numeric_type decimal_type precision_opt
+%type <numVal> length_opt
+%type <convertType> convert_type
+%type <boolean> unsigned_opt zero_fill_opt enforced_opt
+%type <strs> enum_value_list
+%type <str> charset_opt collate_opt
 %type <columnDefinition> column_definition
-%type <columnDefinitions> column_definition_list
+%type <createTable> table_element_list
+%type <indexDefinition> index_definition key_constraint check_constraint
+%type <indexColumns> index_column_list
+%type <indexColumn> index_column
+%type <handlerCond> signal_condition
+%type <signalItems> signal_items_opt signal_item_list
+%type <signalItem> signal_item
+%type <colIdent> index_name_opt
+%type <str> index_using_opt
 %type <statement> create_table_statement
-%type <str> length_opt char_type numeric_type unsigned_opt zero_fill_opt key_att int_type decimal_type precision_opt time_type
-%type <columnAtts> column_atts
+%type <selStmt> create_select_opt
+%type <tableOptions> create_select_options
+%type <str> key_att int_type time_type
+%type <columnDefinition> column_atts
+%type <references> references
+%type <str> ref_action table_option_value
+%type <tableOptions> table_option_list_opt table_option_list
+%type <tableOption> table_option
+%type <indexDefinition> index_options
+%type <str> index_option_value
+%type <empty> equal_opt default_opt as_kw_opt
 
 
 
 %%
 
 any_command:
-  command
+  semicolon_opt command semicolon_opt
+  {
+    SetParseTree(yylex, $2)
+  }
+| semicolon_opt
   {
-    SetParseTree(yylex, $1)
+    SetParseTree(yylex, nil)
   }
 
+semicolon_opt:
+  {}
+| semicolon_opt ';'
+  {}
+
 command:
   select_statement
   {
@@ -192,918 +486,3659 @@ command:
 | insert_statement
 | update_statement
 | delete_statement
+| load_statement
+| call_statement
 | set_statement
 | create_statement
 | alter_statement
 | rename_statement
+| truncate_statement
 | drop_statement
 | analyze_statement
 | other_statement
+| cursor_statement
+| prepare_statement
+| compound_statement
+| signal_statement
+| transaction_statement
+| privilege_statement
 
 select_statement:
-  SELECT comment_opt distinct_opt select_expression_list FROM table_expression_list timerange_opt where_expression_opt group_by_opt having_opt order_by_opt limit_opt lock_opt
+  SELECT comment_opt select_options select_expression_list from_opt timerange_opt clauses_opt where_expression_opt group_by_opt having_opt qualify_opt window_opt order_by_opt limit_opt lock_opt
+  {
+    sel := $3
+    sel.Comments, sel.Hints = splitHints($2)
+    sel.SelectExprs, sel.From, sel.TimeRange, sel.Clauses = $4, $5, $6, $7
+    sel.Where, sel.Having, sel.Qualify, sel.Windows = $8, $10, $11, $12
+    sel.OrderBy, sel.Limit, sel.Lock = $13, $14, $15
+    if $9 != nil {
+      sel.GroupBy, sel.WithRollup = $9.GroupBy, $9.WithRollup
+    }
+    $$ = sel
+  }
+| SELECT comment_opt NEXT next_count FROM dml_table_expression
   {
-    $$ = &Select{Comments: Comments($2), Distinct: $3, SelectExprs: $4, From: $6, TimeRange: $7, Where: NewWhere(AST_WHERE, $8), GroupBy: $9, Having: NewWhere(AST_HAVING, $10), OrderBy: $11, Limit: $12, Lock: $13}
+    sel := &Select{SelectExprs: SelectExprs{&Nextval{Expr: $4}}, From: TableExprs{&AliasedTableExpr{Expr: $6}}}
+    sel.Comments, sel.Hints = splitHints($2)
+    $$ = sel
   }
 | select_statement union_op select_statement %prec UNION
   {
-    $$ = &Union{Type: $2, Left: $1, Right: $3}
+    $$ = newUnion($2, $1, $3)
   }
-
-insert_statement:
-  INSERT comment_opt INTO dml_table_expression column_list_opt row_list on_dup_opt
+| select_statement intersect_op select_statement %prec INTERSECT
   {
-    $$ = &Insert{Comments: Comments($2), Table: $4, Columns: $5, Rows: $6, OnDup: OnDup($7)}
+    $$ = newUnion($2, $1, $3)
   }
-| INSERT comment_opt INTO dml_table_expression SET update_list on_dup_opt
+| paren_select union_op select_statement %prec UNION
   {
-    cols := make(Columns, 0, len($6))
-    vals := make(ValTuple, 0, len($6))
-    for _, col := range $6 {
-      cols = append(cols, &NonStarExpr{Expr: col.Name})
-      vals = append(vals, col.Expr)
-    }
-    $$ = &Insert{Comments: Comments($2), Table: $4, Columns: cols, Rows: Values{vals}, OnDup: OnDup($7)}
+    $$ = newUnion($2, $1, $3)
   }
-
-update_statement:
-  UPDATE comment_opt dml_table_expression SET update_list where_expression_opt order_by_opt limit_opt
+| paren_select intersect_op select_statement %prec INTERSECT
   {
-    $$ = &Update{Comments: Comments($2), Table: $3, Exprs: $5, Where: NewWhere(AST_WHERE, $6), OrderBy: $7, Limit: $8}
+    $$ = newUnion($2, $1, $3)
   }
-
-delete_statement:
-  DELETE comment_opt FROM dml_table_expression where_expression_opt order_by_opt limit_opt
+| select_statement union_op paren_operand %prec UNION
   {
-    $$ = &Delete{Comments: Comments($2), Table: $4, Where: NewWhere(AST_WHERE, $5), OrderBy: $6, Limit: $7}
+    $3.Type, $3.Left = $2, $1
+    $$ = $3
   }
-
-set_statement:
-  SET comment_opt update_list
+| select_statement intersect_op paren_operand %prec INTERSECT
   {
-    $$ = &Set{Comments: Comments($2), Exprs: $3}
+    $3.Type, $3.Left = $2, $1
+    $$ = $3
   }
-
-zero_fill_opt:
+| paren_select union_op paren_operand %prec UNION
   {
-    $$ = ""
+    $3.Type, $3.Left = $2, $1
+    $$ = $3
   }
-| ZEROFILL
+| paren_select intersect_op paren_operand %prec INTERSECT
   {
-    $$ = AST_ZEROFILL
+    $3.Type, $3.Left = $2, $1
+    $$ = $3
   }
-data_type:
-  numeric_type unsigned_opt zero_fill_opt
+| VALUES tuple_list order_by_opt limit_opt
   {
-    $$ = $1
-    if $2 != "" {
-        $$ += " " + $2
-    }
-    if $3 != "" {
-        $$ += " " + $3
+    $$ = &ValuesStatement{Rows: $2, OrderBy: $3, Limit: $4}
+  }
+| with_clause select_statement %prec WITH
+  {
+    switch stmt := $2.(type) {
+    case *Select:
+      stmt.With = $1
+    case *Union:
+      stmt.With = $1
     }
+    $$ = $2
   }
-| char_type
-| time_type
 
-time_type:
-  DATE
+paren_select:
+  '(' select_statement ')'
   {
-    $$ = AST_DATE
+    $$ = &ParenSelect{Select: $2}
   }
-| TIME
+
+/*
+paren_operand is a parenthesized right operand of a UNION,
+along with the ORDER BY, LIMIT and lock clauses following it,
+which apply to the whole UNION. It returns the UNION with its
+Right operand and these clauses set.
+*/
+paren_operand:
+  paren_select %prec UNION
   {
-    $$ = AST_TIME
+    $$ = &Union{Right: $1}
   }
-| TIMESTAMP
+| paren_select order_by limit_opt lock_opt
   {
-    $$ = AST_TIMESTAMP
+    $$ = &Union{Right: $1, OrderBy: $2, Limit: $3, Lock: $4}
   }
-| DATETIME
+| paren_select limit lock_opt
   {
-    $$ = AST_DATETIME
+    $$ = &Union{Right: $1, Limit: $2, Lock: $3}
   }
-| YEAR
+| paren_select lock
   {
-    $$ = AST_YEAR
+    $$ = &Union{Right: $1, Lock: $2}
   }
 
-char_type:
-  CHAR length_opt
+with_clause:
+  WITH recursive_opt cte_list
   {
-    if $2 == "" {
-        $$ = AST_CHAR
-    } else {
-        $$ = AST_CHAR + $2
-    }
+    $$ = &With{Recursive: $2, CTEs: $3}
   }
-| VARCHAR length_opt
+
+recursive_opt:
   {
-    if $2 == "" {
-        $$ = AST_VARCHAR
-    } else {
-        $$ = AST_VARCHAR + $2
-    }
+    $$ = false
   }
-| TEXT
+| RECURSIVE
   {
-    $$ = AST_TEXT
+    $$ = true
   }
 
-numeric_type:
-  int_type length_opt
+cte_list:
+  cte
   {
-    $$ = $1 + $2
+    $$ = []*CommonTableExpr{$1}
   }
-| decimal_type
+| cte_list ',' cte
   {
-    $$ = $1
+    $$ = append($1, $3)
   }
 
-int_type:
-  BIT
-  {
-    $$ = AST_BIT
-  }
-| TINYINT
+cte:
+  table_id AS subquery
   {
-    $$ = AST_TINYINT
+    $$ = &CommonTableExpr{Name: $1, Subquery: $3}
   }
-| SMALLINT
+| table_id '(' sql_id_list ')' AS subquery
   {
-    $$ = AST_SMALLINT
+    $$ = &CommonTableExpr{Name: $1, Columns: $3, Subquery: $6}
   }
-| MEDIUMINT
+
+insert_statement:
+  INSERT comment_opt insert_priority_opt ignore_opt INTO dml_table_expression partition_opt row_list row_alias_opt on_dup_opt returning_opt
   {
-    $$ = AST_MEDIUMINT
+    comments, hints := splitHints($2)
+    $$ = &Insert{Comments: comments, Hints: hints, Priority: $3, Ignore: $4, Table: $6, Partitions: $7, Rows: $8, RowAlias: $9, OnDup: OnDup($10), Returning: $11}
   }
-| INT
+| INSERT comment_opt insert_priority_opt ignore_opt INTO dml_table_expression partition_opt '(' column_list ')' row_list row_alias_opt on_dup_opt returning_opt
   {
-    $$ = AST_INT
+    comments, hints := splitHints($2)
+    $$ = &Insert{Comments: comments, Hints: hints, Priority: $3, Ignore: $4, Table: $6, Partitions: $7, Columns: $9, Rows: $11, RowAlias: $12, OnDup: OnDup($13), Returning: $14}
   }
-| INTEGER
+| INSERT comment_opt insert_priority_opt ignore_opt INTO dml_table_expression partition_opt SET update_list row_alias_opt on_dup_opt returning_opt
   {
-    $$ = AST_INTEGER
+    cols := make(Columns, 0, len($9))
+    vals := make(ValTuple, 0, len($9))
+    for _, col := range $9 {
+      cols = append(cols, NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: col.Name}))
+      vals = append(vals, col.Expr)
+    }
+    comments, hints := splitHints($2)
+    $$ = &Insert{Comments: comments, Hints: hints, Priority: $3, Ignore: $4, Table: $6, Partitions: $7, Columns: cols, Rows: Values{vals}, RowAlias: $10, OnDup: OnDup($11), Returning: $12}
   }
-| BIGINT
+
+update_statement:
+  UPDATE comment_opt low_priority_opt ignore_opt table_expression_list SET update_list where_expression_opt order_by_opt limit_opt returning_opt
   {
-    $$ = AST_BIGINT
+    comments, hints := splitHints($2)
+    $$ = &Update{Comments: comments, Hints: hints, Priority: $3, Ignore: $4, Table: $5, Exprs: $7, Where: $8, OrderBy: $9, Limit: $10, Returning: $11}
   }
 
-decimal_type:
-  REAL precision_opt
+delete_statement:
+  DELETE comment_opt low_priority_opt quick_opt ignore_opt FROM dml_table_expression where_expression_opt order_by_opt limit_opt returning_opt
   {
-    $$ = AST_REAL + $2
+    comments, hints := splitHints($2)
+    $$ = &Delete{Comments: comments, Hints: hints, Priority: $3, Quick: $4, Ignore: $5, Table: $7, Where: $8, OrderBy: $9, Limit: $10, Returning: $11}
   }
-| DOUBLE precision_opt
+| DELETE comment_opt low_priority_opt quick_opt ignore_opt delete_target_list FROM table_expression_list where_expression_opt
   {
-    $$ = AST_DOUBLE + $2
+    comments, hints := splitHints($2)
+    $$ = &Delete{Comments: comments, Hints: hints, Priority: $3, Quick: $4, Ignore: $5, Targets: $6, From: $8, Where: $9}
   }
-| FLOAT precision_opt
+| DELETE comment_opt low_priority_opt quick_opt ignore_opt FROM delete_target_list USING table_expression_list where_expression_opt
   {
-    $$ = AST_FLOAT + $2
+    comments, hints := splitHints($2)
+    $$ = &Delete{Comments: comments, Hints: hints, Priority: $3, Quick: $4, Ignore: $5, Targets: $7, From: $9, Using: true, Where: $10}
   }
-| DECIMAL precision_opt
+
+insert_priority_opt:
   {
-    $$ = AST_DECIMAL + $2
+    $$ = ""
   }
-| DECIMAL length_opt
+| LOW_PRIORITY
   {
-    $$ = AST_DECIMAL + $2
+    $$ = AST_LOW_PRIORITY
   }
-| NUMERIC precision_opt
+| DELAYED
   {
-    $$ = AST_NUMERIC + $2
+    $$ = AST_DELAYED
   }
-| NUMERIC length_opt
+| HIGH_PRIORITY
   {
-    $$ = AST_NUMERIC + $2
+    $$ = AST_HIGH_PRIORITY
   }
 
-precision_opt:
+low_priority_opt:
   {
     $$ = ""
   }
-| '(' NUMBER ',' NUMBER ')'
+| LOW_PRIORITY
   {
-    $$ = "(" + string($2) + ", " + string($4) + ")"
+    $$ = AST_LOW_PRIORITY
   }
 
-length_opt:
+quick_opt:
   {
-    $$ = ""
+    $$ = false
   }
-| '(' NUMBER ')'
+| QUICK
   {
-    $$ = "(" + string($2) + ")"
+    $$ = true
   }
 
-unsigned_opt:
+delete_target_list:
+  delete_target
   {
-    $$ = ""
+    $$ = []*TableName{$1}
   }
-| UNSIGNED
+| delete_target_list ',' delete_target
   {
-    $$ = AST_UNSIGNED
+    $$ = append($1, $3)
   }
 
-column_atts:
+/*
+The tables of a multiple-table DELETE, which may be written
+as t.*.
+*/
+delete_target:
+  dml_table_expression
+| table_id '.' '*'
   {
-    $$ = ColumnAtts{}
+    $$ = NodeArena(yylex).tableName(TableName{Name: $1})
   }
-| column_atts NOT NULL
+| table_id '.' table_id '.' '*'
   {
-    $$ = append($$, AST_NOT_NULL)
+    $$ = NodeArena(yylex).tableName(TableName{Qualifier: $1, Name: $3})
   }
 
-| column_atts NULL
-| column_atts DEFAULT STRING
+/*
+CALL is not reserved, and CALL without arguments of an unqualified
+name is parsed by cursor_statement.
+*/
+call_statement:
+  ID table_id '.' table_id
   {
-    node := StrVal($3)
-    $$ = append($$, "default " + String(node))
+    if !strings.EqualFold($1, "call") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Call{Name: NodeArena(yylex).tableName(TableName{Qualifier: $2, Name: $4})}
   }
-| column_atts DEFAULT NUMBER
+| ID dml_table_expression '(' ')'
   {
-    node := NumVal($3)
-    $$ = append($$, "default " + String(node))
+    if !strings.EqualFold($1, "call") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Call{Name: $2}
   }
-| column_atts AUTO_INCREMENT
+| ID dml_table_expression '(' value_expression_list ')'
   {
-    $$ = append($$, AST_AUTO_INCREMENT)
+    if !strings.EqualFold($1, "call") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Call{Name: $2, Args: $4}
   }
-| column_atts key_att
-{
-    $$ = append($$, $2)
-}
 
-key_att:
-  primary_key
-  {
-    $$ = AST_PRIMARY_KEY
-  }
-| unique_key
+load_statement:
+  LOAD ID load_priority_opt local_opt INFILE STRING load_duplicate_opt INTO TABLE dml_table_expression partition_opt charset_opt load_fields_opt load_lines_opt load_ignore_opt load_columns_opt load_set_opt
   {
-    $$ = AST_UNIQUE_KEY
+    if !strings.EqualFold($2, "data") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &LoadData{
+      Priority: $3, Local: $4, File: $6, Duplicate: $7,
+      Table: $10, Partitions: $11, Charset: $12, Fields: $13, Lines: $14,
+      IgnoreLines: $15, Columns: $16, Set: $17,
+    }
   }
 
-primary_key:
-  PRIMARY KEY
-| KEY
-
-unique_key:
-  UNIQUE
-| UNIQUE KEY
-
-column_definition:
-  ID data_type column_atts
+load_priority_opt:
   {
-    $$ = &ColumnDefinition{ColName: string($1), ColType: $2, ColumnAtts: $3  }
+    $$ = ""
   }
-
-column_definition_list:
-  column_definition
+| LOW_PRIORITY
   {
-    $$ = ColumnDefinitions{$1}
+    $$ = AST_LOW_PRIORITY
   }
-| column_definition_list ',' column_definition
+| ID
   {
-    $$ = append($$, $3)
+    if !strings.EqualFold($1, AST_CONCURRENT) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = AST_CONCURRENT
   }
 
-create_table_statement:
-  CREATE TABLE not_exists_opt ID '(' column_definition_list  ')'
+local_opt:
+  {
+    $$ = false
+  }
+| LOCAL
   {
-    $$ = &CreateTable{Name: $4, ColumnDefinitions: $6}
+    $$ = true
   }
 
-create_statement:
-  create_table_statement
+load_duplicate_opt:
   {
-    $$ = $1
+    $$ = ""
   }
-| CREATE constraint_opt INDEX sql_id using_opt ON ID force_eof
+| ID
   {
-    // Change this to an alter statement
-    $$ = &DDL{Action: AST_ALTER, Table: $7, NewName: $7}
+    if !strings.EqualFold($1, AST_REPLACE) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = AST_REPLACE
   }
-| CREATE VIEW sql_id force_eof
+| IGNORE
   {
-    $$ = &DDL{Action: AST_CREATE, NewName: $3}
+    $$ = AST_IGNORE
   }
 
-alter_statement:
-  ALTER ignore_opt TABLE ID non_rename_operation force_eof
+load_fields_opt:
   {
-    $$ = &DDL{Action: AST_ALTER, Table: $4, NewName: $4}
+    $$ = nil
   }
-| ALTER ignore_opt TABLE ID RENAME to_opt ID
+| ID load_field_list
   {
-    // Change this to a rename statement
-    $$ = &DDL{Action: AST_RENAME, Table: $4, NewName: $7}
+    switch strings.ToLower($1) {
+    case "fields", "columns":
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    if *$2 == (LoadFields{}) {
+      yylex.Error("syntax error: FIELDS without options")
+      return 1
+    }
+    $$ = $2
   }
-| ALTER VIEW sql_id force_eof
+
+load_field_list:
   {
-    $$ = &DDL{Action: AST_ALTER, Table: $3, NewName: $3}
+    $$ = &LoadFields{}
   }
-
-rename_statement:
-  RENAME TABLE ID TO ID
+| load_field_list TERMINATED BY STRING
   {
-    $$ = &DDL{Action: AST_RENAME, Table: $3, NewName: $5}
+    s := $4
+    $1.TerminatedBy = &s
   }
-
-drop_statement:
-  DROP TABLE exists_opt ID
+| load_field_list ENCLOSED BY STRING
   {
-    $$ = &DDL{Action: AST_DROP, Table: $4}
+    s := $4
+    $1.EnclosedBy = &s
   }
-| DROP INDEX sql_id ON ID
+| load_field_list OPTIONALLY ENCLOSED BY STRING
   {
-    // Change this to an alter statement
-    $$ = &DDL{Action: AST_ALTER, Table: $5, NewName: $5}
+    s := $5
+    $1.EnclosedBy, $1.OptionallyEnclosed = &s, true
   }
-| DROP VIEW exists_opt sql_id force_eof
+| load_field_list ESCAPED BY STRING
   {
-    $$ = &DDL{Action: AST_DROP, Table: $4}
+    s := $4
+    $1.EscapedBy = &s
   }
 
-analyze_statement:
-  ANALYZE TABLE ID
+load_lines_opt:
+  {
+    $$ = nil
+  }
+| LINES load_line_list
   {
-    $$ = &DDL{Action: AST_ALTER, Table: $3, NewName: $3}
+    if *$2 == (LoadLines{}) {
+      yylex.Error("syntax error: LINES without options")
+      return 1
+    }
+    $$ = $2
   }
 
-other_statement:
-  SHOW force_eof
+load_line_list:
   {
-    $$ = &Other{}
+    $$ = &LoadLines{}
   }
-| DESCRIBE force_eof
+| load_line_list STARTING BY STRING
   {
-    $$ = &Other{}
+    s := $4
+    $1.StartingBy = &s
   }
-| EXPLAIN force_eof
+| load_line_list TERMINATED BY STRING
   {
-    $$ = &Other{}
+    s := $4
+    $1.TerminatedBy = &s
   }
 
-comment_opt:
+load_ignore_opt:
   {
-    SetAllowComments(yylex, true)
+    $$ = ""
   }
-  comment_list
+| IGNORE NUMBER LINES
   {
-    $$ = $2
-    SetAllowComments(yylex, false)
+    $$ = NumVal($2)
+  }
+| IGNORE NUMBER ROWS
+  {
+    $$ = NumVal($2)
   }
 
-comment_list:
+load_columns_opt:
   {
     $$ = nil
   }
-| comment_list COMMENT
+| '(' column_list ')'
   {
-    $$ = append($1, $2)
+    $$ = $2
   }
 
-union_op:
-  UNION
-  {
-    $$ = AST_UNION
-  }
-| UNION ALL
+load_set_opt:
   {
-    $$ = AST_UNION_ALL
+    $$ = nil
   }
-| MINUS
+| SET update_list
   {
-    $$ = AST_SET_MINUS
+    $$ = $2
   }
-| EXCEPT
+
+returning_opt:
   {
-    $$ = AST_EXCEPT
+    $$ = nil
   }
-| INTERSECT
+| RETURNING select_expression_list
   {
-    $$ = AST_INTERSECT
+    $$ = $2
   }
 
-distinct_opt:
+set_statement:
+  SET comment_opt set_list
   {
-    $$ = ""
+    $$ = &Set{Comments: Comments($2), Exprs: $3}
   }
-| DISTINCT
+| SET comment_opt set_scope_opt ID transaction_characteristic_list
   {
-    $$ = AST_DISTINCT
+    if !strings.EqualFold($4, "transaction") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $4))
+      return 1
+    }
+    stmt := &SetTransaction{Comments: Comments($2), Scope: $3}
+    for _, c := range $5 {
+      if c == AST_READ_ONLY || c == AST_READ_WRITE {
+        stmt.AccessMode = c
+      } else {
+        stmt.IsolationLevel = c
+      }
+    }
+    $$ = stmt
   }
 
-select_expression_list:
-  select_expression
+transaction_characteristic_list:
+  transaction_characteristic
   {
-    $$ = SelectExprs{$1}
+    $$ = []string{$1}
   }
-| select_expression_list ',' select_expression
+| transaction_characteristic_list ',' transaction_characteristic
   {
-    $$ = append($$, $3)
+    $$ = append($1, $3)
   }
 
-select_expression:
-  '*'
+transaction_characteristic:
+  ID ID isolation_level
   {
-    $$ = &StarExpr{}
+    if !strings.EqualFold($1, "isolation") || !strings.EqualFold($2, "level") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = $3
   }
-| expression as_lower_opt
+| ID ID
   {
-    $$ = &NonStarExpr{Expr: $1, As: $2}
-  }
-| ID '.' '*'
-  {
-    $$ = &StarExpr{TableName: $1}
+    switch mode := strings.ToLower($1 + " " + $2); mode {
+    case AST_READ_ONLY, AST_READ_WRITE:
+      $$ = mode
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
   }
 
-expression:
-  boolean_expression
+isolation_level:
+  ID
   {
-    $$ = $1
+    if !strings.EqualFold($1, AST_SERIALIZABLE) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = AST_SERIALIZABLE
   }
-| value_expression
+| ID ID
   {
-    $$ = $1
+    switch level := strings.ToLower($1 + " " + $2); level {
+    case AST_READ_UNCOMMITTED, AST_READ_COMMITTED, AST_REPEATABLE_READ:
+      $$ = level
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
   }
 
-as_lower_opt:
+transaction_statement:
+  BEGIN
   {
-    $$ = nil
+    $$ = &Begin{}
   }
-| sql_id
+| ID
   {
-    $$ = $1
+    switch strings.ToLower($1) {
+    case "commit":
+      $$ = &Commit{}
+    case "rollback":
+      $$ = &Rollback{}
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
   }
-| AS sql_id
+| ID TO sql_id
   {
-    $$ = $2
+    if !strings.EqualFold($1, "rollback") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Rollback{Savepoint: $3}
   }
-
-table_expression_list:
-  table_expression
+| ID TO ID sql_id
   {
-    $$ = TableExprs{$1}
+    if !strings.EqualFold($1, "rollback") || !strings.EqualFold($3, "savepoint") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Rollback{Savepoint: $4}
   }
-| table_expression_list ',' table_expression
+| ID ID TO sql_id
   {
-    $$ = append($$, $3)
+    if !strings.EqualFold($1, "rollback") || !strings.EqualFold($2, "work") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Rollback{Savepoint: $4}
   }
-
-table_expression:
-  simple_table_expression as_opt index_hint_list
+| ID ID TO ID sql_id
   {
-    $$ = &AliasedTableExpr{Expr:$1, As: $2, Hints: $3}
+    if !strings.EqualFold($1, "rollback") || !strings.EqualFold($2, "work") || !strings.EqualFold($4, "savepoint") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Rollback{Savepoint: $5}
   }
-| '(' table_expression ')'
+| ID ID ID ID
   {
-    $$ = &ParenTableExpr{Expr: $2}
+    mode := strings.ToLower($3 + " " + $4)
+    if !strings.EqualFold($1, "start") || !strings.EqualFold($2, "transaction") || mode != AST_READ_ONLY && mode != AST_READ_WRITE {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Begin{AccessMode: mode}
   }
-| table_expression join_type table_expression %prec JOIN
+
+privilege_statement:
+  GRANT privilege_list ON grant_object TO account_list grant_option_opt
   {
-    $$ = &JoinTableExpr{LeftExpr: $1, Join: $2, RightExpr: $3}
+    $$ = &Grant{Privileges: $2, On: $4, To: $6, WithGrantOption: $7}
   }
-| table_expression join_type table_expression ON boolean_expression %prec JOIN
+| REVOKE privilege_list ON grant_object FROM account_list
   {
-    $$ = &JoinTableExpr{LeftExpr: $1, Join: $2, RightExpr: $3, On: $5}
+    $$ = &Revoke{Privileges: $2, On: $4, From: $6}
   }
-
-as_opt:
+| CREATE_USER not_exists_opt user_spec_list
   {
-    $$ = nil
+    $$ = &CreateUser{IfNotExists: $2, Users: $3}
   }
-| ID
+| ALTER_USER exists_opt user_spec_list
   {
-    $$ = $1
+    $$ = &AlterUser{IfExists: $2, Users: $3}
   }
-| AS ID
+| SET_PASSWORD '=' STRING
   {
-    $$ = $2
+    $$ = &SetPassword{Password: $3}
+  }
+| SET_PASSWORD FOR account '=' STRING
+  {
+    $$ = &SetPassword{For: $3, Password: $5}
   }
 
-join_type:
-  JOIN
+privilege_list:
+  privilege
   {
-    $$ = AST_JOIN
+    $$ = []*Privilege{$1}
   }
-| STRAIGHT_JOIN
+| privilege_list ',' privilege
   {
-    $$ = AST_STRAIGHT_JOIN
+    $$ = append($1, $3)
   }
-| LEFT JOIN
+
+privilege:
+  privilege_words
   {
-    $$ = AST_LEFT_JOIN
+    $$ = &Privilege{Type: $1}
   }
-| LEFT OUTER JOIN
+| privilege_words '(' sql_id_list ')'
   {
-    $$ = AST_LEFT_JOIN
+    $$ = &Privilege{Type: $1, Columns: $3}
   }
-| RIGHT JOIN
+
+privilege_words:
+  privilege_word
+| privilege_words privilege_word
   {
-    $$ = AST_RIGHT_JOIN
+    $$ = $1 + " " + $2
   }
-| RIGHT OUTER JOIN
+
+/*
+The words of privileges that are keywords. The others are
+matched as ID.
+*/
+privilege_word:
+  ID
   {
-    $$ = AST_RIGHT_JOIN
+    $$ = strings.ToLower($1)
   }
-| INNER JOIN
+| ALL
   {
-    $$ = AST_JOIN
+    $$ = "all"
   }
-| CROSS JOIN
+| ALTER
   {
-    $$ = AST_CROSS_JOIN
+    $$ = "alter"
   }
-| NATURAL JOIN
+| CREATE
   {
-    $$ = AST_NATURAL_JOIN
+    $$ = "create"
   }
-
-simple_table_expression:
-ID
+| DELETE
   {
-    $$ = &TableName{Name: $1}
+    $$ = "delete"
   }
-| ID '.' ID
+| DROP
   {
-    $$ = &TableName{Qualifier: $1, Name: $3}
+    $$ = "drop"
   }
-| subquery
+| GRANT
   {
-    $$ = $1
+    $$ = "grant"
   }
-
-dml_table_expression:
-ID
+| INDEX
   {
-    $$ = &TableName{Name: $1}
+    $$ = "index"
   }
-| ID '.' ID
+| INSERT
   {
-    $$ = &TableName{Qualifier: $1, Name: $3}
+    $$ = "insert"
   }
-
-index_hint_list:
+| LOCK
   {
-    $$ = nil
+    $$ = "lock"
   }
-| USE INDEX '(' index_list ')'
+| REFERENCES
   {
-    $$ = &IndexHints{Type: AST_USE, Indexes: $4}
+    $$ = "references"
   }
-| IGNORE INDEX '(' index_list ')'
+| SELECT
   {
-    $$ = &IndexHints{Type: AST_IGNORE, Indexes: $4}
+    $$ = "select"
   }
-| FORCE INDEX '(' index_list ')'
+| SHOW
   {
-    $$ = &IndexHints{Type: AST_FORCE, Indexes: $4}
+    $$ = "show"
   }
-
-index_list:
-  sql_id
+| UPDATE
   {
-    $$ = [][]byte{$1}
+    $$ = "update"
   }
-| index_list ',' sql_id
+| VIEW
   {
-    $$ = append($1, $3)
+    $$ = "view"
   }
 
-where_expression_opt:
+grant_object:
+  grant_name
+| TABLE grant_name
   {
-    $$ = nil
+    $2.Type = AST_TABLE
+    $$ = $2
   }
-| WHERE boolean_expression
+| ID grant_name
   {
+    kind := strings.ToLower($1)
+    if kind != AST_FUNCTION && kind != AST_PROCEDURE {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $2.Type = kind
     $$ = $2
   }
 
-boolean_expression:
-  condition
-| boolean_expression AND boolean_expression
+grant_name:
+  '*'
   {
-    $$ = &AndExpr{Left: $1, Right: $3}
+    $$ = &GrantObject{Name: NewTableIdent("*")}
   }
-| boolean_expression OR boolean_expression
+| '*' '.' '*'
   {
-    $$ = &OrExpr{Left: $1, Right: $3}
+    $$ = &GrantObject{Database: NewTableIdent("*"), Name: NewTableIdent("*")}
   }
-| NOT boolean_expression
+| table_id
   {
-    $$ = &NotExpr{Expr: $2}
+    $$ = &GrantObject{Name: $1}
   }
-| '(' boolean_expression ')'
+| table_id '.' '*'
   {
-    $$ = &ParenBoolExpr{Expr: $2}
+    $$ = &GrantObject{Database: $1, Name: NewTableIdent("*")}
+  }
+| table_id '.' table_id
+  {
+    $$ = &GrantObject{Database: $1, Name: $3}
   }
 
-condition:
-  value_expression compare value_expression
+grant_option_opt:
   {
-    $$ = &ComparisonExpr{Left: $1, Operator: $2, Right: $3}
+    $$ = false
   }
-| value_expression IN col_tuple
+| WITH GRANT ID
   {
-    $$ = &ComparisonExpr{Left: $1, Operator: AST_IN, Right: $3}
+    if !strings.EqualFold($3, "option") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+    $$ = true
   }
-| value_expression NOT IN col_tuple
+
+account_list:
+  account
   {
-    $$ = &ComparisonExpr{Left: $1, Operator: AST_NOT_IN, Right: $4}
+    $$ = []*Account{$1}
   }
-| value_expression LIKE value_expression
+| account_list ',' account
   {
-    $$ = &ComparisonExpr{Left: $1, Operator: AST_LIKE, Right: $3}
+    $$ = append($1, $3)
   }
-| value_expression NOT LIKE value_expression
+
+/*
+An account is written as user@host, with either part quoted.
+Unquoted, it is scanned as one identifier, and the host of
+'user'@'host' as a user variable.
+*/
+account:
+  ID
   {
-    $$ = &ComparisonExpr{Left: $1, Operator: AST_NOT_LIKE, Right: $4}
+    if $<quoted>1 {
+      $$ = &Account{User: $1}
+    } else {
+      $$ = newAccount($1)
+    }
   }
-| value_expression BETWEEN value_expression AND value_expression
+| ID USER_VAR
   {
-    $$ = &RangeCond{Left: $1, Operator: AST_BETWEEN, From: $3, To: $5}
+    $$ = &Account{User: $1, Host: $2}
   }
-| value_expression NOT BETWEEN value_expression AND value_expression
+| ID STRING
   {
-    $$ = &RangeCond{Left: $1, Operator: AST_NOT_BETWEEN, From: $4, To: $6}
+    if !strings.HasSuffix($1, "@") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Account{User: strings.TrimSuffix($1, "@"), Host: $2.Val}
   }
-| value_expression IS NULL
+| STRING
   {
-    $$ = &NullCheck{Operator: AST_IS_NULL, Expr: $1}
+    $$ = &Account{User: $1.Val}
   }
-| value_expression IS NOT NULL
+| STRING USER_VAR
   {
-    $$ = &NullCheck{Operator: AST_IS_NOT_NULL, Expr: $1}
+    $$ = &Account{User: $1.Val, Host: $2}
   }
-| EXISTS subquery
+
+user_spec_list:
+  user_spec
   {
-    $$ = &ExistsExpr{Subquery: $2}
+    $$ = []*UserSpec{$1}
+  }
+| user_spec_list ',' user_spec
+  {
+    $$ = append($1, $3)
   }
 
-compare:
-  '='
+user_spec:
+  account
   {
-    $$ = AST_EQ
+    $$ = &UserSpec{Account: $1}
   }
-| '<'
+| account ID BY STRING
   {
-    $$ = AST_LT
+    if !strings.EqualFold($2, "identified") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    password := $4
+    $$ = &UserSpec{Account: $1, Password: &password}
   }
-| '>'
+| account ID WITH sql_id
   {
-    $$ = AST_GT
+    if !strings.EqualFold($2, "identified") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &UserSpec{Account: $1, Plugin: $4.Lowered()}
   }
-| LE
+| account ID WITH sql_id BY STRING
   {
-    $$ = AST_LE
+    if !strings.EqualFold($2, "identified") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    password := $6
+    $$ = &UserSpec{Account: $1, Plugin: $4.Lowered(), Password: &password}
   }
-| GE
+| account ID WITH sql_id AS STRING
   {
-    $$ = AST_GE
+    if !strings.EqualFold($2, "identified") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    authString := $6
+    $$ = &UserSpec{Account: $1, Plugin: $4.Lowered(), AuthString: &authString}
   }
-| NE
+
+zero_fill_opt:
   {
-    $$ = AST_NE
+    $$ = false
   }
-| NULL_SAFE_EQUAL
+| ZEROFILL
   {
-    $$ = AST_NSE
+    $$ = true
   }
 
-col_tuple:
-  '(' value_expression_list ')'
+data_type:
+  numeric_type unsigned_opt zero_fill_opt
   {
-    $$ = ValTuple($2)
+    $$ = $1
+    $$.Unsigned, $$.Zerofill = $2, $3
   }
-| subquery
+| char_type charset_opt collate_opt
   {
     $$ = $1
+    $$.Charset, $$.Collate = $2, $3
   }
-| LIST_ARG
+| time_type length_opt
   {
-    $$ = ListArg($1)
+    $$ = ColumnType{Type: $1, Length: $2}
   }
 
-subquery:
-  '(' select_statement ')'
+cast_type:
+  data_type
+| ID
   {
-    $$ = &Subquery{$2}
+    $$ = ColumnType{Type: strings.ToLower($1)}
   }
 
-value_expression_list:
-  value_expression
+convert_type:
+  CHAR length_opt charset_opt
   {
-    $$ = ValExprs{$1}
+    $$ = &ConvertType{Type: AST_CHAR, Length: $2, Charset: $3}
   }
-| value_expression_list ',' value_expression
+| decimal_type
   {
-    $$ = append($1, $3)
+    $$ = &ConvertType{Type: $1.Type, Length: $1.Length, Scale: $1.Scale}
   }
-
-value_expression:
-  '*'
+| time_type length_opt
   {
-    $$ = &StarExpr{}
+    $$ = &ConvertType{Type: $1, Length: $2}
   }
-| value
+| UNSIGNED
   {
-    $$ = $1
+    $$ = &ConvertType{Type: AST_UNSIGNED}
   }
-| column_name
+| UNSIGNED int_type
   {
-    $$ = $1
+    if $2 != AST_INT && $2 != AST_INTEGER {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &ConvertType{Type: AST_UNSIGNED}
   }
-| row_tuple
+| ID length_opt
   {
-    $$ = $1
+    $$ = &ConvertType{Type: strings.ToLower($1), Length: $2}
   }
-| value_expression '&' value_expression
+| ID int_type
   {
-    $$ = &BinaryExpr{Left: $1, Operator: AST_BITAND, Right: $3}
+    if !strings.EqualFold($1, AST_SIGNED) || $2 != AST_INT && $2 != AST_INTEGER {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &ConvertType{Type: AST_SIGNED}
   }
-| value_expression '|' value_expression
+
+time_type:
+  DATE
   {
-    $$ = &BinaryExpr{Left: $1, Operator: AST_BITOR, Right: $3}
+    $$ = AST_DATE
   }
-| value_expression '^' value_expression
+| TIME
   {
-    $$ = &BinaryExpr{Left: $1, Operator: AST_BITXOR, Right: $3}
+    $$ = AST_TIME
   }
-| value_expression '+' value_expression
+| TIMESTAMP
   {
-    $$ = &BinaryExpr{Left: $1, Operator: AST_PLUS, Right: $3}
+    $$ = AST_TIMESTAMP
   }
-| value_expression '-' value_expression
+| DATETIME
   {
-    $$ = &BinaryExpr{Left: $1, Operator: AST_MINUS, Right: $3}
+    $$ = AST_DATETIME
   }
-| value_expression '*' value_expression
+| YEAR
   {
-    $$ = &BinaryExpr{Left: $1, Operator: AST_MULT, Right: $3}
+    $$ = AST_YEAR
   }
-| value_expression '/' value_expression
+
+char_type:
+  CHAR length_opt
   {
-    $$ = &BinaryExpr{Left: $1, Operator: AST_DIV, Right: $3}
+    $$ = ColumnType{Type: AST_CHAR, Length: $2}
   }
-| value_expression '%' value_expression
+| VARCHAR length_opt
   {
-    $$ = &BinaryExpr{Left: $1, Operator: AST_MOD, Right: $3}
+    $$ = ColumnType{Type: AST_VARCHAR, Length: $2}
   }
-| unary_operator value_expression %prec UNARY
+| TEXT
   {
-    if num, ok := $2.(NumVal); ok {
-      switch $1 {
-      case '-':
-        $$ = append(NumVal("-"), num...)
-      case '+':
-        $$ = num
-      default:
-        $$ = &UnaryExpr{Operator: $1, Expr: $2}
-      }
-    } else {
-      $$ = &UnaryExpr{Operator: $1, Expr: $2}
+    $$ = ColumnType{Type: AST_TEXT}
+  }
+| ID '(' enum_value_list ')'
+  {
+    if !strings.EqualFold($1, AST_ENUM) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
     }
+    $$ = ColumnType{Type: AST_ENUM, EnumValues: $3}
   }
-| sql_id '(' ')'
+| SET '(' enum_value_list ')'
   {
-    $$ = &FuncExpr{Name: $1}
+    $$ = ColumnType{Type: AST_SET, EnumValues: $3}
   }
-| sql_id '(' select_expression_list ')'
+
+enum_value_list:
+  STRING
   {
-    $$ = &FuncExpr{Name: $1, Exprs: $3}
+    $$ = []string{$1.Val}
+  }
+| enum_value_list ',' STRING
+  {
+    $$ = append($1, $3.Val)
+  }
+
+charset_opt:
+  {
+    $$ = ""
+  }
+| CHARACTER SET sql_id
+  {
+    $$ = $3.String()
+  }
+| CHARSET sql_id
+  {
+    $$ = $2.String()
+  }
+
+/* A COLLATE after a type, as in a::text collate c, is the type's. */
+collate_opt:
+  %prec UNARY
+  {
+    $$ = ""
+  }
+| COLLATE sql_id
+  {
+    $$ = $2.String()
+  }
+| COLLATE STRING
+  {
+    $$ = $2.Val
+  }
+
+numeric_type:
+  int_type length_opt
+  {
+    $$ = ColumnType{Type: $1, Length: $2}
+  }
+| decimal_type
+  {
+    $$ = $1
+  }
+
+int_type:
+  BIT
+  {
+    $$ = AST_BIT
+  }
+| TINYINT
+  {
+    $$ = AST_TINYINT
+  }
+| SMALLINT
+  {
+    $$ = AST_SMALLINT
+  }
+| MEDIUMINT
+  {
+    $$ = AST_MEDIUMINT
+  }
+| INT
+  {
+    $$ = AST_INT
+  }
+| INTEGER
+  {
+    $$ = AST_INTEGER
+  }
+| BIGINT
+  {
+    $$ = AST_BIGINT
+  }
+
+decimal_type:
+  REAL precision_opt
+  {
+    $$ = $2
+    $$.Type = AST_REAL
+  }
+| DOUBLE precision_opt
+  {
+    $$ = $2
+    $$.Type = AST_DOUBLE
+  }
+| FLOAT precision_opt
+  {
+    $$ = $2
+    $$.Type = AST_FLOAT
+  }
+| DECIMAL precision_opt
+  {
+    $$ = $2
+    $$.Type = AST_DECIMAL
+  }
+| NUMERIC precision_opt
+  {
+    $$ = $2
+    $$.Type = AST_NUMERIC
+  }
+
+precision_opt:
+  {
+    $$ = ColumnType{}
+  }
+| '(' NUMBER ')'
+  {
+    $$ = ColumnType{Length: NumVal($2)}
+  }
+| '(' NUMBER ',' NUMBER ')'
+  {
+    $$ = ColumnType{Length: NumVal($2), Scale: NumVal($4)}
+  }
+
+length_opt:
+  {
+    $$ = ""
+  }
+| '(' NUMBER ')'
+  {
+    $$ = NumVal($2)
+  }
+
+unsigned_opt:
+  {
+    $$ = false
+  }
+| UNSIGNED
+  {
+    $$ = true
+  }
+
+column_atts:
+  {
+    $$ = &ColumnDefinition{ColumnAtts: ColumnAtts{}}
+  }
+| column_atts NOT NULL
+  {
+    $1.Nullable = NullNotAllowed
+    $$ = $1
+  }
+| column_atts NULL
+  {
+    $1.Nullable = NullAllowed
+    $$ = $1
+  }
+| column_atts DEFAULT value_expression
+  {
+    $1.HasDefault, $1.DefaultValue = true, timestampFunc($3)
+    $$ = $1
+  }
+| column_atts ON UPDATE value_expression
+  {
+    $1.OnUpdate = timestampFunc($4)
+    $$ = $1
+  }
+| column_atts AUTO_INCREMENT
+  {
+    $1.ColumnAtts = append($1.ColumnAtts, AST_AUTO_INCREMENT)
+    $$ = $1
+  }
+| column_atts key_att
+  {
+    $1.ColumnAtts = append($1.ColumnAtts, $2)
+    $$ = $1
+  }
+| column_atts COMMENT_KEYWORD STRING
+  {
+    $1.Comment = $3.Val
+    $$ = $1
+  }
+
+key_att:
+  primary_key
+  {
+    $$ = AST_PRIMARY_KEY
+  }
+| unique_key
+  {
+    $$ = AST_UNIQUE_KEY
+  }
+
+primary_key:
+  PRIMARY KEY
+| KEY
+
+unique_key:
+  UNIQUE
+| UNIQUE KEY
+
+column_definition:
+  ID data_type column_atts
+  {
+    $3.ColName, $3.ColType = $1, $2
+    $$ = $3
+  }
+
+table_element_list:
+  column_definition
+  {
+    $$ = &CreateTable{ColumnDefinitions: ColumnDefinitions{$1}}
+  }
+| index_definition
+  {
+    $$ = &CreateTable{Indexes: []*IndexDefinition{$1}}
+  }
+| table_element_list ',' column_definition
+  {
+    $1.ColumnDefinitions = append($1.ColumnDefinitions, $3)
+    $$ = $1
+  }
+| table_element_list ',' index_definition
+  {
+    $1.Indexes = append($1.Indexes, $3)
+    $$ = $1
+  }
+
+index_definition:
+  key_constraint
+  {
+    $$ = $1
+  }
+| CONSTRAINT key_constraint
+  {
+    $$ = $2
+  }
+| CONSTRAINT sql_id key_constraint
+  {
+    $3.Constraint = $2
+    $$ = $3
+  }
+| index_or_key index_name_opt '(' index_column_list ')' index_using_opt
+  {
+    $$ = &IndexDefinition{Type: AST_INDEX, Name: $2, Columns: $4, Using: $6}
+  }
+| ID index_or_key index_name_opt '(' index_column_list ')' index_using_opt
+  {
+    var typ string
+    switch strings.ToLower($1) {
+    case "fulltext":
+      typ = AST_FULLTEXT_KEY
+    case "spatial":
+      typ = AST_SPATIAL_KEY
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &IndexDefinition{Type: typ, Name: $3, Columns: $5, Using: $7}
+  }
+| check_constraint
+  {
+    $$ = $1
+  }
+| CONSTRAINT check_constraint
+  {
+    $$ = $2
+  }
+| CONSTRAINT sql_id check_constraint
+  {
+    $3.Constraint = $2
+    $$ = $3
+  }
+
+check_constraint:
+  ID '(' boolean_expression ')' enforced_opt
+  {
+    if !strings.EqualFold($1, AST_CHECK) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &IndexDefinition{Type: AST_CHECK, Check: $3, NotEnforced: !$5}
+  }
+
+enforced_opt:
+  {
+    $$ = true
+  }
+| ID
+  {
+    if !strings.EqualFold($1, "enforced") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = true
+  }
+| NOT ID
+  {
+    if !strings.EqualFold($2, "enforced") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = false
+  }
+
+key_constraint:
+  PRIMARY KEY '(' index_column_list ')' index_using_opt
+  {
+    $$ = &IndexDefinition{Type: AST_PRIMARY_KEY, Columns: $4, Using: $6}
+  }
+| UNIQUE index_or_key_opt index_name_opt '(' index_column_list ')' index_using_opt
+  {
+    $$ = &IndexDefinition{Type: AST_UNIQUE_KEY, Name: $3, Columns: $5, Using: $7}
+  }
+| FOREIGN KEY index_name_opt '(' index_column_list ')' references
+  {
+    $$ = &IndexDefinition{Type: AST_FOREIGN_KEY, Name: $3, Columns: $5, References: $7}
+  }
+
+references:
+  REFERENCES table_id '(' index_column_list ')'
+  {
+    $$ = &References{Table: $2, Columns: $4}
+  }
+| references ON DELETE ref_action
+  {
+    $1.OnDelete = $4
+    $$ = $1
+  }
+| references ON UPDATE ref_action
+  {
+    $1.OnUpdate = $4
+    $$ = $1
+  }
+
+ref_action:
+  ID
+  {
+    switch strings.ToLower($1) {
+    case AST_CASCADE:
+      $$ = AST_CASCADE
+    case AST_RESTRICT:
+      $$ = AST_RESTRICT
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+  }
+| ID ID
+  {
+    if !strings.EqualFold($1, "no") || !strings.EqualFold($2, "action") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = AST_NO_ACTION
+  }
+| SET NULL
+  {
+    $$ = AST_SET_NULL
+  }
+| SET DEFAULT
+  {
+    $$ = AST_SET_DEFAULT
+  }
+
+index_or_key:
+  INDEX
+| KEY
+
+index_or_key_opt:
+  {}
+| index_or_key
+
+index_name_opt:
+  {
+    $$ = ColIdent{}
+  }
+| sql_id
+  {
+    $$ = $1
+  }
+
+index_column_list:
+  index_column
+  {
+    $$ = []*IndexColumn{$1}
+  }
+| index_column_list ',' index_column
+  {
+    $$ = append($1, $3)
+  }
+
+index_column:
+  sql_id length_opt
+  {
+    $$ = &IndexColumn{Column: $1, Length: $2}
+  }
+| sql_id length_opt ASC
+  {
+    $$ = &IndexColumn{Column: $1, Length: $2, Direction: AST_ASC}
+  }
+| sql_id length_opt DESC
+  {
+    $$ = &IndexColumn{Column: $1, Length: $2, Direction: AST_DESC}
+  }
+
+index_using_opt:
+  {
+    $$ = ""
+  }
+| USING sql_id
+  {
+    $$ = $2.Lowered()
+  }
+
+/*
+index_options are the options following the columns of CREATE
+INDEX, including its ALGORITHM and LOCK. Those without a value,
+as VISIBLE, are distinguished from those with one by the '=',
+number or string that must follow a name to give its value.
+*/
+index_options:
+  {
+    $$ = &IndexDefinition{}
+  }
+| index_options USING sql_id
+  {
+    $1.Using = $3.Lowered()
+    $$ = $1
+  }
+| index_options ID
+  {
+    name := strings.ToLower($2)
+    if name != AST_VISIBLE && name != AST_INVISIBLE {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $1.Options = append($1.Options, &IndexOption{Name: name})
+    $$ = $1
+  }
+| index_options ID '=' index_option_value
+  {
+    $1.Options = append($1.Options, &IndexOption{Name: strings.ToLower($2), Value: $4})
+    $$ = $1
+  }
+| index_options ID NUMBER
+  {
+    $1.Options = append($1.Options, &IndexOption{Name: strings.ToLower($2), Value: $3})
+    $$ = $1
+  }
+| index_options ID STRING
+  {
+    name := strings.ToLower($2)
+    if name != AST_COMMENT && name != "engine_attribute" && name != "secondary_engine_attribute" {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $1.Options = append($1.Options, &IndexOption{Name: name, Value: $3.Val})
+    $$ = $1
+  }
+| index_options COMMENT_KEYWORD STRING
+  {
+    $1.Options = append($1.Options, &IndexOption{Name: AST_COMMENT, Value: $3.Val})
+    $$ = $1
+  }
+| index_options LOCK equal_opt index_option_value
+  {
+    $1.Options = append($1.Options, &IndexOption{Name: AST_LOCK, Value: $4})
+    $$ = $1
+  }
+| index_options WITH ID sql_id
+  {
+    if !strings.EqualFold($3, "parser") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+    $1.Options = append($1.Options, &IndexOption{Name: AST_WITH_PARSER, Value: $4.String()})
+    $$ = $1
+  }
+
+index_option_value:
+  table_option_value
+  {
+    $$ = $1
+  }
+| DEFAULT
+  {
+    $$ = AST_DEFAULT
+  }
+
+create_table_statement:
+  CREATE temporary_opt TABLE not_exists_opt table_id '(' table_element_list ')' table_option_list_opt create_select_opt
+  {
+    $7.Temporary, $7.IfNotExists, $7.Name, $7.Options, $7.Select = $2, $4, $5, $9, $10
+    $$ = $7
+  }
+| CREATE temporary_opt TABLE not_exists_opt table_id '(' table_element_list ')' table_option_list_opt PARTITION BY partition_rest
+  {
+    $7.Temporary, $7.IfNotExists, $7.Name, $7.Options, $7.Partitions = $2, $4, $5, $9, $12
+    $$ = $7
+  }
+| CREATE temporary_opt TABLE not_exists_opt table_id create_select_options select_statement
+  {
+    $$ = &CreateTable{Temporary: $2, IfNotExists: $4, Name: $5, Options: $6, Select: $7}
+  }
+| CREATE temporary_opt TABLE not_exists_opt table_id LIKE dml_table_expression
+  {
+    $$ = &CreateTable{Temporary: $2, IfNotExists: $4, Name: $5, LikeTable: $7}
+  }
+| CREATE temporary_opt TABLE not_exists_opt table_id '(' LIKE dml_table_expression ')'
+  {
+    $$ = &CreateTable{Temporary: $2, IfNotExists: $4, Name: $5, LikeTable: $8}
+  }
+
+create_select_opt:
+  {
+    $$ = nil
+  }
+| as_kw_opt select_statement
+  {
+    $$ = $2
+  }
+
+/*
+create_select_options are the table options of a CREATE TABLE
+... SELECT without definitions. A '(' right after the table name
+starts the definitions rather than a parenthesized SELECT, which
+must follow AS.
+*/
+create_select_options:
+  %prec NO_ALIAS
+  {
+    $$ = nil
+  }
+| AS
+  {
+    $$ = nil
+  }
+| table_option_list as_kw_opt
+  {
+    $$ = $1
+  }
+
+temporary_opt:
+  {
+    $$ = false
+  }
+| ID
+  {
+    if !strings.EqualFold($1, "temporary") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = true
+  }
+
+table_option_list_opt:
+  {
+    $$ = nil
+  }
+| table_option_list
+  {
+    $$ = $1
+  }
+
+table_option_list:
+  table_option
+  {
+    $$ = TableOptions{$1}
+  }
+| table_option_list table_option
+  {
+    $$ = append($1, $2)
+  }
+| table_option_list ',' table_option
+  {
+    $$ = append($1, $3)
+  }
+
+table_option:
+  ID equal_opt table_option_value
+  {
+    name := strings.ToLower($1)
+    if !tableOptionNames[name] {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &TableOption{Name: name, Value: $3}
+  }
+| AUTO_INCREMENT equal_opt NUMBER
+  {
+    $$ = &TableOption{Name: AST_AUTO_INCREMENT, Value: $3}
+  }
+| COMMENT_KEYWORD STRING
+  {
+    $$ = &TableOption{Name: AST_COMMENT, Value: $2.Val}
+  }
+| default_opt CHARSET equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: AST_CHARACTER_SET, Value: $4}
+  }
+| default_opt CHARACTER SET equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: AST_CHARACTER_SET, Value: $5}
+  }
+| default_opt COLLATE equal_opt table_option_value
+  {
+    $$ = &TableOption{Name: AST_COLLATE, Value: $4}
+  }
+
+table_option_value:
+  ID
+  {
+    $$ = $1
+  }
+| NUMBER
+  {
+    $$ = $1
+  }
+| STRING
+  {
+    $$ = $1.Val
+  }
+
+equal_opt:
+  {}
+| '='
+  {}
+
+default_opt:
+  {}
+| DEFAULT
+  {}
+
+create_statement:
+  create_table_statement
+  {
+    $$ = $1
+  }
+| CREATE unique_opt INDEX not_exists_opt sql_id index_using_opt ON table_id '(' index_column_list ')' index_options
+  {
+    index := $12
+    index.Type, index.Name, index.Columns = AST_INDEX, $5, $10
+    if index.Using == "" {
+      index.Using = $6
+    }
+    if $2 {
+      index.Type = AST_UNIQUE_KEY
+    }
+    $$ = &DDL{Action: AST_CREATE, Kind: AST_INDEX, IfNotExists: $4, Table: $8, IndexName: $5, Index: index}
+  }
+| CREATE or_replace_opt VIEW table_id view_columns_opt AS select_statement check_option_opt
+  {
+    $$ = &DDL{Action: AST_CREATE, Kind: AST_VIEW, OrReplace: $2, Table: $4, Columns: $5, Select: $7, CheckOption: $8}
+  }
+| CREATE database_or_schema not_exists_opt table_id force_eof
+  {
+    $$ = &DDL{Action: AST_CREATE, Kind: AST_DATABASE, IfNotExists: $3, Table: $4}
+  }
+| CREATE ID not_exists_opt dml_table_expression '(' routine_body
+  {
+    kind := strings.ToLower($2)
+    if kind != AST_PROCEDURE && kind != AST_FUNCTION {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &CreateRoutine{Kind: kind, IfNotExists: $3, Name: $4, Body: "(" + $6}
+  }
+| CREATE ID not_exists_opt dml_table_expression sequence_items
+  {
+    seq, err := NewSequence(AST_CREATE, $2, $4, $5)
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    seq.IfNotExists = $3
+    $$ = seq
+  }
+
+/*
+routine_body is the rest of the statement, which is not parsed.
+It is read without a lookahead token, right after the '(' of the
+parameter list.
+*/
+routine_body:
+  {
+    $$ = yylex.(*Tokenizer).scanRest()
+  }
+
+/*
+partition_rest is the partitioning of a CREATE TABLE after its
+PARTITION BY, which is not parsed. Like routine_body, it is read
+without a lookahead token.
+*/
+partition_rest:
+  {
+    $$ = strings.TrimLeft(yylex.(*Tokenizer).scanRest(), " \n\r\t")
+  }
+
+or_replace_opt:
+  {
+    $$ = false
+  }
+| OR ID
+  {
+    if !strings.EqualFold($2, AST_REPLACE) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = true
+  }
+
+view_columns_opt:
+  {
+    $$ = nil
+  }
+| '(' sql_id_list ')'
+  {
+    $$ = $2
+  }
+
+check_option_opt:
+  {
+    $$ = ""
+  }
+| WITH_CHECK_OPTION
+  {
+    $$ = $1
+  }
+
+alter_statement:
+  ALTER ignore_opt TABLE table_id non_rename_operation force_eof
+  {
+    $$ = &DDL{Action: AST_ALTER, Kind: AST_TABLE, Table: $4}
+  }
+| ALTER ignore_opt TABLE table_id DROP PARTITION force_eof
+  {
+    $$ = &DDL{Action: AST_ALTER, Kind: AST_TABLE, Table: $4}
+  }
+| ALTER ignore_opt TABLE table_id alter_spec_list
+  {
+    $$ = &AlterTable{Ignore: $2, Table: $4, Specs: $5}
+  }
+| ALTER ignore_opt TABLE table_id RENAME to_opt table_id
+  {
+    // Change this to a rename statement
+    $$ = &DDL{Action: AST_RENAME, Kind: AST_TABLE, Table: $4, NewName: $7}
+  }
+| ALTER VIEW table_id force_eof
+  {
+    $$ = &DDL{Action: AST_ALTER, Kind: AST_VIEW, Table: $3}
+  }
+| ALTER ID dml_table_expression sequence_items
+  {
+    seq, err := NewSequence(AST_ALTER, $2, $3, $4)
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    $$ = seq
+  }
+
+alter_spec_list:
+  alter_spec
+  {
+    $$ = []*AlterSpec{$1}
+  }
+| alter_spec_list ',' alter_spec
+  {
+    $$ = append($1, $3)
+  }
+
+/*
+A column added without COLUMN is told from an index by the token
+after its name, so ADD has no column_opt.
+*/
+alter_spec:
+  ADD column_definition column_position_opt
+  {
+    $$ = $3
+    $$.Action, $$.Column = AST_ADD_COLUMN, $2
+  }
+| ADD COLUMN column_definition column_position_opt
+  {
+    $$ = $4
+    $$.Action, $$.Column = AST_ADD_COLUMN, $3
+  }
+| ADD index_definition
+  {
+    $$ = &AlterSpec{Action: AST_ADD_INDEX, Index: $2}
+  }
+| DROP column_opt sql_id
+  {
+    $$ = &AlterSpec{Action: AST_DROP_COLUMN, Name: $3}
+  }
+| DROP index_or_key sql_id
+  {
+    $$ = &AlterSpec{Action: AST_DROP_INDEX, Name: $3}
+  }
+| DROP PRIMARY KEY
+  {
+    $$ = &AlterSpec{Action: AST_DROP_PRIMARY_KEY}
+  }
+| DROP FOREIGN KEY sql_id
+  {
+    $$ = &AlterSpec{Action: AST_DROP_FOREIGN_KEY, Name: $4}
+  }
+| MODIFY column_opt column_definition column_position_opt
+  {
+    $$ = $4
+    $$.Action, $$.Column = AST_MODIFY_COLUMN, $3
+  }
+| CHANGE column_opt sql_id column_definition column_position_opt
+  {
+    $$ = $5
+    $$.Action, $$.Name, $$.Column = AST_CHANGE_COLUMN, $3, $4
+  }
+| RENAME COLUMN sql_id TO sql_id
+  {
+    $$ = &AlterSpec{Action: AST_RENAME_COLUMN, Name: $3, NewName: $5}
+  }
+| RENAME index_or_key sql_id TO sql_id
+  {
+    $$ = &AlterSpec{Action: AST_RENAME_INDEX, Name: $3, NewName: $5}
+  }
+| table_option
+  {
+    $$ = &AlterSpec{Action: AST_TABLE_OPTION, Option: $1}
+  }
+
+column_opt:
+  {}
+| COLUMN
+  {}
+
+column_position_opt:
+  {
+    $$ = &AlterSpec{}
+  }
+| ID
+  {
+    if !strings.EqualFold($1, "first") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &AlterSpec{First: true}
+  }
+| ID sql_id
+  {
+    if !strings.EqualFold($1, "after") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &AlterSpec{After: $2}
+  }
+
+rename_statement:
+  RENAME TABLE table_rename_list
+  {
+    $$ = &DDL{Action: AST_RENAME, Kind: AST_TABLE, Table: $3[0].From, NewName: $3[0].To, Renames: $3}
+  }
+
+table_rename_list:
+  table_id TO table_id
+  {
+    $$ = []*TableRename{{From: $1, To: $3}}
+  }
+| table_rename_list ',' table_id TO table_id
+  {
+    $$ = append($1, &TableRename{From: $3, To: $5})
+  }
+
+truncate_statement:
+  ID TABLE table_id
+  {
+    if !strings.EqualFold($1, AST_TRUNCATE) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &DDL{Action: AST_TRUNCATE, Kind: AST_TABLE, Table: $3}
+  }
+
+drop_statement:
+  DROP temporary_opt TABLE exists_opt table_id_list
+  {
+    $$ = &DDL{Action: AST_DROP, Kind: AST_TABLE, Temporary: $2, IfExists: $4, Table: $5[0], Tables: $5}
+  }
+| DROP INDEX exists_opt sql_id ON table_id
+  {
+    $$ = &DDL{Action: AST_DROP, Kind: AST_INDEX, IfExists: $3, Table: $6, IndexName: $4}
+  }
+| DROP VIEW exists_opt table_id force_eof
+  {
+    $$ = &DDL{Action: AST_DROP, Kind: AST_VIEW, IfExists: $3, Table: $4}
+  }
+| DROP database_or_schema exists_opt table_id
+  {
+    $$ = &DDL{Action: AST_DROP, Kind: AST_DATABASE, IfExists: $3, Table: $4}
+  }
+| DROP ID exists_opt dml_table_expression
+  {
+    if strings.EqualFold($2, "prepare") && !$3 && $4.Qualifier.IsEmpty() {
+      // DROP PREPARE is a synonym for DEALLOCATE PREPARE.
+      $$ = &Deallocate{Name: makeColIdent($4.Name.val, $4.Name.quoted)}
+    } else {
+      seq, err := NewSequence(AST_DROP, $2, $4, nil)
+      if err != nil {
+        yylex.Error(err.Error())
+        return 1
+      }
+      seq.IfExists = $3
+      $$ = seq
+    }
+  }
+
+analyze_statement:
+  ANALYZE TABLE table_id
+  {
+    $$ = &DDL{Action: AST_ALTER, Kind: AST_TABLE, Table: $3}
+  }
+
+other_statement:
+  SHOW show_word_list show_filter_opt
+  {
+    show, err := newShow($2, nil, TableIdent{})
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    show.Filter = $3
+    $$ = show
+  }
+| SHOW show_word_list from_or_in dml_table_expression show_filter_opt
+  {
+    show, err := newShow($2, $4, TableIdent{})
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    show.Filter = $5
+    $$ = show
+  }
+| SHOW show_word_list from_or_in dml_table_expression from_or_in table_id show_filter_opt
+  {
+    show, err := newShow($2, $4, $6)
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    show.Filter = $7
+    $$ = show
+  }
+| SHOW CREATE TABLE dml_table_expression
+  {
+    $$ = &Show{Type: AST_SHOW_CREATE_TABLE, Table: $4}
+  }
+| SHOW CREATE VIEW dml_table_expression
+  {
+    $$ = &Show{Type: AST_CREATE + " " + AST_VIEW, Table: $4}
+  }
+| SHOW CREATE DATABASE table_id
+  {
+    $$ = &Show{Type: AST_CREATE + " " + AST_DATABASE, Database: $4}
+  }
+| SHOW CREATE ID dml_table_expression
+  {
+    switch kind := strings.ToLower($3); kind {
+    case "procedure", "function", "trigger", "event":
+      $$ = &Show{Type: AST_CREATE + " " + kind, Table: $4}
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+  }
+| explain_verb dml_table_expression
+  {
+    $$ = &Describe{Table: $2}
+  }
+| explain_verb explain_format_opt explainable_statement
+  {
+    $$ = &Explain{Statement: $3, OutputFormat: $2}
+  }
+
+show_word_list:
+  show_word
+  {
+    $$ = []string{$1}
+  }
+| show_word_list show_word
+  {
+    $$ = append($1, $2)
+  }
+
+show_word:
+  ID
+  {
+    if $<quoted>1 {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = strings.ToLower($1)
+  }
+| TABLE
+  {
+    $$ = AST_TABLE
+  }
+| INDEX
+  {
+    $$ = AST_SHOW_INDEX
+  }
+| CHARACTER SET
+  {
+    $$ = AST_CHARACTER_SET
+  }
+
+from_or_in:
+  FROM
+| IN
+
+show_filter_opt:
+  {
+    $$ = nil
+  }
+| LIKE STRING
+  {
+    $$ = &ShowFilter{Like: $2.Val}
+  }
+| WHERE boolean_expression
+  {
+    $$ = &ShowFilter{Filter: $2}
+  }
+
+explain_verb:
+  EXPLAIN
+| DESCRIBE
+| DESC
+
+explain_format_opt:
+  {
+    $$ = ""
+  }
+| ID '=' ID
+  {
+    if !strings.EqualFold($1, "format") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = strings.ToLower($3)
+  }
+
+explainable_statement:
+  select_statement
+  {
+    $$ = $1
+  }
+| insert_statement
+| update_statement
+| delete_statement
+
+cursor_statement:
+  DECLARE sql_id CURSOR FOR select_statement
+  {
+    $$ = &DeclareCursor{Name: $2, Select: $5}
+  }
+| ID sql_id
+  {
+    // COMMIT WORK, ROLLBACK WORK, START TRANSACTION,
+    // SAVEPOINT, EXECUTE, CALL and TRUNCATE take this form
+    // too, as none of their words are reserved.
+    switch word := strings.ToLower($1); {
+    case word == AST_OPEN:
+      $$ = &OpenCursor{Name: $2}
+    case word == AST_CLOSE:
+      $$ = &CloseCursor{Name: $2}
+    case word == "commit" && $2.EqualString("work"):
+      $$ = &Commit{}
+    case word == "rollback" && $2.EqualString("work"):
+      $$ = &Rollback{}
+    case word == "start" && $2.EqualString("transaction"):
+      $$ = &Begin{}
+    case word == "savepoint":
+      $$ = &Savepoint{Name: $2}
+    case word == "execute":
+      $$ = &Execute{Name: $2}
+    case word == AST_TRUNCATE:
+      $$ = &DDL{Action: AST_TRUNCATE, Kind: AST_TABLE, Table: makeTableIdent($2.val, $2.quoted)}
+    case word == "call":
+      $$ = &Call{Name: NodeArena(yylex).tableName(TableName{Name: makeTableIdent($2.val, $2.quoted)})}
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+  }
+| FETCH sql_id INTO fetch_var_list
+  {
+    $$ = &FetchCursor{Name: $2, Into: $4}
+  }
+| FETCH FROM sql_id INTO fetch_var_list
+  {
+    $$ = &FetchCursor{Name: $3, Into: $5}
+  }
+| FETCH sql_id FROM sql_id INTO fetch_var_list
+  {
+    if !$2.EqualString("next") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2.String()))
+      return 1
+    }
+    $$ = &FetchCursor{Name: $4, Into: $6}
+  }
+
+fetch_var_list:
+  fetch_var
+  {
+    $$ = []ColIdent{$1}
+  }
+| fetch_var_list ',' fetch_var
+  {
+    $$ = append($1, $3)
+  }
+
+fetch_var:
+  sql_id
+| USER_VAR
+  {
+    $$ = NewColIdent("@" + $1)
+  }
+
+prepare_statement:
+  ID sql_id FROM prepare_source
+  {
+    if !strings.EqualFold($1, "prepare") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Prepare{Name: $2, Stmt: $4}
+  }
+| ID sql_id USING user_var_list
+  {
+    if !strings.EqualFold($1, "execute") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &Execute{Name: $2, Using: $4}
+  }
+| ID ID sql_id
+  {
+    // RELEASE SAVEPOINT takes this form too.
+    switch {
+    case strings.EqualFold($1, "deallocate") && strings.EqualFold($2, "prepare"):
+      $$ = &Deallocate{Name: $3}
+    case strings.EqualFold($1, "release") && strings.EqualFold($2, "savepoint"):
+      $$ = &ReleaseSavepoint{Name: $3}
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+  }
+
+prepare_source:
+  STRING
+  {
+    $$ = $1
+  }
+| user_var
+  {
+    $$ = $1
+  }
+
+user_var_list:
+  user_var
+  {
+    $$ = []*UserVar{$1}
+  }
+| user_var_list ',' user_var
+  {
+    $$ = append($1, $3)
+  }
+
+compound_statement:
+  BEGIN statement_list END end_label_opt
+  {
+    $$ = &Block{Statements: $2, EndLabel: $4}
+  }
+| sql_id ':' BEGIN statement_list END end_label_opt
+  {
+    $$ = &Block{Label: $1, Statements: $4, EndLabel: $6}
+  }
+| IF boolean_expression THEN statement_list elseif_list else_statements_opt END IF
+  {
+    $$ = &IfStatement{Cond: $2, Then: $4, ElseIfs: $5, Else: $6}
+  }
+| begin_label_opt WHILE boolean_expression DO statement_list END WHILE end_label_opt
+  {
+    $$ = &While{Label: $1, Cond: $3, Body: $5, EndLabel: $8}
+  }
+| begin_label_opt LOOP statement_list END LOOP end_label_opt
+  {
+    $$ = &Loop{Label: $1, Body: $3, EndLabel: $6}
+  }
+| begin_label_opt REPEAT statement_list UNTIL boolean_expression END REPEAT end_label_opt
+  {
+    $$ = &Repeat{Label: $1, Body: $3, Until: $5, EndLabel: $8}
+  }
+| LEAVE sql_id
+  {
+    $$ = &Leave{Label: $2}
+  }
+| ITERATE sql_id
+  {
+    $$ = &Iterate{Label: $2}
+  }
+| DECLARE sql_id_list data_type default_value_opt
+  {
+    $$ = &DeclareVars{Names: $2, Type: $3, Default: $4}
+  }
+| DECLARE handler_action ID FOR handler_condition_list command
+  {
+    if !strings.EqualFold($3, "handler") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+    $$ = &DeclareHandler{Action: $2, Conditions: $5, Body: $6}
+  }
+
+statement_list:
+  {
+    $$ = nil
+  }
+| statement_list command ';'
+  {
+    $$ = append($1, $2)
+  }
+
+elseif_list:
+  {
+    $$ = nil
+  }
+| elseif_list ELSEIF boolean_expression THEN statement_list
+  {
+    $$ = append($1, &ElseIf{Cond: $3, Then: $5})
+  }
+
+else_statements_opt:
+  {
+    $$ = nil
+  }
+| ELSE statement_list
+  {
+    $$ = $2
+    if $$ == nil {
+      $$ = Statements{}
+    }
+  }
+
+begin_label_opt:
+  {
+    $$ = ColIdent{}
+  }
+| sql_id ':'
+  {
+    $$ = $1
+  }
+
+end_label_opt:
+  {
+    $$ = ColIdent{}
+  }
+| sql_id
+  {
+    $$ = $1
+  }
+
+default_value_opt:
+  {
+    $$ = nil
+  }
+| DEFAULT value_expression
+  {
+    $$ = $2
+  }
+
+handler_action:
+  CONTINUE
+  {
+    $$ = AST_CONTINUE
+  }
+| EXIT
+  {
+    $$ = AST_EXIT
+  }
+
+handler_condition_list:
+  handler_condition
+  {
+    $$ = []*HandlerCondition{$1}
+  }
+| handler_condition_list ',' handler_condition
+  {
+    $$ = append($1, $3)
+  }
+
+handler_condition:
+  SQLEXCEPTION
+  {
+    $$ = &HandlerCondition{Type: AST_SQLEXCEPTION}
+  }
+| SQLWARNING
+  {
+    $$ = &HandlerCondition{Type: AST_SQLWARNING}
+  }
+| NOT ID
+  {
+    if !strings.EqualFold($2, "found") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &HandlerCondition{Type: AST_NOT_FOUND}
+  }
+| SQLSTATE STRING
+  {
+    $$ = &HandlerCondition{Type: AST_SQLSTATE, Value: $2.Val}
+  }
+| SQLSTATE ID STRING
+  {
+    if !strings.EqualFold($2, "value") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &HandlerCondition{Type: AST_SQLSTATE, Value: $3.Val}
+  }
+| NUMBER
+  {
+    $$ = &HandlerCondition{Type: AST_ERROR_CODE, Value: $1}
+  }
+| sql_id
+  {
+    $$ = &HandlerCondition{Type: AST_CONDITION_NAME, Value: $1.String()}
+  }
+
+signal_statement:
+  SIGNAL signal_condition signal_items_opt
+  {
+    $$ = &Signal{Action: AST_SIGNAL, Condition: $2, Items: $3}
+  }
+| RESIGNAL signal_items_opt
+  {
+    $$ = &Signal{Action: AST_RESIGNAL, Items: $2}
+  }
+| RESIGNAL signal_condition signal_items_opt
+  {
+    $$ = &Signal{Action: AST_RESIGNAL, Condition: $2, Items: $3}
+  }
+
+signal_condition:
+  SQLSTATE STRING
+  {
+    $$ = &HandlerCondition{Type: AST_SQLSTATE, Value: $2.Val}
+  }
+| SQLSTATE ID STRING
+  {
+    if !strings.EqualFold($2, "value") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &HandlerCondition{Type: AST_SQLSTATE, Value: $3.Val}
+  }
+| sql_id
+  {
+    $$ = &HandlerCondition{Type: AST_CONDITION_NAME, Value: $1.String()}
+  }
+
+signal_items_opt:
+  {
+    $$ = nil
+  }
+| SET signal_item_list
+  {
+    $$ = $2
+  }
+
+signal_item_list:
+  signal_item
+  {
+    $$ = []*SignalItem{$1}
+  }
+| signal_item_list ',' signal_item
+  {
+    $$ = append($1, $3)
+  }
+
+signal_item:
+  ID '=' value_expression
+  {
+    name := strings.ToLower($1)
+    if !signalItemNames[name] {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &SignalItem{Name: name, Value: $3}
+  }
+
+/* The count of SELECT NEXT n VALUES FROM seq. */
+next_count:
+  NUMBER VALUES
+  {
+    $$ = NumVal($1)
+  }
+| VALUE_ARG VALUES
+  {
+    $$ = ValArg($1)
+  }
+
+comment_opt:
+  {
+    SetAllowComments(yylex, true)
+  }
+  comment_list
+  {
+    $$ = $2
+    SetAllowComments(yylex, false)
+  }
+
+comment_list:
+  {
+    $$ = nil
+  }
+| comment_list COMMENT
+  {
+    $$ = append($1, $2)
+  }
+
+union_op:
+  UNION
+  {
+    $$ = AST_UNION
+  }
+| UNION ALL
+  {
+    $$ = AST_UNION_ALL
+  }
+| UNION DISTINCT
+  {
+    $$ = AST_UNION_DISTINCT
+  }
+| MINUS
+  {
+    $$ = AST_SET_MINUS
+  }
+| EXCEPT
+  {
+    $$ = AST_EXCEPT
+  }
+| EXCEPT ALL
+  {
+    $$ = AST_EXCEPT_ALL
+  }
+| EXCEPT DISTINCT
+  {
+    $$ = AST_EXCEPT_DISTINCT
+  }
+
+intersect_op:
+  INTERSECT
+  {
+    $$ = AST_INTERSECT
+  }
+| INTERSECT ALL
+  {
+    $$ = AST_INTERSECT_ALL
+  }
+| INTERSECT DISTINCT
+  {
+    $$ = AST_INTERSECT_DISTINCT
+  }
+
+select_options:
+  {
+    $$ = &Select{}
+  }
+| select_options DISTINCT
+  {
+    $1.Distinct = AST_DISTINCT
+    $$ = $1
+  }
+| select_options DISTINCTROW
+  {
+    $1.Distinct = AST_DISTINCTROW
+    $$ = $1
+  }
+| select_options HIGH_PRIORITY
+  {
+    $1.HighPriority = true
+    $$ = $1
+  }
+| select_options STRAIGHT_JOIN
+  {
+    $1.StraightJoin = true
+    $$ = $1
+  }
+| select_options SQL_SMALL_RESULT
+  {
+    if $1.ResultSize != "" {
+      yylex.Error("conflicting result size options")
+      return 1
+    }
+    $1.ResultSize = AST_SQL_SMALL_RESULT
+    $$ = $1
+  }
+| select_options SQL_BIG_RESULT
+  {
+    if $1.ResultSize != "" {
+      yylex.Error("conflicting result size options")
+      return 1
+    }
+    $1.ResultSize = AST_SQL_BIG_RESULT
+    $$ = $1
+  }
+| select_options SQL_BUFFER_RESULT
+  {
+    $1.BufferResult = true
+    $$ = $1
+  }
+| select_options SQL_CACHE
+  {
+    if $1.Cache != "" {
+      yylex.Error("conflicting cache options")
+      return 1
+    }
+    $1.Cache = AST_SQL_CACHE
+    $$ = $1
+  }
+| select_options SQL_NO_CACHE
+  {
+    if $1.Cache != "" {
+      yylex.Error("conflicting cache options")
+      return 1
+    }
+    $1.Cache = AST_SQL_NO_CACHE
+    $$ = $1
+  }
+| select_options SQL_CALC_FOUND_ROWS
+  {
+    $1.CalcFoundRows = true
+    $$ = $1
+  }
+| select_options MAX_STATEMENT_TIME '=' NUMBER
+  {
+    $1.MaxStatementTime = NumVal($4)
+    $$ = $1
+  }
+
+select_expression_list:
+  select_expression
+  {
+    $$ = SelectExprs{$1}
+  }
+| select_expression_list ',' select_expression
+  {
+    $$ = append($$, $3)
+  }
+
+select_expression:
+  '*'
+  {
+    $$ = &StarExpr{}
+  }
+| expression as_lower_opt
+  {
+    $$ = NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: $1, As: $2})
+  }
+| table_id '.' '*'
+  {
+    $$ = &StarExpr{TableName: $1}
+  }
+
+expression:
+  boolean_expression
+  {
+    $$ = $1
+  }
+| value_expression
+  {
+    $$ = $1
+  }
+
+as_lower_opt:
+  %prec NO_ALIAS
+  {
+    $$ = ColIdent{}
+  }
+| sql_id
+  {
+    $$ = $1
+  }
+| AS sql_id
+  {
+    $$ = $2
+  }
+
+from_opt:
+  {
+    $$ = nil
+  }
+| FROM table_expression_list
+  {
+    $$ = $2
+  }
+
+table_expression_list:
+  table_expression
+  {
+    $$ = TableExprs{$1}
+  }
+| table_expression_list ',' table_expression
+  {
+    $$ = append($$, $3)
+  }
+
+table_expression:
+  simple_table_expression partition_opt system_time_opt as_opt index_hint_list
+  {
+    $$ = NodeArena(yylex).aliasedTableExpr(AliasedTableExpr{Expr:$1, Partitions: $2, SystemTime: $3, As: $4, Hints: $5})
+  }
+| simple_table_expression partition_opt system_time_opt table_alias '(' sql_id_list ')' index_hint_list
+  {
+    $$ = NodeArena(yylex).aliasedTableExpr(AliasedTableExpr{Expr:$1, Partitions: $2, SystemTime: $3, As: $4, Columns: $6, Hints: $8})
+  }
+| LATERAL subquery as_opt
+  {
+    $$ = NodeArena(yylex).aliasedTableExpr(AliasedTableExpr{Expr: $2, As: $3, Lateral: true})
+  }
+| LATERAL subquery table_alias '(' sql_id_list ')'
+  {
+    $$ = NodeArena(yylex).aliasedTableExpr(AliasedTableExpr{Expr: $2, As: $3, Columns: $5, Lateral: true})
+  }
+| '(' table_expression ')'
+  {
+    $$ = &ParenTableExpr{Expr: $2}
+  }
+/*
+A join without a condition binds more loosely than the joins
+after it, so that in a JOIN b JOIN c ON x ON y the ON clauses
+nest, as in MySQL. NATURAL JOIN takes no condition and binds
+left to right.
+*/
+| table_expression join_type table_expression %prec CONDITIONLESS_JOIN
+  {
+    $$ = &JoinTableExpr{LeftExpr: $1, Join: $2, RightExpr: $3}
+  }
+| table_expression join_type table_expression ON boolean_expression %prec JOIN
+  {
+    $$ = &JoinTableExpr{LeftExpr: $1, Join: $2, RightExpr: $3, On: $5}
+  }
+| table_expression join_type table_expression USING '(' column_list ')' %prec JOIN
+  {
+    $$ = &JoinTableExpr{LeftExpr: $1, Join: $2, RightExpr: $3, Using: $6}
+  }
+| table_expression NATURAL JOIN table_expression %prec JOIN
+  {
+    $$ = &JoinTableExpr{LeftExpr: $1, Join: AST_NATURAL_JOIN, RightExpr: $4}
+  }
+| table_expression PIVOT '(' select_expression_list FOR column_name IN '(' select_expression_list ')' ')' as_opt
+  {
+    $$ = &PivotTableExpr{Expr: $1, Aggregates: $4, For: $6, In: $9, As: $12}
+  }
+| table_expression UNPIVOT '(' column_name FOR column_name IN '(' select_expression_list ')' ')' as_opt
+  {
+    $$ = &UnpivotTableExpr{Expr: $1, Value: $4, For: $6, In: $9, As: $12}
+  }
+
+partition_opt:
+  {
+    $$ = nil
+  }
+| PARTITION '(' sql_id_list ')'
+  {
+    $$ = Partitions($3)
+  }
+
+system_time_opt:
+  {
+    $$ = nil
+  }
+| FOR_SYSTEM_TIME AS ID value_expression
+  {
+    if !strings.EqualFold($3, "of") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+    $$ = &SystemTime{Type: AST_SYSTEM_TIME_AS_OF, From: $4}
+  }
+| FOR_SYSTEM_TIME FROM value_expression TO value_expression
+  {
+    $$ = &SystemTime{Type: AST_SYSTEM_TIME_FROM_TO, From: $3, To: $5}
+  }
+| FOR_SYSTEM_TIME BETWEEN value_expression AND value_expression
+  {
+    $$ = &SystemTime{Type: AST_SYSTEM_TIME_BETWEEN, From: $3, To: $5}
+  }
+| FOR_SYSTEM_TIME ALL
+  {
+    $$ = &SystemTime{Type: AST_SYSTEM_TIME_ALL}
+  }
+
+as_opt:
+  %prec NO_ALIAS
+  {
+    $$ = TableIdent{}
+  }
+| table_alias
+
+table_alias:
+  table_id
+  {
+    $$ = $1
+  }
+| AS table_id
+  {
+    $$ = $2
+  }
+
+join_type:
+  JOIN
+  {
+    $$ = AST_JOIN
+  }
+| STRAIGHT_JOIN
+  {
+    $$ = AST_STRAIGHT_JOIN
+  }
+| LEFT JOIN
+  {
+    $$ = AST_LEFT_JOIN
+  }
+| LEFT OUTER JOIN
+  {
+    $$ = AST_LEFT_JOIN
+  }
+| RIGHT JOIN
+  {
+    $$ = AST_RIGHT_JOIN
+  }
+| RIGHT OUTER JOIN
+  {
+    $$ = AST_RIGHT_JOIN
+  }
+| INNER JOIN
+  {
+    $$ = AST_JOIN
+  }
+| CROSS JOIN
+  {
+    $$ = AST_CROSS_JOIN
+  }
+
+simple_table_expression:
+table_id
+  {
+    $$ = NodeArena(yylex).tableName(TableName{Name: $1})
+  }
+| table_id '.' table_id
+  {
+    $$ = NodeArena(yylex).tableName(TableName{Qualifier: $1, Name: $3})
+  }
+| subquery
+  {
+    $$ = $1
+  }
+| table_id '(' ')'
+  {
+    $$ = &FuncExpr{Name: NewColIdent($1.String())}
+  }
+| table_id '(' select_expression_list ')'
+  {
+    $$ = &FuncExpr{Name: NewColIdent($1.String()), Exprs: $3}
+  }
+| JSON_TABLE '(' value_expression ',' STRING ID '(' json_table_column_list ')' ')'
+  {
+    if !strings.EqualFold($6, "columns") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $6))
+      return 1
+    }
+    $$ = &JSONTableExpr{Expr: $3, Path: $5, Columns: $8}
+  }
+
+json_table_column_list:
+  json_table_column
+  {
+    $$ = []*JSONTableColumn{$1}
+  }
+| json_table_column_list ',' json_table_column
+  {
+    $$ = append($1, $3)
+  }
+
+/*
+Column names are matched as ID rather than sql_id, so that
+NESTED PATH can be told apart from a column named nested.
+*/
+json_table_column:
+  ID FOR ID
+  {
+    if !strings.EqualFold($3, "ordinality") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+    $$ = &JSONTableColumn{Kind: AST_FOR_ORDINALITY, Name: makeColIdent($1, $<quoted>1)}
+  }
+| ID cast_type ID STRING json_table_response_list
+  {
+    if !strings.EqualFold($3, AST_PATH) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+    $$ = $5
+    $$.Kind, $$.Name, $$.Type, $$.Path = AST_PATH, makeColIdent($1, $<quoted>1), $2, $4
+  }
+| ID cast_type EXISTS ID STRING
+  {
+    if !strings.EqualFold($4, AST_PATH) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $4))
+      return 1
+    }
+    $$ = &JSONTableColumn{Kind: AST_EXISTS_PATH, Name: makeColIdent($1, $<quoted>1), Type: $2, Path: $5}
+  }
+| ID STRING ID '(' json_table_column_list ')'
+  {
+    if !strings.EqualFold($1, "nested") || !strings.EqualFold($3, "columns") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &JSONTableColumn{Kind: AST_NESTED_PATH, Path: $2, Columns: $5}
+  }
+| ID ID STRING ID '(' json_table_column_list ')'
+  {
+    if !strings.EqualFold($1 + " " + $2, AST_NESTED_PATH) || !strings.EqualFold($4, "columns") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &JSONTableColumn{Kind: AST_NESTED_PATH, Path: $3, Columns: $6}
+  }
+
+json_table_response_list:
+  {
+    $$ = &JSONTableColumn{}
+  }
+| json_table_response_list json_table_response ON ID
+  {
+    switch {
+    case strings.EqualFold($4, "empty") && $1.OnEmpty == nil && $1.OnError == nil:
+      $1.OnEmpty = $2
+    case strings.EqualFold($4, AST_ERROR) && $1.OnError == nil:
+      $1.OnError = $2
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $4))
+      return 1
+    }
+  }
+
+json_table_response:
+  NULL
+  {
+    $$ = &JSONTableResponse{Type: AST_NULL}
+  }
+| ID
+  {
+    if !strings.EqualFold($1, AST_ERROR) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = &JSONTableResponse{Type: AST_ERROR}
+  }
+| DEFAULT STRING
+  {
+    $$ = &JSONTableResponse{Type: AST_DEFAULT, Default: $2}
+  }
+
+dml_table_expression:
+table_id
+  {
+    $$ = NodeArena(yylex).tableName(TableName{Name: $1})
+  }
+| table_id '.' table_id
+  {
+    $$ = NodeArena(yylex).tableName(TableName{Qualifier: $1, Name: $3})
+  }
+
+index_hint_list:
+  {
+    $$ = nil
+  }
+| USE INDEX '(' sql_id_list ')'
+  {
+    $$ = &IndexHints{Type: AST_USE, Indexes: $4}
+  }
+| IGNORE INDEX '(' sql_id_list ')'
+  {
+    $$ = &IndexHints{Type: AST_IGNORE, Indexes: $4}
+  }
+| FORCE INDEX '(' sql_id_list ')'
+  {
+    $$ = &IndexHints{Type: AST_FORCE, Indexes: $4}
+  }
+
+sql_id_list:
+  sql_id
+  {
+    $$ = []ColIdent{$1}
+  }
+| sql_id_list ',' sql_id
+  {
+    $$ = append($1, $3)
+  }
+
+where_expression_opt:
+  {
+    $$ = nil
+  }
+| WHERE boolean_expression
+  {
+    $$ = NewWhere(AST_WHERE, $2)
+  }
+
+boolean_expression:
+  condition
+| boolean_expression AND boolean_expression
+  {
+    $$ = NodeArena(yylex).andExpr(AndExpr{Left: $1, Right: $3})
+  }
+| boolean_expression OR boolean_expression
+  {
+    $$ = NodeArena(yylex).orExpr(OrExpr{Left: $1, Right: $3})
+  }
+| NOT boolean_expression
+  {
+    $$ = &NotExpr{Expr: $2}
+  }
+| '(' boolean_expression ')'
+  {
+    $$ = &ParenBoolExpr{Expr: $2}
+  }
+| boolean_expression IS is_value
+  {
+    $$ = &IsExpr{Operator: "is " + $3, Expr: $1}
+  }
+| boolean_expression IS NOT is_value
+  {
+    $$ = &IsExpr{Operator: "is not " + $4, Expr: $1}
+  }
+
+condition:
+  match_expression
+  {
+    $$ = $1
+  }
+| match_expression compare value_expression
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: $2, Right: $3})
+  }
+| value_expression compare value_expression
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: $2, Right: $3})
+  }
+| value_expression compare ALL subquery
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: $2, Quantifier: AST_ALL, Right: $4})
+  }
+| value_expression compare ANY subquery
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: $2, Quantifier: $3, Right: $4})
+  }
+| value_expression IN col_tuple
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_IN, Right: $3})
+  }
+| value_expression NOT IN col_tuple
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_NOT_IN, Right: $4})
+  }
+| value_expression LIKE value_expression like_escape_opt
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_LIKE, Right: $3, Escape: $4})
+  }
+| value_expression NOT LIKE value_expression like_escape_opt
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_NOT_LIKE, Right: $4, Escape: $5})
+  }
+| value_expression REGEXP value_expression
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_REGEXP, Right: $3})
+  }
+| value_expression NOT REGEXP value_expression
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_NOT_REGEXP, Right: $4})
+  }
+| value_expression NOT_REGEXP value_expression
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_NOT_REGEXP, Right: $3})
+  }
+| value_expression SOUNDS_LIKE value_expression
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_SOUNDS_LIKE, Right: $3})
+  }
+| value_expression ILIKE value_expression
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_ILIKE, Right: $3})
+  }
+| value_expression NOT ILIKE value_expression
+  {
+    $$ = NodeArena(yylex).comparisonExpr(ComparisonExpr{Left: $1, Operator: AST_NOT_ILIKE, Right: $4})
+  }
+| value_expression BETWEEN value_expression AND value_expression
+  {
+    $$ = &RangeCond{Left: $1, Operator: AST_BETWEEN, From: $3, To: $5}
+  }
+| value_expression NOT BETWEEN value_expression AND value_expression
+  {
+    $$ = &RangeCond{Left: $1, Operator: AST_NOT_BETWEEN, From: $4, To: $6}
+  }
+| value_expression IS NULL
+  {
+    $$ = &NullCheck{Operator: AST_IS_NULL, Expr: $1}
+  }
+| value_expression IS NOT NULL
+  {
+    $$ = &NullCheck{Operator: AST_IS_NOT_NULL, Expr: $1}
+  }
+| value_expression IS is_value
+  {
+    $$ = &IsExpr{Operator: "is " + $3, Expr: $1}
+  }
+| value_expression IS NOT is_value
+  {
+    $$ = &IsExpr{Operator: "is not " + $4, Expr: $1}
+  }
+| EXISTS subquery
+  {
+    $$ = &ExistsExpr{Subquery: $2}
+  }
+
+compare:
+  '='
+  {
+    $$ = AST_EQ
+  }
+| '<'
+  {
+    $$ = AST_LT
+  }
+| '>'
+  {
+    $$ = AST_GT
+  }
+| LE
+  {
+    $$ = AST_LE
+  }
+| GE
+  {
+    $$ = AST_GE
+  }
+| NE
+  {
+    $$ = AST_NE
+  }
+| NULL_SAFE_EQUAL
+  {
+    $$ = AST_NSE
+  }
+
+col_tuple:
+  '(' value_expression_list ')'
+  {
+    $$ = ValTuple($2)
+  }
+| subquery
+  {
+    $$ = $1
+  }
+| LIST_ARG
+  {
+    $$ = ListArg($1)
+  }
+
+subquery:
+  '(' select_statement ')'
+  {
+    $$ = &Subquery{$2}
+  }
+
+value_expression_list:
+  value_expression
+  {
+    $$ = ValExprs{$1}
+  }
+| value_expression_list ',' value_expression
+  {
+    $$ = append($1, $3)
+  }
+
+value_expression:
+  '*'
+  {
+    $$ = &StarExpr{}
+  }
+| value
+  {
+    $$ = $1
+  }
+| column_name
+  {
+    $$ = NextValColumn(yylex, $1)
+  }
+| user_var
+  {
+    $$ = $1
+  }
+| user_var ASSIGN value_expression
+  {
+    $$ = &AssignExpr{Var: $1, Expr: $3}
+  }
+| NEXT_VALUE_FOR dml_table_expression
+  {
+    $$ = &NextValExpr{Sequence: $2}
+  }
+| ARRAY '[' ']'
+  {
+    $$ = &ArrayExpr{}
+  }
+| ARRAY '[' value_expression_list ']'
+  {
+    $$ = &ArrayExpr{Elems: $3}
+  }
+| STRUCT '(' select_expression_list ')'
+  {
+    $$ = &StructExpr{Fields: $3}
+  }
+| value_expression TYPECAST cast_type
+  {
+    $$ = &CastExpr{Expr: $1, Type: $3}
+  }
+| value_expression JSON_EXTRACT_OP STRING
+  {
+    $$ = &JSONExtractExpr{Expr: $1, Path: $3}
+  }
+| value_expression JSON_UNQUOTE_EXTRACT_OP STRING
+  {
+    $$ = &JSONExtractExpr{Expr: $1, Path: $3, Unquote: true}
+  }
+| value_expression '[' value_expression ']'
+  {
+    if !yylex.(*Tokenizer).opts.Dialect.hasArrays() {
+      yylex.Error("subscripts are not supported in this dialect")
+      return 1
+    }
+    $$ = &SubscriptExpr{Expr: $1, Index: $3}
+  }
+| row_tuple
+  {
+    if tuple, ok := $1.(ValTuple); ok && len(tuple) == 1 {
+      $$ = &ParenExpr{Expr: tuple[0]}
+    } else {
+      $$ = $1
+    }
+  }
+| value_expression '&' value_expression
+  {
+    $$ = NodeArena(yylex).binaryExpr(BinaryExpr{Left: $1, Operator: AST_BITAND, Right: $3})
+  }
+| value_expression '|' value_expression
+  {
+    $$ = NodeArena(yylex).binaryExpr(BinaryExpr{Left: $1, Operator: AST_BITOR, Right: $3})
+  }
+| value_expression '^' value_expression
+  {
+    $$ = NodeArena(yylex).binaryExpr(BinaryExpr{Left: $1, Operator: AST_BITXOR, Right: $3})
+  }
+| value_expression '+' value_expression
+  {
+    $$ = NodeArena(yylex).binaryExpr(BinaryExpr{Left: $1, Operator: AST_PLUS, Right: $3})
+  }
+| value_expression '-' value_expression
+  {
+    $$ = NodeArena(yylex).binaryExpr(BinaryExpr{Left: $1, Operator: AST_MINUS, Right: $3})
+  }
+| value_expression '*' value_expression
+  {
+    $$ = NodeArena(yylex).binaryExpr(BinaryExpr{Left: $1, Operator: AST_MULT, Right: $3})
+  }
+| value_expression '/' value_expression
+  {
+    $$ = NodeArena(yylex).binaryExpr(BinaryExpr{Left: $1, Operator: AST_DIV, Right: $3})
+  }
+| value_expression '%' value_expression
+  {
+    $$ = NodeArena(yylex).binaryExpr(BinaryExpr{Left: $1, Operator: AST_MOD, Right: $3})
+  }
+| UNDERSCORE_CHARSET value_expression %prec UNARY
+  {
+    $$ = &IntroducerExpr{CharacterSet: strings.ToLower($1[1:]), Expr: $2}
+  }
+| value_expression COLLATE sql_id
+  {
+    $$ = &CollateExpr{Expr: $1, Collation: $3.String()}
+  }
+| unary_operator value_expression %prec UNARY
+  {
+    if num, ok := $2.(NumVal); ok {
+      switch $1 {
+      case '-':
+        if strings.HasPrefix(string(num), "-") {
+          $$ = num[1:]
+        } else {
+          $$ = "-" + num
+        }
+      case '+':
+        $$ = num
+      default:
+        $$ = &UnaryExpr{Operator: $1, Expr: $2}
+      }
+    } else {
+      $$ = &UnaryExpr{Operator: $1, Expr: $2}
+    }
+  }
+| sql_id '(' ')' over_opt
+  {
+    $$ = &FuncExpr{Name: $1, Over: $4}
+  }
+| sql_id '(' select_expression_list ')' over_opt
+  {
+    if seq := NextValFunc(yylex, $1, $3); seq != nil && $5 == nil {
+      $$ = seq
+    } else {
+      $$ = &FuncExpr{Name: $1, Exprs: $3, Over: $5}
+    }
+  }
+| sql_id '(' DISTINCT select_expression_list ')' over_opt
+  {
+    $$ = &FuncExpr{Name: $1, Distinct: true, Exprs: $4, Over: $6}
+  }
+| keyword_as_func '(' ')'
+  {
+    $$ = &FuncExpr{Name: $1}
+  }
+| keyword_as_func '(' select_expression_list ')'
+  {
+    if values := ValuesFunc($1, $3); values != nil {
+      $$ = values
+      break
+    }
+    $$ = &FuncExpr{Name: $1, Exprs: $3}
+  }
+| INTERVAL value_expression interval_unit
+  {
+    $$ = &IntervalExpr{Expr: $2, Unit: $3}
+  }
+| CAST '(' value_expression AS convert_type ')'
+  {
+    $$ = &ConvertExpr{Name: AST_CAST, Expr: $3, Type: $5}
+  }
+| CONVERT '(' value_expression ',' convert_type ')'
+  {
+    $$ = &ConvertExpr{Name: AST_CONVERT, Expr: $3, Type: $5}
+  }
+| CONVERT '(' value_expression USING sql_id ')'
+  {
+    $$ = &ConvertUsingExpr{Expr: $3, Charset: $5.String()}
+  }
+| case_expression
+  {
+    $$ = $1
+  }
+
+over_opt:
+  {
+    $$ = nil
+  }
+| OVER window_spec
+  {
+    $$ = $2
+  }
+| OVER sql_id
+  {
+    $$ = &WindowSpec{Name: $2}
+  }
+
+window_spec:
+  '(' window_name_opt partition_by_opt order_by_opt frame_opt ')'
+  {
+    $$ = &WindowSpec{Name: $2, PartitionBy: $3, OrderBy: $4, Frame: $5}
+  }
+
+window_name_opt:
+  {
+    $$ = ColIdent{}
+  }
+| sql_id
+  {
+    $$ = $1
+  }
+
+partition_by_opt:
+  {
+    $$ = nil
+  }
+| PARTITION BY value_expression_list
+  {
+    $$ = $3
+  }
+
+frame_opt:
+  {
+    $$ = nil
+  }
+| frame_unit frame_bound
+  {
+    $$ = &WindowFrame{Unit: $1, Start: $2}
+  }
+| frame_unit BETWEEN frame_bound AND frame_bound
+  {
+    $$ = &WindowFrame{Unit: $1, Start: $3, End: $5}
+  }
+
+frame_unit:
+  ROWS
+  {
+    $$ = AST_ROWS
+  }
+| RANGE
+  {
+    $$ = AST_RANGE
+  }
+
+frame_bound:
+  ID ID
+  {
+    typ := strings.ToLower($1 + " " + $2)
+    switch typ {
+    case AST_UNBOUNDED_PRECEDING, AST_CURRENT_ROW, AST_UNBOUNDED_FOLLOWING:
+    default:
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &FrameBound{Type: typ}
+  }
+| frame_offset ID
+  {
+    typ := strings.ToLower($2)
+    if typ != AST_PRECEDING && typ != AST_FOLLOWING {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = &FrameBound{Type: typ, Expr: $1}
+  }
+
+frame_offset:
+  NUMBER
+  {
+    $$ = NumVal($1)
+  }
+| VALUE_ARG
+  {
+    $$ = ValArg($1)
+  }
+| INTERVAL value_expression interval_unit
+  {
+    $$ = &IntervalExpr{Expr: $2, Unit: $3}
+  }
+
+window_opt:
+  {
+    $$ = nil
+  }
+| WINDOW named_window_list
+  {
+    $$ = $2
+  }
+
+named_window_list:
+  named_window
+  {
+    $$ = []*NamedWindow{$1}
+  }
+| named_window_list ',' named_window
+  {
+    $$ = append($1, $3)
+  }
+
+named_window:
+  sql_id AS window_spec
+  {
+    $$ = &NamedWindow{Name: $1, Spec: $3}
+  }
+
+keyword_as_func:
+  IF
+  {
+    $$ = NewColIdent(string(IF_BYTES))
+  }
+/*
+VALUES followed by '(' is the VALUES() function: a VALUES
+statement in parentheses needs ROW, as in (VALUES ROW(1)).
+*/
+| VALUES %prec VALUES_FUNC
+  {
+    $$ = NewColIdent(string(VALUES_BYTES))
+  }
+| DATABASE
+  {
+    $$ = NewColIdent(string(DATABASE_BYTES))
+  }
+| SCHEMA
+  {
+    $$ = NewColIdent(string(SCHEMA_BYTES))
+  }
+
+interval_unit:
+  ID
+  {
+    unit := strings.ToLower($1)
+    if !intervalUnits[unit] {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = unit
+  }
+| YEAR
+  {
+    $$ = AST_YEAR_UNIT
+  }
+
+unary_operator:
+  '+'
+  {
+    $$ = AST_UPLUS
+  }
+| '-'
+  {
+    $$ = AST_UMINUS
+  }
+| '~'
+  {
+    $$ = AST_TILDA
+  }
+
+case_expression:
+  CASE value_expression_opt when_expression_list else_expression_opt END
+  {
+    $$ = &CaseExpr{Expr: $2, Whens: $3, Else: $4}
+  }
+
+value_expression_opt:
+  {
+    $$ = nil
+  }
+| value_expression
+  {
+    $$ = $1
+  }
+
+when_expression_list:
+  when_expression
+  {
+    $$ = []*When{$1}
+  }
+| when_expression_list when_expression
+  {
+    $$ = append($1, $2)
+  }
+
+when_expression:
+  WHEN boolean_expression THEN value_expression
+  {
+    $$ = &When{Cond: $2, Val: $4}
+  }
+
+else_expression_opt:
+  {
+    $$ = nil
+  }
+| ELSE value_expression
+  {
+    $$ = $2
+  }
+
+column_name:
+  sql_id
+  {
+    $$ = NodeArena(yylex).colName(ColName{Name: $1})
+  }
+| table_id '.' sql_id
+  {
+    $$ = NodeArena(yylex).colName(ColName{Qualifier: $1, Name: $3})
+  }
+
+match_expression:
+  MATCH '(' column_list ')' ID '(' value_expression match_option ')'
+  {
+    if !strings.EqualFold($5, "against") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $5))
+      return 1
+    }
+    $$ = &MatchExpr{Columns: $3, Expr: $7, Option: $8}
+  }
+
+match_option:
+  {
+    $$ = ""
+  }
+| IN NATURAL ID ID
+  {
+    if !strings.EqualFold($3 + " " + $4, "language mode") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+    $$ = AST_NATURAL_LANGUAGE_MODE
+  }
+| IN NATURAL ID ID WITH ID ID
+  {
+    if !strings.EqualFold($3 + " " + $4, "language mode") || !strings.EqualFold($6 + " " + $7, "query expansion") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $3))
+      return 1
+    }
+    $$ = AST_NATURAL_LANGUAGE_MODE_WITH_QUERY_EXPANSION
+  }
+| IN ID ID
+  {
+    if !strings.EqualFold($2 + " " + $3, "boolean mode") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = AST_BOOLEAN_MODE
+  }
+| WITH ID ID
+  {
+    if !strings.EqualFold($2 + " " + $3, "query expansion") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    $$ = AST_QUERY_EXPANSION
+  }
+
+like_escape_opt:
+  {
+    $$ = nil
+  }
+| ESCAPE value_expression
+  {
+    $$ = $2
   }
-| sql_id '(' DISTINCT select_expression_list ')'
+
+is_value:
+  TRUE
   {
-    $$ = &FuncExpr{Name: $1, Distinct: true, Exprs: $4}
+    $$ = AST_TRUE
   }
-| keyword_as_func '(' select_expression_list ')'
+| FALSE
   {
-    $$ = &FuncExpr{Name: $1, Exprs: $3}
+    $$ = AST_FALSE
   }
-| case_expression
+| ID
   {
-    $$ = $1
+    if !strings.EqualFold($1, AST_UNKNOWN) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = AST_UNKNOWN
   }
 
-keyword_as_func:
-  IF
+value:
+  STRING
   {
-    $$ = IF_BYTES
+    $$ = $1
   }
-| VALUES
+| NUMBER
   {
-    $$ = VALUES_BYTES
+    $$ = NumVal($1)
   }
-
-unary_operator:
-  '+'
+| HEX
   {
-    $$ = AST_UPLUS
+    $$ = HexVal($1)
   }
-| '-'
+| BIT_LITERAL
   {
-    $$ = AST_UMINUS
+    $$ = BitVal($1)
   }
-| '~'
+| VALUE_ARG
   {
-    $$ = AST_TILDA
+    $$ = ValArg($1)
   }
-
-case_expression:
-  CASE value_expression_opt when_expression_list else_expression_opt END
+| NULL
   {
-    $$ = &CaseExpr{Expr: $2, Whens: $3, Else: $4}
+    $$ = &NullVal{}
   }
-
-value_expression_opt:
+| TRUE
   {
-    $$ = nil
+    $$ = BoolVal(true)
   }
-| value_expression
+| FALSE
   {
-    $$ = $1
+    $$ = BoolVal(false)
   }
 
-when_expression_list:
-  when_expression
+/* group_by_opt returns a Select holding just the GROUP BY clause. */
+group_by_opt:
   {
-    $$ = []*When{$1}
+    $$ = nil
   }
-| when_expression_list when_expression
+| GROUP BY group_by_list
   {
-    $$ = append($1, $2)
+    $$ = &Select{GroupBy: $3}
   }
-
-when_expression:
-  WHEN boolean_expression THEN value_expression
+| GROUP BY group_by_list WITH ID
   {
-    $$ = &When{Cond: $2, Val: $4}
+    if !strings.EqualFold($5, "rollup") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $5))
+      return 1
+    }
+    $$ = &Select{GroupBy: $3, WithRollup: true}
   }
 
-else_expression_opt:
+group_by_list:
+  group_by_element
   {
-    $$ = nil
+    $$ = SelectExprs{$1}
   }
-| ELSE value_expression
+| group_by_list ',' group_by_element
   {
-    $$ = $2
+    $$ = append($1, $3)
   }
 
-column_name:
-  sql_id
+group_by_element:
+  expression
   {
-    $$ = &ColName{Name: $1}
+    $$ = NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: groupingFunc($1)})
   }
-| ID '.' sql_id
+| GROUPING_SETS '(' grouping_set_list ')'
   {
-    $$ = &ColName{Qualifier: $1, Name: $3}
+    $$ = NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: &GroupingExpr{Type: AST_GROUPING_SETS, Exprs: $3}})
   }
 
-value:
-  STRING
-  {
-    $$ = StrVal($1)
-  }
-| NUMBER
+grouping_set_list:
+  grouping_set
   {
-    $$ = NumVal($1)
+    $$ = ValExprs{$1}
   }
-| VALUE_ARG
+| grouping_set_list ',' grouping_set
   {
-    $$ = ValArg($1)
+    $$ = append($1, $3)
   }
-| NULL
+
+grouping_set:
+  value_expression
+| '(' ')'
   {
-    $$ = &NullVal{}
+    $$ = ValTuple{}
   }
 
-group_by_opt:
+
+having_opt:
   {
     $$ = nil
   }
-| GROUP BY select_expression_list
+| HAVING boolean_expression
   {
-    $$ = $3
+    $$ = NewWhere(AST_HAVING, $2)
   }
 
-having_opt:
+qualify_opt:
   {
     $$ = nil
   }
-| HAVING boolean_expression
+| QUALIFY boolean_expression
   {
-    $$ = $2
+    $$ = NewWhere(AST_QUALIFY, $2)
   }
 
 order_by_opt:
   {
     $$ = nil
   }
-| ORDER BY order_list
+| order_by
+
+order_by:
+  ORDER BY order_list
   {
     $$ = $3
   }
@@ -1123,6 +4158,10 @@ order:
   {
     $$ = &Order{Expr: $1, Direction: $2}
   }
+| match_expression asc_desc_opt
+  {
+    $$ = &Order{Expr: $1, Direction: $2}
+  }
 
 asc_desc_opt:
   {
@@ -1141,20 +4180,56 @@ timerange_opt:
 {
   $$ = nil
 }
-| ASOF STRING
+| ASOF value_expression
+  {
+    $$ = &TimeRange{From: $2}
+  }
+| ASOF value_expression UNTIL value_expression
+  {
+    $$ = &TimeRange{From: $2, To: $4}
+  }
+
+clauses_opt:
+  {
+    $$ = nil
+  }
+| clause_list
+  {
+    if err := yylex.(*Tokenizer).opts.checkClauses($1); err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    $$ = $1
+  }
+
+clause_list:
+  CLAUSE_KEYWORD value_expression
   {
-    $$ = &TimeRange{From: string($2)}
+    clauses, err := yylex.(*Tokenizer).opts.addClausePart(nil, $1, $2)
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    $$ = clauses
   }
-| ASOF STRING UNTIL STRING
+| clause_list CLAUSE_KEYWORD value_expression
   {
-    $$ = &TimeRange{From: string($2), To: string($4)}
+    clauses, err := yylex.(*Tokenizer).opts.addClausePart($1, $2, $3)
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    $$ = clauses
   }
 
 limit_opt:
   {
     $$ = nil
   }
-| LIMIT value_expression
+| limit
+
+limit:
+  LIMIT value_expression
   {
     $$ = &Limit{Rowcount: $2}
   }
@@ -1162,45 +4237,93 @@ limit_opt:
   {
     $$ = &Limit{Offset: $2, Rowcount: $4}
   }
+| LIMIT value_expression ID value_expression
+  {
+    if !strings.EqualFold($3, AST_OFFSET) {
+      yylex.Error("expecting offset")
+      return 1
+    }
+    $$ = &Limit{Offset: $4, Rowcount: $2}
+  }
 
 lock_opt:
   {
     $$ = ""
   }
-| FOR UPDATE
+| lock
+
+lock:
+  FOR UPDATE lock_wait_opt
+  {
+    $$ = AST_FOR_UPDATE + $3
+  }
+| FOR ID lock_wait_opt
   {
-    $$ = AST_FOR_UPDATE
+    if !strings.EqualFold($2, string(SHARE)) {
+      yylex.Error("expecting share")
+      return 1
+    }
+    if $3 == "" {
+      $$ = AST_SHARE_MODE
+    } else {
+      $$ = AST_FOR_SHARE + $3
+    }
   }
 | LOCK IN sql_id sql_id
   {
-    if !bytes.Equal($3, SHARE) {
+    if !$3.EqualString(string(SHARE)) {
       yylex.Error("expecting share")
       return 1
     }
-    if !bytes.Equal($4, MODE) {
+    if !$4.EqualString(string(MODE)) {
       yylex.Error("expecting mode")
       return 1
     }
     $$ = AST_SHARE_MODE
   }
 
-column_list_opt:
+lock_wait_opt:
   {
-    $$ = nil
+    $$ = ""
   }
-| '(' column_list ')'
+| ID
   {
-    $$ = $2
+    if !strings.EqualFold($1, "nowait") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = AST_NOWAIT
+  }
+| ID ID
+  {
+    if !strings.EqualFold($1, "skip") || !strings.EqualFold($2, "locked") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = AST_SKIP_LOCKED
   }
 
 column_list:
   column_name
   {
-    $$ = Columns{&NonStarExpr{Expr: $1}}
+    $$ = Columns{NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: $1})}
   }
 | column_list ',' column_name
   {
-    $$ = append($$, &NonStarExpr{Expr: $3})
+    $$ = append($$, NodeArena(yylex).nonStarExpr(NonStarExpr{Expr: $3}))
+  }
+
+row_alias_opt:
+  {
+    $$ = nil
+  }
+| AS table_id
+  {
+    $$ = &RowAlias{Name: $2}
+  }
+| AS table_id '(' column_list ')'
+  {
+    $$ = &RowAlias{Name: $2, Columns: $4}
   }
 
 on_dup_opt:
@@ -1212,24 +4335,38 @@ on_dup_opt:
     $$ = $5
   }
 
+/*
+The VALUES of INSERT ... VALUES are parsed as a VALUES statement,
+which takes the place of the values in the grammar.
+*/
 row_list:
-  VALUES tuple_list
-  {
-    $$ = $2
-  }
-| select_statement
+  select_statement
   {
-    $$ = $1
+    $$ = insertRows($1)
   }
 
+/*
+tuple_list always follows VALUES, whose value, $<boolean>0, tells
+whether its rows are those of the statement, for a RowHandler.
+*/
 tuple_list:
-  row_tuple
+  values_row
   {
-    $$ = Values{$1}
+    rows, err := appendRow(yylex, $<boolean>0, nil, $1)
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    $$ = rows
   }
-| tuple_list ',' row_tuple
+| tuple_list ',' values_row
   {
-    $$ = append($1, $3)
+    rows, err := appendRow(yylex, $<boolean>0, $1, $3)
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    $$ = rows
   }
 
 row_tuple:
@@ -1242,6 +4379,20 @@ row_tuple:
     $$ = $1
   }
 
+values_row:
+  row_tuple
+  {
+    $$ = $1
+  }
+| ID '(' value_expression_list ')'
+  {
+    if !strings.EqualFold($1, "row") {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $1))
+      return 1
+    }
+    $$ = ValTuple($3)
+  }
+
 update_list:
   update_expression
   {
@@ -1258,28 +4409,155 @@ update_expression:
     $$ = &UpdateExpr{Name: $1, Expr: $3}
   }
 
+set_list:
+  set_expression
+  {
+    $$ = SetExprs{$1}
+  }
+| set_list ',' set_expression
+  {
+    $$ = append($1, $3)
+  }
+
+set_expression:
+  set_scope_opt column_name assign_op value_expression
+  {
+    setExpr, err := newSetExpr($1, $2, $3, $4)
+    if err != nil {
+      yylex.Error(err.Error())
+      return 1
+    }
+    $$ = setExpr
+  }
+| user_var assign_op value_expression
+  {
+    $$ = &SetExpr{Kind: AST_USER_VAR, Name: $1.Name, Operator: $2, Expr: $3}
+  }
+| set_scope_opt ID charset_value collate_opt
+  {
+    if !strings.EqualFold($2, AST_NAMES) {
+      yylex.Error(fmt.Sprintf("syntax error near %s", $2))
+      return 1
+    }
+    if $1 != "" {
+      yylex.Error(fmt.Sprintf("scope cannot be applied to %s", $2))
+      return 1
+    }
+    $$ = &SetExpr{Kind: AST_NAMES, Charset: $3, Collation: $4}
+  }
+| set_scope_opt CHARACTER SET charset_value
+  {
+    if $1 != "" {
+      yylex.Error("scope cannot be applied to character set")
+      return 1
+    }
+    $$ = &SetExpr{Kind: AST_CHARACTER_SET, Charset: $4}
+  }
+| set_scope_opt CHARSET charset_value
+  {
+    if $1 != "" {
+      yylex.Error("scope cannot be applied to character set")
+      return 1
+    }
+    $$ = &SetExpr{Kind: AST_CHARACTER_SET, Charset: $3}
+  }
+
+charset_value:
+  ID
+  {
+    $$ = $1
+  }
+| STRING
+  {
+    $$ = $1.Val
+  }
+| DEFAULT
+  {
+    $$ = AST_DEFAULT
+  }
+
+user_var:
+  USER_VAR
+  {
+    $$ = &UserVar{Name: makeColIdent($1, $<quoted>1)}
+  }
+
+set_scope_opt:
+  {
+    $$ = ""
+  }
+| GLOBAL
+  {
+    $$ = AST_GLOBAL
+  }
+| SESSION
+  {
+    $$ = AST_SESSION
+  }
+| LOCAL
+  {
+    $$ = AST_LOCAL
+  }
+
+assign_op:
+  '='
+  {
+    $$ = AST_EQ
+  }
+| ASSIGN
+  {
+    $$ = AST_ASSIGN
+  }
+
+sequence_items:
+  {
+    $$ = nil
+  }
+| sequence_items ID
+  {
+    $$ = append($1, strings.ToLower($2))
+  }
+| sequence_items BY
+  {
+    $$ = append($1, "by")
+  }
+| sequence_items WITH
+  {
+    $$ = append($1, "with")
+  }
+| sequence_items '='
+  {
+    $$ = append($1, "=")
+  }
+| sequence_items NUMBER
+  {
+    $$ = append($1, $2)
+  }
+| sequence_items '-' NUMBER
+  {
+    $$ = append($1, "-" + $3)
+  }
+
 exists_opt:
-  { $$ = struct{}{} }
+  { $$ = false }
 | IF EXISTS
-  { $$ = struct{}{} }
+  { $$ = true }
 
 not_exists_opt:
-  { $$ = struct{}{} }
+  { $$ = false }
 | IF NOT EXISTS
-  { $$ = struct{}{} }
+  { $$ = true }
 
 ignore_opt:
-  { $$ = struct{}{} }
+  { $$ = false }
 | IGNORE
-  { $$ = struct{}{} }
+  { $$ = true }
 
 non_rename_operation:
   ALTER
   { $$ = struct{}{} }
 | DEFAULT
   { $$ = struct{}{} }
-| DROP
-  { $$ = struct{}{} }
 | ORDER
   { $$ = struct{}{} }
 | ID
@@ -1289,21 +4567,43 @@ to_opt:
   { $$ = struct{}{} }
 | TO
   { $$ = struct{}{} }
-
-constraint_opt:
-  { $$ = struct{}{} }
-| UNIQUE
+| AS
   { $$ = struct{}{} }
 
-using_opt:
+as_kw_opt:
   { $$ = struct{}{} }
-| USING sql_id
+| AS
   { $$ = struct{}{} }
 
+unique_opt:
+  { $$ = false }
+| UNIQUE
+  { $$ = true }
+
+database_or_schema:
+  DATABASE
+| SCHEMA
+
 sql_id:
   ID
   {
-    $$ = bytes.ToLower($1)
+    $$ = makeColIdent($1, $<quoted>1)
+  }
+
+table_id:
+  ID
+  {
+    $$ = makeTableIdent($1, $<quoted>1)
+  }
+
+table_id_list:
+  table_id
+  {
+    $$ = []TableIdent{$1}
+  }
+| table_id_list ',' table_id
+  {
+    $$ = append($1, $3)
   }
 
 force_eof:
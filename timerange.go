@@ -0,0 +1,193 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// timerange.go resolves TIMERANGE clauses into concrete times.
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Resolve returns the times the clause refers to, relative to now,
+// binding any bind variables to bindVars. A clause without UNTIL
+// extends to now. The bounds can be:
+//
+//   - timestamp strings, such as '2020-01-02 03:04:05';
+//   - numbers, taken as Unix times in seconds;
+//   - now(), current_timestamp() or utc_timestamp();
+//   - a time plus or minus an INTERVAL;
+//   - bare durations, given as an INTERVAL or as a duration string
+//     such as '90m', which mean that long before now;
+//   - bind variables holding any of the above, a time.Time or a
+//     time.Duration.
+func (node *TimeRange) Resolve(now time.Time, bindVars map[string]interface{}) (from, to time.Time, err error) {
+	r := &timeResolver{now: now, compiler: predicateCompiler{bindVars: bindVars}}
+	if from, err = r.resolve(node.From); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if node.To == nil {
+		return from, now, nil
+	}
+	if to, err = r.resolve(node.To); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to, nil
+}
+
+type timeResolver struct {
+	now      time.Time
+	compiler predicateCompiler
+}
+
+// nowFuncs are the functions that return the current time.
+var nowFuncs = map[string]bool{
+	"now":               true,
+	"current_timestamp": true,
+	"localtimestamp":    true,
+	"sysdate":           true,
+	"utc_timestamp":     true,
+}
+
+func (r *timeResolver) resolve(expr ValExpr) (time.Time, error) {
+	switch expr := expr.(type) {
+	case StrVal:
+		return r.resolveString(expr.Val)
+	case NumVal:
+		n, err := literalNumber(expr)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return unixTime(n), nil
+	case ValArg:
+		v, _, err := FetchBindVar(string(expr), r.compiler.bindVars)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return r.resolveValue(v)
+	case *IntervalExpr:
+		return r.addInterval(r.now, expr, -1)
+	case *FuncExpr:
+		if nowFuncs[expr.Name.Lowered()] && len(expr.Exprs) == 0 {
+			if expr.Name.Lowered() == "utc_timestamp" {
+				return r.now.UTC(), nil
+			}
+			return r.now, nil
+		}
+	case *ParenExpr:
+		return r.resolve(expr.Expr)
+	case *BinaryExpr:
+		if expr.Operator != AST_PLUS && expr.Operator != AST_MINUS {
+			break
+		}
+		left, lok := expr.Left.(ValExpr)
+		interval, iok := expr.Right.(*IntervalExpr)
+		if !iok && expr.Operator == AST_PLUS {
+			// INTERVAL 1 DAY + t is the same as t + INTERVAL 1 DAY.
+			left, lok = expr.Right.(ValExpr)
+			interval, iok = expr.Left.(*IntervalExpr)
+		}
+		if !lok || !iok {
+			break
+		}
+		t, err := r.resolve(left)
+		if err != nil {
+			return time.Time{}, err
+		}
+		sign := 1
+		if expr.Operator == AST_MINUS {
+			sign = -1
+		}
+		return r.addInterval(t, interval, sign)
+	}
+	return time.Time{}, fmt.Errorf("unsupported time expression: %s", String(expr))
+}
+
+func (r *timeResolver) resolveValue(v interface{}) (time.Time, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, nil
+	case time.Duration:
+		return r.now.Add(-v), nil
+	}
+	n, err := normalizeValue(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch n := n.(type) {
+	case string:
+		return r.resolveString(n)
+	case int64, float64:
+		return unixTime(n), nil
+	}
+	return time.Time{}, fmt.Errorf("unsupported time value %v", v)
+}
+
+func (r *timeResolver) resolveString(s string) (time.Time, error) {
+	if t, err := parseTime(s); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return r.now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %s", s)
+}
+
+// addInterval adds sign times the interval to t. Units of a day
+// or longer follow the calendar, and must be whole numbers.
+func (r *timeResolver) addInterval(t time.Time, interval *IntervalExpr, sign int) (time.Time, error) {
+	fn, err := r.compiler.valExpr(interval.Expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	v, err := fn(nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v == nil {
+		return time.Time{}, fmt.Errorf("null interval: %s", String(interval))
+	}
+	n, err := toNumber(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	amount := toFloat64(n) * float64(sign)
+	switch interval.Unit {
+	case AST_MICROSECOND:
+		return t.Add(time.Duration(amount * float64(time.Microsecond))), nil
+	case AST_SECOND:
+		return t.Add(time.Duration(amount * float64(time.Second))), nil
+	case AST_MINUTE:
+		return t.Add(time.Duration(amount * float64(time.Minute))), nil
+	case AST_HOUR:
+		return t.Add(time.Duration(amount * float64(time.Hour))), nil
+	}
+	if amount != math.Trunc(amount) {
+		return time.Time{}, fmt.Errorf("fractional interval: %s", String(interval))
+	}
+	whole := int(amount)
+	switch interval.Unit {
+	case AST_DAY:
+		return t.AddDate(0, 0, whole), nil
+	case AST_WEEK:
+		return t.AddDate(0, 0, 7*whole), nil
+	case AST_MONTH:
+		return t.AddDate(0, whole, 0), nil
+	case AST_QUARTER:
+		return t.AddDate(0, 3*whole, 0), nil
+	case AST_YEAR_UNIT:
+		return t.AddDate(whole, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("unsupported interval unit: %s", interval.Unit)
+}
+
+func unixTime(n interface{}) time.Time {
+	if i, ok := n.(int64); ok {
+		return time.Unix(i, 0)
+	}
+	sec, frac := math.Modf(n.(float64))
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
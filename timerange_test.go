@@ -0,0 +1,75 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRangeResolve(t *testing.T) {
+	now := time.Date(2020, 3, 31, 12, 0, 0, 0, time.UTC)
+	bindVars := map[string]interface{}{
+		"t":   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		"d":   time.Hour,
+		"s":   "2020-02-01",
+		"n":   1577836800,
+		"two": 2,
+	}
+	tcases := []struct {
+		clause   string
+		from, to time.Time
+	}{
+		{"ASOF '2020-01-02 03:04:05'", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), now},
+		{"ASOF '2020-01-01' UNTIL '2020-02-01'", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"ASOF now() - interval 1 hour UNTIL now()", now.Add(-time.Hour), now},
+		{"ASOF interval 90 minute", now.Add(-90 * time.Minute), now},
+		{"ASOF '90m'", now.Add(-90 * time.Minute), now},
+		{"ASOF now() - interval 1 month", time.Date(2020, 3, 2, 12, 0, 0, 0, time.UTC), now},
+		{"ASOF '2020-01-01' + interval :two week UNTIL interval 1 day + '2020-02-01'", time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{"ASOF (current_timestamp() - interval 1 quarter)", time.Date(2019, 12, 31, 12, 0, 0, 0, time.UTC), now},
+		{"ASOF :t UNTIL :s", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"ASOF :d", now.Add(-time.Hour), now},
+		{"ASOF :n UNTIL 1577840400", time.Unix(1577836800, 0), time.Unix(1577840400, 0)},
+		{"ASOF now() - interval 1.5 second", now.Add(-1500 * time.Millisecond), now},
+	}
+	for _, tcase := range tcases {
+		tree, err := Parse("select * from t " + tcase.clause)
+		if err != nil {
+			t.Fatal(err)
+		}
+		from, to, err := tree.(*Select).TimeRange.Resolve(now, bindVars)
+		if !assert.Nil(t, err, tcase.clause) {
+			continue
+		}
+		assert.True(t, tcase.from.Equal(from), "%s: from %v, want %v", tcase.clause, from, tcase.from)
+		assert.True(t, tcase.to.Equal(to), "%s: to %v, want %v", tcase.clause, to, tcase.to)
+	}
+}
+
+func TestTimeRangeResolveErrors(t *testing.T) {
+	tcases := []struct {
+		clause, err string
+	}{
+		{"ASOF 'yesterday'", "invalid time yesterday"},
+		{"ASOF a", "unsupported time expression: a"},
+		{"ASOF now() * 2", "unsupported time expression: now()*2"},
+		{"ASOF now() - interval 1.5 day", "fractional interval: interval 1.5 day"},
+		{"ASOF now() - interval null hour", "null interval: interval null hour"},
+		{"ASOF :missing", "missing bind var missing"},
+	}
+	for _, tcase := range tcases {
+		tree, err := Parse("select * from t " + tcase.clause)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = tree.(*Select).TimeRange.Resolve(time.Now(), nil)
+		if assert.NotNil(t, err, tcase.clause) {
+			assert.Equal(t, tcase.err, err.Error(), tcase.clause)
+		}
+	}
+}
@@ -5,8 +5,11 @@
 package sqlparser
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"github.com/getlantern/sqlparser/dependency/sqltypes"
@@ -22,90 +25,236 @@ type Tokenizer struct {
 	ForceEOF      bool
 	lastChar      uint16
 	Position      int
-	errorToken    []byte
+	errorToken    string
 	LastError     string
 	posVarIndex   int
 	ParseTree     Statement
+
+	// RowHandler, if set, receives the rows of an INSERT ... VALUES
+	// or VALUES statement as they are parsed, instead of them being
+	// collected in the statement.
+	RowHandler func(RowTuple) error
+	rowErr     error
+	// streamedRows is set once RowHandler received a row.
+	streamedRows bool
+
+	// arena, if set, is used to allocate the most frequent nodes.
+	arena *Arena
+	opts  Options
+
+	// maxTokens, if set, limits the number of tokens returned
+	// by Lex. limitErr is set once a limit is exceeded.
+	maxTokens, tokens int
+	limitErr          error
+	// depth is the number of open parentheses, and queries the
+	// number of queries combined by UNION, INTERSECT and EXCEPT.
+	depth, queries int
+
+	// quote and doubled describe the last scanned string literal.
+	quote   byte
+	doubled bool
+	// quotedID is set if the last scanned token was a
+	// backquoted identifier.
+	quotedID bool
+
+	// start is the offset of the last scanned token.
+	start int
+	// spans, if set, records the spans of the parsed nodes.
+	spans map[spanKey]Span
+	// comments collects the comments returned by Scan, if
+	// recordComments is set.
+	comments       []*Comment
+	recordComments bool
+
+	// reader, if set, is read instead of InStream, and readErr
+	// records the first error it returns other than io.EOF.
+	reader  io.ByteReader
+	readErr error
+	// pending holds bytes to be read again before the input,
+	// and recorded, if not nil, collects the bytes read.
+	pending  []byte
+	recorded []byte
+	// src is the input if it is a string, which the values of
+	// tokens are sliced from. Otherwise buf holds the bytes read
+	// for the last scanned token, from offset bufStart.
+	src      string
+	buf      []byte
+	bufStart int
+	// stack is the largest parser stack of the parse, see
+	// parseStack.
+	stack []yySymType
+	// multi is set by ParseNext, and makes the delimiter end the
+	// input. delimiter is set by a DELIMITER directive, and is
+	// a semicolon if empty.
+	multi     bool
+	delimiter string
+
+	// line counts the newlines read, and lineStart is the offset
+	// of the line after the last one. startLine and startColumn
+	// locate the last scanned token.
+	line, lineStart        int
+	startLine, startColumn int
+	// lastToken is the type of the last token returned by Lex,
+	// and firstToken of the first one of the statement, other
+	// than comments.
+	lastToken, firstToken int
+	// handler is set from the HANDLER of a DECLARE ... HANDLER
+	// to the end of its conditions, see beginKeyword.
+	handler bool
+	// errPosition, errLine, errColumn and expected describe
+	// the last error, see ParseError.
+	errPosition        int
+	errLine, errColumn int
+	expected           []string
 }
 
 // NewStringTokenizer creates a new Tokenizer for the
-// sql string.
+// sql string. The values of its tokens, and so the identifiers
+// and literals of the trees parsed from them, are slices of sql
+// rather than copies.
 func NewStringTokenizer(sql string) *Tokenizer {
-	return &Tokenizer{InStream: strings.NewReader(sql)}
+	return &Tokenizer{InStream: strings.NewReader(sql), src: sql}
+}
+
+// NewTokenizer creates a new Tokenizer that reads the sql from r
+// as it is needed, for use with ParseNext.
+func NewTokenizer(r io.Reader) *Tokenizer {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Tokenizer{reader: br}
 }
 
 var keywords = map[string]int{
-	"all":           ALL,
-	"alter":         ALTER,
-	"analyze":       ANALYZE,
-	"and":           AND,
-	"as":            AS,
-	"asc":           ASC,
-	"asof":          ASOF,
-	"between":       BETWEEN,
-	"by":            BY,
-	"case":          CASE,
-	"create":        CREATE,
-	"cross":         CROSS,
-	"default":       DEFAULT,
-	"delete":        DELETE,
-	"desc":          DESC,
-	"describe":      DESCRIBE,
-	"distinct":      DISTINCT,
-	"drop":          DROP,
-	"duplicate":     DUPLICATE,
-	"else":          ELSE,
-	"end":           END,
-	"except":        EXCEPT,
-	"exists":        EXISTS,
-	"explain":       EXPLAIN,
-	"for":           FOR,
-	"force":         FORCE,
-	"from":          FROM,
-	"group":         GROUP,
-	"having":        HAVING,
-	"if":            IF,
-	"ignore":        IGNORE,
-	"in":            IN,
-	"index":         INDEX,
-	"inner":         INNER,
-	"insert":        INSERT,
-	"intersect":     INTERSECT,
-	"into":          INTO,
-	"is":            IS,
-	"join":          JOIN,
-	"key":           KEY,
-	"left":          LEFT,
-	"like":          LIKE,
-	"limit":         LIMIT,
-	"lock":          LOCK,
-	"minus":         MINUS,
-	"natural":       NATURAL,
-	"not":           NOT,
-	"null":          NULL,
-	"on":            ON,
-	"or":            OR,
-	"order":         ORDER,
-	"outer":         OUTER,
-	"rename":        RENAME,
-	"right":         RIGHT,
-	"select":        SELECT,
-	"set":           SET,
-	"show":          SHOW,
-	"straight_join": STRAIGHT_JOIN,
-	"table":         TABLE,
-	"then":          THEN,
-	"to":            TO,
-	"union":         UNION,
-	"unique":        UNIQUE,
-	"until":         UNTIL,
-	"update":        UPDATE,
-	"use":           USE,
-	"using":         USING,
-	"values":        VALUES,
-	"view":          VIEW,
-	"when":          WHEN,
-	"where":         WHERE,
+	"add":                 ADD,
+	"all":                 ALL,
+	"alter":               ALTER,
+	"analyze":             ANALYZE,
+	"and":                 AND,
+	"as":                  AS,
+	"asc":                 ASC,
+	"asof":                ASOF,
+	"between":             BETWEEN,
+	"by":                  BY,
+	"case":                CASE,
+	"change":              CHANGE,
+	"column":              COLUMN,
+	"continue":            CONTINUE,
+	"convert":             CONVERT,
+	"create":              CREATE,
+	"cross":               CROSS,
+	"cursor":              CURSOR,
+	"declare":             DECLARE,
+	"default":             DEFAULT,
+	"delayed":             DELAYED,
+	"delete":              DELETE,
+	"desc":                DESC,
+	"describe":            DESCRIBE,
+	"distinct":            DISTINCT,
+	"distinctrow":         DISTINCTROW,
+	"do":                  DO,
+	"drop":                DROP,
+	"duplicate":           DUPLICATE,
+	"else":                ELSE,
+	"elseif":              ELSEIF,
+	"enclosed":            ENCLOSED,
+	"end":                 END,
+	"except":              EXCEPT,
+	"escaped":             ESCAPED,
+	"exists":              EXISTS,
+	"exit":                EXIT,
+	"explain":             EXPLAIN,
+	"fetch":               FETCH,
+	"for":                 FOR,
+	"force":               FORCE,
+	"from":                FROM,
+	"grant":               GRANT,
+	"group":               GROUP,
+	"having":              HAVING,
+	"high_priority":       HIGH_PRIORITY,
+	"if":                  IF,
+	"ignore":              IGNORE,
+	"in":                  IN,
+	"index":               INDEX,
+	"inner":               INNER,
+	"infile":              INFILE,
+	"insert":              INSERT,
+	"intersect":           INTERSECT,
+	"interval":            INTERVAL,
+	"into":                INTO,
+	"is":                  IS,
+	"iterate":             ITERATE,
+	"join":                JOIN,
+	"key":                 KEY,
+	"lateral":             LATERAL,
+	"leave":               LEAVE,
+	"lines":               LINES,
+	"left":                LEFT,
+	"like":                LIKE,
+	"match":               MATCH,
+	"regexp":              REGEXP,
+	"rlike":               REGEXP,
+	"limit":               LIMIT,
+	"load":                LOAD,
+	"low_priority":        LOW_PRIORITY,
+	"lock":                LOCK,
+	"loop":                LOOP,
+	"max_statement_time":  MAX_STATEMENT_TIME,
+	"minus":               MINUS,
+	"natural":             NATURAL,
+	"not":                 NOT,
+	"null":                NULL,
+	"true":                TRUE,
+	"false":               FALSE,
+	"foreign":             FOREIGN,
+	"on":                  ON,
+	"references":          REFERENCES,
+	"optionally":          OPTIONALLY,
+	"or":                  OR,
+	"order":               ORDER,
+	"range":               RANGE,
+	"partition":           PARTITION,
+	"outer":               OUTER,
+	"over":                OVER,
+	"recursive":           RECURSIVE,
+	"rename":              RENAME,
+	"revoke":              REVOKE,
+	"repeat":              REPEAT,
+	"resignal":            RESIGNAL,
+	"right":               RIGHT,
+	"rows":                ROWS,
+	"select":              SELECT,
+	"set":                 SET,
+	"show":                SHOW,
+	"signal":              SIGNAL,
+	"sql_big_result":      SQL_BIG_RESULT,
+	"sql_buffer_result":   SQL_BUFFER_RESULT,
+	"sql_cache":           SQL_CACHE,
+	"sql_calc_found_rows": SQL_CALC_FOUND_ROWS,
+	"sql_no_cache":        SQL_NO_CACHE,
+	"sql_small_result":    SQL_SMALL_RESULT,
+	"sqlexception":        SQLEXCEPTION,
+	"sqlstate":            SQLSTATE,
+	"sqlwarning":          SQLWARNING,
+	"starting":            STARTING,
+	"straight_join":       STRAIGHT_JOIN,
+	"table":               TABLE,
+	"terminated":          TERMINATED,
+	"then":                THEN,
+	"to":                  TO,
+	"union":               UNION,
+	"unique":              UNIQUE,
+	"until":               UNTIL,
+	"update":              UPDATE,
+	"use":                 USE,
+	"using":               USING,
+	"values":              VALUES,
+	"view":                VIEW,
+	"when":                WHEN,
+	"where":               WHERE,
+	"window":              WINDOW,
+	"with":                WITH,
 
 	//keywords for creat table
 
@@ -123,64 +272,587 @@ var keywords = map[string]int{
 	"decimal":   DECIMAL,
 	"numeric":   NUMERIC,
 
-	"char":    CHAR,
-	"varchar": VARCHAR,
-	"text":    TEXT,
+	"char":      CHAR,
+	"character": CHARACTER,
+	"collate":   COLLATE,
+	"varchar":   VARCHAR,
+	"text":      TEXT,
 
 	"date":      DATE,
 	"time":      TIME,
 	"timestamp": TIMESTAMP,
 	"datetime":  DATETIME,
+	"while":     WHILE,
 	"year":      YEAR,
 
 	//other keywords
 	"unsigned":       UNSIGNED,
 	"zerofill":       ZEROFILL,
 	"primary":        PRIMARY,
+	"constraint":     CONSTRAINT,
+	"database":       DATABASE,
+	"schema":         SCHEMA,
 	"auto_increment": AUTO_INCREMENT,
 }
 
+// reservedWords is the set of MySQL reserved words. Unlike the
+// keywords above, which are reserved by this parser's grammar,
+// these must be quoted when used as identifiers in MySQL itself.
+// QuoteReserved quotes both.
+var reservedWords = map[string]bool{
+	"accessible": true, "add": true, "all": true, "alter": true, "analyze": true,
+	"and": true, "as": true, "asc": true, "asensitive": true, "before": true,
+	"between": true, "bigint": true, "binary": true, "blob": true, "both": true,
+	"by": true, "call": true, "cascade": true, "case": true, "change": true,
+	"char": true, "character": true, "check": true, "collate": true, "column": true,
+	"condition": true, "constraint": true, "continue": true, "convert": true, "create": true,
+	"cross": true, "current_date": true, "current_time": true, "current_timestamp": true, "current_user": true,
+	"cursor": true, "database": true, "databases": true, "day_hour": true, "day_microsecond": true,
+	"day_minute": true, "day_second": true, "dec": true, "decimal": true, "declare": true,
+	"default": true, "delayed": true, "delete": true, "desc": true, "describe": true,
+	"deterministic": true, "distinct": true, "distinctrow": true, "div": true, "double": true,
+	"drop": true, "dual": true, "each": true, "else": true, "elseif": true,
+	"enclosed": true, "escaped": true, "exists": true, "exit": true, "explain": true,
+	"false": true, "fetch": true, "float": true, "for": true, "force": true,
+	"foreign": true, "from": true, "fulltext": true, "generated": true, "get": true,
+	"grant": true, "group": true, "having": true, "high_priority": true, "hour_microsecond": true,
+	"hour_minute": true, "hour_second": true, "if": true, "ignore": true, "in": true,
+	"index": true, "infile": true, "inner": true, "inout": true, "insensitive": true,
+	"insert": true, "int": true, "integer": true, "interval": true, "into": true,
+	"is": true, "iterate": true, "join": true, "key": true, "keys": true,
+	"kill": true, "leading": true, "leave": true, "left": true, "like": true,
+	"limit": true, "linear": true, "lines": true, "load": true, "localtime": true,
+	"localtimestamp": true, "lock": true, "long": true, "loop": true, "low_priority": true,
+	"match": true, "maxvalue": true, "mediumint": true, "minute_microsecond": true, "minute_second": true,
+	"mod": true, "modifies": true, "natural": true, "not": true, "no_write_to_binlog": true,
+	"null": true, "numeric": true, "on": true, "optimize": true, "option": true,
+	"optionally": true, "or": true, "order": true, "out": true, "outer": true,
+	"outfile": true, "partition": true, "precision": true, "primary": true, "procedure": true,
+	"purge": true, "range": true, "read": true, "reads": true, "real": true,
+	"references": true, "regexp": true, "release": true, "rename": true, "repeat": true,
+	"replace": true, "require": true, "resignal": true, "restrict": true, "return": true,
+	"revoke": true, "right": true, "rlike": true, "schema": true, "schemas": true,
+	"second_microsecond": true, "select": true, "sensitive": true, "separator": true, "set": true,
+	"show": true, "signal": true, "smallint": true, "spatial": true, "specific": true,
+	"sql": true, "sqlexception": true, "sqlstate": true, "sqlwarning": true, "sql_big_result": true,
+	"sql_calc_found_rows": true, "sql_small_result": true, "ssl": true, "starting": true, "stored": true,
+	"straight_join": true, "table": true, "terminated": true, "then": true, "tinyint": true,
+	"to": true, "trailing": true, "trigger": true, "true": true, "undo": true,
+	"union": true, "unique": true, "unlock": true, "unsigned": true, "update": true,
+	"usage": true, "use": true, "using": true, "utc_date": true, "utc_time": true,
+	"utc_timestamp": true, "values": true, "varbinary": true, "varchar": true, "varying": true,
+	"virtual": true, "when": true, "where": true, "while": true, "with": true,
+	"write": true, "xor": true, "year_month": true, "zerofill": true,
+}
+
+// charsets holds the MySQL character sets, which, preceded by
+// an underscore, introduce string literals, as in _utf8mb4'abc'.
+var charsets = map[string]bool{
+	"armscii8": true, "ascii": true, "big5": true, "binary": true, "cp1250": true,
+	"cp1251": true, "cp1256": true, "cp1257": true, "cp850": true, "cp852": true,
+	"cp866": true, "cp932": true, "dec8": true, "eucjpms": true, "euckr": true,
+	"gb18030": true, "gb2312": true, "gbk": true, "geostd8": true, "greek": true,
+	"hebrew": true, "hp8": true, "keybcs2": true, "koi8r": true, "koi8u": true,
+	"latin1": true, "latin2": true, "latin5": true, "latin7": true, "macce": true,
+	"macroman": true, "sjis": true, "swe7": true, "tis620": true, "ucs2": true,
+	"ujis": true, "utf16": true, "utf16le": true, "utf32": true, "utf8": true,
+	"utf8mb3": true, "utf8mb4": true,
+}
+
+// postgresKeywords holds the keywords only recognized
+// in the Postgres dialect.
+var postgresKeywords = map[string]int{
+	"ilike":     ILIKE,
+	"returning": RETURNING,
+}
+
 // Lex returns the next token form the Tokenizer.
 // This function is used by go yacc.
 func (tkn *Tokenizer) Lex(lval *yySymType) int {
-	typ, val := tkn.Scan()
-	for typ == COMMENT {
-		if tkn.AllowComments {
+	if tkn.maxTokens > 0 {
+		if tkn.tokens++; tkn.tokens > tkn.maxTokens {
+			tkn.limitErr = &LimitError{Limit: LimitTokens, Max: tkn.maxTokens}
+			return LEX_ERROR
+		}
+	}
+	if max := tkn.opts.MaxLength; max > 0 && tkn.InStream != nil && tkn.InStream.Size() > int64(max) {
+		tkn.limitErr = &LimitError{Limit: LimitLength, Max: max, Length: int(tkn.InStream.Size())}
+		return LEX_ERROR
+	}
+	typ, val := tkn.scan()
+	for {
+		if typ == COMMENT {
+			if tkn.recordComments {
+				tkn.comments = append(tkn.comments, &Comment{Text: val, Span: Span{tkn.start, tkn.Position - 1}})
+			}
+			if tkn.AllowComments {
+				break
+			}
+		} else if !tkn.scanDelimiterDirective(typ, val) {
 			break
 		}
-		typ, val = tkn.Scan()
+		typ, val = tkn.scan()
+	}
+	switch typ {
+	case '(':
+		if tkn.depth++; tkn.opts.MaxDepth > 0 && tkn.depth > tkn.opts.MaxDepth {
+			tkn.limitErr = &LimitError{Limit: LimitDepth, Max: tkn.opts.MaxDepth}
+			return LEX_ERROR
+		}
+	case ')':
+		tkn.depth--
+	case UNION, INTERSECT, EXCEPT, MINUS:
+		if tkn.queries == 0 {
+			tkn.queries = 1
+		}
+		if tkn.queries++; tkn.opts.MaxQueries > 0 && tkn.queries > tkn.opts.MaxQueries {
+			tkn.limitErr = &LimitError{Limit: LimitQueries, Max: tkn.opts.MaxQueries}
+			return LEX_ERROR
+		}
 	}
+	lval.pos = tkn.start
 	switch typ {
-	case ID, STRING, NUMBER, VALUE_ARG, LIST_ARG, COMMENT:
-		lval.bytes = val
+	case ID:
+		if !tkn.quotedID && strings.EqualFold(val, "next") && tkn.scanWords("value", "for") {
+			typ, val = NEXT_VALUE_FOR, "next value for"
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "next") && (tkn.lastToken == SELECT || tkn.lastToken == COMMENT) && tkn.peekNextValues() {
+			typ = NEXT
+			break
+		}
+		if !tkn.quotedID && len(val) > 1 && val[0] == '_' && charsets[strings.ToLower(val[1:])] {
+			typ = UNDERSCORE_CHARSET
+			lval.str = val
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "sounds") && tkn.scanWords("like") {
+			typ, val = SOUNDS_LIKE, "sounds like"
+			break
+		}
+		if !tkn.quotedID && (strings.EqualFold(val, AST_ANY) || strings.EqualFold(val, AST_SOME)) && tkn.peekSubquery() {
+			typ = ANY
+			lval.str = strings.ToLower(val)
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "grouping") && tkn.scanWords("sets") {
+			typ, val = GROUPING_SETS, "grouping sets"
+			break
+		}
+		// PIVOT and UNPIVOT follow a table, and are followed
+		// by their parenthesis.
+		if !tkn.quotedID && (tkn.lastToken == ID || tkn.lastToken == ')') && tkn.peek() == '(' {
+			if strings.EqualFold(val, "pivot") {
+				typ = PIVOT
+				break
+			}
+			if strings.EqualFold(val, "unpivot") {
+				typ = UNPIVOT
+				break
+			}
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "qualify") && tkn.betweenOperands() {
+			typ = QUALIFY
+			break
+		}
+		// ESCAPE is between the pattern of LIKE and its
+		// escape character.
+		if !tkn.quotedID && strings.EqualFold(val, "escape") && tkn.betweenOperands() {
+			typ = ESCAPE
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "begin") && tkn.beginKeyword() {
+			typ = BEGIN
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "modify") && tkn.modifyKeyword() {
+			typ = MODIFY
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "quick") && tkn.quickKeyword() {
+			typ = QUICK
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, AST_COMMENT) && tkn.commentKeyword() {
+			typ = COMMENT_KEYWORD
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "charset") && tkn.charsetKeyword() {
+			typ = CHARSET
+			break
+		}
+		if !tkn.quotedID && strings.EqualFold(val, "handler") && (tkn.lastToken == CONTINUE || tkn.lastToken == EXIT) {
+			tkn.handler = true
+		}
+		if !tkn.quotedID {
+			if scope := tkn.scopeKeyword(val); scope != 0 {
+				typ = scope
+				break
+			}
+		}
+		// CAST and JSON_TABLE are only keywords as function
+		// names, which must be followed directly by their
+		// parenthesis.
+		if !tkn.quotedID && tkn.lastChar == '(' {
+			if strings.EqualFold(val, "cast") {
+				typ = CAST
+				break
+			}
+			if strings.EqualFold(val, "json_table") {
+				typ = JSON_TABLE
+				break
+			}
+		}
+		if !tkn.quotedID && tkn.opts.Dialect.hasArrays() {
+			if strings.EqualFold(val, "array") {
+				typ = ARRAY
+				break
+			}
+			if strings.EqualFold(val, "struct") && tkn.opts.Dialect == BigQuery {
+				typ = STRUCT
+				break
+			}
+		}
+		if !tkn.quotedID && tkn.opts.Dialect == MariaDB && strings.EqualFold(val, "returning") {
+			typ = RETURNING
+			break
+		}
+		if !tkn.quotedID && tkn.opts.clauseKeyword(val) {
+			typ = CLAUSE_KEYWORD
+			lval.str = val
+			break
+		}
+		if !tkn.quotedID && tkn.opts.Dialect == Postgres {
+			if typ = postgresKeywords[strings.ToLower(val)]; typ != 0 {
+				break
+			}
+			typ = ID
+		}
+		lval.str = val
+		lval.quoted = tkn.quotedID
+	case ASOF, UNTIL:
+		if tkn.opts.NoTimeRange {
+			typ = ID
+			lval.str, lval.quoted = val, false
+		}
+	case FOR:
+		if tkn.scanWords("system_time") {
+			typ, val = FOR_SYSTEM_TIME, "for system_time"
+		}
+	case CREATE:
+		if tkn.scanWords("user") {
+			typ, val = CREATE_USER, "create user"
+		}
+	case ALTER:
+		if tkn.scanWords("user") {
+			typ, val = ALTER_USER, "alter user"
+		}
+	case SET:
+		if tkn.scanWords("password") {
+			typ, val = SET_PASSWORD, "set password"
+		}
+	case WITH:
+		switch {
+		case tkn.scanWords("check", "option"):
+			typ, val = WITH_CHECK_OPTION, AST_CHECK_OPTION
+		case tkn.scanWords("cascaded", "check", "option"):
+			typ, val = WITH_CHECK_OPTION, AST_CASCADED_CHECK_OPTION
+		case tkn.scanWords("local", "check", "option"):
+			typ, val = WITH_CHECK_OPTION, AST_LOCAL_CHECK_OPTION
+		}
+		if typ == WITH_CHECK_OPTION {
+			lval.str = val
+		}
+	case NUMBER, HEX, BIT_LITERAL, VALUE_ARG, LIST_ARG, COMMENT:
+		lval.str = val
+	case USER_VAR:
+		lval.str = val
+		lval.quoted = tkn.quotedID
+	case STRING:
+		lval.strVal = StrVal{Val: val, Quote: tkn.quote, Doubled: tkn.doubled}
+	}
+	if tkn.firstToken == 0 && typ != COMMENT {
+		tkn.firstToken = typ
+	}
+	if typ == BEGIN || typ == ';' {
+		tkn.handler = false
+	}
+	if typ == VALUES {
+		// The rows of an INSERT or VALUES statement are those
+		// of a VALUES outside of any parentheses.
+		lval.boolean = (tkn.firstToken == INSERT || tkn.firstToken == VALUES) && tkn.depth == 0
 	}
-	tkn.errorToken = val
+	tkn.errorToken, tkn.lastToken = val, typ
+	lval.end = tkn.Position - 1
 	return typ
 }
 
+// scanWords reports whether the next tokens are the given
+// words, and consumes them if so. It lets multi-word constructs
+// such as NEXT VALUE FOR be recognized without reserving each
+// word, or be told apart from other uses of their first word.
+func (tkn *Tokenizer) scanWords(words ...string) bool {
+	m := tkn.mark()
+	for _, word := range words {
+		typ, val := tkn.scan()
+		if tkn.quotedID || typ != ID && typ != keywords[word] || !strings.EqualFold(val, word) {
+			tkn.rewind(m)
+			return false
+		}
+	}
+	tkn.recorded = nil
+	return true
+}
+
+// peekSubquery reports whether the next tokens start a
+// subquery, a parenthesis followed by SELECT or WITH, without
+// consuming them.
+func (tkn *Tokenizer) peekSubquery() bool {
+	m := tkn.mark()
+	typ, _ := tkn.scan()
+	found := typ == '('
+	if found {
+		typ, _ = tkn.scan()
+		found = typ == SELECT || typ == WITH
+	}
+	tkn.rewind(m)
+	return found
+}
+
+// peek returns the type of the next token, without consuming it.
+func (tkn *Tokenizer) peek() int {
+	m := tkn.mark()
+	typ, _ := tkn.scan()
+	tkn.rewind(m)
+	return typ
+}
+
+// betweenOperands reports whether the last token can end an
+// operand or a table, and the next one start an expression, as
+// for a keyword such as QUALIFY or ESCAPE that is followed by an
+// expression, rather than an identifier.
+func (tkn *Tokenizer) betweenOperands() bool {
+	switch tkn.lastToken {
+	case ID, ')', NUMBER, STRING, VALUE_ARG, LIST_ARG, HEX, BIT_LITERAL, NULL, TRUE, FALSE, USER_VAR:
+	default:
+		return false
+	}
+	switch tkn.peek() {
+	case ID, '(', NUMBER, STRING, VALUE_ARG, LIST_ARG, HEX, BIT_LITERAL, NULL, TRUE, FALSE, USER_VAR,
+		NOT, '!', '-', '+', '~', EXISTS, CASE, INTERVAL, CONVERT, MATCH:
+		return true
+	}
+	return false
+}
+
+// scopeKeyword returns the token of val if it is GLOBAL, SESSION
+// or LOCAL where these are keywords, or else 0: as the scope of
+// the variable or transaction of a SET statement, which follows
+// it, and for LOCAL, before the INFILE of LOAD DATA. They are
+// identifiers elsewhere.
+func (tkn *Tokenizer) scopeKeyword(val string) int {
+	var typ int
+	switch strings.ToLower(val) {
+	case AST_GLOBAL:
+		typ = GLOBAL
+	case AST_SESSION:
+		typ = SESSION
+	case AST_LOCAL:
+		typ = LOCAL
+	default:
+		return 0
+	}
+	next := tkn.peek()
+	if typ == LOCAL && next == INFILE {
+		return typ
+	}
+	if tkn.firstToken == SET && tkn.depth == 0 && (tkn.lastToken == SET || tkn.lastToken == ',') && next == ID {
+		return typ
+	}
+	return 0
+}
+
+// beginKeyword reports whether a BEGIN is a keyword where it is
+// found: at the start of a statement, after a label, and where a
+// statement starts in a compound statement, including after the
+// conditions of a handler. It is an identifier elsewhere.
+func (tkn *Tokenizer) beginKeyword() bool {
+	if tkn.firstToken == 0 || tkn.lastToken == ':' {
+		return true
+	}
+	switch tkn.firstToken {
+	case BEGIN, IF, WHILE, LOOP, REPEAT, ID:
+	default:
+		return false
+	}
+	switch tkn.lastToken {
+	case ';', BEGIN, THEN, ELSE, DO, LOOP, REPEAT:
+		return true
+	case SQLEXCEPTION, SQLWARNING, ID, STRING, NUMBER:
+		return tkn.handler
+	}
+	return false
+}
+
+// modifyKeyword reports whether a MODIFY is a keyword where it
+// is found: as the action of ALTER TABLE, after the table or a
+// comma and before the column. It is an identifier elsewhere.
+func (tkn *Tokenizer) modifyKeyword() bool {
+	if tkn.firstToken != ALTER || tkn.depth != 0 || tkn.lastToken != ID && tkn.lastToken != ',' {
+		return false
+	}
+	next := tkn.peek()
+	return next == ID || next == COLUMN
+}
+
+// quickKeyword reports whether a QUICK is a keyword where it is
+// found: as a modifier of DELETE, after DELETE or LOW_PRIORITY. It
+// is an identifier elsewhere, and as a table of a multiple-table
+// DELETE, followed by a comma or a dot.
+func (tkn *Tokenizer) quickKeyword() bool {
+	if tkn.firstToken != DELETE || tkn.lastToken != DELETE && tkn.lastToken != LOW_PRIORITY && tkn.lastToken != COMMENT {
+		return false
+	}
+	next := tkn.peek()
+	return next != ',' && next != '.'
+}
+
+// commentKeyword reports whether a COMMENT is a keyword where it
+// is found: in CREATE and ALTER statements, before the string it
+// gives a column, an index or the table. It is an identifier
+// elsewhere, and in COMMENT=... table options.
+func (tkn *Tokenizer) commentKeyword() bool {
+	if tkn.firstToken != CREATE && tkn.firstToken != ALTER || tkn.lastToken == SELECT || tkn.lastToken == ',' {
+		return false
+	}
+	return tkn.peek() == STRING
+}
+
+// charsetKeyword reports whether a CHARSET is a keyword where it
+// is found: after a character type, as a table option, and in a
+// SET statement, and before the character set's name. It is an
+// identifier elsewhere.
+func (tkn *Tokenizer) charsetKeyword() bool {
+	switch tkn.peek() {
+	case ID, STRING, NUMBER, '=', DEFAULT:
+	default:
+		return false
+	}
+	switch tkn.lastToken {
+	case ')', CHAR, VARCHAR, TEXT, ID, NUMBER, STRING, DEFAULT:
+		return true
+	case SET, GLOBAL, SESSION, LOCAL:
+		return tkn.firstToken == SET
+	case ',':
+		switch tkn.firstToken {
+		case SET:
+			return true
+		case CREATE, ALTER:
+			return tkn.depth == 0
+		}
+	}
+	return false
+}
+
+// peekNextValues reports whether the next tokens are the count
+// of SELECT NEXT n VALUES FROM seq, a number or bind variable
+// followed by VALUES, without consuming them. SELECT NEXT VALUE
+// FROM t selects the column next, as value.
+func (tkn *Tokenizer) peekNextValues() bool {
+	m := tkn.mark()
+	typ, _ := tkn.scan()
+	found := typ == NUMBER || typ == VALUE_ARG
+	if found {
+		typ, _ = tkn.scan()
+		found = typ == VALUES
+	}
+	tkn.rewind(m)
+	return found
+}
+
+// tokenizerMark is the state of a Tokenizer between tokens,
+// as returned by mark.
+type tokenizerMark struct {
+	lastChar        uint16
+	position        int
+	line, lineStart int
+	posVarIndex     int
+}
+
+// mark returns the state of tkn, for rewind to scan the input
+// again from there. If the input is not a string, the bytes read
+// after it are recorded to be read again.
+func (tkn *Tokenizer) mark() tokenizerMark {
+	if tkn.src == "" {
+		tkn.recorded = []byte{}
+	}
+	return tokenizerMark{tkn.lastChar, tkn.Position, tkn.line, tkn.lineStart, tkn.posVarIndex}
+}
+
+// rewind returns tkn to the state m returned by mark.
+func (tkn *Tokenizer) rewind(m tokenizerMark) {
+	if tkn.src != "" {
+		offset := m.position
+		if offset > len(tkn.src) {
+			offset = len(tkn.src)
+		}
+		tkn.InStream.Seek(int64(offset), io.SeekStart)
+	} else {
+		tkn.pending = append(tkn.recorded, tkn.pending...)
+		tkn.recorded = nil
+	}
+	tkn.lastChar, tkn.Position = m.lastChar, m.position
+	tkn.line, tkn.lineStart = m.line, m.lineStart
+	tkn.posVarIndex = m.posVarIndex
+	tkn.quotedID = false
+}
+
 // Error is called by go yacc if there's a parsing error.
 func (tkn *Tokenizer) Error(err string) {
 	buf := bytes.NewBuffer(make([]byte, 0, 32))
-	if tkn.errorToken != nil {
+	if tkn.errorToken != "" {
 		fmt.Fprintf(buf, "%s at position %v near %s", err, tkn.Position, tkn.errorToken)
 	} else {
 		fmt.Fprintf(buf, "%s at position %v", err, tkn.Position)
 	}
 	tkn.LastError = buf.String()
+	tkn.errPosition = tkn.Position
+	tkn.errLine, tkn.errColumn = tkn.startLine, tkn.startColumn
 }
 
 // Scan scans the tokenizer for the next token and returns
 // the token type and an optional value.
 func (tkn *Tokenizer) Scan() (int, []byte) {
+	typ, val := tkn.scan()
+	if val == "" {
+		return typ, nil
+	}
+	return typ, []byte(val)
+}
+
+// scan is Scan, with the value as a string. If the input is a
+// string, the value is a slice of it where it can be, rather than
+// a copy.
+func (tkn *Tokenizer) scan() (int, string) {
 	if tkn.ForceEOF {
-		return 0, nil
+		return 0, ""
 	}
+	tkn.quotedID = false
 
 	if tkn.lastChar == 0 {
 		tkn.next()
 	}
 	tkn.skipBlank()
+	tkn.start = tkn.Position - 1
+	tkn.startLine, tkn.startColumn = tkn.line+1, tkn.start-tkn.lineStart+1
+	if tkn.src == "" {
+		tkn.buf, tkn.bufStart = append(tkn.buf[:0], byte(tkn.lastChar)), tkn.start
+	}
 	switch ch := tkn.lastChar; {
+	case tkn.multi && tkn.atDelimiter():
+		// The end of the statement. The delimiter is left
+		// unread, so that later calls return it again.
+		return 0, ""
 	case isLetter(ch):
 		return tkn.scanIdentifier()
 	case isDigit(ch):
@@ -191,77 +863,146 @@ func (tkn *Tokenizer) Scan() (int, []byte) {
 		tkn.next()
 		switch ch {
 		case EOFCHAR:
-			return 0, nil
-		case '=', ',', ';', '(', ')', '+', '*', '%', '&', '|', '^', '~':
-			return int(ch), nil
+			return 0, ""
+		case '=', ',', ';', '(', ')', '+', '*', '%', '&', '|', '^', '[', ']':
+			return int(ch), ""
+		case '~':
+			// Postgres matches regular expressions ignoring case
+			// with ~*, as MySQL does with REGEXP.
+			if tkn.opts.Dialect == Postgres && tkn.lastChar == '*' {
+				tkn.next()
+				return REGEXP, ""
+			}
+			return int(ch), ""
 		case '?':
 			tkn.posVarIndex++
-			buf := new(bytes.Buffer)
-			fmt.Fprintf(buf, ":v%d", tkn.posVarIndex)
-			return VALUE_ARG, buf.Bytes()
+			return VALUE_ARG, ":v" + strconv.Itoa(tkn.posVarIndex)
 		case '.':
 			if isDigit(tkn.lastChar) {
 				return tkn.scanNumber(true)
 			} else {
-				return int(ch), nil
+				return int(ch), ""
 			}
 		case '/':
 			switch tkn.lastChar {
 			case '/':
 				tkn.next()
-				return tkn.scanCommentType1("//")
+				return tkn.scanCommentType1()
 			case '*':
 				tkn.next()
 				return tkn.scanCommentType2()
 			default:
-				return int(ch), nil
+				return int(ch), ""
 			}
 		case '-':
-			if tkn.lastChar == '-' {
+			switch tkn.lastChar {
+			case '-':
 				tkn.next()
-				return tkn.scanCommentType1("--")
-			} else {
-				return int(ch), nil
+				return tkn.scanCommentType1()
+			case '>':
+				tkn.next()
+				if tkn.lastChar == '>' {
+					tkn.next()
+					return JSON_UNQUOTE_EXTRACT_OP, ""
+				}
+				return JSON_EXTRACT_OP, ""
+			default:
+				return int(ch), ""
 			}
 		case '<':
 			switch tkn.lastChar {
 			case '>':
 				tkn.next()
-				return NE, nil
+				return NE, ""
 			case '=':
 				tkn.next()
 				switch tkn.lastChar {
 				case '>':
 					tkn.next()
-					return NULL_SAFE_EQUAL, nil
+					return NULL_SAFE_EQUAL, ""
 				default:
-					return LE, nil
+					return LE, ""
 				}
 			default:
-				return int(ch), nil
+				return int(ch), ""
 			}
 		case '>':
 			if tkn.lastChar == '=' {
 				tkn.next()
-				return GE, nil
+				return GE, ""
 			} else {
-				return int(ch), nil
+				return int(ch), ""
 			}
 		case '!':
 			if tkn.lastChar == '=' {
 				tkn.next()
-				return NE, nil
+				return NE, ""
+			} else if tkn.opts.Dialect == Postgres && tkn.lastChar == '~' {
+				tkn.next()
+				if tkn.lastChar == '*' {
+					tkn.next()
+					return NOT_REGEXP, ""
+				}
+				return LEX_ERROR, "!~"
 			} else {
-				return LEX_ERROR, []byte("!")
+				return LEX_ERROR, "!"
+			}
+		case '"':
+			if tkn.opts.Dialect == Postgres {
+				return tkn.scanLiteralIdentifier(ch)
 			}
-		case '\'', '"':
-			return tkn.scanString(ch, STRING)
+			return tkn.scanString(ch, STRING, true)
+		case '\'':
+			// Backslashes are literal in Postgres standard strings.
+			return tkn.scanString(ch, STRING, tkn.opts.Dialect != Postgres)
 		case '`':
-			return tkn.scanLiteralIdentifier()
+			return tkn.scanLiteralIdentifier(ch)
+		case '$':
+			if tkn.opts.Dialect == Postgres && isDigit(tkn.lastChar) {
+				return tkn.scanPositionalArg()
+			}
+			return LEX_ERROR, tkn.text(tkn.start)
 		default:
-			return LEX_ERROR, []byte{byte(ch)}
+			return LEX_ERROR, tkn.text(tkn.start)
+		}
+	}
+}
+
+// text returns the input from offset from up to the current
+// character, the one last read. It is a slice of the input if that
+// is a string, or else a copy of the bytes read for the token.
+func (tkn *Tokenizer) text(from int) string {
+	end := tkn.Position - 1
+	if tkn.src == "" {
+		from, end = from-tkn.bufStart, end-tkn.bufStart
+		if end > len(tkn.buf) {
+			end = len(tkn.buf)
 		}
+		if from > end {
+			from = end
+		}
+		return string(tkn.buf[from:end])
+	}
+	if end > len(tkn.src) {
+		end = len(tkn.src)
+	}
+	if from > end {
+		from = end
+	}
+	return tkn.src[from:end]
+}
+
+// scanRest returns the rest of the statement as written, up
+// to the delimiter that ends it in multi mode, and forces an EOF
+// after it. It is used for the parts of statements that are not
+// parsed.
+func (tkn *Tokenizer) scanRest() string {
+	from := tkn.Position - 1
+	for tkn.lastChar != EOFCHAR && !(tkn.multi && tkn.atDelimiter()) {
+		tkn.next()
 	}
+	tkn.ForceEOF = true
+	return strings.TrimRight(tkn.text(from), " \n\r\t")
 }
 
 func (tkn *Tokenizer) skipBlank() {
@@ -272,176 +1013,332 @@ func (tkn *Tokenizer) skipBlank() {
 	}
 }
 
-func (tkn *Tokenizer) scanIdentifier() (int, []byte) {
-	buffer := bytes.NewBuffer(make([]byte, 0, 8))
-	buffer.WriteByte(byte(tkn.lastChar))
-	for tkn.next(); isLetter(tkn.lastChar) || isDigit(tkn.lastChar); tkn.next() {
-		buffer.WriteByte(byte(tkn.lastChar))
+func (tkn *Tokenizer) scanIdentifier() (int, string) {
+	first := tkn.lastChar
+	tkn.next()
+	if first == '@' && tkn.lastChar != '@' {
+		return tkn.scanUserVar()
+	}
+	if tkn.lastChar == '\'' {
+		switch first {
+		case 'x', 'X':
+			return tkn.scanQuotedNumber(16, HEX)
+		case 'b', 'B':
+			return tkn.scanQuotedNumber(2, BIT_LITERAL)
+		case 'e', 'E':
+			if tkn.opts.Dialect == Postgres {
+				tkn.next()
+				return tkn.scanString('\'', STRING, true)
+			}
+		}
 	}
-	lowered := bytes.ToLower(buffer.Bytes())
-	if keywordId, found := keywords[string(lowered)]; found {
-		return keywordId, lowered
+	for isLetter(tkn.lastChar) || isDigit(tkn.lastChar) {
+		tkn.next()
+	}
+	word := tkn.text(tkn.start)
+	if keywordID, lowered, found := lookupKeyword(word); found {
+		return keywordID, lowered
 	}
-	return ID, buffer.Bytes()
+	return ID, word
 }
 
-func (tkn *Tokenizer) scanLiteralIdentifier() (int, []byte) {
-	buffer := bytes.NewBuffer(make([]byte, 0, 8))
-	buffer.WriteByte(byte(tkn.lastChar))
-	for tkn.next(); tkn.lastChar != '`'; tkn.next() {
-		buffer.WriteByte(byte(tkn.lastChar))
+// maxKeywordLen is the length of the longest keyword.
+const maxKeywordLen = 32
+
+// keywordTexts maps each keyword to itself, so that a keyword can
+// be returned in lower case without being allocated.
+var keywordTexts = func() map[string]string {
+	texts := make(map[string]string, len(keywords))
+	for word := range keywords {
+		if len(word) > maxKeywordLen {
+			panic("keyword too long: " + word)
+		}
+		texts[word] = word
 	}
-	if tkn.lastChar != '`' {
-		return LEX_ERROR, buffer.Bytes()
+	return texts
+}()
+
+// lookupKeyword returns the token of word if it is a keyword,
+// regardless of case, and the keyword in lower case.
+func lookupKeyword(word string) (int, string, bool) {
+	if len(word) > maxKeywordLen {
+		return 0, "", false
 	}
-	tkn.next()
-	return ID, buffer.Bytes()
+	var lower [maxKeywordLen]byte
+	for i := 0; i < len(word); i++ {
+		ch := word[i]
+		if 'A' <= ch && ch <= 'Z' {
+			ch += 'a' - 'A'
+		}
+		lower[i] = ch
+	}
+	lowered, found := keywordTexts[string(lower[:len(word)])]
+	if !found {
+		return 0, "", false
+	}
+	return keywords[lowered], lowered, true
 }
 
-func (tkn *Tokenizer) scanBindVar() (int, []byte) {
-	buffer := bytes.NewBuffer(make([]byte, 0, 8))
-	buffer.WriteByte(byte(tkn.lastChar))
+// scanUserVar scans the name of a user variable after its @,
+// as in @var, @`my var` or @'my var'.
+func (tkn *Tokenizer) scanUserVar() (int, string) {
+	switch delim := tkn.lastChar; delim {
+	case '`', '\'', '"':
+		tkn.next()
+		typ, val := tkn.scanLiteralIdentifier(delim)
+		if typ != ID {
+			return typ, val
+		}
+		return USER_VAR, val
+	}
+	from := tkn.Position - 1
+	for isLetter(tkn.lastChar) || isDigit(tkn.lastChar) || tkn.lastChar == '.' || tkn.lastChar == '$' {
+		tkn.next()
+	}
+	name := tkn.text(from)
+	if name == "" {
+		return LEX_ERROR, ""
+	}
+	return USER_VAR, name
+}
+
+// scanLiteralIdentifier scans an identifier quoted with delim,
+// which is a backtick, or a double quote in the Postgres dialect.
+func (tkn *Tokenizer) scanLiteralIdentifier(delim uint16) (int, string) {
+	tkn.quotedID = true
+	from := tkn.Position - 1
+	doubled := false
+	for {
+		switch tkn.lastChar {
+		case delim:
+			tkn.next()
+			if tkn.lastChar != delim {
+				// A doubled quote is an escaped quote;
+				// a single one ends the identifier.
+				name := tkn.text(from)
+				name = name[:len(name)-1]
+				if name == "" {
+					return LEX_ERROR, ""
+				}
+				if doubled {
+					quote := string(rune(delim))
+					name = strings.Replace(name, quote+quote, quote, -1)
+				}
+				return ID, name
+			}
+			doubled = true
+		case EOFCHAR:
+			return LEX_ERROR, tkn.text(from)
+		}
+		tkn.next()
+	}
+}
+
+func (tkn *Tokenizer) scanBindVar() (int, string) {
 	token := VALUE_ARG
 	tkn.next()
+	if tkn.lastChar == '=' {
+		tkn.next()
+		return ASSIGN, ""
+	}
+	if tkn.lastChar == ':' && tkn.opts.Dialect == Postgres {
+		tkn.next()
+		return TYPECAST, ""
+	}
 	if tkn.lastChar == ':' {
 		token = LIST_ARG
-		buffer.WriteByte(byte(tkn.lastChar))
 		tkn.next()
 	}
 	if !isLetter(tkn.lastChar) {
-		return LEX_ERROR, buffer.Bytes()
+		if token == VALUE_ARG {
+			// A lone colon, as in a statement label.
+			return ':', ""
+		}
+		return LEX_ERROR, tkn.text(tkn.start)
 	}
 	for isLetter(tkn.lastChar) || isDigit(tkn.lastChar) || tkn.lastChar == '.' {
-		buffer.WriteByte(byte(tkn.lastChar))
 		tkn.next()
 	}
-	return token, buffer.Bytes()
+	return token, tkn.text(tkn.start)
 }
 
-func (tkn *Tokenizer) scanMantissa(base int, buffer *bytes.Buffer) {
+// scanPositionalArg scans a Postgres positional parameter
+// such as $1, after its '$'.
+func (tkn *Tokenizer) scanPositionalArg() (int, string) {
+	for isDigit(tkn.lastChar) {
+		tkn.next()
+	}
+	return VALUE_ARG, tkn.text(tkn.start)
+}
+
+// scanQuotedNumber scans the quoted digits of an X'1F' or a
+// b'1010' literal, starting at the opening quote. The digits of
+// an X” literal must come in pairs, one per byte.
+func (tkn *Tokenizer) scanQuotedNumber(base int, typ int) (int, string) {
+	tkn.next()
+	from := tkn.Position - 1
+	tkn.scanMantissa(base)
+	digits := tkn.text(from)
+	if tkn.lastChar != '\'' {
+		return LEX_ERROR, digits
+	}
+	tkn.next()
+	if typ == HEX && len(digits)%2 != 0 {
+		return LEX_ERROR, digits
+	}
+	return typ, digits
+}
+
+func (tkn *Tokenizer) scanMantissa(base int) {
 	for digitVal(tkn.lastChar) < base {
-		tkn.ConsumeNext(buffer)
+		tkn.next()
 	}
 }
 
-func (tkn *Tokenizer) scanNumber(seenDecimalPoint bool) (int, []byte) {
-	buffer := bytes.NewBuffer(make([]byte, 0, 8))
+func (tkn *Tokenizer) scanNumber(seenDecimalPoint bool) (int, string) {
 	if seenDecimalPoint {
-		buffer.WriteByte('.')
-		tkn.scanMantissa(10, buffer)
+		tkn.scanMantissa(10)
 		goto exponent
 	}
 
 	if tkn.lastChar == '0' {
 		// int or float
-		tkn.ConsumeNext(buffer)
+		tkn.next()
 		if tkn.lastChar == 'x' || tkn.lastChar == 'X' {
 			// hexadecimal int
-			tkn.ConsumeNext(buffer)
-			tkn.scanMantissa(16, buffer)
+			tkn.next()
+			tkn.scanMantissa(16)
+		} else if tkn.lastChar == 'b' || tkn.lastChar == 'B' {
+			// binary int
+			tkn.next()
+			tkn.scanMantissa(2)
 		} else {
-			// octal int or float
-			seenDecimalDigit := false
-			tkn.scanMantissa(8, buffer)
-			if tkn.lastChar == '8' || tkn.lastChar == '9' {
-				// illegal octal int or float
-				seenDecimalDigit = true
-				tkn.scanMantissa(10, buffer)
-			}
-			if tkn.lastChar == '.' || tkn.lastChar == 'e' || tkn.lastChar == 'E' {
-				goto fraction
-			}
-			// octal int
-			if seenDecimalDigit {
-				return LEX_ERROR, buffer.Bytes()
-			}
+			// decimal int or float: leading zeros do not make
+			// a number octal, as in MySQL
+			tkn.scanMantissa(10)
+			goto fraction
 		}
 		goto exit
 	}
 
 	// decimal int or float
-	tkn.scanMantissa(10, buffer)
+	tkn.scanMantissa(10)
 
 fraction:
 	if tkn.lastChar == '.' {
-		tkn.ConsumeNext(buffer)
-		tkn.scanMantissa(10, buffer)
+		tkn.next()
+		tkn.scanMantissa(10)
 	}
 
 exponent:
 	if tkn.lastChar == 'e' || tkn.lastChar == 'E' {
-		tkn.ConsumeNext(buffer)
+		tkn.next()
 		if tkn.lastChar == '+' || tkn.lastChar == '-' {
-			tkn.ConsumeNext(buffer)
+			tkn.next()
 		}
-		tkn.scanMantissa(10, buffer)
+		tkn.scanMantissa(10)
 	}
 
 exit:
-	return NUMBER, buffer.Bytes()
+	return NUMBER, tkn.text(tkn.start)
 }
 
-func (tkn *Tokenizer) scanString(delim uint16, typ int) (int, []byte) {
-	buffer := bytes.NewBuffer(make([]byte, 0, 8))
+// scanString scans a string quoted with delim. Backslashes escape
+// the next character if escapes is set, and are literal otherwise.
+func (tkn *Tokenizer) scanString(delim uint16, typ int, escapes bool) (int, string) {
+	tkn.quote, tkn.doubled = byte(delim), false
+	from := tkn.Position - 1
+	escaped := false
 	for {
 		ch := tkn.lastChar
 		tkn.next()
 		if ch == delim {
 			if tkn.lastChar == delim {
+				tkn.doubled = true
 				tkn.next()
 			} else {
 				break
 			}
-		} else if ch == '\\' {
+		} else if ch == '\\' && escapes {
 			if tkn.lastChar == EOFCHAR {
-				return LEX_ERROR, buffer.Bytes()
-			}
-			if decodedChar := sqltypes.SqlDecodeMap[byte(tkn.lastChar)]; decodedChar == sqltypes.DONTESCAPE {
-				ch = tkn.lastChar
-			} else {
-				ch = uint16(decodedChar)
+				return LEX_ERROR, tkn.text(from)
 			}
+			escaped = true
 			tkn.next()
 		}
 		if ch == EOFCHAR {
-			return LEX_ERROR, buffer.Bytes()
+			return LEX_ERROR, tkn.text(from)
+		}
+	}
+	val := tkn.text(from)
+	val = val[:len(val)-1]
+	if escapes && (escaped || tkn.doubled) {
+		val = unescapeString(val, byte(delim))
+	} else if tkn.doubled {
+		quote := string(rune(delim))
+		val = strings.Replace(val, quote+quote, quote, -1)
+	}
+	return typ, val
+}
+
+// unescapeString returns the value of a string literal quoted
+// with delim, whose text between the quotes is raw.
+func unescapeString(raw string, delim byte) string {
+	buf := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+		switch {
+		case ch == delim:
+			// The first of a doubled quote.
+			i++
+		case ch == '\\' && i+1 < len(raw) && (raw[i+1] == '%' || raw[i+1] == '_'):
+			// As in MySQL, \% and \_ keep their backslash, so
+			// that they match % and _ in LIKE patterns.
+			buf = append(buf, ch)
+			i++
+			ch = raw[i]
+		case ch == '\\' && i+1 < len(raw):
+			i++
+			if decodedChar := sqltypes.SqlDecodeMap[raw[i]]; decodedChar == sqltypes.DONTESCAPE {
+				ch = raw[i]
+			} else {
+				ch = decodedChar
+			}
 		}
-		buffer.WriteByte(byte(ch))
+		buf = append(buf, ch)
 	}
-	return typ, buffer.Bytes()
+	return string(buf)
 }
 
-func (tkn *Tokenizer) scanCommentType1(prefix string) (int, []byte) {
-	buffer := bytes.NewBuffer(make([]byte, 0, 8))
-	buffer.WriteString(prefix)
+// scanCommentType1 scans a comment that runs to the end of the
+// line, after its // or -- prefix.
+func (tkn *Tokenizer) scanCommentType1() (int, string) {
 	for tkn.lastChar != EOFCHAR {
 		if tkn.lastChar == '\n' {
-			tkn.ConsumeNext(buffer)
+			tkn.next()
 			break
 		}
-		tkn.ConsumeNext(buffer)
+		tkn.next()
 	}
-	return COMMENT, buffer.Bytes()
+	return COMMENT, tkn.text(tkn.start)
 }
 
-func (tkn *Tokenizer) scanCommentType2() (int, []byte) {
-	buffer := bytes.NewBuffer(make([]byte, 0, 8))
-	buffer.WriteString("/*")
+func (tkn *Tokenizer) scanCommentType2() (int, string) {
 	for {
 		if tkn.lastChar == '*' {
-			tkn.ConsumeNext(buffer)
+			tkn.next()
 			if tkn.lastChar == '/' {
-				tkn.ConsumeNext(buffer)
+				tkn.next()
 				break
 			}
 			continue
 		}
 		if tkn.lastChar == EOFCHAR {
-			return LEX_ERROR, buffer.Bytes()
+			return LEX_ERROR, tkn.text(tkn.start)
 		}
-		tkn.ConsumeNext(buffer)
+		tkn.next()
 	}
-	return COMMENT, buffer.Bytes()
+	return COMMENT, tkn.text(tkn.start)
 }
 
 func (tkn *Tokenizer) ConsumeNext(buffer *bytes.Buffer) {
@@ -454,15 +1351,118 @@ func (tkn *Tokenizer) ConsumeNext(buffer *bytes.Buffer) {
 }
 
 func (tkn *Tokenizer) next() {
-	if ch, err := tkn.InStream.ReadByte(); err != nil {
-		// Only EOF is possible.
+	if ch, err := tkn.readByte(); err != nil {
+		if err != io.EOF && tkn.readErr == nil {
+			tkn.readErr = err
+		}
 		tkn.lastChar = EOFCHAR
 	} else {
 		tkn.lastChar = uint16(ch)
+		if tkn.src == "" {
+			tkn.buf = append(tkn.buf, ch)
+			if tkn.recorded != nil {
+				tkn.recorded = append(tkn.recorded, ch)
+			}
+		}
+		if ch == '\n' {
+			tkn.line++
+			tkn.lineStart = tkn.Position + 1
+		}
 	}
 	tkn.Position++
 }
 
+func (tkn *Tokenizer) readByte() (byte, error) {
+	switch {
+	case len(tkn.pending) != 0:
+		ch := tkn.pending[0]
+		tkn.pending = tkn.pending[1:]
+		return ch, nil
+	case tkn.reader != nil:
+		return tkn.reader.ReadByte()
+	}
+	return tkn.InStream.ReadByte()
+}
+
+// skipStatement skips the rest of the current statement,
+// up to the delimiter that ends it in multi mode.
+func (tkn *Tokenizer) skipStatement() {
+	tkn.ForceEOF = false
+	for {
+		if typ, _ := tkn.scan(); typ == 0 {
+			return
+		}
+	}
+}
+
+// atDelimiter reports whether the input starts with the delimiter
+// at the current character. The characters that follow it are
+// read ahead, and left to be read again.
+func (tkn *Tokenizer) atDelimiter() bool {
+	delimiter := tkn.delimiter
+	if delimiter == "" {
+		return tkn.lastChar == ';'
+	}
+	if tkn.lastChar != uint16(delimiter[0]) {
+		return false
+	}
+	if tkn.src != "" {
+		return strings.HasPrefix(tkn.src[tkn.Position-1:], delimiter)
+	}
+	ahead := make([]byte, 0, len(delimiter)-1)
+	for len(ahead) < cap(ahead) {
+		ch, err := tkn.readByte()
+		if err != nil {
+			if err != io.EOF && tkn.readErr == nil {
+				tkn.readErr = err
+			}
+			break
+		}
+		ahead = append(ahead, ch)
+	}
+	tkn.pending = append(ahead, tkn.pending...)
+	return string(ahead) == delimiter[1:]
+}
+
+// skipDelimiter skips the delimiter at the current character.
+func (tkn *Tokenizer) skipDelimiter() {
+	tkn.next()
+	for i := 1; i < len(tkn.delimiter); i++ {
+		tkn.next()
+	}
+}
+
+// scanDelimiterDirective reports whether the token typ with value
+// val starts a DELIMITER directive of the mysql command line
+// client, as the first token of a statement read by ParseNext.
+// If so, it sets the delimiter to the word that follows, on the
+// same line, and skips the rest of the line.
+func (tkn *Tokenizer) scanDelimiterDirective(typ int, val string) bool {
+	if !tkn.multi || tkn.firstToken != 0 || typ != ID || tkn.quotedID || !strings.EqualFold(val, "delimiter") {
+		return false
+	}
+	for tkn.lastChar == ' ' || tkn.lastChar == '\t' {
+		tkn.next()
+	}
+	var delimiter []byte
+	for tkn.lastChar != EOFCHAR && strings.IndexByte(" \t\r\n", byte(tkn.lastChar)) == -1 {
+		delimiter = append(delimiter, byte(tkn.lastChar))
+		tkn.next()
+	}
+	if len(delimiter) == 0 {
+		// Left to be reported as a syntax error.
+		return false
+	}
+	for tkn.lastChar != EOFCHAR && tkn.lastChar != '\n' {
+		tkn.next()
+	}
+	tkn.delimiter = string(delimiter)
+	if tkn.delimiter == ";" {
+		tkn.delimiter = ""
+	}
+	return true
+}
+
 func isLetter(ch uint16) bool {
 	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || ch == '@'
 }
@@ -0,0 +1,206 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// tolerant.go parses sql that may not be valid, as it is while
+// being edited, into as much of a parse tree as it can.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxRecoveries bounds the clauses ParseTolerant drops from a
+// statement before giving up on it.
+const maxRecoveries = 8
+
+// ParseTolerant parses sql without stopping at the first error.
+// A statement that does not parse is parsed again without the
+// clause holding the error, such as its WHERE or ORDER BY clause,
+// until it parses, and nil is returned if its first clause holds
+// the error. Statements following the first one are dropped as
+// errors too; ParseScriptTolerant parses them all. The errors are
+// in order, and their positions refer to sql. The partial tree is
+// meant for editor tooling such as autocompletion and linting, not
+// for executing.
+func ParseTolerant(sql string) (Statement, []ParseError) {
+	var errs []ParseError
+	var removals []removal
+	text := sql
+	for {
+		stmt, err := Parse(text)
+		if err == nil {
+			return stmt, errs
+		}
+		perr, ok := err.(*ParseError)
+		if !ok {
+			return nil, append(errs, ParseError{Message: err.Error(), Position: originalOffset(len(text), removals)})
+		}
+		start := errorTokenOffset(text, perr)
+		if len(removals) != 0 && start == removals[len(removals)-1].at {
+			// The error is where the last clause was dropped,
+			// so the text without it would not parse either.
+			return nil, errs
+		}
+		position := perr.Position
+		perr.Position = originalOffset(position, removals)
+		perr.Message = relocateMessage(perr.Message, position, perr.Position)
+		if perr.Line != 0 {
+			perr.Line, perr.Column = lineColumn(sql, originalOffset(start, removals))
+		}
+		errs = append(errs, *perr)
+		if len(removals) == maxRecoveries {
+			return nil, errs
+		}
+		from, to, ok := brokenClause(text, start)
+		if !ok {
+			return nil, errs
+		}
+		removals = append(removals, removal{at: from, n: to - from})
+		text = text[:from] + text[to:]
+	}
+}
+
+// ParseScriptTolerant splits script with SplitScript, and parses
+// each of its statements with ParseTolerant. Statements that could
+// not be parsed at all are left out of the result. If script cannot
+// be split, it is parsed as a single statement.
+func ParseScriptTolerant(script string) ([]Statement, []ParseError) {
+	pieces, err := SplitScript(script)
+	if err != nil {
+		pieces = []string{strings.TrimSpace(script)}
+	}
+	var stmts []Statement
+	var errs []ParseError
+	offset := 0
+	for _, piece := range pieces {
+		if i := strings.Index(script[offset:], piece); i >= 0 {
+			offset += i
+		}
+		stmt, pieceErrs := ParseTolerant(piece)
+		for _, perr := range pieceErrs {
+			position := perr.Position
+			perr.Position += offset
+			perr.Message = relocateMessage(perr.Message, position, perr.Position)
+			if perr.Line != 0 {
+				perr.Line, perr.Column = lineColumn(script, offset+lineColumnOffset(piece, perr.Line, perr.Column))
+			}
+			errs = append(errs, perr)
+		}
+		if stmt != nil {
+			stmts = append(stmts, stmt)
+		}
+		offset += len(piece)
+	}
+	return stmts, errs
+}
+
+// removal is a part of a statement ParseTolerant dropped:
+// n bytes at offset at of the text it was dropped from.
+type removal struct {
+	at, n int
+}
+
+// originalOffset maps the offset pos of a text from which the
+// removals were dropped, in order, back to the original text.
+func originalOffset(pos int, removals []removal) int {
+	for i := len(removals) - 1; i >= 0; i-- {
+		if pos >= removals[i].at {
+			pos += removals[i].n
+		}
+	}
+	return pos
+}
+
+// errorTokenOffset returns the offset in sql of the token the
+// error was found at, or the length of sql at the end of input.
+func errorTokenOffset(sql string, perr *ParseError) int {
+	if perr.Token == "" {
+		return len(sql)
+	}
+	return lineColumnOffset(sql, perr.Line, perr.Column)
+}
+
+// lineColumnOffset returns the offset in sql of the byte at
+// line and column, both counted from 1.
+func lineColumnOffset(sql string, line, column int) int {
+	offset := 0
+	for ; line > 1; line-- {
+		i := strings.IndexByte(sql[offset:], '\n')
+		if i < 0 {
+			return len(sql)
+		}
+		offset += i + 1
+	}
+	if offset += column - 1; offset > len(sql) {
+		return len(sql)
+	}
+	return offset
+}
+
+// lineColumn returns the line and column, both counted from 1,
+// of the byte at offset of sql.
+func lineColumn(sql string, offset int) (int, int) {
+	return strings.Count(sql[:offset], "\n") + 1, offset - strings.LastIndexByte(sql[:offset], '\n')
+}
+
+// relocateMessage returns message, the message of an error found
+// at position, for the error found at newPosition instead.
+func relocateMessage(message string, position, newPosition int) string {
+	return strings.Replace(message, fmt.Sprintf("at position %d", position), fmt.Sprintf("at position %d", newPosition), 1)
+}
+
+// clauseTokens are the keywords that start a clause of a
+// statement, and the semicolon ending it.
+var clauseTokens = map[int]bool{
+	FROM:   true,
+	WHERE:  true,
+	GROUP:  true,
+	HAVING: true,
+	WINDOW: true,
+	ORDER:  true,
+	LIMIT:  true,
+	SET:    true,
+	VALUES: true,
+	UNION:  true,
+	';':    true,
+}
+
+// brokenClause returns the span of the clause of sql holding the
+// error found at offset errStart: from the last clause keyword
+// before it, outside parentheses, to the next one or the end of
+// sql, or to the end of sql if the clause is what follows a
+// semicolon. An error found at a clause keyword is taken to be in
+// the clause before it. It reports false if the error is in the
+// first clause, which the statement cannot do without.
+func brokenClause(sql string, errStart int) (int, int, bool) {
+	tkn := NewStringTokenizer(sql)
+	from, to := -1, len(sql)
+	depth := 0
+	for first := true; ; first = false {
+		typ, _ := tkn.scan()
+		switch typ {
+		case 0, LEX_ERROR:
+			return from, to, from >= 0
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if first || depth != 0 || !clauseTokens[typ] {
+			continue
+		}
+		if tkn.start >= errStart {
+			if from < 0 {
+				return 0, 0, false
+			}
+			if sql[from] != ';' {
+				to = tkn.start
+			}
+			return from, to, true
+		}
+		from = tkn.start
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTolerant(t *testing.T) {
+	tcases := []struct {
+		sql       string
+		want      string
+		positions []int
+	}{{
+		sql:  "select a from t where b = 1",
+		want: "select a from t where b = 1",
+	}, {
+		sql:       "select a from t where b = = 1 order by c",
+		want:      "select a from t order by c asc",
+		positions: []int{28},
+	}, {
+		sql:       "select a from t where b = 1 order by",
+		want:      "select a from t where b = 1",
+		positions: []int{38},
+	}, {
+		sql:       "select a from t where (b = ) and c limit 1",
+		want:      "select a from t limit 1",
+		positions: []int{29},
+	}, {
+		sql:       "select a from t\nwhere b = = 1\ngroup by c\nhaving\nlimit 3",
+		want:      "select a from t group by c limit 3",
+		positions: []int{28, 54},
+	}, {
+		sql:       "select a from t; select b from u",
+		want:      "select a from t",
+		positions: []int{24},
+	}, {
+		sql:       "select a, from t",
+		positions: []int{15},
+	}, {
+		sql:       "update t set a = where b = 1",
+		positions: []int{23},
+	}}
+	for _, tcase := range tcases {
+		stmt, errs := ParseTolerant(tcase.sql)
+		if tcase.want == "" {
+			assert.Nil(t, stmt, tcase.sql)
+		} else {
+			assert.Equal(t, tcase.want, String(stmt), tcase.sql)
+		}
+		var positions []int
+		for _, err := range errs {
+			positions = append(positions, err.Position)
+		}
+		assert.Equal(t, tcase.positions, positions, tcase.sql)
+	}
+
+	_, errs := ParseTolerant("select a from t\nwhere b = = 1\ngroup by c\nhaving\nlimit 3")
+	assert.Equal(t, "syntax error at position 54 near limit", errs[1].Message)
+	assert.Equal(t, 5, errs[1].Line)
+	assert.Equal(t, 1, errs[1].Column)
+}
+
+func TestParseScriptTolerant(t *testing.T) {
+	stmts, errs := ParseScriptTolerant("select a from t;\nselect b from u where;\nselect from;\nupdate t set a = 1 where b = 1 order")
+	assert.Equal(t, "select a from t; select b from u; update t set a = 1 where b = 1; ", String(Statements(stmts)))
+	if assert.Len(t, errs, 3) {
+		assert.Equal(t, "syntax error at position 40", errs[0].Message)
+		assert.Equal(t, 2, errs[0].Line)
+		assert.Equal(t, 22, errs[0].Column)
+		assert.Equal(t, 52, errs[1].Position)
+		assert.Equal(t, 3, errs[1].Line)
+		assert.Equal(t, []string{"BY"}, errs[2].Expected)
+		assert.Equal(t, 4, errs[2].Line)
+	}
+}
@@ -7,6 +7,9 @@ package sqlparser
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 )
 
 // TrackedBuffer is used to rebuild a query from the ast.
@@ -20,8 +23,61 @@ type TrackedBuffer struct {
 	*bytes.Buffer
 	bindLocations []bindLocation
 	nodeFormatter func(buf *TrackedBuffer, node SQLNode)
+	idQuoting     IDQuoting
+	dialect       Dialect
+	dialectSet    bool
+	dialectErr    error
+	// positionalArgs counts the $n parameters written as ?.
+	positionalArgs int
+	pretty         *prettyState
+	placeholders   Placeholders
+	bindVars       []string
+
+	// w, if set, receives the query as it is formatted, and werr
+	// is the first error it returned.
+	w    io.Writer
+	werr error
 }
 
+// streamChunk is the number of bytes a TrackedBuffer writing to an
+// io.Writer buffers before writing them out.
+const streamChunk = 32 << 10
+
+// Placeholders selects how bind variables are written.
+type Placeholders int
+
+const (
+	// NamedPlaceholders writes bind variables as they were
+	// parsed, such as :name. This is the default.
+	NamedPlaceholders Placeholders = iota
+	// QuestionPlaceholders writes every bind variable as ?.
+	QuestionPlaceholders
+	// DollarPlaceholders numbers the bind variables in the
+	// order they are written, as $1, $2 and so on.
+	DollarPlaceholders
+)
+
+// IDQuoting selects when identifiers are quoted with backticks.
+type IDQuoting int
+
+const (
+	// QuoteWhenNeeded quotes identifiers that this parser would
+	// not read back as the same plain identifier, including all
+	// of its keywords. This is the default.
+	QuoteWhenNeeded IDQuoting = iota
+	// QuoteAlways quotes every identifier.
+	QuoteAlways
+	// QuoteReserved quotes the identifiers QuoteWhenNeeded does,
+	// which include the words reserved by this parser's keyword
+	// table, and MySQL's reserved words, but leaves the words that
+	// are only keywords where they are used, such as "status" or
+	// "session", alone.
+	QuoteReserved
+	// QuoteOriginal quotes identifiers that were quoted in the
+	// parsed input, plus any that would not read back correctly.
+	QuoteOriginal
+)
+
 func NewTrackedBuffer(nodeFormatter func(buf *TrackedBuffer, node SQLNode)) *TrackedBuffer {
 	buf := &TrackedBuffer{
 		Buffer:        bytes.NewBuffer(make([]byte, 0, 128)),
@@ -31,6 +87,44 @@ func NewTrackedBuffer(nodeFormatter func(buf *TrackedBuffer, node SQLNode)) *Tra
 	return buf
 }
 
+// NewWriterTrackedBuffer returns a TrackedBuffer that writes the
+// query to w as it is formatted, rather than keeping all of it in
+// memory, so that very large statements, such as INSERTs of many
+// rows, can be streamed to a file or connection. It is written out
+// in chunks, between nodes. Flush must be called when done, to
+// write the rest. String, Bytes and ParsedQuery only see what has
+// not been written out yet.
+func NewWriterTrackedBuffer(w io.Writer, nodeFormatter func(buf *TrackedBuffer, node SQLNode)) *TrackedBuffer {
+	buf := NewTrackedBuffer(nodeFormatter)
+	buf.w = w
+	return buf
+}
+
+// FormatTo formats node like String, writing it to w as it goes.
+func FormatTo(w io.Writer, node SQLNode) error {
+	buf := NewWriterTrackedBuffer(w, nil)
+	buf.Myprintf("%v", node)
+	return buf.Flush()
+}
+
+// Flush writes what buf holds to its writer, and returns the first
+// error the writer returned. Once it has returned one, nothing more
+// is written to it. Flush does nothing without a writer.
+func (buf *TrackedBuffer) Flush() error {
+	if buf.w != nil {
+		buf.flush(buf.Len())
+	}
+	return buf.werr
+}
+
+// flush writes out the first n bytes of buf.
+func (buf *TrackedBuffer) flush(n int) {
+	if buf.werr == nil {
+		_, buf.werr = buf.w.Write(buf.Bytes()[:n])
+	}
+	buf.Next(n)
+}
+
 // Myprintf mimics fmt.Fprintf(buf, ...), but limited to Node(%v),
 // Node.Value(%s) and string(%s). It also allows a %a for a value argument, in
 // which case it adds tracking info for future substitutions.
@@ -72,12 +166,29 @@ func (buf *TrackedBuffer) Myprintf(format string, values ...interface{}) {
 				panic(fmt.Sprintf("unexpected type %T", v))
 			}
 		case 'v':
+			// A nil node formats as nothing, so that partially
+			// built trees can still be printed.
+			if values[fieldnum] == nil {
+				break
+			}
 			node := values[fieldnum].(SQLNode)
+			if buf.pretty != nil && buf.formatPretty(node) {
+				break
+			}
 			if buf.nodeFormatter == nil {
 				node.Format(buf)
 			} else {
 				buf.nodeFormatter(buf, node)
 			}
+			if buf.w != nil && buf.Len() >= streamChunk {
+				// Trailing spaces are kept, as a pretty
+				// newline drops them.
+				n := buf.Len()
+				for n > 0 && buf.Bytes()[n-1] == ' ' {
+					n--
+				}
+				buf.flush(n)
+			}
 		case 'a':
 			buf.WriteArg(values[fieldnum].(string))
 		default:
@@ -90,7 +201,28 @@ func (buf *TrackedBuffer) Myprintf(format string, values ...interface{}) {
 
 // WriteArg writes a value argument into the buffer. arg should not contain
 // the ':' prefix. It also adds tracking info for future substitutions.
+// With positional placeholders, the placeholder is written instead,
+// and there is nothing to substitute.
 func (buf *TrackedBuffer) WriteArg(arg string) {
+	buf.bindVars = append(buf.bindVars, bindVarName(arg))
+	switch buf.placeholders {
+	case QuestionPlaceholders:
+		buf.WriteByte('?')
+		return
+	case DollarPlaceholders:
+		fmt.Fprintf(buf, "$%d", len(buf.bindVars))
+		return
+	}
+	if strings.HasPrefix(arg, "$") && buf.formatsFor(MySQL, MariaDB, BigQuery) {
+		// A ? takes the place of $n only if the parameters
+		// are numbered in the order they are written.
+		buf.positionalArgs++
+		if arg != "$"+strconv.Itoa(buf.positionalArgs) {
+			buf.unsupported("positional parameter " + arg + " out of order")
+		}
+		buf.WriteByte('?')
+		return
+	}
 	buf.bindLocations = append(buf.bindLocations, bindLocation{
 		offset: buf.Len(),
 		length: len(arg),
@@ -98,10 +230,80 @@ func (buf *TrackedBuffer) WriteArg(arg string) {
 	buf.WriteString(arg)
 }
 
+// bindVarName returns the name of the bind variable arg, as
+// FetchBindVar looks it up.
+func bindVarName(arg string) string {
+	if len(arg) < 2 {
+		return arg
+	}
+	return strings.TrimPrefix(arg[1:], ":")
+}
+
+// SetPlaceholders sets how bind variables are written. Positional
+// placeholders let a query be passed to drivers that do not take
+// named ones, with the values of BindVars in order. A list bind
+// variable, such as ::ids, is written as a single placeholder.
+func (buf *TrackedBuffer) SetPlaceholders(placeholders Placeholders) {
+	buf.placeholders = placeholders
+}
+
+// BindVars returns the names of the bind variables written so far,
+// without their ':' prefix, in the order they were written. A name
+// is repeated if its variable was written more than once, so that
+// the names match positional placeholders one to one.
+func (buf *TrackedBuffer) BindVars() []string {
+	return buf.bindVars
+}
+
+// SetIDQuoting sets the identifier quoting policy.
+func (buf *TrackedBuffer) SetIDQuoting(policy IDQuoting) {
+	buf.idQuoting = policy
+}
+
+// SetDialect sets the dialect to format for, as FormatDialect
+// does. In the Postgres dialect identifiers are quoted with
+// double quotes, and strings only with single quotes. Constructs
+// of other dialects are translated where the dialect has an
+// equivalent. Those it cannot express are still written as they
+// would be for MySQL, and reported by DialectError.
+func (buf *TrackedBuffer) SetDialect(dialect Dialect) {
+	buf.dialect, buf.dialectSet = dialect, true
+}
+
+// formatsFor reports whether the dialect set by SetDialect is one
+// of dialects. Without SetDialect, as for String, constructs are
+// written as they were parsed, whatever their dialect.
+func (buf *TrackedBuffer) formatsFor(dialects ...Dialect) bool {
+	if !buf.dialectSet {
+		return false
+	}
+	for _, dialect := range dialects {
+		if buf.dialect == dialect {
+			return true
+		}
+	}
+	return false
+}
+
+// DialectError returns an error describing the first construct
+// formatted so far that the dialect set by SetDialect cannot
+// express, or nil if there was none.
+func (buf *TrackedBuffer) DialectError() error {
+	return buf.dialectErr
+}
+
+// unsupported records that what cannot be expressed in the
+// dialect formatted for.
+func (buf *TrackedBuffer) unsupported(what string) {
+	if buf.dialectErr == nil {
+		buf.dialectErr = fmt.Errorf("%s cannot be expressed in %s", what, buf.dialect)
+	}
+}
+
 func (buf *TrackedBuffer) ParsedQuery() *ParsedQuery {
 	return &ParsedQuery{Query: buf.String(), bindLocations: buf.bindLocations}
 }
 
 func (buf *TrackedBuffer) HasBindVars() bool {
-	return len(buf.bindLocations) != 0
+	return len(buf.bindVars) != 0
 }
@@ -0,0 +1,96 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Limits applied by ParseUntrusted.
+const (
+	// UntrustedMaxLength is the maximum length of the sql, in bytes.
+	UntrustedMaxLength = 1 << 20
+	// UntrustedMaxTokens is the maximum number of tokens in the sql.
+	UntrustedMaxTokens = 1 << 16
+	// UntrustedMaxDepth is the maximum nesting depth of the AST.
+	UntrustedMaxDepth = 200
+)
+
+// ParseUntrusted parses sql like Parse, but is meant for input
+// coming from untrusted sources such as internet-facing endpoints.
+// It guarantees that:
+//
+//   - sql longer than UntrustedMaxLength bytes, or made of more
+//     than UntrustedMaxTokens tokens, is rejected before the parse
+//     consumes a proportional amount of memory;
+//   - statements nested deeper than UntrustedMaxDepth are rejected,
+//     so the recursive Format and Rewrite functions cannot exhaust
+//     the stack on the returned Statement;
+//   - a panic during the parse is returned as an error instead of
+//     crashing the program.
+//
+// The limits are reported as LimitErrors.
+func ParseUntrusted(sql string) (stmt Statement, err error) {
+	if len(sql) > UntrustedMaxLength {
+		return nil, &LimitError{Limit: LimitLength, Max: UntrustedMaxLength, Length: len(sql)}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			stmt, err = nil, panicError(r)
+		}
+	}()
+	tokenizer := NewStringTokenizer(sql)
+	tokenizer.maxTokens = UntrustedMaxTokens
+	if yyParse(tokenizer) != 0 {
+		return nil, tokenizer.parseError()
+	}
+	if !withinDepth(reflect.ValueOf(tokenizer.ParseTree), UntrustedMaxDepth) {
+		return nil, &LimitError{Limit: LimitDepth, Max: UntrustedMaxDepth}
+	}
+	return tokenizer.ParseTree, nil
+}
+
+// panicError returns the error reported for r, the value of
+// a panic during a parse.
+func panicError(r interface{}) error {
+	return fmt.Errorf("internal error parsing sql: %v", r)
+}
+
+// withinDepth reports whether the nodes reachable from val are
+// nested no deeper than depth. It stops descending once the limit
+// is exceeded, so it is safe to call on arbitrarily deep trees.
+func withinDepth(val reflect.Value, depth int) bool {
+	if !val.IsValid() {
+		return true
+	}
+	typ := val.Type()
+	// Count each node once, not once for the interface
+	// holding it and once for its pointer and value.
+	if typ.Kind() != reflect.Interface && typ.Implements(typeOfSQLNode) &&
+		!(typ.Kind() == reflect.Ptr && typ.Elem().Implements(typeOfSQLNode)) {
+		if depth == 0 {
+			return false
+		}
+		depth--
+	}
+	switch typ.Kind() {
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			if !withinDepth(val.Index(i), depth) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			if !withinDepth(val.Field(i), depth) {
+				return false
+			}
+		}
+	case reflect.Ptr, reflect.Interface:
+		return withinDepth(val.Elem(), depth)
+	}
+	return true
+}
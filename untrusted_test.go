@@ -0,0 +1,161 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// untrustedCorpus holds truncated, malformed and hostile inputs
+// of the kind a fuzzer produces. None of them may panic or hang.
+var untrustedCorpus = []string{
+	"",
+	";",
+	"\x00",
+	"select \x00 from t",
+	"select '\x00' from t",
+	"select 'abc",
+	"select \"abc",
+	"select `abc",
+	"select /* abc",
+	"select a from t where b = 'it\\",
+	"select :",
+	"select ::",
+	"select ? from ?",
+	"select 0x",
+	"select 1e",
+	"select 1.e+",
+	"select @@",
+	"select @@global.",
+	"select a. from t",
+	"select * from t for system_time",
+	"next value for",
+	"create sequence s start with",
+	"delimiter $$",
+	"begin",
+	"if",
+	"declare",
+	"fetch next from",
+	"select \xff\xfe from t",
+	"select 'a' 'b' from t",
+	"select case when then end from t",
+	"select ((((((((((((((((((((((((((((((((((((((((((((((((((a",
+	"select a from t where ((((((((((((((((((((((((((((((((((((((((((b = 1",
+	"select a from t where b = '\\",
+	"select `a``",
+	"select x'1",
+	"select b'",
+	"select 0b2",
+	"select 08",
+	"select $",
+	"select $1",
+	"select a::",
+	"select a->",
+	"select a->>1",
+	"select @'",
+	"select @`a",
+	"select _utf8",
+	"select next value",
+	"select * from t where a = any (",
+	"select * from t group by grouping",
+	"create user",
+	"with check",
+	"select\x01a\x02from\x03t",
+	"select a\rfrom\tt\n--",
+	"select 1 -- \x00",
+	"insert into t values (1, 2",
+	"insert into t values (1) as",
+	"insert into t values (1) on duplicate key update a = values(",
+	"select (select (select (select (select 1",
+	"select * from t limit 1, 2, 3",
+	"select * from t asof",
+	strings.Repeat("(", 10000),
+	"select " + strings.Repeat("(select ", 1000) + "1",
+	"select " + strings.Repeat("not ", 1000) + "1",
+}
+
+// parseAll parses sql in each dialect, with Parse and ParseNext,
+// and formats what it parses.
+func parseAll(sql string) {
+	for _, dialect := range []Dialect{MySQL, MariaDB, Postgres, BigQuery} {
+		if tree, err := ParseWithOptions(sql, Options{Dialect: dialect}); err == nil {
+			String(tree)
+		}
+	}
+	tokenizer := NewTokenizer(strings.NewReader(sql))
+	for {
+		if _, err := ParseNext(tokenizer); err != nil {
+			break
+		}
+	}
+	ParseScriptTolerant(sql)
+}
+
+func TestParseUntrustedCorpus(t *testing.T) {
+	for _, sql := range untrustedCorpus {
+		ParseUntrusted(sql)
+		parseAll(sql)
+	}
+	for _, tcase := range validSQL {
+		_, err := ParseUntrusted(tcase.input)
+		assert.Nil(t, err, tcase.input)
+	}
+}
+
+func TestParseUntrustedLimits(t *testing.T) {
+	_, err := ParseUntrusted("select " + strings.Repeat(" ", UntrustedMaxLength) + "1 from dual")
+	assert.Equal(t, "sql is 1048594 bytes long, exceeding the limit of 1048576", err.Error())
+
+	_, err = ParseUntrusted("select " + strings.Repeat("1+", UntrustedMaxTokens) + "1 from dual")
+	assert.Equal(t, "sql has more than the limit of 65536 tokens", err.Error())
+
+	deep := "select " + strings.Repeat("(", 1000) + "1" + strings.Repeat(")", 1000) + " from dual"
+	_, err = Parse(deep)
+	assert.Nil(t, err)
+	_, err = ParseUntrusted(deep)
+	assert.Equal(t, "sql is nested deeper than the limit of 200", err.Error())
+
+	deep = "select " + strings.Repeat("~", 1000) + "a from dual"
+	_, err = ParseUntrusted(deep)
+	assert.Equal(t, "sql is nested deeper than the limit of 200", err.Error())
+
+	shallow := "select " + strings.Repeat("(", 50) + "1" + strings.Repeat(")", 50) + " from dual"
+	_, err = ParseUntrusted(shallow)
+	assert.Nil(t, err)
+}
+
+// FuzzParse checks that no input makes the parser panic or hang,
+// in any dialect, and that the statements parsed can be formatted.
+// Run it with go test -fuzz FuzzParse, and add the inputs it finds
+// to untrustedCorpus.
+func FuzzParse(f *testing.F) {
+	for _, sql := range untrustedCorpus {
+		f.Add(sql)
+	}
+	for _, tcase := range validSQL {
+		f.Add(tcase.input)
+	}
+	f.Fuzz(func(t *testing.T, sql string) {
+		parseAll(sql)
+	})
+}
+
+func TestParsePanic(t *testing.T) {
+	opts := Options{Clauses: []ClauseDef{{
+		Keywords: []string{"SAMPLE"},
+		Check:    func(*Clause) error { panic("bad clause") },
+	}}}
+	_, err := ParseWithOptions("select * from t sample 1", opts)
+	assert.EqualError(t, err, "internal error parsing sql: bad clause")
+
+	// The parser is left usable.
+	tree, err := Parse("select * from t")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "select * from t", String(tree))
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+// validate.go checks statements against the tables of a database.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError is a problem found by Validate. Node is the
+// offending node.
+type ValidationError struct {
+	Message string
+	Node    SQLNode
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Message, String(err.Node))
+}
+
+// Validate checks stmt against schema, and returns the problems
+// it finds, as *ValidationErrors: the unknown tables, and unknown
+// or ambiguous columns, that Resolve reports; INSERT statements
+// whose rows do not have as many values as they have columns; and
+// comparisons of incompatible types: of a numeric or date column
+// with a string that does not read as a number or a time, such as
+// 'abc', and of a numeric column with a date column. Comparisons
+// MySQL converts the operands of, such as of a numeric column with
+// a string column, are not reported. Comparisons are only checked
+// for columns whose types the schema gives.
+func Validate(stmt Statement, schema Schema) []error {
+	v := &validator{schema: schema, res: Resolve(stmt, schema)}
+	for _, p := range v.res.Problems {
+		v.errorf(p.Node, "%s", p.Message)
+	}
+	Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *Insert:
+			v.checkInsert(node)
+		case *ComparisonExpr:
+			v.checkComparison(node)
+		}
+		return true, nil
+	}, stmt)
+	return v.errs
+}
+
+type validator struct {
+	schema Schema
+	res    *Resolution
+	errs   []error
+}
+
+func (v *validator) errorf(node SQLNode, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{Message: fmt.Sprintf(format, args...), Node: node})
+}
+
+// checkInsert checks that the rows of stmt have as many values as
+// it has columns, or as its table has if it lists none.
+func (v *validator) checkInsert(stmt *Insert) {
+	want := len(stmt.Columns)
+	if want == 0 {
+		if v.schema == nil {
+			return
+		}
+		defs, ok := v.schema.Table(stmt.Table)
+		if !ok {
+			return
+		}
+		want = len(defs)
+	}
+	switch rows := stmt.Rows.(type) {
+	case Values:
+		for _, row := range rows {
+			if tuple, ok := row.(ValTuple); ok && len(tuple) != want {
+				v.errorf(tuple, "%d columns but %d values", want, len(tuple))
+			}
+		}
+	case SelectStatement:
+		if n, ok := selectWidth(rows); ok && n != want {
+			v.errorf(rows, "%d columns but %d values", want, n)
+		}
+	}
+}
+
+// selectWidth returns the number of result columns of stmt,
+// and false if they are not known.
+func selectWidth(stmt SelectStatement) (int, bool) {
+	switch stmt := stmt.(type) {
+	case *Select:
+		for _, expr := range stmt.SelectExprs {
+			if _, ok := expr.(*StarExpr); ok {
+				return 0, false
+			}
+		}
+		return len(stmt.SelectExprs), true
+	case *Union:
+		return selectWidth(stmt.Left)
+	case *ParenSelect:
+		return selectWidth(stmt.Select)
+	}
+	return 0, false
+}
+
+// checkComparison checks the operands of a comparison, and of the
+// values of an IN list, for incompatible types.
+func (v *validator) checkComparison(cmp *ComparisonExpr) {
+	switch cmp.Operator {
+	case AST_EQ, AST_LT, AST_GT, AST_LE, AST_GE, AST_NE, AST_NSE:
+		v.checkOperands(cmp, cmp.Left, cmp.Right)
+	case AST_IN, AST_NOT_IN:
+		if tuple, ok := cmp.Right.(ValTuple); ok {
+			for _, expr := range tuple {
+				v.checkOperands(cmp, cmp.Left, expr)
+			}
+		}
+	}
+}
+
+func (v *validator) checkOperands(cmp *ComparisonExpr, left, right ValExpr) {
+	lclass, rclass := v.typeClass(left), v.typeClass(right)
+	if !compatible(lclass, rclass) {
+		v.errorf(cmp, "incompatible comparison of %s and %s", lclass.name, rclass.name)
+	}
+}
+
+// operandClass is what Validate knows of the type of an operand:
+// the class of the type of a column, or, for a string literal,
+// whether it reads as a number or a time.
+type operandClass struct {
+	name    string
+	column  bool
+	numeric bool
+	time    bool
+}
+
+// compatible reports whether operands of the classes a and b
+// can be compared: columns with columns other than numeric with
+// time columns, and numeric and time columns with strings that
+// read as numbers and times.
+func compatible(a, b operandClass) bool {
+	if a.name == "" || b.name == "" {
+		return true
+	}
+	if a.column && b.column {
+		return a.name == b.name || a.name == "string" || b.name == "string"
+	}
+	if !a.column {
+		a, b = b, a
+	}
+	switch {
+	case !a.column:
+		return true
+	case a.name == "number":
+		return b.numeric
+	case a.name == "time":
+		return b.time
+	}
+	return true
+}
+
+// typeClass returns the class of expr, which is empty if it is
+// neither a column whose type is known nor a string literal.
+func (v *validator) typeClass(expr ValExpr) operandClass {
+	switch expr := expr.(type) {
+	case *ColName:
+		if typ := v.columnType(expr); typ != "" {
+			return operandClass{name: columnClass(typ), column: true}
+		}
+	case StrVal:
+		_, err := parseNumber(strings.TrimSpace(expr.Val))
+		_, terr := parseTime(expr.Val)
+		return operandClass{name: "string", numeric: err == nil, time: terr == nil}
+	}
+	return operandClass{}
+}
+
+// columnType returns the type of the column col is bound to, or
+// "" if it is not known.
+func (v *validator) columnType(col *ColName) string {
+	src := v.res.Bindings[col]
+	if src == nil || src.Table == nil || v.schema == nil {
+		return ""
+	}
+	defs, _ := v.schema.Table(src.Table)
+	for _, def := range defs {
+		if col.Name.EqualString(def.ColName) {
+			return def.ColType.Type
+		}
+	}
+	return ""
+}
+
+// columnClass returns the class of the column type typ: number,
+// time for dates and timestamps, or string.
+func columnClass(typ string) string {
+	switch strings.ToLower(typ) {
+	case "bit", "tinyint", "smallint", "mediumint", "int", "integer", "bigint",
+		"real", "double", "float", "decimal", "numeric", "bool", "boolean", "year":
+		return "number"
+	case "date", "timestamp", "datetime":
+		return "time"
+	}
+	return "string"
+}
@@ -0,0 +1,91 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ddlSchema is a Schema of the tables of CREATE TABLE statements.
+type ddlSchema map[string]ColumnDefinitions
+
+func (s ddlSchema) Table(name *TableName) ([]*ColumnDefinition, bool) {
+	defs, ok := s[name.Name.Lowered()]
+	return defs, ok
+}
+
+func TestValidate(t *testing.T) {
+	schema := ddlSchema{}
+	for _, sql := range []string{
+		"create table t (id int, name varchar(10), created datetime)",
+		"create table u (id bigint, t_id int, note text)",
+	} {
+		tree, err := Parse(sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		create := tree.(*CreateTable)
+		schema[create.Name.Lowered()] = create.ColumnDefinitions
+	}
+	tcases := []struct {
+		sql  string
+		errs []string
+	}{{
+		sql: "select name from t join u on t.id = u.t_id where t.created > '2024-01-01' and t.id in (1, '2')",
+	}, {
+		sql:  "select nme from t where id = 1",
+		errs: []string{"unknown column: nme"},
+	}, {
+		sql:  "select t.id from t join v on t.id = v.id",
+		errs: []string{"unknown table: v"},
+	}, {
+		sql:  "select id from t, u",
+		errs: []string{"ambiguous column: id"},
+	}, {
+		sql:  "select name from t where id = 'abc' or created < 'yesterday' or id in (1, 'x')",
+		errs: []string{"incompatible comparison of number and string: id = 'abc'", "incompatible comparison of time and string: created < 'yesterday'", "incompatible comparison of number and string: id in (1, 'x')"},
+	}, {
+		sql:  "select t.id from t join u on t.created = u.id where u.note = t.name",
+		errs: []string{"incompatible comparison of time and number: t.created = u.id"},
+	}, {
+		sql: "select t.id from t join u on t.name = u.id where t.created = u.note",
+	}, {
+		sql:  "select t.id from t join u on u.id = t.created",
+		errs: []string{"incompatible comparison of number and time: u.id = t.created"},
+	}, {
+		sql:  "insert into t (id, name) values (1, 'a'), (2)",
+		errs: []string{"2 columns but 1 values: (2)"},
+	}, {
+		sql:  "insert into t values (1, 'a')",
+		errs: []string{"3 columns but 2 values: (1, 'a')"},
+	}, {
+		sql:  "insert into u (id, t_id) select id, name, created from t",
+		errs: []string{"2 columns but 3 values: select id, name, created from t"},
+	}, {
+		sql: "insert into u select * from t",
+	}, {
+		sql: "insert into t values (1, 'a', now()) as new on duplicate key update name = new.name",
+	}, {
+		sql:  "insert into t values (1, 'a', now()) as new on duplicate key update name = new.nme",
+		errs: []string{"unknown column: new.nme"},
+	}}
+	for _, tcase := range tcases {
+		tree, err := Parse(tcase.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var errs []string
+		for _, err := range Validate(tree, schema) {
+			errs = append(errs, err.Error())
+		}
+		assert.Equal(t, tcase.errs, errs, tcase.sql)
+	}
+
+	tree, _ := Parse("select a from t where b = 'x'")
+	errs := Validate(tree, MapSchema{"t": {"a", "b"}})
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,78 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"reflect"
+)
+
+// Walk calls visit on each of nodes and on every node reachable
+// from them, parents before children and children in the order of
+// the fields that hold them. Nil nodes and empty NumVals are not
+// visited. Walk skips the children of a node if visit returns
+// false, and stops at the first error visit returns, which it
+// returns.
+//
+// Value nodes such as ColIdent and NumVal are visited as well as
+// pointer nodes, and so are lists such as SelectExprs before their
+// elements.
+func Walk(visit func(node SQLNode) (kontinue bool, err error), nodes ...SQLNode) error {
+	for _, node := range nodes {
+		if err := walk(reflect.ValueOf(node), visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walk(val reflect.Value, visit func(node SQLNode) (bool, error)) error {
+	if !val.IsValid() {
+		return nil
+	}
+	switch val.Kind() {
+	case reflect.Interface:
+		return walk(val.Elem(), visit)
+	case reflect.Ptr, reflect.Slice:
+		if val.IsNil() {
+			return nil
+		}
+	case reflect.String:
+		// An unset NumVal, such as Select.MaxStatementTime.
+		if val.Len() == 0 {
+			return nil
+		}
+	}
+	if val.Type().Implements(typeOfSQLNode) && val.CanInterface() {
+		kontinue, err := visit(val.Interface().(SQLNode))
+		if err != nil || !kontinue {
+			return err
+		}
+	}
+	return walkChildren(val, visit)
+}
+
+func walkChildren(val reflect.Value, visit func(node SQLNode) (bool, error)) error {
+	switch val.Kind() {
+	case reflect.Ptr:
+		// The value a pointer node points to is the same node.
+		return walkChildren(val.Elem(), visit)
+	case reflect.Slice:
+		if val.Type() == typeOfBytes {
+			return nil
+		}
+		for i := 0; i < val.Len(); i++ {
+			if err := walk(val.Index(i), visit); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			if err := walk(val.Field(i), visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk(t *testing.T) {
+	tree, err := Parse("select a, b + 1 from t where c = (select d from u) order by a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var visited []string
+	err = Walk(func(node SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *ColName, *TableName, NumVal:
+			visited = append(visited, String(node))
+		case *Subquery:
+			visited = append(visited, "subquery")
+			return false, nil
+		}
+		return true, nil
+	}, tree)
+	assert.Nil(t, err)
+	assert.Equal(t, "a b 1 t c subquery a", strings.Join(visited, " "))
+
+	var types []string
+	Walk(func(node SQLNode) (bool, error) {
+		types = append(types, fmt.Sprintf("%T", node))
+		return true, nil
+	}, tree.(*Select).SelectExprs[1])
+	assert.Equal(t, []string{
+		"*sqlparser.NonStarExpr",
+		"*sqlparser.BinaryExpr",
+		"*sqlparser.ColName",
+		"sqlparser.ColIdent",
+		"sqlparser.TableIdent",
+		"sqlparser.NumVal",
+		"sqlparser.ColIdent",
+	}, types)
+}
+
+func TestWalkError(t *testing.T) {
+	tree, err := Parse("select a from t where b = 1 and c = 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := errors.New("stop")
+	var cols []string
+	err = Walk(func(node SQLNode) (bool, error) {
+		if col, ok := node.(*ColName); ok {
+			cols = append(cols, String(col))
+			if col.Name.EqualString("b") {
+				return false, stop
+			}
+		}
+		return true, nil
+	}, tree, nil)
+	assert.Equal(t, stop, err)
+	assert.Equal(t, []string{"a", "b"}, cols)
+}